@@ -27,6 +27,7 @@ import (
 const (
 	externalIPMapFlag  = "external-ip-map"
 	dnsResolverAddress = "dns-resolver-address"
+	useExitNodeFlag    = "use-exit-node"
 )
 
 var (
@@ -49,6 +50,7 @@ var (
 	preSharedKey            string
 	natExternalIPs          []string
 	customDNSAddress        string
+	useExitNode             bool
 	rootCmd                 = &cobra.Command{
 		Use:          "netbird",
 		Short:        "",
@@ -118,6 +120,10 @@ func init() {
 			`An empty string "" clears the previous configuration. `+
 			`E.g. --dns-resolver-address 127.0.0.1:5053 or --dns-resolver-address ""`,
 	)
+	upCmd.PersistentFlags().BoolVar(&useExitNode, useExitNodeFlag, false,
+		`Routes all the traffic through the exit nodes (routing peers of a 0.0.0.0/0 route) available to this peer. `+
+			`The setting is kept in the configuration, use --use-exit-node=false to stop using exit nodes.`,
+	)
 }
 
 // SetupCloseHandler handles SIGTERM signal and exits with success
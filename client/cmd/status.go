@@ -48,6 +48,12 @@ type managementStateOutput struct {
 	Connected bool   `json:"connected" yaml:"connected"`
 }
 
+type dnsCacheStateOutput struct {
+	Entries int64  `json:"entries" yaml:"entries"`
+	Hits    uint64 `json:"hits" yaml:"hits"`
+	Misses  uint64 `json:"misses" yaml:"misses"`
+}
+
 type iceCandidateType struct {
 	Local  string `json:"local" yaml:"local"`
 	Remote string `json:"remote" yaml:"remote"`
@@ -60,14 +66,17 @@ type statusOutputOverview struct {
 	ManagementState managementStateOutput `json:"management" yaml:"management"`
 	SignalState     signalStateOutput     `json:"signal" yaml:"signal"`
 	IP              string                `json:"netbirdIp" yaml:"netbirdIp"`
+	IPv6            string                `json:"netbirdIpv6,omitempty" yaml:"netbirdIpv6,omitempty"`
 	PubKey          string                `json:"publicKey" yaml:"publicKey"`
 	KernelInterface bool                  `json:"usesKernelInterface" yaml:"usesKernelInterface"`
 	FQDN            string                `json:"fqdn" yaml:"fqdn"`
+	DNSCache        dnsCacheStateOutput   `json:"dnsCache" yaml:"dnsCache"`
 }
 
 var (
 	detailFlag   bool
 	ipv4Flag     bool
+	ipv6Flag     bool
 	jsonFlag     bool
 	yamlFlag     bool
 	ipsFilter    []string
@@ -87,6 +96,7 @@ func init() {
 	statusCmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "display detailed status information in json format")
 	statusCmd.PersistentFlags().BoolVar(&yamlFlag, "yaml", false, "display detailed status information in yaml format")
 	statusCmd.PersistentFlags().BoolVar(&ipv4Flag, "ipv4", false, "display only NetBird IPv4 of this peer, e.g., --ipv4 will output 100.64.0.33")
+	statusCmd.PersistentFlags().BoolVar(&ipv6Flag, "ipv6", false, "display only NetBird IPv6 overlay address of this peer, e.g., --ipv6 will output fd5e:1c2a:9b00::1")
 	statusCmd.MarkFlagsMutuallyExclusive("detail", "json", "yaml", "ipv4")
 	statusCmd.PersistentFlags().StringSliceVar(&ipsFilter, "filter-by-ips", []string{}, "filters the detailed output by a list of one or more IPs, e.g., --filter-by-ips 100.64.0.100,100.64.0.200")
 	statusCmd.PersistentFlags().StringVar(&statusFilter, "filter-by-status", "", "filters the detailed output by connection status(connected|disconnected), e.g., --filter-by-status connected")
@@ -131,6 +141,11 @@ func statusFunc(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if ipv6Flag {
+		cmd.Print(parseInterfaceIP(resp.GetFullStatus().GetLocalPeerState().GetIPv6()))
+		return nil
+	}
+
 	outputInformationHolder := convertToStatusOutputOverview(resp)
 
 	var statusOutputString string
@@ -205,6 +220,13 @@ func convertToStatusOutputOverview(resp *proto.StatusResponse) statusOutputOverv
 		Connected: signalState.GetConnected(),
 	}
 
+	dnsCacheState := pbFullStatus.GetDnsCacheState()
+	dnsCacheOverview := dnsCacheStateOutput{
+		Entries: dnsCacheState.GetEntries(),
+		Hits:    dnsCacheState.GetHits(),
+		Misses:  dnsCacheState.GetMisses(),
+	}
+
 	peersOverview := mapPeers(resp.GetFullStatus().GetPeers())
 
 	overview := statusOutputOverview{
@@ -214,9 +236,11 @@ func convertToStatusOutputOverview(resp *proto.StatusResponse) statusOutputOverv
 		ManagementState: managementOverview,
 		SignalState:     signalOverview,
 		IP:              pbFullStatus.GetLocalPeerState().GetIP(),
+		IPv6:            pbFullStatus.GetLocalPeerState().GetIPv6(),
 		PubKey:          pbFullStatus.GetLocalPeerState().GetPubKey(),
 		KernelInterface: pbFullStatus.GetLocalPeerState().GetKernelInterface(),
 		FQDN:            pbFullStatus.GetLocalPeerState().GetFqdn(),
+		DNSCache:        dnsCacheOverview,
 	}
 
 	return overview
@@ -333,8 +357,15 @@ func parseGeneralSummary(overview statusOutputOverview, showURL bool) string {
 		interfaceIP = "N/A"
 	}
 
+	if overview.IPv6 != "" {
+		interfaceIP = fmt.Sprintf("%s, %s", interfaceIP, overview.IPv6)
+	}
+
 	peersCountString := fmt.Sprintf("%d/%d Connected", overview.Peers.Connected, overview.Peers.Total)
 
+	dnsCacheString := fmt.Sprintf("%d entries, %d hits, %d misses",
+		overview.DNSCache.Entries, overview.DNSCache.Hits, overview.DNSCache.Misses)
+
 	summary := fmt.Sprintf(
 		"Daemon version: %s\n"+
 			"CLI version: %s\n"+
@@ -343,7 +374,8 @@ func parseGeneralSummary(overview statusOutputOverview, showURL bool) string {
 			"FQDN: %s\n"+
 			"NetBird IP: %s\n"+
 			"Interface type: %s\n"+
-			"Peers count: %s\n",
+			"Peers count: %s\n"+
+			"DNS cache: %s\n",
 		overview.DaemonVersion,
 		version.NetbirdVersion(),
 		managementConnString,
@@ -352,6 +384,7 @@ func parseGeneralSummary(overview statusOutputOverview, showURL bool) string {
 		interfaceIP,
 		interfaceTypeString,
 		peersCountString,
+		dnsCacheString,
 	)
 	return summary
 }
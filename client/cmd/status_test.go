@@ -61,6 +61,11 @@ var resp = &proto.StatusResponse{
 			KernelInterface: true,
 			Fqdn:            "some-localhost.awesome-domain.com",
 		},
+		DnsCacheState: &proto.DNSCacheState{
+			Entries: 10,
+			Hits:    25,
+			Misses:  12,
+		},
 	},
 	DaemonVersion: "0.14.1",
 }
@@ -112,6 +117,11 @@ var overview = statusOutputOverview{
 	PubKey:          "Some-Pub-Key",
 	KernelInterface: true,
 	FQDN:            "some-localhost.awesome-domain.com",
+	DNSCache: dnsCacheStateOutput{
+		Entries: 10,
+		Hits:    25,
+		Misses:  12,
+	},
 }
 
 func TestConversionFromFullStatusToOutputOverview(t *testing.T) {
@@ -200,7 +210,13 @@ func TestParsingToJSON(t *testing.T) {
 		"\"netbirdIp\":\"192.168.178.100/16\"," +
 		"\"publicKey\":\"Some-Pub-Key\"," +
 		"\"usesKernelInterface\":true," +
-		"\"fqdn\":\"some-localhost.awesome-domain.com\"" +
+		"\"fqdn\":\"some-localhost.awesome-domain.com\"," +
+		"\"dnsCache\":" +
+		"{" +
+		"\"entries\":10," +
+		"\"hits\":25," +
+		"\"misses\":12" +
+		"}" +
 		"}"
 	// @formatter:on
 
@@ -245,7 +261,11 @@ func TestParsingToYAML(t *testing.T) {
 		"netbirdIp: 192.168.178.100/16\n" +
 		"publicKey: Some-Pub-Key\n" +
 		"usesKernelInterface: true\n" +
-		"fqdn: some-localhost.awesome-domain.com\n"
+		"fqdn: some-localhost.awesome-domain.com\n" +
+		"dnsCache:\n" +
+		"    entries: 10\n" +
+		"    hits: 25\n" +
+		"    misses: 12\n"
 
 	assert.Equal(t, expectedYAML, yaml)
 }
@@ -281,7 +301,8 @@ func TestParsingToDetail(t *testing.T) {
 		"FQDN: some-localhost.awesome-domain.com\n" +
 		"NetBird IP: 192.168.178.100/16\n" +
 		"Interface type: Kernel\n" +
-		"Peers count: 2/2 Connected\n"
+		"Peers count: 2/2 Connected\n" +
+		"DNS cache: 10 entries, 25 hits, 12 misses\n"
 
 	assert.Equal(t, expectedDetail, detail)
 }
@@ -296,7 +317,8 @@ func TestParsingToShortVersion(t *testing.T) {
 		"FQDN: some-localhost.awesome-domain.com\n" +
 		"NetBird IP: 192.168.178.100/16\n" +
 		"Interface type: Kernel\n" +
-		"Peers count: 2/2 Connected\n"
+		"Peers count: 2/2 Connected\n" +
+		"DNS cache: 10 entries, 25 hits, 12 misses\n"
 
 	assert.Equal(t, expectedString, shortVersion)
 }
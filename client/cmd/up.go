@@ -85,6 +85,9 @@ func runInForegroundMode(ctx context.Context, cmd *cobra.Command) error {
 		NATExternalIPs:   natExternalIPs,
 		CustomDNSAddress: customDNSAddressConverted,
 	}
+	if cmd.Flag(useExitNodeFlag).Changed {
+		ic.UseExitNode = &useExitNode
+	}
 	if preSharedKey != "" {
 		ic.PreSharedKey = &preSharedKey
 	}
@@ -136,6 +139,13 @@ func runInDaemonMode(ctx context.Context, cmd *cobra.Command) error {
 	}
 
 	if status.Status == string(internal.StatusConnected) {
+		if cmd.Flag(useExitNodeFlag).Changed {
+			_, err = client.SetExitNode(ctx, &proto.SetExitNodeRequest{Enabled: useExitNode})
+			if err != nil {
+				return fmt.Errorf("failed to update the exit node setting: %v", err)
+			}
+			cmd.Printf("Exit node usage set to %t\n", useExitNode)
+		}
 		cmd.Println("Already connected")
 		return nil
 	}
@@ -150,6 +160,9 @@ func runInDaemonMode(ctx context.Context, cmd *cobra.Command) error {
 		CustomDNSAddress:     customDNSAddressConverted,
 		IsLinuxDesktopClient: isLinuxRunningDesktop(),
 	}
+	if cmd.Flag(useExitNodeFlag).Changed {
+		loginRequest.UseExitNode = &useExitNode
+	}
 
 	var loginErr error
 
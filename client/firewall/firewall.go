@@ -69,3 +69,12 @@ type Manager interface {
 
 	// TODO: migrate routemanager firewal actions to this interface
 }
+
+// StatefulManager is implemented by the firewall managers tracking the connections.
+// The replies of the connections allowed by a rule are accepted without an inverted rule
+type StatefulManager interface {
+	Manager
+
+	// IsStateful returns true if the replies of the allowed connections are accepted
+	IsStateful() bool
+}
@@ -3,6 +3,7 @@ package iptables
 import (
 	"fmt"
 	"net"
+	"os"
 	"strconv"
 	"sync"
 
@@ -33,9 +34,7 @@ type Manager struct {
 	ipv4Client *iptables.IPTables
 	ipv6Client *iptables.IPTables
 
-	inputDefaultRuleSpecs  []string
-	outputDefaultRuleSpecs []string
-	wgIface                iFaceMapper
+	wgIface iFaceMapper
 
 	rulesets map[string]ruleset
 }
@@ -55,11 +54,7 @@ type ruleset struct {
 // Create iptables firewall manager
 func Create(wgIface iFaceMapper, ipv6Supported bool) (*Manager, error) {
 	m := &Manager{
-		wgIface: wgIface,
-		inputDefaultRuleSpecs: []string{
-			"-i", wgIface.Name(), "-j", ChainInputFilterName, "-s", wgIface.Address().String()},
-		outputDefaultRuleSpecs: []string{
-			"-o", wgIface.Name(), "-j", ChainOutputFilterName, "-d", wgIface.Address().String()},
+		wgIface:  wgIface,
 		rulesets: make(map[string]ruleset),
 	}
 
@@ -74,15 +69,20 @@ func Create(wgIface iFaceMapper, ipv6Supported bool) (*Manager, error) {
 		return nil, fmt.Errorf("iptables is not installed in the system or not supported")
 	}
 
+	// the IPv6 client is created even if the interface has no IPv6 overlay address yet,
+	// so the IPv6 rules can be applied as soon as they are received
 	if ipv6Supported {
 		m.ipv6Client, err = iptables.NewWithProtocol(iptables.ProtocolIPv6)
 		if err != nil {
-			log.Warnf("ip6tables is not installed in the system or not supported: %v. Access rules for this protocol won't be applied.", err)
+			log.Warnf("ip6tables is not installed in the system or not supported: %v", err)
 		}
 	}
 
-	if m.ipv4Client == nil && m.ipv6Client == nil {
-		return nil, fmt.Errorf("iptables is not installed in the system or not enough permissions to use it")
+	if m.ipv6Client == nil && wgIface.Address().HasIPv6() {
+		if err := disableIPv6(wgIface.Name()); err != nil {
+			return nil, fmt.Errorf("ip6tables is not available and IPv6 can't be disabled on the interface: %v", err)
+		}
+		log.Warnf("ip6tables is not available, disabled IPv6 on the interface %s", wgIface.Name())
 	}
 
 	if err := m.Reset(); err != nil {
@@ -112,15 +112,8 @@ func (m *Manager) AddFiltering(
 		return nil, err
 	}
 
-	var dPortVal, sPortVal string
-	if dPort != nil && dPort.Values != nil {
-		// TODO: we support only one port per rule in current implementation of ACLs
-		dPortVal = strconv.Itoa(dPort.Values[0])
-	}
-	if sPort != nil && sPort.Values != nil {
-		sPortVal = strconv.Itoa(sPort.Values[0])
-	}
-	ipsetName = m.transformIPsetName(ipsetName, sPortVal, dPortVal)
+	sPortSpecs, dPortSpecs := portSpecs("sport", sPort), portSpecs("dport", dPort)
+	ipsetName = m.transformIPsetName(ipsetName, len(sPortSpecs) != 0, len(dPortSpecs) != 0)
 
 	ruleID := uuid.New().String()
 
@@ -130,7 +123,11 @@ func (m *Manager) AddFiltering(
 			if err := ipset.Flush(ipsetName); err != nil {
 				log.Errorf("flush ipset %q before use it: %v", ipsetName, err)
 			}
-			if err := ipset.Create(ipsetName); err != nil {
+			var opts []ipset.Option
+			if ip.To4() == nil {
+				opts = append(opts, ipset.OptIPv6())
+			}
+			if err := ipset.Create(ipsetName, opts...); err != nil {
 				return nil, fmt.Errorf("failed to create ipset: %w", err)
 			}
 		}
@@ -154,7 +151,7 @@ func (m *Manager) AddFiltering(
 		// this is new ipset so we need to create firewall rule for it
 	}
 
-	specs := m.filterRuleSpecs(ip, string(protocol), sPortVal, dPortVal, direction, action, ipsetName)
+	specs := m.filterRuleSpecs(ip, string(protocol), sPortSpecs, dPortSpecs, direction, action, ipsetName)
 
 	if direction == fw.RuleDirectionOUT {
 		ok, err := client.Exists("filter", ChainOutputFilterName, specs...)
@@ -259,7 +256,7 @@ func (m *Manager) Reset() error {
 	if err := m.reset(m.ipv4Client, "filter"); err != nil {
 		return fmt.Errorf("clean ipv4 firewall ACL input chain: %w", err)
 	}
-	if m.ipv6Client != nil {
+	if m.ipv6Client != nil && m.wgIface.Address().HasIPv6() {
 		if err := m.reset(m.ipv6Client, "filter"); err != nil {
 			return fmt.Errorf("clean ipv6 firewall ACL input chain: %w", err)
 		}
@@ -270,9 +267,18 @@ func (m *Manager) Reset() error {
 
 // AllowNetbird allows netbird interface traffic
 func (m *Manager) AllowNetbird() error {
-	if m.wgIface.IsUserspaceBind() {
+	if !m.wgIface.IsUserspaceBind() {
+		return nil
+	}
+
+	anyIPs := []net.IP{net.ParseIP("0.0.0.0")}
+	if m.ipv6Client != nil && m.wgIface.Address().HasIPv6() {
+		anyIPs = append(anyIPs, net.ParseIP("::"))
+	}
+
+	for _, anyIP := range anyIPs {
 		_, err := m.AddFiltering(
-			net.ParseIP("0.0.0.0"),
+			anyIP,
 			"all",
 			nil,
 			nil,
@@ -285,7 +291,7 @@ func (m *Manager) AllowNetbird() error {
 			return fmt.Errorf("failed to allow netbird interface traffic: %w", err)
 		}
 		_, err = m.AddFiltering(
-			net.ParseIP("0.0.0.0"),
+			anyIP,
 			"all",
 			nil,
 			nil,
@@ -294,7 +300,9 @@ func (m *Manager) AllowNetbird() error {
 			"",
 			"",
 		)
-		return err
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -303,17 +311,31 @@ func (m *Manager) AllowNetbird() error {
 // Flush doesn't need to be implemented for this manager
 func (m *Manager) Flush() error { return nil }
 
+// defaultRuleSpecs returns the specs of the rules jumping to the NetBird chains for the address family of the client
+func (m *Manager) defaultRuleSpecs(client *iptables.IPTables) (input []string, output []string) {
+	address := m.wgIface.Address().String()
+	if client.Proto() == iptables.ProtocolIPv6 {
+		address = m.wgIface.Address().StringV6()
+	}
+
+	input = []string{"-i", m.wgIface.Name(), "-j", ChainInputFilterName, "-s", address}
+	output = []string{"-o", m.wgIface.Name(), "-j", ChainOutputFilterName, "-d", address}
+	return input, output
+}
+
 // reset firewall chain, clear it and drop it
 func (m *Manager) reset(client *iptables.IPTables, table string) error {
+	inputDefaultRuleSpecs, outputDefaultRuleSpecs := m.defaultRuleSpecs(client)
+
 	ok, err := client.ChainExists(table, ChainInputFilterName)
 	if err != nil {
 		return fmt.Errorf("failed to check if input chain exists: %w", err)
 	}
 	if ok {
-		if ok, err := client.Exists("filter", "INPUT", m.inputDefaultRuleSpecs...); err != nil {
+		if ok, err := client.Exists("filter", "INPUT", inputDefaultRuleSpecs...); err != nil {
 			return err
 		} else if ok {
-			if err := client.Delete("filter", "INPUT", m.inputDefaultRuleSpecs...); err != nil {
+			if err := client.Delete("filter", "INPUT", inputDefaultRuleSpecs...); err != nil {
 				log.WithError(err).Errorf("failed to delete default input rule: %v", err)
 			}
 		}
@@ -324,10 +346,10 @@ func (m *Manager) reset(client *iptables.IPTables, table string) error {
 		return fmt.Errorf("failed to check if output chain exists: %w", err)
 	}
 	if ok {
-		if ok, err := client.Exists("filter", "OUTPUT", m.outputDefaultRuleSpecs...); err != nil {
+		if ok, err := client.Exists("filter", "OUTPUT", outputDefaultRuleSpecs...); err != nil {
 			return err
 		} else if ok {
-			if err := client.Delete("filter", "OUTPUT", m.outputDefaultRuleSpecs...); err != nil {
+			if err := client.Delete("filter", "OUTPUT", outputDefaultRuleSpecs...); err != nil {
 				log.WithError(err).Errorf("failed to delete default output rule: %v", err)
 			}
 		}
@@ -358,7 +380,7 @@ func (m *Manager) reset(client *iptables.IPTables, table string) error {
 
 // filterRuleSpecs returns the specs of a filtering rule
 func (m *Manager) filterRuleSpecs(
-	ip net.IP, protocol string, sPort, dPort string, direction fw.RuleDirection, action fw.Action, ipsetName string,
+	ip net.IP, protocol string, sPort, dPort []string, direction fw.RuleDirection, action fw.Action, ipsetName string,
 ) (specs []string) {
 	matchByIP := true
 	// don't use IP matching if IP is ip 0.0.0.0
@@ -386,13 +408,29 @@ func (m *Manager) filterRuleSpecs(
 	if protocol != "all" {
 		specs = append(specs, "-p", protocol)
 	}
-	if sPort != "" {
-		specs = append(specs, "--sport", sPort)
+	specs = append(specs, sPort...)
+	specs = append(specs, dPort...)
+	return append(specs, "-j", m.actionToStr(action))
+}
+
+// portSpecs returns the match of the port for the given flag, sport or dport.
+// The ranges and the lists of ports are matched by the multiport module
+func portSpecs(flag string, port *fw.Port) []string {
+	if port == nil || len(port.Values) == 0 {
+		return nil
 	}
-	if dPort != "" {
-		specs = append(specs, "--dport", dPort)
+
+	if len(port.Values) == 1 {
+		return []string{"--" + flag, strconv.Itoa(port.Values[0])}
 	}
-	return append(specs, "-j", m.actionToStr(action))
+
+	var value string
+	if port.IsRange {
+		value = fmt.Sprintf("%d:%d", port.Values[0], port.Values[1])
+	} else {
+		value = port.String()
+	}
+	return []string{"-m", "multiport", "--" + flag + "s", value}
 }
 
 // rawClient returns corresponding iptables client for the given ip
@@ -400,18 +438,36 @@ func (m *Manager) rawClient(ip net.IP) (*iptables.IPTables, error) {
 	if ip.To4() != nil {
 		return m.ipv4Client, nil
 	}
-	if m.ipv6Client == nil {
-		return nil, fmt.Errorf("ipv6 is not supported")
+	if m.ipv6Client != nil {
+		return m.ipv6Client, nil
+	}
+
+	client, err := iptables.NewWithProtocol(iptables.ProtocolIPv6)
+	if err != nil {
+		// fail closed, the IPv6 traffic must not bypass the rules that can't be applied
+		if m.wgIface.Address().HasIPv6() {
+			if err := disableIPv6(m.wgIface.Name()); err != nil {
+				log.Errorf("failed to disable IPv6 on the interface %s: %v", m.wgIface.Name(), err)
+			}
+		}
+		return nil, fmt.Errorf("ipv6 is not supported: %w", err)
 	}
+	m.ipv6Client = client
 	return m.ipv6Client, nil
 }
 
+// disableIPv6 turns IPv6 off on the interface, used when the IPv6 access rules can't be applied
+func disableIPv6(ifaceName string) error {
+	return os.WriteFile(fmt.Sprintf("/proc/sys/net/ipv6/conf/%s/disable_ipv6", ifaceName), []byte("1"), 0644)
+}
+
 // client returns client with initialized chain and default rules
 func (m *Manager) client(ip net.IP) (*iptables.IPTables, error) {
 	client, err := m.rawClient(ip)
 	if err != nil {
 		return nil, err
 	}
+	inputDefaultRuleSpecs, outputDefaultRuleSpecs := m.defaultRuleSpecs(client)
 
 	ok, err := client.ChainExists("filter", ChainInputFilterName)
 	if err != nil {
@@ -427,7 +483,7 @@ func (m *Manager) client(ip net.IP) (*iptables.IPTables, error) {
 			return nil, fmt.Errorf("failed to create default drop all in netbird input chain: %w", err)
 		}
 
-		if err := client.Insert("filter", "INPUT", 1, m.inputDefaultRuleSpecs...); err != nil {
+		if err := client.Insert("filter", "INPUT", 1, inputDefaultRuleSpecs...); err != nil {
 			return nil, fmt.Errorf("failed to create input chain jump rule: %w", err)
 		}
 
@@ -447,7 +503,7 @@ func (m *Manager) client(ip net.IP) (*iptables.IPTables, error) {
 			return nil, fmt.Errorf("failed to create default drop all in netbird output chain: %w", err)
 		}
 
-		if err := client.AppendUnique("filter", "OUTPUT", m.outputDefaultRuleSpecs...); err != nil {
+		if err := client.AppendUnique("filter", "OUTPUT", outputDefaultRuleSpecs...); err != nil {
 			return nil, fmt.Errorf("failed to create output chain jump rule: %w", err)
 		}
 	}
@@ -462,14 +518,14 @@ func (m *Manager) actionToStr(action fw.Action) string {
 	return "DROP"
 }
 
-func (m *Manager) transformIPsetName(ipsetName string, sPort, dPort string) string {
+func (m *Manager) transformIPsetName(ipsetName string, sPort, dPort bool) string {
 	if ipsetName == "" {
 		return ""
-	} else if sPort != "" && dPort != "" {
+	} else if sPort && dPort {
 		return ipsetName + "-sport-dport"
-	} else if sPort != "" {
+	} else if sPort {
 		return ipsetName + "-sport"
-	} else if dPort != "" {
+	} else if dPort {
 		return ipsetName + "-dport"
 	}
 	return ipsetName
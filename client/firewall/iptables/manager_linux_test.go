@@ -81,13 +81,15 @@ func TestIptablesManager(t *testing.T) {
 	t.Run("add second rule", func(t *testing.T) {
 		ip := net.ParseIP("10.20.0.3")
 		port := &fw.Port{
-			Values: []int{8043: 8046},
+			IsRange: true,
+			Values:  []int{8043, 8046},
 		}
 		rule2, err = manager.AddFiltering(
 			ip, "tcp", port, nil, fw.RuleDirectionIN, fw.ActionAccept, "", "accept HTTPS traffic from ports range")
 		require.NoError(t, err, "failed to add rule")
 
 		checkRuleSpecs(t, ipv4Client, ChainInputFilterName, true, rule2.(*Rule).specs...)
+		require.Contains(t, rule2.(*Rule).specs, "8043:8046", "the range should be matched by the multiport module")
 	})
 
 	t.Run("delete first rule", func(t *testing.T) {
@@ -205,6 +207,44 @@ func TestIptablesManagerIPSet(t *testing.T) {
 	})
 }
 
+func TestPortSpecs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		port     *fw.Port
+		expected []string
+	}{
+		{
+			name: "no port",
+		},
+		{
+			name:     "single port",
+			port:     &fw.Port{Values: []int{8080}},
+			expected: []string{"--dport", "8080"},
+		},
+		{
+			name:     "range of ports",
+			port:     &fw.Port{IsRange: true, Values: []int{8000, 8100}},
+			expected: []string{"-m", "multiport", "--dports", "8000:8100"},
+		},
+		{
+			name:     "list of ports",
+			port:     &fw.Port{Values: []int{80, 443}},
+			expected: []string{"-m", "multiport", "--dports", "80,443"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, portSpecs("dport", tc.port))
+		})
+	}
+
+	m := &Manager{}
+	specs := m.filterRuleSpecs(net.ParseIP("10.20.0.2"), "tcp", nil,
+		portSpecs("dport", &fw.Port{IsRange: true, Values: []int{8000, 8100}}), fw.RuleDirectionIN, fw.ActionAccept, "")
+	require.Equal(t, []string{"-s", "10.20.0.2", "-p", "tcp", "-m", "multiport", "--dports", "8000:8100", "-j", "ACCEPT"}, specs)
+}
+
 func checkRuleSpecs(t *testing.T, ipv4Client *iptables.IPTables, chainName string, mustExists bool, rulespec ...string) {
 	exists, err := ipv4Client.Exists("filter", chainName, rulespec...)
 	require.NoError(t, err, "failed to check rule")
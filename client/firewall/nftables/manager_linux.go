@@ -189,12 +189,17 @@ func (m *Manager) AddFiltering(
 	}
 
 	if proto != "all" {
-		expressions = append(expressions, &expr.Payload{
-			DestRegister: 1,
-			Base:         expr.PayloadBaseNetworkHeader,
-			Offset:       uint32(9),
-			Len:          uint32(1),
-		})
+		if len(rawIP) == net.IPv6len {
+			// the IPv6 header may be followed by extension headers, use the transport protocol found by nftables
+			expressions = append(expressions, &expr.Meta{Key: expr.MetaKeyL4PROTO, Register: 1})
+		} else {
+			expressions = append(expressions, &expr.Payload{
+				DestRegister: 1,
+				Base:         expr.PayloadBaseNetworkHeader,
+				Offset:       uint32(9),
+				Len:          uint32(1),
+			})
+		}
 
 		var protoData []byte
 		switch proto {
@@ -204,6 +209,9 @@ func (m *Manager) AddFiltering(
 			protoData = []byte{unix.IPPROTO_UDP}
 		case fw.ProtocolICMP:
 			protoData = []byte{unix.IPPROTO_ICMP}
+			if len(rawIP) == net.IPv6len {
+				protoData = []byte{unix.IPPROTO_ICMPV6}
+			}
 		default:
 			return nil, fmt.Errorf("unsupported protocol: %s", proto)
 		}
@@ -257,37 +265,9 @@ func (m *Manager) AddFiltering(
 		}
 	}
 
-	if sPort != nil && len(sPort.Values) != 0 {
-		expressions = append(expressions,
-			&expr.Payload{
-				DestRegister: 1,
-				Base:         expr.PayloadBaseTransportHeader,
-				Offset:       0,
-				Len:          2,
-			},
-			&expr.Cmp{
-				Op:       expr.CmpOpEq,
-				Register: 1,
-				Data:     encodePort(*sPort),
-			},
-		)
-	}
-
-	if dPort != nil && len(dPort.Values) != 0 {
-		expressions = append(expressions,
-			&expr.Payload{
-				DestRegister: 1,
-				Base:         expr.PayloadBaseTransportHeader,
-				Offset:       2,
-				Len:          2,
-			},
-			&expr.Cmp{
-				Op:       expr.CmpOpEq,
-				Register: 1,
-				Data:     encodePort(*dPort),
-			},
-		)
-	}
+	// source and destination ports are the first two fields of the TCP and UDP headers
+	expressions = append(expressions, portExpressions(sPort, 0)...)
+	expressions = append(expressions, portExpressions(dPort, 2)...)
 
 	if action == fw.ActionAccept {
 		expressions = append(expressions, &expr.Verdict{Kind: expr.VerdictAccept})
@@ -394,10 +374,10 @@ func (m *Manager) chain(
 	}
 	if name == FilterInputChainName {
 		m.filterInputChainIPv6, err = getChain(m.filterInputChainIPv6, nftables.TableFamilyIPv6)
-		return m.tableIPv4, m.filterInputChainIPv6, err
+		return m.tableIPv6, m.filterInputChainIPv6, err
 	}
 	m.filterOutputChainIPv6, err = getChain(m.filterOutputChainIPv6, nftables.TableFamilyIPv6)
-	return m.tableIPv4, m.filterOutputChainIPv6, err
+	return m.tableIPv6, m.filterOutputChainIPv6, err
 }
 
 // table returns the table for the given family of the IP address
@@ -448,7 +428,7 @@ func (m *Manager) createTableIfNotExists(
 		}
 	}
 
-	table := m.rConn.AddTable(&nftables.Table{Name: tableName, Family: nftables.TableFamilyIPv4})
+	table := m.rConn.AddTable(&nftables.Table{Name: tableName, Family: family})
 	if err := m.rConn.Flush(); err != nil {
 		return nil, err
 	}
@@ -507,9 +487,13 @@ func (m *Manager) createChainIfNotExists(
 		},
 	}
 
-	mask, _ := netip.AddrFromSlice(m.wgIface.Address().Network.Mask)
-	if m.wgIface.Address().IP.To4() == nil {
-		ip, _ := netip.AddrFromSlice(m.wgIface.Address().Network.IP.To16())
+	if family == nftables.TableFamilyIPv6 {
+		// accept the IPv6 traffic not originated from (or not destined to) the IPv6 overlay network
+		network := m.wgIface.Address().NetworkV6
+		if network == nil {
+			network = &net.IPNet{IP: net.IPv6unspecified, Mask: net.CIDRMask(128, 128)}
+		}
+		ip, _ := netip.AddrFromSlice(network.IP.To16())
 		expressions = append(expressions,
 			&expr.Payload{
 				DestRegister: 2,
@@ -521,13 +505,13 @@ func (m *Manager) createChainIfNotExists(
 				SourceRegister: 2,
 				DestRegister:   2,
 				Len:            16,
-				Xor:            []byte{0x0, 0x0, 0x0, 0x0},
-				Mask:           mask.Unmap().AsSlice(),
+				Xor:            make([]byte, net.IPv6len),
+				Mask:           network.Mask,
 			},
 			&expr.Cmp{
 				Op:       expr.CmpOpNeq,
 				Register: 2,
-				Data:     ip.Unmap().AsSlice(),
+				Data:     ip.AsSlice(),
 			},
 			&expr.Verdict{Kind: expr.VerdictAccept},
 		)
@@ -735,11 +719,19 @@ func (m *Manager) AllowNetbird() error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	tf := nftables.TableFamilyIPv4
-	if m.wgIface.Address().IP.To4() == nil {
-		tf = nftables.TableFamilyIPv6
+	err := m.allowNetbird(nftables.TableFamilyIPv4)
+	if err != nil {
+		return err
 	}
 
+	if m.wgIface.Address().HasIPv6() {
+		return m.allowNetbird(nftables.TableFamilyIPv6)
+	}
+	return nil
+}
+
+// allowNetbird allows netbird interface traffic in the INPUT chain of the filter table of the given family
+func (m *Manager) allowNetbird(tf nftables.TableFamily) error {
 	chains, err := m.rConn.ListChainsOfTableFamily(tf)
 	if err != nil {
 		return fmt.Errorf("list of chains: %w", err)
@@ -858,9 +850,41 @@ func (m *Manager) detectAllowNetbirdRule(existedRules []*nftables.Rule) *nftable
 	return nil
 }
 
-func encodePort(port fw.Port) []byte {
+// portExpressions returns the expressions matching the port at the given offset of the transport header,
+// the range of ports is matched by the range expression
+func portExpressions(port *fw.Port, offset uint32) []expr.Any {
+	if port == nil || len(port.Values) == 0 {
+		return nil
+	}
+
+	expressions := []expr.Any{
+		&expr.Payload{
+			DestRegister: 1,
+			Base:         expr.PayloadBaseTransportHeader,
+			Offset:       offset,
+			Len:          2,
+		},
+	}
+
+	if port.IsRange && len(port.Values) == 2 {
+		return append(expressions, &expr.Range{
+			Op:       expr.CmpOpEq,
+			Register: 1,
+			FromData: encodePort(port.Values[0]),
+			ToData:   encodePort(port.Values[1]),
+		})
+	}
+
+	return append(expressions, &expr.Cmp{
+		Op:       expr.CmpOpEq,
+		Register: 1,
+		Data:     encodePort(port.Values[0]),
+	})
+}
+
+func encodePort(port int) []byte {
 	bs := make([]byte, 2)
-	binary.BigEndian.PutUint16(bs, uint16(port.Values[0]))
+	binary.BigEndian.PutUint16(bs, uint16(port))
 	return bs
 }
 
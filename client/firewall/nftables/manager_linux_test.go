@@ -154,6 +154,38 @@ func TestNftablesManager(t *testing.T) {
 	require.NoError(t, err, "failed to reset")
 }
 
+func TestPortExpressions(t *testing.T) {
+	require.Empty(t, portExpressions(nil, 2), "no expressions are expected without port")
+
+	destinationPort := &expr.Payload{
+		DestRegister: 1,
+		Base:         expr.PayloadBaseTransportHeader,
+		Offset:       2,
+		Len:          2,
+	}
+
+	expectedExprs := []expr.Any{
+		destinationPort,
+		&expr.Cmp{
+			Op:       expr.CmpOpEq,
+			Register: 1,
+			Data:     []byte{0x1f, 0x90},
+		},
+	}
+	require.Equal(t, expectedExprs, portExpressions(&fw.Port{Values: []int{8080}}, 2))
+
+	expectedExprs = []expr.Any{
+		destinationPort,
+		&expr.Range{
+			Op:       expr.CmpOpEq,
+			Register: 1,
+			FromData: []byte{0x1f, 0x40},
+			ToData:   []byte{0x1f, 0xa4},
+		},
+	}
+	require.Equal(t, expectedExprs, portExpressions(&fw.Port{IsRange: true, Values: []int{8000, 8100}}, 2))
+}
+
 func TestNFtablesCreatePerformance(t *testing.T) {
 	mock := &iFaceMock{
 		NameFunc: func() string {
@@ -35,6 +35,10 @@ type Port struct {
 
 // String interface implementation
 func (p *Port) String() string {
+	if p.IsRange && len(p.Values) == 2 {
+		return strconv.Itoa(p.Values[0]) + "-" + strconv.Itoa(p.Values[1])
+	}
+
 	var ports string
 	for _, port := range p.Values {
 		if ports != "" {
@@ -9,6 +9,7 @@ func (m *Manager) Reset() error {
 
 	m.outgoingRules = make(map[string]RuleSet)
 	m.incomingRules = make(map[string]RuleSet)
+	m.conntrack.reset()
 
 	return nil
 }
@@ -12,6 +12,7 @@ func (m *Manager) Reset() error {
 
 	m.outgoingRules = make(map[string]RuleSet)
 	m.incomingRules = make(map[string]RuleSet)
+	m.conntrack.reset()
 
 	if m.resetHook != nil {
 		return m.resetHook()
@@ -0,0 +1,214 @@
+package uspfilter
+
+import (
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// tcpTimeout is the idle timeout of a TCP connection
+	tcpTimeout = 30 * time.Minute
+	// tcpClosingTimeout is the timeout of a TCP connection after a FIN or RST was seen
+	tcpClosingTimeout = 30 * time.Second
+	// udpTimeout is the idle timeout of a UDP flow
+	udpTimeout = 3 * time.Minute
+	// icmpTimeout is the idle timeout of an ICMP echo flow
+	icmpTimeout = 30 * time.Second
+
+	// conntrackCleanupInterval is the minimum interval between two sweeps of the expired connections
+	conntrackCleanupInterval = time.Minute
+	// conntrackMaxEntries bounds the connection table, new connections are not tracked when it is full
+	conntrackMaxEntries = 65536
+)
+
+// connKey identifies a connection in the direction of the packet which opened it.
+// For ICMP echo, both ports hold the echo identifier
+type connKey struct {
+	proto   gopacket.LayerType
+	srcIP   netip.Addr
+	dstIP   netip.Addr
+	srcPort uint16
+	dstPort uint16
+}
+
+func (k connKey) reverse() connKey {
+	return connKey{
+		proto:   k.proto,
+		srcIP:   k.dstIP,
+		dstIP:   k.srcIP,
+		srcPort: k.dstPort,
+		dstPort: k.srcPort,
+	}
+}
+
+type connEntry struct {
+	lastSeen time.Time
+	timeout  time.Duration
+}
+
+// connTracker keeps the connections allowed by the rules, so the replies are accepted without a reverse rule
+type connTracker struct {
+	mutex       sync.Mutex
+	conns       map[connKey]*connEntry
+	lastCleanup time.Time
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func newConnTracker() *connTracker {
+	return &connTracker{
+		conns:       make(map[connKey]*connEntry),
+		lastCleanup: time.Now(),
+		now:         time.Now,
+	}
+}
+
+// track adds or refreshes the connection opened by an accepted packet
+func (c *connTracker) track(key connKey, d *decoder) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	c.cleanup(now)
+
+	entry, found := c.conns[key]
+	if !found {
+		if len(c.conns) >= conntrackMaxEntries {
+			return
+		}
+		entry = &connEntry{}
+		c.conns[key] = entry
+	}
+	entry.lastSeen = now
+	entry.timeout = connTimeout(key.proto, d)
+}
+
+// isReply returns true if the packet is a reply of a tracked connection, the connection is refreshed
+func (c *connTracker) isReply(key connKey, d *decoder) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	reverseKey := key.reverse()
+	entry, found := c.conns[reverseKey]
+	if !found {
+		return false
+	}
+
+	now := c.now()
+	if now.Sub(entry.lastSeen) > entry.timeout {
+		delete(c.conns, reverseKey)
+		return false
+	}
+
+	entry.lastSeen = now
+	if timeout := connTimeout(key.proto, d); timeout < entry.timeout {
+		entry.timeout = timeout
+	}
+	return true
+}
+
+// reset removes all the tracked connections
+func (c *connTracker) reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.conns = make(map[connKey]*connEntry)
+}
+
+func (c *connTracker) cleanup(now time.Time) {
+	if now.Sub(c.lastCleanup) < conntrackCleanupInterval {
+		return
+	}
+	c.lastCleanup = now
+
+	for key, entry := range c.conns {
+		if now.Sub(entry.lastSeen) > entry.timeout {
+			delete(c.conns, key)
+		}
+	}
+}
+
+// connTimeout returns the idle timeout of a connection after the decoded packet
+func connTimeout(proto gopacket.LayerType, d *decoder) time.Duration {
+	switch proto {
+	case layers.LayerTypeTCP:
+		if d.tcp.FIN || d.tcp.RST {
+			return tcpClosingTimeout
+		}
+		return tcpTimeout
+	case layers.LayerTypeUDP:
+		return udpTimeout
+	default:
+		return icmpTimeout
+	}
+}
+
+// newConnKey returns the connection key of a decoded packet, false if the packet can't be tracked.
+// Only the ICMP echo requests and replies are tracked.
+func newConnKey(d *decoder) (connKey, bool) {
+	var key connKey
+	var srcIP, dstIP net.IP
+	switch d.decoded[0] {
+	case layers.LayerTypeIPv4:
+		srcIP, dstIP = d.ip4.SrcIP, d.ip4.DstIP
+	case layers.LayerTypeIPv6:
+		srcIP, dstIP = d.ip6.SrcIP, d.ip6.DstIP
+	default:
+		return key, false
+	}
+
+	var ok bool
+	if key.srcIP, ok = netip.AddrFromSlice(srcIP); !ok {
+		return key, false
+	}
+	if key.dstIP, ok = netip.AddrFromSlice(dstIP); !ok {
+		return key, false
+	}
+	key.srcIP, key.dstIP = key.srcIP.Unmap(), key.dstIP.Unmap()
+
+	key.proto = d.decoded[1]
+	switch key.proto {
+	case layers.LayerTypeTCP:
+		key.srcPort, key.dstPort = uint16(d.tcp.SrcPort), uint16(d.tcp.DstPort)
+	case layers.LayerTypeUDP:
+		key.srcPort, key.dstPort = uint16(d.udp.SrcPort), uint16(d.udp.DstPort)
+	case layers.LayerTypeICMPv4:
+		switch d.icmp4.TypeCode.Type() {
+		case layers.ICMPv4TypeEchoRequest, layers.ICMPv4TypeEchoReply:
+			key.srcPort, key.dstPort = d.icmp4.Id, d.icmp4.Id
+		default:
+			return key, false
+		}
+	case layers.LayerTypeICMPv6:
+		echo, ok := icmpv6Echo(d)
+		if !ok {
+			return key, false
+		}
+		key.srcPort, key.dstPort = echo, echo
+	default:
+		return key, false
+	}
+
+	return key, true
+}
+
+// icmpv6Echo returns the identifier of an ICMPv6 echo request or reply
+func icmpv6Echo(d *decoder) (uint16, bool) {
+	switch d.icmp6.TypeCode.Type() {
+	case layers.ICMPv6TypeEchoRequest, layers.ICMPv6TypeEchoReply:
+	default:
+		return 0, false
+	}
+
+	// the echo identifier is the first field of the ICMPv6 payload
+	payload := d.icmp6.Payload
+	if len(payload) < 2 {
+		return 0, false
+	}
+	return uint16(payload[0])<<8 | uint16(payload[1]), true
+}
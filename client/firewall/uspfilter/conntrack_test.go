@@ -0,0 +1,137 @@
+package uspfilter
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/require"
+
+	fw "github.com/netbirdio/netbird/client/firewall"
+	"github.com/netbirdio/netbird/iface"
+)
+
+func serializePacket(t *testing.T, src, dst string, transport gopacket.SerializableLayer) []byte {
+	t.Helper()
+
+	ipv4 := &layers.IPv4{
+		TTL:     64,
+		Version: 4,
+		SrcIP:   net.ParseIP(src),
+		DstIP:   net.ParseIP(dst),
+	}
+
+	switch l := transport.(type) {
+	case *layers.TCP:
+		ipv4.Protocol = layers.IPProtocolTCP
+		require.NoError(t, l.SetNetworkLayerForChecksum(ipv4))
+	case *layers.UDP:
+		ipv4.Protocol = layers.IPProtocolUDP
+		require.NoError(t, l.SetNetworkLayerForChecksum(ipv4))
+	case *layers.ICMPv4:
+		ipv4.Protocol = layers.IPProtocolICMPv4
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		ComputeChecksums: true,
+		FixLengths:       true,
+	}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, ipv4, transport, gopacket.Payload("test")))
+	return buf.Bytes()
+}
+
+func newConntrackTestManager(t *testing.T) *Manager {
+	t.Helper()
+
+	m, err := Create(&IFaceMock{
+		SetFilterFunc: func(iface.PacketFilter) error { return nil },
+	})
+	require.NoError(t, err)
+
+	m.wgNetwork = &net.IPNet{
+		IP:   net.ParseIP("100.10.0.0"),
+		Mask: net.CIDRMask(16, 32),
+	}
+	return m
+}
+
+func TestConntrackAcceptsReplies(t *testing.T) {
+	const (
+		localIP  = "100.10.0.1"
+		remoteIP = "100.10.0.2"
+	)
+
+	m := newConntrackTestManager(t)
+	require.True(t, m.IsStateful())
+
+	_, err := m.AddFiltering(net.ParseIP(remoteIP), fw.ProtocolTCP, nil, &fw.Port{Values: []int{80}},
+		fw.RuleDirectionOUT, fw.ActionAccept, "", "")
+	require.NoError(t, err)
+	_, err = m.AddFiltering(net.ParseIP(remoteIP), fw.ProtocolICMP, nil, nil,
+		fw.RuleDirectionOUT, fw.ActionAccept, "", "")
+	require.NoError(t, err)
+
+	reply := serializePacket(t, remoteIP, localIP, &layers.TCP{SrcPort: 80, DstPort: 51000, SYN: true, ACK: true})
+	require.True(t, m.DropIncoming(reply), "a reply without a tracked connection should be dropped")
+
+	request := serializePacket(t, localIP, remoteIP, &layers.TCP{SrcPort: 51000, DstPort: 80, SYN: true})
+	require.False(t, m.DropOutgoing(request), "the connection should be allowed by the outgoing rule")
+	require.False(t, m.DropIncoming(reply), "the reply of the tracked connection should be accepted")
+
+	newConnection := serializePacket(t, remoteIP, localIP, &layers.TCP{SrcPort: 51000, DstPort: 80, SYN: true})
+	require.True(t, m.DropIncoming(newConnection), "the remote peer should not open connections to the same port")
+
+	otherPort := serializePacket(t, remoteIP, localIP, &layers.TCP{SrcPort: 80, DstPort: 51001, SYN: true, ACK: true})
+	require.True(t, m.DropIncoming(otherPort), "only the replies of the tracked connection should be accepted")
+
+	echoRequest := serializePacket(t, localIP, remoteIP, &layers.ICMPv4{
+		TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoRequest, 0), Id: 7, Seq: 1})
+	echoReply := serializePacket(t, remoteIP, localIP, &layers.ICMPv4{
+		TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoReply, 0), Id: 7, Seq: 1})
+	require.True(t, m.DropIncoming(echoReply), "an echo reply without a request should be dropped")
+	require.False(t, m.DropOutgoing(echoRequest))
+	require.False(t, m.DropIncoming(echoReply), "the echo reply should be accepted")
+
+	require.NoError(t, m.Reset())
+	require.True(t, m.DropIncoming(reply), "the tracked connections should be removed on reset")
+}
+
+func TestConntrackTimeouts(t *testing.T) {
+	const (
+		localIP  = "100.10.0.1"
+		remoteIP = "100.10.0.2"
+	)
+
+	m := newConntrackTestManager(t)
+	now := time.Now()
+	m.conntrack.now = func() time.Time { return now }
+
+	_, err := m.AddFiltering(net.ParseIP(remoteIP), fw.ProtocolUDP, nil, nil,
+		fw.RuleDirectionIN, fw.ActionAccept, "", "")
+	require.NoError(t, err)
+	_, err = m.AddFiltering(net.ParseIP(remoteIP), fw.ProtocolTCP, nil, &fw.Port{Values: []int{22}},
+		fw.RuleDirectionIN, fw.ActionAccept, "", "")
+	require.NoError(t, err)
+
+	// the replies of the connections allowed by an incoming rule are accepted as well
+	require.False(t, m.DropIncoming(serializePacket(t, remoteIP, localIP, &layers.UDP{SrcPort: 5000, DstPort: 6000})))
+	udpReply := serializePacket(t, localIP, remoteIP, &layers.UDP{SrcPort: 6000, DstPort: 5000})
+	require.False(t, m.DropOutgoing(udpReply))
+
+	now = now.Add(udpTimeout + time.Second)
+	require.True(t, m.DropOutgoing(udpReply), "the idle UDP flow should expire")
+
+	require.False(t, m.DropIncoming(serializePacket(t, remoteIP, localIP, &layers.TCP{SrcPort: 40000, DstPort: 22, SYN: true})))
+	tcpReply := serializePacket(t, localIP, remoteIP, &layers.TCP{SrcPort: 22, DstPort: 40000, ACK: true})
+	require.False(t, m.DropOutgoing(tcpReply))
+
+	now = now.Add(tcpClosingTimeout + time.Second)
+	require.False(t, m.DropOutgoing(tcpReply), "the established TCP connection should not expire")
+
+	require.False(t, m.DropOutgoing(serializePacket(t, localIP, remoteIP, &layers.TCP{SrcPort: 22, DstPort: 40000, FIN: true, ACK: true})))
+	now = now.Add(tcpClosingTimeout + time.Second)
+	require.True(t, m.DropOutgoing(tcpReply), "the closed TCP connection should expire")
+}
@@ -16,8 +16,8 @@ type Rule struct {
 	matchByIP  bool
 	protoLayer gopacket.LayerType
 	direction  fw.RuleDirection
-	sPort      uint16
-	dPort      uint16
+	sPort      portRange
+	dPort      portRange
 	drop       bool
 	comment    string
 
@@ -28,3 +28,33 @@ type Rule struct {
 func (r *Rule) GetRuleID() string {
 	return r.id
 }
+
+// portRange is an inclusive range of ports, the zero value matches no port
+type portRange struct {
+	start uint16
+	end   uint16
+}
+
+// newPortRange converts the port of a firewall rule, a single port or a range of ports
+func newPortRange(port *fw.Port) portRange {
+	if port == nil {
+		return portRange{}
+	}
+
+	switch {
+	case port.IsRange && len(port.Values) == 2:
+		return portRange{start: uint16(port.Values[0]), end: uint16(port.Values[1])}
+	case len(port.Values) == 1:
+		return portRange{start: uint16(port.Values[0]), end: uint16(port.Values[0])}
+	default:
+		return portRange{}
+	}
+}
+
+func (p portRange) isEmpty() bool {
+	return p.start == 0
+}
+
+func (p portRange) contains(port uint16) bool {
+	return !p.isEmpty() && port >= p.start && port <= p.end
+}
@@ -30,8 +30,10 @@ type Manager struct {
 	outgoingRules map[string]RuleSet
 	incomingRules map[string]RuleSet
 	wgNetwork     *net.IPNet
+	wgNetworkV6   *net.IPNet
 	decoders      sync.Pool
 	wgIface       IFaceMapper
+	conntrack     *connTracker
 	resetHook func() error
 
 	mutex sync.RWMutex
@@ -48,6 +50,7 @@ type decoder struct {
 	icmp6   layers.ICMPv6
 	decoded []gopacket.LayerType
 	parser  *gopacket.DecodingLayerParser
+	parser6 *gopacket.DecodingLayerParser
 }
 
 // Create userspace firewall manager constructor
@@ -63,12 +66,18 @@ func Create(iface IFaceMapper) (*Manager, error) {
 					&d.eth, &d.ip4, &d.ip6, &d.icmp4, &d.icmp6, &d.tcp, &d.udp,
 				)
 				d.parser.IgnoreUnsupported = true
+				d.parser6 = gopacket.NewDecodingLayerParser(
+					layers.LayerTypeIPv6,
+					&d.eth, &d.ip4, &d.ip6, &d.icmp4, &d.icmp6, &d.tcp, &d.udp,
+				)
+				d.parser6.IgnoreUnsupported = true
 				return d
 			},
 		},
 		outgoingRules: make(map[string]RuleSet),
 		incomingRules: make(map[string]RuleSet),
 		wgIface:       iface,
+		conntrack:     newConnTracker(),
 	}
 
 	if err := iface.SetFilter(m); err != nil {
@@ -109,13 +118,8 @@ func (m *Manager) AddFiltering(
 		r.matchByIP = false
 	}
 
-	if sPort != nil && len(sPort.Values) == 1 {
-		r.sPort = uint16(sPort.Values[0])
-	}
-
-	if dPort != nil && len(dPort.Values) == 1 {
-		r.dPort = uint16(dPort.Values[0])
-	}
+	r.sPort = newPortRange(sPort)
+	r.dPort = newPortRange(dPort)
 
 	switch proto {
 	case fw.ProtocolTCP:
@@ -196,7 +200,12 @@ func (m *Manager) dropFilter(packetData []byte, rules map[string]RuleSet, isInco
 	d := m.decoders.Get().(*decoder)
 	defer m.decoders.Put(d)
 
-	if err := d.parser.DecodeLayers(packetData, &d.decoded); err != nil {
+	parser := d.parser
+	if len(packetData) > 0 && packetData[0]>>4 == 6 {
+		parser = d.parser6
+	}
+
+	if err := parser.DecodeLayers(packetData, &d.decoded); err != nil {
 		log.Tracef("couldn't decode layer, err: %s", err)
 		return true
 	}
@@ -214,7 +223,7 @@ func (m *Manager) dropFilter(packetData []byte, rules map[string]RuleSet, isInco
 			return false
 		}
 	case layers.LayerTypeIPv6:
-		if !m.wgNetwork.Contains(d.ip6.SrcIP) || !m.wgNetwork.Contains(d.ip6.DstIP) {
+		if m.wgNetworkV6 == nil || !m.wgNetworkV6.Contains(d.ip6.SrcIP) || !m.wgNetworkV6.Contains(d.ip6.DstIP) {
 			return false
 		}
 	default:
@@ -222,6 +231,12 @@ func (m *Manager) dropFilter(packetData []byte, rules map[string]RuleSet, isInco
 		return true
 	}
 
+	// the replies of the connections allowed in the other direction are accepted without a rule
+	key, trackable := newConnKey(d)
+	if trackable && m.conntrack.isReply(key, d) {
+		return false
+	}
+
 	var ip net.IP
 	switch ipLayer {
 	case layers.LayerTypeIPv4:
@@ -238,16 +253,14 @@ func (m *Manager) dropFilter(packetData []byte, rules map[string]RuleSet, isInco
 		}
 	}
 
-	filter, ok := validateRule(ip, packetData, rules[ip.String()], d)
-	if ok {
-		return filter
-	}
-	filter, ok = validateRule(ip, packetData, rules["0.0.0.0"], d)
-	if ok {
-		return filter
-	}
-	filter, ok = validateRule(ip, packetData, rules["::"], d)
-	if ok {
+	for _, ruleSetKey := range []string{ip.String(), "0.0.0.0", "::"} {
+		filter, ok := validateRule(ip, packetData, rules[ruleSetKey], d)
+		if !ok {
+			continue
+		}
+		if !filter && trackable {
+			m.conntrack.track(key, d)
+		}
 		return filter
 	}
 
@@ -255,6 +268,11 @@ func (m *Manager) dropFilter(packetData []byte, rules map[string]RuleSet, isInco
 	return true
 }
 
+// IsStateful returns true as the replies of the allowed connections are accepted by the connection tracking
+func (m *Manager) IsStateful() bool {
+	return true
+}
+
 func validateRule(ip net.IP, packetData []byte, rules map[string]Rule, d *decoder) (bool, bool) {
 	payloadLayer := d.decoded[1]
 	for _, rule := range rules {
@@ -272,13 +290,10 @@ func validateRule(ip net.IP, packetData []byte, rules map[string]Rule, d *decode
 
 		switch payloadLayer {
 		case layers.LayerTypeTCP:
-			if rule.sPort == 0 && rule.dPort == 0 {
-				return rule.drop, true
-			}
-			if rule.sPort != 0 && rule.sPort == uint16(d.tcp.SrcPort) {
+			if rule.sPort.isEmpty() && rule.dPort.isEmpty() {
 				return rule.drop, true
 			}
-			if rule.dPort != 0 && rule.dPort == uint16(d.tcp.DstPort) {
+			if rule.sPort.contains(uint16(d.tcp.SrcPort)) || rule.dPort.contains(uint16(d.tcp.DstPort)) {
 				return rule.drop, true
 			}
 		case layers.LayerTypeUDP:
@@ -288,16 +303,12 @@ func validateRule(ip net.IP, packetData []byte, rules map[string]Rule, d *decode
 				return rule.udpHook(packetData), true
 			}
 
-			if rule.sPort == 0 && rule.dPort == 0 {
+			if rule.sPort.isEmpty() && rule.dPort.isEmpty() {
 				return rule.drop, true
 			}
-			if rule.sPort != 0 && rule.sPort == uint16(d.udp.SrcPort) {
+			if rule.sPort.contains(uint16(d.udp.SrcPort)) || rule.dPort.contains(uint16(d.udp.DstPort)) {
 				return rule.drop, true
 			}
-			if rule.dPort != 0 && rule.dPort == uint16(d.udp.DstPort) {
-				return rule.drop, true
-			}
-			return rule.drop, true
 		case layers.LayerTypeICMPv4, layers.LayerTypeICMPv6:
 			return rule.drop, true
 		}
@@ -305,9 +316,11 @@ func validateRule(ip net.IP, packetData []byte, rules map[string]Rule, d *decode
 	return false, false
 }
 
-// SetNetwork of the wireguard interface to which filtering applied
+// SetNetwork of the wireguard interface to which filtering applied.
+// The IPv6 overlay network, if any, is taken from the interface address
 func (m *Manager) SetNetwork(network *net.IPNet) {
 	m.wgNetwork = network
+	m.wgNetworkV6 = m.wgIface.Address().NetworkV6
 }
 
 // AddUDPPacketHook calls hook when UDP packet from given direction matched
@@ -320,7 +333,7 @@ func (m *Manager) AddUDPPacketHook(
 		id:         uuid.New().String(),
 		ip:         ip,
 		protoLayer: layers.LayerTypeUDP,
-		dPort:      dPort,
+		dPort:      portRange{start: dPort, end: dPort},
 		ipLayer:    layers.LayerTypeIPv6,
 		direction:  fw.RuleDirectionOUT,
 		comment:    fmt.Sprintf("UDP Hook direction: %v, ip:%v, dport:%d", in, ip, dPort),
@@ -206,8 +206,8 @@ func TestAddUDPPacketHook(t *testing.T) {
 				t.Errorf("expected ip %s, got %s", tt.ip, addedRule.ip)
 				return
 			}
-			if tt.dPort != addedRule.dPort {
-				t.Errorf("expected dPort %d, got %d", tt.dPort, addedRule.dPort)
+			if !addedRule.dPort.contains(tt.dPort) || addedRule.dPort.start != addedRule.dPort.end {
+				t.Errorf("expected dPort %d, got %v", tt.dPort, addedRule.dPort)
 				return
 			}
 			if layers.LayerTypeUDP != addedRule.protoLayer {
@@ -409,3 +409,37 @@ func TestUSPFilterCreatePerformance(t *testing.T) {
 		})
 	}
 }
+
+func TestPortRange(t *testing.T) {
+	const (
+		localIP  = "100.10.0.1"
+		remoteIP = "100.10.0.2"
+	)
+
+	m := newConntrackTestManager(t)
+
+	port := &fw.Port{IsRange: true, Values: []int{8000, 8100}}
+	_, err := m.AddFiltering(net.ParseIP(remoteIP), fw.ProtocolTCP, nil, port, fw.RuleDirectionIN, fw.ActionAccept, "", "")
+	require.NoError(t, err)
+	_, err = m.AddFiltering(net.ParseIP(remoteIP), fw.ProtocolUDP, nil, port, fw.RuleDirectionIN, fw.ActionAccept, "", "")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		port    uint16
+		allowed bool
+	}{
+		{port: 7999},
+		{port: 8000, allowed: true},
+		{port: 8050, allowed: true},
+		{port: 8100, allowed: true},
+		{port: 8101},
+	}
+
+	for _, tc := range testCases {
+		tcp := serializePacket(t, remoteIP, localIP, &layers.TCP{SrcPort: 40000, DstPort: layers.TCPPort(tc.port), SYN: true})
+		require.Equal(t, !tc.allowed, m.DropIncoming(tcp), "tcp port %d", tc.port)
+
+		udp := serializePacket(t, remoteIP, localIP, &layers.UDP{SrcPort: 40000, DstPort: layers.UDPPort(tc.port)})
+		require.Equal(t, !tc.allowed, m.DropIncoming(udp), "udp port %d", tc.port)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -96,13 +97,15 @@ func (d *DefaultManager) ApplyFiltering(networkMap *mgmProto.NetworkMap) {
 	// we add default firewall rule which accepts connection to any peer
 	// in the network by SSH (TCP 22 port).
 	if enableSSH {
-		rules = append(rules, &mgmProto.FirewallRule{
-			PeerIP:    "0.0.0.0",
-			Direction: mgmProto.FirewallRule_IN,
-			Action:    mgmProto.FirewallRule_ACCEPT,
-			Protocol:  mgmProto.FirewallRule_TCP,
-			Port:      strconv.Itoa(ssh.DefaultSSHPort),
-		})
+		for _, anyIP := range anyPeerIPs(networkMap) {
+			rules = append(rules, &mgmProto.FirewallRule{
+				PeerIP:    anyIP,
+				Direction: mgmProto.FirewallRule_IN,
+				Action:    mgmProto.FirewallRule_ACCEPT,
+				Protocol:  mgmProto.FirewallRule_TCP,
+				Port:      strconv.Itoa(ssh.DefaultSSHPort),
+			})
+		}
 	}
 
 	// if we got empty rules list but management not set networkMap.FirewallRulesIsEmpty flag
@@ -217,12 +220,10 @@ func (d *DefaultManager) protoRuleToFirewallRule(
 
 	var port *firewall.Port
 	if r.Port != "" {
-		value, err := strconv.Atoi(r.Port)
+		var err error
+		port, err = convertToFirewallPort(r.Port)
 		if err != nil {
-			return "", nil, fmt.Errorf("invalid port, skipping firewall rule")
-		}
-		port = &firewall.Port{
-			Values: []int{value},
+			return "", nil, fmt.Errorf("invalid port %q, skipping firewall rule: %w", r.Port, err)
 		}
 	}
 
@@ -266,7 +267,7 @@ func (d *DefaultManager) addInRules(
 	}
 	rules = append(rules, rule)
 
-	if shouldSkipInvertedRule(protocol, port) {
+	if shouldSkipInvertedRule(protocol, port) || d.isStateful() {
 		return rules, nil
 	}
 
@@ -295,7 +296,7 @@ func (d *DefaultManager) addOutRules(
 	}
 	rules = append(rules, rule)
 
-	if shouldSkipInvertedRule(protocol, port) {
+	if shouldSkipInvertedRule(protocol, port) || d.isStateful() {
 		return rules, nil
 	}
 
@@ -369,7 +370,7 @@ func (d *DefaultManager) squashAcceptRules(
 		// special case, when we recieve this all network IP address
 		// it means that rules for that protocol was already optimized on the
 		// management side
-		if r.PeerIP == "0.0.0.0" {
+		if r.PeerIP == "0.0.0.0" || r.PeerIP == "::" {
 			squashedRules = append(squashedRules, r)
 			squashedProtocols[r.Protocol] = struct{}{}
 			return
@@ -410,13 +411,15 @@ func (d *DefaultManager) squashAcceptRules(
 				continue
 			}
 
-			// add special rule 0.0.0.0 which allows all IP's in our firewall implementations
-			squashedRules = append(squashedRules, &mgmProto.FirewallRule{
-				PeerIP:    "0.0.0.0",
-				Direction: direction,
-				Action:    mgmProto.FirewallRule_ACCEPT,
-				Protocol:  protocol,
-			})
+			// add special rule 0.0.0.0 (and :: for the IPv6 overlay) which allows all IP's in our firewall implementations
+			for _, anyIP := range anyPeerIPs(networkMap) {
+				squashedRules = append(squashedRules, &mgmProto.FirewallRule{
+					PeerIP:    anyIP,
+					Direction: direction,
+					Action:    mgmProto.FirewallRule_ACCEPT,
+					Protocol:  protocol,
+				})
+			}
 			squashedProtocols[protocol] = struct{}{}
 
 			if protocol == mgmProto.FirewallRule_ALL {
@@ -456,9 +459,22 @@ func (d *DefaultManager) squashAcceptRules(
 	return append(rules, squashedRules...), squashedProtocols
 }
 
-// getRuleGroupingSelector takes all rule properties except IP address to build selector
+// getRuleGroupingSelector takes all rule properties except IP address to build selector.
+// IPv4 and IPv6 addresses can't share a set, so the address family is part of the selector
 func (d *DefaultManager) getRuleGroupingSelector(rule *mgmProto.FirewallRule) string {
-	return fmt.Sprintf("%v:%v:%v:%s", strconv.Itoa(int(rule.Direction)), rule.Action, rule.Protocol, rule.Port)
+	selector := fmt.Sprintf("%v:%v:%v:%s", strconv.Itoa(int(rule.Direction)), rule.Action, rule.Protocol, rule.Port)
+	if ip := net.ParseIP(rule.PeerIP); ip != nil && ip.To4() == nil {
+		selector += ":v6"
+	}
+	return selector
+}
+
+// anyPeerIPs returns the addresses matching all the peers, :: is included only if the peer has an IPv6 overlay address
+func anyPeerIPs(networkMap *mgmProto.NetworkMap) []string {
+	if networkMap.GetPeerConfig().GetAddressV6() != "" {
+		return []string{"0.0.0.0", "::"}
+	}
+	return []string{"0.0.0.0"}
 }
 
 func convertToFirewallProtocol(protocol mgmProto.FirewallRuleProtocol) firewall.Protocol {
@@ -476,10 +492,42 @@ func convertToFirewallProtocol(protocol mgmProto.FirewallRuleProtocol) firewall.
 	}
 }
 
+// isStateful returns true if the firewall accepts the replies of the allowed connections,
+// the inverted rules would open the port in both directions
+func (d *DefaultManager) isStateful() bool {
+	stateful, ok := d.manager.(firewall.StatefulManager)
+	return ok && stateful.IsStateful()
+}
+
 func shouldSkipInvertedRule(protocol firewall.Protocol, port *firewall.Port) bool {
 	return protocol == firewall.ProtocolALL || protocol == firewall.ProtocolICMP || port == nil
 }
 
+// convertToFirewallPort parses the port of a rule, a single port or a range of ports in the "start-end" form
+func convertToFirewallPort(value string) (*firewall.Port, error) {
+	start, end, isRange := strings.Cut(value, "-")
+	if !isRange {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		return &firewall.Port{Values: []int{port}}, nil
+	}
+
+	startPort, err := strconv.Atoi(start)
+	if err != nil {
+		return nil, err
+	}
+	endPort, err := strconv.Atoi(end)
+	if err != nil {
+		return nil, err
+	}
+	if startPort > endPort {
+		return nil, fmt.Errorf("the range start is greater than the end")
+	}
+	return &firewall.Port{IsRange: true, Values: []int{startPort, endPort}}, nil
+}
+
 func convertFirewallAction(action mgmProto.FirewallRuleAction) firewall.Action {
 	switch action {
 	case mgmProto.FirewallRule_ACCEPT:
@@ -2,10 +2,12 @@ package acl
 
 import (
 	"net"
+	"reflect"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/netbirdio/netbird/client/firewall"
 	"github.com/netbirdio/netbird/client/internal/acl/mocks"
 	"github.com/netbirdio/netbird/iface"
 	mgmProto "github.com/netbirdio/netbird/management/proto"
@@ -63,6 +65,14 @@ func TestDefaultManager(t *testing.T) {
 			t.Errorf("firewall rules not applied: %v", acl.rulesPairs)
 			return
 		}
+
+		// the userspace firewall tracks the connections, the replies don't need the inverted rules
+		for _, rules := range acl.rulesPairs {
+			if len(rules) != 1 {
+				t.Errorf("inverted rules should not be added to a stateful firewall, got: %v", rules)
+				return
+			}
+		}
 	})
 
 	t.Run("add extra rules", func(t *testing.T) {
@@ -219,6 +229,40 @@ func TestDefaultManagerSquashRules(t *testing.T) {
 	}
 }
 
+func TestDefaultManagerSquashRulesIPv6(t *testing.T) {
+	networkMap := &mgmProto.NetworkMap{
+		PeerConfig: &mgmProto.PeerConfig{Address: "10.93.0.5/16", AddressV6: "fd5e:1c2a:9b00::5/64"},
+		RemotePeers: []*mgmProto.RemotePeerConfig{
+			{AllowedIps: []string{"10.93.0.1/32", "fd5e:1c2a:9b00::1/128"}},
+			{AllowedIps: []string{"10.93.0.2/32", "fd5e:1c2a:9b00::2/128"}},
+		},
+	}
+	for _, ip := range []string{"10.93.0.1", "10.93.0.2", "fd5e:1c2a:9b00::1", "fd5e:1c2a:9b00::2"} {
+		networkMap.FirewallRules = append(networkMap.FirewallRules, &mgmProto.FirewallRule{
+			PeerIP:    ip,
+			Direction: mgmProto.FirewallRule_IN,
+			Action:    mgmProto.FirewallRule_ACCEPT,
+			Protocol:  mgmProto.FirewallRule_ALL,
+		})
+	}
+
+	manager := &DefaultManager{}
+	rules, _ := manager.squashAcceptRules(networkMap)
+	if len(rules) != 2 {
+		t.Errorf("rules should contain 2, got: %v", rules)
+		return
+	}
+
+	if rules[0].PeerIP != "0.0.0.0" || rules[1].PeerIP != "::" {
+		t.Errorf("rules should allow all the IPv4 and IPv6 peers, got: %v", rules)
+		return
+	}
+
+	if manager.getRuleGroupingSelector(rules[0]) == manager.getRuleGroupingSelector(rules[1]) {
+		t.Errorf("IPv4 and IPv6 rules should not share the grouping selector")
+	}
+}
+
 func TestDefaultManagerSquashRulesNoAffect(t *testing.T) {
 	networkMap := &mgmProto.NetworkMap{
 		RemotePeers: []*mgmProto.RemotePeerConfig{
@@ -351,3 +395,33 @@ func TestDefaultManagerEnableSSHRules(t *testing.T) {
 		return
 	}
 }
+
+func TestConvertToFirewallPort(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected *firewall.Port
+	}{
+		{value: "80", expected: &firewall.Port{Values: []int{80}}},
+		{value: "8000-8100", expected: &firewall.Port{IsRange: true, Values: []int{8000, 8100}}},
+		{value: "8100-8000"},
+		{value: "80-"},
+		{value: "http"},
+	}
+
+	for _, tc := range testCases {
+		port, err := convertToFirewallPort(tc.value)
+		if tc.expected == nil {
+			if err == nil {
+				t.Errorf("port %q should be invalid", tc.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("port %q should be valid: %v", tc.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(port, tc.expected) {
+			t.Errorf("port %q: expected %v, got %v", tc.value, tc.expected, port)
+		}
+	}
+}
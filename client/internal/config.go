@@ -37,6 +37,7 @@ type ConfigInput struct {
 	PreSharedKey     *string
 	NATExternalIPs   []string
 	CustomDNSAddress []byte
+	UseExitNode      *bool
 }
 
 // Config Configuration type
@@ -71,6 +72,9 @@ type Config struct {
 	NATExternalIPs []string
 	// CustomDNSAddress sets the DNS resolver listening address in format ip:port
 	CustomDNSAddress string
+	// UseExitNode routes all the traffic through the routing peers of a default route (0.0.0.0/0)
+	// distributed by the Management service. Disabled by default, such routes are ignored then.
+	UseExitNode bool
 }
 
 // ReadConfig read config file and return with Config. If it is not exists create a new with default values
@@ -147,6 +151,10 @@ func createNewConfig(input ConfigInput) (*Config, error) {
 		CustomDNSAddress:     string(input.CustomDNSAddress),
 	}
 
+	if input.UseExitNode != nil {
+		config.UseExitNode = *input.UseExitNode
+	}
+
 	defaultManagementURL, err := parseURL("Management URL", DefaultManagementURL)
 	if err != nil {
 		return nil, err
@@ -246,6 +254,12 @@ func update(input ConfigInput) (*Config, error) {
 		refresh = true
 	}
 
+	if input.UseExitNode != nil && config.UseExitNode != *input.UseExitNode {
+		log.Infof("exit node usage updated to %t", *input.UseExitNode)
+		config.UseExitNode = *input.UseExitNode
+		refresh = true
+	}
+
 	if refresh {
 		// since we have new management URL, we need to update config file
 		if err := util.WriteJson(input.ConfigPath, config); err != nil {
@@ -134,3 +134,29 @@ func TestHiddenPreSharedKey(t *testing.T) {
 		})
 	}
 }
+
+func TestUseExitNode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	config, err := UpdateOrCreateConfig(ConfigInput{ConfigPath: path})
+	assert.NoError(t, err)
+	assert.False(t, config.UseExitNode, "exit nodes should be disabled by default")
+
+	enabled := true
+	config, err = UpdateOrCreateConfig(ConfigInput{ConfigPath: path, UseExitNode: &enabled})
+	assert.NoError(t, err)
+	assert.True(t, config.UseExitNode)
+
+	config, err = UpdateOrCreateConfig(ConfigInput{ConfigPath: path})
+	assert.NoError(t, err)
+	assert.True(t, config.UseExitNode, "the setting should be kept when not provided")
+
+	disabled := false
+	config, err = UpdateConfig(ConfigInput{ConfigPath: path, UseExitNode: &disabled})
+	assert.NoError(t, err)
+	assert.False(t, config.UseExitNode)
+
+	readConfig, err := util.ReadJson(path, &Config{})
+	assert.NoError(t, err)
+	assert.False(t, readConfig.(*Config).UseExitNode)
+}
@@ -126,6 +126,7 @@ func runClient(ctx context.Context, config *Config, statusRecorder *peer.Status,
 
 		localPeerState := peer.LocalPeerState{
 			IP:              loginResp.GetPeerConfig().GetAddress(),
+			IPv6:            loginResp.GetPeerConfig().GetAddressV6(),
 			PubKey:          myPrivateKey.PublicKey().String(),
 			KernelInterface: iface.WireGuardModuleIsLoaded(),
 			FQDN:            loginResp.GetPeerConfig().GetFqdn(),
@@ -216,6 +217,7 @@ func createEngineConfig(key wgtypes.Key, config *Config, peerConfig *mgmProto.Pe
 	engineConf := &EngineConfig{
 		WgIfaceName:          config.WgIface,
 		WgAddr:               peerConfig.Address,
+		WgAddrV6:             peerConfig.GetAddressV6(),
 		IFaceBlackList:       config.IFaceBlackList,
 		DisableIPv6Discovery: config.DisableIPv6Discovery,
 		WgPrivateKey:         key,
@@ -223,6 +225,7 @@ func createEngineConfig(key wgtypes.Key, config *Config, peerConfig *mgmProto.Pe
 		SSHKey:               []byte(config.SSHKey),
 		NATExternalIPs:       config.NATExternalIPs,
 		CustomDNSAddress:     config.CustomDNSAddress,
+		UseExitNode:          config.UseExitNode,
 	}
 
 	if config.PreSharedKey != "" {
@@ -0,0 +1,237 @@
+package dns
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	defaultCacheSize = 4096
+	// maxCacheTTL caps the time a response is served from the cache, even if the records have longer TTLs
+	maxCacheTTL = time.Hour
+	// maxNegativeCacheTTL caps the time a NXDOMAIN or an empty answer is served from the cache
+	maxNegativeCacheTTL = 5 * time.Minute
+)
+
+// CacheStats contains the counters of the upstream response cache
+type CacheStats struct {
+	Entries int
+	Hits    uint64
+	Misses  uint64
+}
+
+type cacheKey struct {
+	name   string
+	qtype  uint16
+	qclass uint16
+	// do and cd are part of the key as they change the records returned by the upstream
+	do bool
+	cd bool
+}
+
+type cacheEntry struct {
+	key      cacheKey
+	msg      *dns.Msg
+	storedAt time.Time
+	expires  time.Time
+}
+
+// responseCache is a size bounded cache of the upstream responses. The entries expire with the smallest TTL
+// of the response records, negative responses expire with the TTL of the zone SOA record (RFC 2308).
+// When the cache is full, the least recently used entry is evicted.
+type responseCache struct {
+	mux     sync.Mutex
+	size    int
+	entries map[cacheKey]*list.Element
+	lru     *list.List
+	hits    uint64
+	misses  uint64
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func newResponseCache(size int) *responseCache {
+	return &responseCache{
+		size:    size,
+		entries: make(map[cacheKey]*list.Element),
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+func newCacheKey(r *dns.Msg) (cacheKey, bool) {
+	if len(r.Question) != 1 {
+		return cacheKey{}, false
+	}
+
+	question := r.Question[0]
+	key := cacheKey{
+		name:   strings.ToLower(question.Name),
+		qtype:  question.Qtype,
+		qclass: question.Qclass,
+		cd:     r.CheckingDisabled,
+	}
+	if opt := r.IsEdns0(); opt != nil {
+		key.do = opt.Do()
+	}
+	return key, true
+}
+
+// get returns a copy of the cached response to the request with the TTLs decreased by the time spent in the cache
+func (c *responseCache) get(r *dns.Msg) *dns.Msg {
+	key, ok := newCacheKey(r)
+	if !ok {
+		return nil
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	element, found := c.entries[key]
+	if !found {
+		c.misses++
+		return nil
+	}
+
+	entry := element.Value.(*cacheEntry)
+	now := c.now()
+	if !now.Before(entry.expires) {
+		c.remove(element)
+		c.misses++
+		return nil
+	}
+
+	c.lru.MoveToFront(element)
+	c.hits++
+
+	elapsed := uint32(now.Sub(entry.storedAt) / time.Second)
+	response := entry.msg.Copy()
+	response.Id = r.Id
+	response.Question = r.Question
+	for _, section := range [][]dns.RR{response.Answer, response.Ns, response.Extra} {
+		for _, rr := range section {
+			if rr.Header().Rrtype == dns.TypeOPT {
+				continue
+			}
+			if rr.Header().Ttl > elapsed {
+				rr.Header().Ttl -= elapsed
+			} else {
+				rr.Header().Ttl = 0
+			}
+		}
+	}
+	return response
+}
+
+// add caches the upstream response to the request if it is cacheable
+func (c *responseCache) add(r *dns.Msg, response *dns.Msg) {
+	key, ok := newCacheKey(r)
+	if !ok {
+		return
+	}
+
+	ttl := cacheTTL(response)
+	if ttl <= 0 {
+		return
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if element, found := c.entries[key]; found {
+		c.remove(element)
+	}
+
+	now := c.now()
+	entry := &cacheEntry{
+		key:      key,
+		msg:      response.Copy(),
+		storedAt: now,
+		expires:  now.Add(ttl),
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+// flush removes all the entries, the counters are kept
+func (c *responseCache) flush() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.entries = make(map[cacheKey]*list.Element)
+	c.lru.Init()
+}
+
+func (c *responseCache) stats() CacheStats {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return CacheStats{
+		Entries: c.lru.Len(),
+		Hits:    c.hits,
+		Misses:  c.misses,
+	}
+}
+
+func (c *responseCache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.key)
+}
+
+// cacheTTL returns how long a response can be cached, zero if it is not cacheable
+func cacheTTL(response *dns.Msg) time.Duration {
+	if response.Truncated {
+		return 0
+	}
+
+	switch {
+	case response.Rcode == dns.RcodeSuccess && len(response.Answer) > 0:
+		return minTTL(maxCacheTTL, response.Answer, response.Ns, response.Extra)
+	case response.Rcode == dns.RcodeSuccess || response.Rcode == dns.RcodeNameError:
+		return negativeTTL(response)
+	default:
+		return 0
+	}
+}
+
+func minTTL(limit time.Duration, sections ...[]dns.RR) time.Duration {
+	ttl := limit
+	for _, section := range sections {
+		for _, rr := range section {
+			if rr.Header().Rrtype == dns.TypeOPT {
+				continue
+			}
+			rrTTL := time.Duration(rr.Header().Ttl) * time.Second
+			if rrTTL < ttl {
+				ttl = rrTTL
+			}
+		}
+	}
+	return ttl
+}
+
+// negativeTTL returns the minimum of the SOA TTL and the SOA minimum field, responses without SOA are not cached
+func negativeTTL(response *dns.Msg) time.Duration {
+	for _, rr := range response.Ns {
+		soa, ok := rr.(*dns.SOA)
+		if !ok {
+			continue
+		}
+		ttl := time.Duration(soa.Hdr.Ttl) * time.Second
+		if minimum := time.Duration(soa.Minttl) * time.Second; minimum < ttl {
+			ttl = minimum
+		}
+		if ttl > maxNegativeCacheTTL {
+			ttl = maxNegativeCacheTTL
+		}
+		return ttl
+	}
+	return 0
+}
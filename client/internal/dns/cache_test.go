@@ -0,0 +1,175 @@
+package dns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func newTestResponse(r *dns.Msg, rcode int, answer []string, ns []string) *dns.Msg {
+	m := new(dns.Msg).SetRcode(r, rcode)
+	for _, record := range answer {
+		rr, _ := dns.NewRR(record)
+		m.Answer = append(m.Answer, rr)
+	}
+	for _, record := range ns {
+		rr, _ := dns.NewRR(record)
+		m.Ns = append(m.Ns, rr)
+	}
+	return m
+}
+
+func TestResponseCache(t *testing.T) {
+	now := time.Now()
+	cache := newResponseCache(2)
+	cache.now = func() time.Time { return now }
+
+	query := new(dns.Msg).SetQuestion("host.example.com.", dns.TypeA)
+	cache.add(query, newTestResponse(query, dns.RcodeSuccess, []string{
+		"host.example.com. 300 IN CNAME www.example.com.",
+		"www.example.com. 60 IN A 10.0.0.1",
+	}, nil))
+
+	now = now.Add(20 * time.Second)
+	otherQuery := new(dns.Msg).SetQuestion("HOST.example.com.", dns.TypeA)
+	cached := cache.get(otherQuery)
+	if cached == nil {
+		t.Fatal("the response should be cached case insensitive")
+	}
+	if cached.Id != otherQuery.Id || cached.Question[0].Name != "HOST.example.com." {
+		t.Errorf("the cached response should match the query id and question, got %d %s", cached.Id, cached.Question[0].Name)
+	}
+	if cached.Answer[0].Header().Ttl != 280 || cached.Answer[1].Header().Ttl != 40 {
+		t.Errorf("the TTLs should be decreased by the time spent in the cache, got %d and %d",
+			cached.Answer[0].Header().Ttl, cached.Answer[1].Header().Ttl)
+	}
+
+	aaaaQuery := new(dns.Msg).SetQuestion("host.example.com.", dns.TypeAAAA)
+	if cache.get(aaaaQuery) != nil {
+		t.Error("the response should be cached only for the query type")
+	}
+
+	now = now.Add(40 * time.Second)
+	if cache.get(query) != nil {
+		t.Error("the response should expire with the smallest TTL")
+	}
+
+	stats := cache.stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Entries != 0 {
+		t.Errorf("unexpected cache stats %+v", stats)
+	}
+}
+
+func TestResponseCache_Negative(t *testing.T) {
+	now := time.Now()
+	cache := newResponseCache(10)
+	cache.now = func() time.Time { return now }
+
+	soa := "example.com. 3600 IN SOA ns.example.com. admin.example.com. 1 7200 3600 1209600 30"
+
+	nxQuery := new(dns.Msg).SetQuestion("missing.example.com.", dns.TypeA)
+	cache.add(nxQuery, newTestResponse(nxQuery, dns.RcodeNameError, nil, []string{soa}))
+
+	noDataQuery := new(dns.Msg).SetQuestion("host.example.com.", dns.TypeAAAA)
+	cache.add(noDataQuery, newTestResponse(noDataQuery, dns.RcodeSuccess, nil, []string{soa}))
+
+	noSOAQuery := new(dns.Msg).SetQuestion("nosoa.example.com.", dns.TypeA)
+	cache.add(noSOAQuery, newTestResponse(noSOAQuery, dns.RcodeNameError, nil, nil))
+
+	failQuery := new(dns.Msg).SetQuestion("fail.example.com.", dns.TypeA)
+	cache.add(failQuery, newTestResponse(failQuery, dns.RcodeServerFailure, nil, []string{soa}))
+
+	cached := cache.get(nxQuery)
+	if cached == nil || cached.Rcode != dns.RcodeNameError {
+		t.Fatalf("the NXDOMAIN response should be cached, got %v", cached)
+	}
+	if cache.get(noDataQuery) == nil {
+		t.Error("the empty answer should be cached")
+	}
+	if cache.get(noSOAQuery) != nil {
+		t.Error("the negative response without SOA should not be cached")
+	}
+	if cache.get(failQuery) != nil {
+		t.Error("the server failure should not be cached")
+	}
+
+	now = now.Add(30 * time.Second)
+	if cache.get(nxQuery) != nil {
+		t.Error("the negative response should expire with the SOA minimum TTL")
+	}
+}
+
+func TestResponseCache_EvictionAndFlush(t *testing.T) {
+	cache := newResponseCache(2)
+
+	queries := []*dns.Msg{
+		new(dns.Msg).SetQuestion("a.example.com.", dns.TypeA),
+		new(dns.Msg).SetQuestion("b.example.com.", dns.TypeA),
+		new(dns.Msg).SetQuestion("c.example.com.", dns.TypeA),
+	}
+
+	for i, query := range queries[:2] {
+		cache.add(query, newTestResponse(query, dns.RcodeSuccess, []string{query.Question[0].Name + " 300 IN A 10.0.0.1"}, nil))
+		if i == 1 {
+			// refresh the first entry so the second one is the least recently used
+			cache.get(queries[0])
+		}
+	}
+	cache.add(queries[2], newTestResponse(queries[2], dns.RcodeSuccess, []string{"c.example.com. 300 IN A 10.0.0.1"}, nil))
+
+	if cache.get(queries[1]) != nil {
+		t.Error("the least recently used entry should be evicted")
+	}
+	if cache.get(queries[0]) == nil || cache.get(queries[2]) == nil {
+		t.Error("the recently used entries should be kept")
+	}
+
+	cache.flush()
+	if cache.stats().Entries != 0 || cache.get(queries[0]) != nil {
+		t.Error("the cache should be empty after a flush")
+	}
+}
+
+type countingUpstreamClient struct {
+	calls int
+}
+
+func (c *countingUpstreamClient) ExchangeContext(_ context.Context, m *dns.Msg, _ string) (*dns.Msg, time.Duration, error) {
+	c.calls++
+	return newTestResponse(m, dns.RcodeSuccess, []string{m.Question[0].Name + " 300 IN A 10.0.0.1"}, nil), time.Millisecond, nil
+}
+
+func TestUpstreamResolver_ServeDNSFromCache(t *testing.T) {
+	client := &countingUpstreamClient{}
+	cache := newResponseCache(defaultCacheSize)
+
+	// the cache is shared between the resolvers of different nameserver groups
+	var resolvers []*upstreamResolver
+	for i := 0; i < 2; i++ {
+		resolver := newUpstreamResolver(context.Background())
+		resolver.upstreamClient = client
+		resolver.upstreamServers = []string{"10.0.0.53:53"}
+		resolver.cache = cache
+		resolvers = append(resolvers, resolver)
+	}
+
+	for _, resolver := range resolvers {
+		var responseMSG *dns.Msg
+		responseWriter := &mockResponseWriter{
+			WriteMsgFunc: func(m *dns.Msg) error {
+				responseMSG = m
+				return nil
+			},
+		}
+		resolver.ServeDNS(responseWriter, new(dns.Msg).SetQuestion("host.example.com.", dns.TypeA))
+		if responseMSG == nil || len(responseMSG.Answer) != 1 {
+			t.Fatalf("should write a response message, got %v", responseMSG)
+		}
+	}
+
+	if client.calls != 1 {
+		t.Errorf("the upstream should be queried once, got %d queries", client.calls)
+	}
+}
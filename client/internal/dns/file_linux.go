@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -72,32 +73,13 @@ func (f *fileConfigurator) applyDNSConfig(config hostDNSConfig) error {
 		return fmt.Errorf("something happened and file manager is not your prefered host dns configurator, restart the agent")
 	}
 
-	var searchDomains string
-	appendedDomains := 0
-	for _, dConf := range config.domains {
-		if dConf.matchOnly || dConf.disabled {
-			continue
-		}
-		if appendedDomains >= fileMaxNumberOfSearchDomains {
-			// lets log all skipped domains
-			log.Infof("already appended %d domains to search list. Skipping append of %s domain", fileMaxNumberOfSearchDomains, dConf.domain)
-			continue
-		}
-		if fileSearchLineBeginCharCount+len(searchDomains) > fileMaxLineCharsLimit {
-			// lets log all skipped domains
-			log.Infof("search list line is larger than %d characters. Skipping append of %s domain", fileMaxLineCharsLimit, dConf.domain)
-			continue
-		}
-
-		searchDomains += " " + dConf.domain
-		appendedDomains++
-	}
+	searchDomains := resolvConfSearchDomains(config)
 
 	originalContent, err := os.ReadFile(fileDefaultResolvConfBackupLocation)
 	if err != nil {
 		log.Errorf("Could not read existing resolv.conf")
 	}
-	content := fmt.Sprintf(fileGeneratedResolvConfContentFormat, fileDefaultResolvConfBackupLocation, config.serverIP, searchDomains, string(originalContent))
+	content := fmt.Sprintf(fileGeneratedResolvConfContentFormat, fileDefaultResolvConfBackupLocation, config.serverIP, strings.Join(searchDomains, " "), string(originalContent))
 	err = writeDNSConfig(content, defaultResolvConfPath, f.originalPerms)
 	if err != nil {
 		err = f.restore()
@@ -106,10 +88,38 @@ func (f *fileConfigurator) applyDNSConfig(config hostDNSConfig) error {
 		}
 		return err
 	}
-	log.Infof("created a NetBird managed %s file with your DNS settings. Added %d search domains. Search list: %s", defaultResolvConfPath, appendedDomains, searchDomains)
+	log.Infof("created a NetBird managed %s file with your DNS settings. Added %d search domains. Search list: %s", defaultResolvConfPath, len(searchDomains), searchDomains)
 	return nil
 }
 
+// resolvConfSearchDomains returns the search domains of the config that fit in the search line of a resolv.conf file,
+// the resolver only reads the first domains of the line up to the number of domains and characters limits
+func resolvConfSearchDomains(config hostDNSConfig) []string {
+	var searchDomains []string
+	added := make(map[string]struct{})
+	lineLength := fileSearchLineBeginCharCount
+	for _, dConf := range config.domains {
+		if _, found := added[dConf.domain]; found || dConf.matchOnly || dConf.disabled {
+			continue
+		}
+		if len(searchDomains) >= fileMaxNumberOfSearchDomains {
+			// lets log all skipped domains
+			log.Infof("already appended %d domains to search list. Skipping append of %s domain", fileMaxNumberOfSearchDomains, dConf.domain)
+			continue
+		}
+		if lineLength+len(dConf.domain) > fileMaxLineCharsLimit {
+			// lets log all skipped domains
+			log.Infof("search list line is larger than %d characters. Skipping append of %s domain", fileMaxLineCharsLimit, dConf.domain)
+			continue
+		}
+
+		searchDomains = append(searchDomains, dConf.domain)
+		added[dConf.domain] = struct{}{}
+		lineLength += len(dConf.domain) + 1
+	}
+	return searchDomains
+}
+
 func (f *fileConfigurator) restoreHostDNS() error {
 	return f.restore()
 }
@@ -0,0 +1,32 @@
+//go:build !android
+
+package dns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvConfSearchDomains(t *testing.T) {
+	config := hostDNSConfig{
+		domains: []domainConfig{
+			{domain: "netbird.cloud"},
+			{domain: "match.example.com", matchOnly: true},
+			{domain: "disabled.example.com", disabled: true},
+			{domain: "netbird.cloud"},
+			{domain: "one.example.com"},
+			{domain: strings.Repeat("a", 250) + ".example.com"},
+			{domain: "two.example.com"},
+			{domain: "three.example.com"},
+			{domain: "four.example.com"},
+			{domain: "five.example.com"},
+			{domain: "six.example.com"},
+		},
+	}
+
+	expected := []string{"netbird.cloud", "one.example.com", "two.example.com", "three.example.com", "four.example.com", "five.example.com"}
+	assert.Equal(t, expected, resolvConfSearchDomains(config),
+		"only the search domains fitting in the resolv.conf search line should be returned, without duplicates")
+}
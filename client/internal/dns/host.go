@@ -78,15 +78,16 @@ func dnsConfigToHostDNSConfig(dnsConfig nbdns.Config, ip string, port int) hostD
 		for _, domain := range nsConfig.Domains {
 			config.domains = append(config.domains, domainConfig{
 				domain:    strings.TrimSuffix(domain, "."),
-				matchOnly: true,
+				matchOnly: !nsConfig.SearchDomainsEnabled,
 			})
 		}
 	}
 
 	for _, customZone := range dnsConfig.CustomZones {
 		config.domains = append(config.domains, domainConfig{
-			domain:    strings.TrimSuffix(customZone.Domain, "."),
-			matchOnly: false,
+			domain: strings.TrimSuffix(customZone.Domain, "."),
+			// reverse zones only route the PTR lookups of the peers, they are never a search domain
+			matchOnly: !customZone.SearchDomainEnabled || nbdns.IsReverseZone(customZone.Domain),
 		})
 	}
 
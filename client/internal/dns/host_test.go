@@ -0,0 +1,36 @@
+package dns
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	nbdns "github.com/netbirdio/netbird/dns"
+)
+
+func TestDNSConfigToHostDNSConfig(t *testing.T) {
+	config := dnsConfigToHostDNSConfig(nbdns.Config{
+		CustomZones: []nbdns.CustomZone{
+			{Domain: "netbird.cloud.", SearchDomainEnabled: true},
+			{Domain: "corp.example.com."},
+			{Domain: "64.100.in-addr.arpa.", SearchDomainEnabled: true},
+			{Domain: "c.b.a.9.8.7.6.5.4.3.2.1.0.0.d.f.ip6.arpa."},
+		},
+		NameServerGroups: []*nbdns.NameServerGroup{
+			{
+				Domains:     []string{"example.com"},
+				NameServers: []nbdns.NameServer{{IP: netip.MustParseAddr("8.8.8.8"), NSType: nbdns.UDPNameServerType, Port: 53}},
+			},
+		},
+	}, "100.64.0.1", 53)
+
+	expected := []domainConfig{
+		{domain: "example.com", matchOnly: true},
+		{domain: "netbird.cloud", matchOnly: false},
+		{domain: "corp.example.com", matchOnly: true},
+		{domain: "64.100.in-addr.arpa", matchOnly: true},
+		{domain: "c.b.a.9.8.7.6.5.4.3.2.1.0.0.d.f.ip6.arpa", matchOnly: true},
+	}
+	assert.Equal(t, expected, config.domains, "zones without search domain and reverse zones should only be match domains")
+}
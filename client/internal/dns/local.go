@@ -2,6 +2,7 @@ package dns
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/miekg/dns"
@@ -10,11 +11,15 @@ import (
 	nbdns "github.com/netbirdio/netbird/dns"
 )
 
+// maxCNAMEChain limits the number of CNAME records followed within the local records
+const maxCNAMEChain = 8
+
 type registrationMap map[string]struct{}
 
 type localResolver struct {
 	registeredMap registrationMap
-	records       sync.Map
+	// records holds the []dns.RR registered for every record key
+	records sync.Map
 }
 
 func (d *localResolver) stop() {
@@ -28,10 +33,7 @@ func (d *localResolver) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	replyMessage.RecursionAvailable = true
 	replyMessage.Rcode = dns.RcodeSuccess
 
-	response := d.lookupRecord(r)
-	if response != nil {
-		replyMessage.Answer = append(replyMessage.Answer, response)
-	}
+	replyMessage.Answer = append(replyMessage.Answer, d.lookupRecords(r.Question[0])...)
 
 	err := w.WriteMsg(replyMessage)
 	if err != nil {
@@ -39,35 +41,75 @@ func (d *localResolver) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	}
 }
 
-func (d *localResolver) lookupRecord(r *dns.Msg) dns.RR {
-	question := r.Question[0]
-	record, found := d.records.Load(buildRecordKey(question.Name, question.Qclass, question.Qtype))
+// lookupRecords returns the records matching the question. When the name has a CNAME record instead, the CNAME chain is
+// returned followed by the records of its target if the target is local
+func (d *localResolver) lookupRecords(question dns.Question) []dns.RR {
+	records := d.loadRecords(question.Name, question.Qclass, question.Qtype)
+	if records != nil || question.Qtype == dns.TypeCNAME {
+		return records
+	}
+
+	var answer []dns.RR
+	name := question.Name
+	for i := 0; i < maxCNAMEChain; i++ {
+		cnames := d.loadRecords(name, question.Qclass, dns.TypeCNAME)
+		if len(cnames) == 0 {
+			break
+		}
+		answer = append(answer, cnames[0])
+
+		cname, ok := cnames[0].(*dns.CNAME)
+		if !ok {
+			break
+		}
+		name = cname.Target
+
+		if records = d.loadRecords(name, question.Qclass, question.Qtype); records != nil {
+			return append(answer, records...)
+		}
+	}
+
+	return answer
+}
+
+func (d *localResolver) loadRecords(name string, class, qType uint16) []dns.RR {
+	records, found := d.records.Load(buildRecordKey(name, class, qType))
 	if !found {
 		return nil
 	}
 
-	return record.(dns.RR)
+	return records.([]dns.RR)
 }
 
-func (d *localResolver) registerRecord(record nbdns.SimpleRecord) error {
-	fullRecord, err := dns.NewRR(record.String())
-	if err != nil {
-		return err
-	}
+// registerRecords registers the records replacing the records previously registered with the same name, class and type
+func (d *localResolver) registerRecords(records ...nbdns.SimpleRecord) error {
+	update := make(map[string][]dns.RR)
+	for _, record := range records {
+		fullRecord, err := dns.NewRR(record.String())
+		if err != nil {
+			return err
+		}
 
-	fullRecord.Header().Rdlength = record.Len()
+		fullRecord.Header().Rdlength = record.Len()
 
-	header := fullRecord.Header()
-	d.records.Store(buildRecordKey(header.Name, header.Class, header.Rrtype), fullRecord)
+		header := fullRecord.Header()
+		key := buildRecordKey(header.Name, header.Class, header.Rrtype)
+		update[key] = append(update[key], fullRecord)
+	}
+
+	for key, rrs := range update {
+		d.records.Store(key, rrs)
+	}
 
 	return nil
 }
 
 func (d *localResolver) deleteRecord(recordKey string) {
-	d.records.Delete(dns.Fqdn(recordKey))
+	d.records.Delete(recordKey)
 }
 
+// buildRecordKey returns the key of the records with the given name, class and type. Names are case-insensitive
 func buildRecordKey(name string, class, qType uint16) string {
-	key := fmt.Sprintf("%s_%d_%d", name, class, qType)
+	key := fmt.Sprintf("%s_%d_%d", strings.ToLower(dns.Fqdn(name)), class, qType)
 	return key
 }
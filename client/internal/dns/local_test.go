@@ -1,10 +1,14 @@
 package dns
 
 import (
-	"github.com/miekg/dns"
-	nbdns "github.com/netbirdio/netbird/dns"
 	"strings"
 	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nbdns "github.com/netbirdio/netbird/dns"
 )
 
 func TestLocalResolver_ServeDNS(t *testing.T) {
@@ -53,7 +57,7 @@ func TestLocalResolver_ServeDNS(t *testing.T) {
 			resolver := &localResolver{
 				registeredMap: make(registrationMap),
 			}
-			_ = resolver.registerRecord(testCase.inputRecord)
+			_ = resolver.registerRecords(testCase.inputRecord)
 			var responseMSG *dns.Msg
 			responseWriter := &mockResponseWriter{
 				WriteMsgFunc: func(m *dns.Msg) error {
@@ -84,3 +88,96 @@ func TestLocalResolver_ServeDNS(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalResolver_LookupRecords(t *testing.T) {
+	records := []nbdns.SimpleRecord{
+		{Name: "web.corp.example.", Type: int(dns.TypeA), Class: nbdns.DefaultClass, TTL: 300, RData: "10.0.0.1"},
+		{Name: "web.corp.example.", Type: int(dns.TypeA), Class: nbdns.DefaultClass, TTL: 300, RData: "10.0.0.2"},
+		{Name: "web.corp.example.", Type: int(dns.TypeAAAA), Class: nbdns.DefaultClass, TTL: 300, RData: "fd00::1"},
+		{Name: "www.corp.example.", Type: int(dns.TypeCNAME), Class: nbdns.DefaultClass, TTL: 300, RData: "web.corp.example."},
+		{Name: "docs.corp.example.", Type: int(dns.TypeCNAME), Class: nbdns.DefaultClass, TTL: 300, RData: "www.corp.example."},
+		{Name: "corp.example.", Type: int(dns.TypeTXT), Class: nbdns.DefaultClass, TTL: 300, RData: `"v=spf1 -all" "second string"`},
+		{Name: "_sip._tcp.corp.example.", Type: int(dns.TypeSRV), Class: nbdns.DefaultClass, TTL: 300, RData: "10 5 5060 sip.corp.example."},
+		{Name: "1.0.64.100.in-addr.arpa.", Type: int(dns.TypePTR), Class: nbdns.DefaultClass, TTL: 300, RData: "peer.netbird.cloud."},
+	}
+
+	resolver := &localResolver{registeredMap: make(registrationMap)}
+	require.NoError(t, resolver.registerRecords(records...))
+
+	testCases := []struct {
+		name     string
+		question dns.Question
+		expected []string
+	}{
+		{
+			name:     "multiple A records",
+			question: dns.Question{Name: "web.corp.example.", Qtype: dns.TypeA, Qclass: dns.ClassINET},
+			expected: []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name:     "case insensitive name",
+			question: dns.Question{Name: "WEB.Corp.Example.", Qtype: dns.TypeAAAA, Qclass: dns.ClassINET},
+			expected: []string{"fd00::1"},
+		},
+		{
+			name:     "CNAME chain followed to the local target",
+			question: dns.Question{Name: "docs.corp.example.", Qtype: dns.TypeA, Qclass: dns.ClassINET},
+			expected: []string{"www.corp.example.", "web.corp.example.", "10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name:     "CNAME record",
+			question: dns.Question{Name: "www.corp.example.", Qtype: dns.TypeCNAME, Qclass: dns.ClassINET},
+			expected: []string{"web.corp.example."},
+		},
+		{
+			name:     "TXT record",
+			question: dns.Question{Name: "corp.example.", Qtype: dns.TypeTXT, Qclass: dns.ClassINET},
+			expected: []string{`"v=spf1 -all" "second string"`},
+		},
+		{
+			name:     "SRV record",
+			question: dns.Question{Name: "_sip._tcp.corp.example.", Qtype: dns.TypeSRV, Qclass: dns.ClassINET},
+			expected: []string{"10 5 5060 sip.corp.example."},
+		},
+		{
+			name:     "PTR record",
+			question: dns.Question{Name: "1.0.64.100.in-addr.arpa.", Qtype: dns.TypePTR, Qclass: dns.ClassINET},
+			expected: []string{"peer.netbird.cloud."},
+		},
+		{
+			name:     "unknown name",
+			question: dns.Question{Name: "unknown.corp.example.", Qtype: dns.TypeA, Qclass: dns.ClassINET},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var responseMSG *dns.Msg
+			responseWriter := &mockResponseWriter{
+				WriteMsgFunc: func(m *dns.Msg) error {
+					responseMSG = m
+					return nil
+				},
+			}
+
+			resolver.ServeDNS(responseWriter, new(dns.Msg).SetQuestion(testCase.question.Name, testCase.question.Qtype))
+			require.NotNil(t, responseMSG, "should write a response message")
+
+			// the response has to survive the wire format
+			packed, err := responseMSG.Pack()
+			require.NoError(t, err)
+			unpacked := new(dns.Msg)
+			require.NoError(t, unpacked.Unpack(packed))
+
+			var answers []string
+			for _, answer := range unpacked.Answer {
+				answers = append(answers, strings.TrimPrefix(answer.String(), answer.Header().String()))
+			}
+			assert.ElementsMatch(t, testCase.expected, answers)
+		})
+	}
+
+	resolver.deleteRecord(buildRecordKey("web.corp.example.", dns.ClassINET, dns.TypeA))
+	assert.Nil(t, resolver.lookupRecords(dns.Question{Name: "web.corp.example.", Qtype: dns.TypeA, Qclass: dns.ClassINET}),
+		"deleted records should not be resolved")
+}
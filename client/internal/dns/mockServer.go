@@ -36,6 +36,11 @@ func (m *MockServer) OnUpdatedHostDNSServer(strings []string) {
 	panic("implement me")
 }
 
+// CacheStats mock implementation of CacheStats from Server interface
+func (m *MockServer) CacheStats() CacheStats {
+	return CacheStats{}
+}
+
 // UpdateDNSServer mock implementation of UpdateDNSServer from Server interface
 func (m *MockServer) UpdateDNSServer(serial uint64, update nbdns.Config) error {
 	if m.UpdateDNSServerFunc != nil {
@@ -25,6 +25,7 @@ type Server interface {
 	DnsIP() string
 	UpdateDNSServer(serial uint64, update nbdns.Config) error
 	OnUpdatedHostDNSServer(strings []string)
+	CacheStats() CacheStats
 }
 
 type registeredHandlerMap map[string]handlerWithStop
@@ -37,6 +38,7 @@ type DefaultServer struct {
 	service            service
 	dnsMuxMap          registeredHandlerMap
 	localResolver      *localResolver
+	cache              *responseCache
 	wgInterface        WGIface
 	hostManager        hostManager
 	updateSerial       uint64
@@ -101,6 +103,7 @@ func newDefaultServer(ctx context.Context, wgInterface WGIface, dnsService servi
 		localResolver: &localResolver{
 			registeredMap: make(registrationMap),
 		},
+		cache:       newResponseCache(defaultCacheSize),
 		wgInterface: wgInterface,
 	}
 
@@ -135,6 +138,11 @@ func (s *DefaultServer) DnsIP() string {
 	return s.service.RuntimeIP()
 }
 
+// CacheStats returns the counters of the upstream response cache
+func (s *DefaultServer) CacheStats() CacheStats {
+	return s.cache.stats()
+}
+
 // Stop stops the server
 func (s *DefaultServer) Stop() {
 	s.mux.Lock()
@@ -231,6 +239,8 @@ func (s *DefaultServer) applyConfiguration(update nbdns.Config) error {
 	}
 	muxUpdates := append(localMuxUpdates, upstreamMuxUpdates...)
 
+	// the cached responses may come from nameservers that are no longer in the configuration
+	s.cache.flush()
 	s.updateMux(muxUpdates)
 	s.updateLocalResolver(localRecords)
 	s.currentConfig = dnsConfigToHostDNSConfig(update, s.service.RuntimeIP(), s.service.RuntimePort())
@@ -249,9 +259,9 @@ func (s *DefaultServer) applyConfiguration(update nbdns.Config) error {
 	return nil
 }
 
-func (s *DefaultServer) buildLocalHandlerUpdate(customZones []nbdns.CustomZone) ([]muxUpdate, map[string]nbdns.SimpleRecord, error) {
+func (s *DefaultServer) buildLocalHandlerUpdate(customZones []nbdns.CustomZone) ([]muxUpdate, map[string][]nbdns.SimpleRecord, error) {
 	var muxUpdates []muxUpdate
-	localRecords := make(map[string]nbdns.SimpleRecord, 0)
+	localRecords := make(map[string][]nbdns.SimpleRecord, 0)
 
 	for _, customZone := range customZones {
 
@@ -270,7 +280,7 @@ func (s *DefaultServer) buildLocalHandlerUpdate(customZones []nbdns.CustomZone)
 				return nil, nil, fmt.Errorf("received an invalid class type: %s", record.Class)
 			}
 			key := buildRecordKey(record.Name, class, uint16(record.Type))
-			localRecords[key] = record
+			localRecords[key] = append(localRecords[key], record)
 		}
 	}
 	return muxUpdates, localRecords, nil
@@ -286,13 +296,21 @@ func (s *DefaultServer) buildUpstreamHandlerUpdate(nameServerGroups []*nbdns.Nam
 		}
 
 		handler := newUpstreamResolver(s.ctx)
+		handler.cache = s.cache
 		for _, ns := range nsGroup.NameServers {
-			if ns.NSType != nbdns.UDPNameServerType {
-				log.Warnf("skiping nameserver %s with type %s, this peer supports only %s",
-					ns.IP.String(), ns.NSType.String(), nbdns.UDPNameServerType.String())
-				continue
+			switch ns.NSType {
+			case nbdns.UDPNameServerType:
+				handler.upstreamServers = append(handler.upstreamServers, getNSHostPort(ns))
+			case nbdns.TLSNameServerType, nbdns.HTTPSNameServerType:
+				err := handler.addEncryptedUpstream(ns)
+				if err != nil {
+					log.Warnf("skiping nameserver %s: %v", ns.IP.String(), err)
+				}
+			default:
+				log.Warnf("skiping nameserver %s with type %s, this peer supports only %s, %s and %s",
+					ns.IP.String(), ns.NSType.String(), nbdns.UDPNameServerType.String(),
+					nbdns.TLSNameServerType.String(), nbdns.HTTPSNameServerType.String())
 			}
-			handler.upstreamServers = append(handler.upstreamServers, getNSHostPort(ns))
 		}
 
 		if len(handler.upstreamServers) == 0 {
@@ -373,7 +391,7 @@ func (s *DefaultServer) updateMux(muxUpdates []muxUpdate) {
 	s.dnsMuxMap = muxUpdateMap
 }
 
-func (s *DefaultServer) updateLocalResolver(update map[string]nbdns.SimpleRecord) {
+func (s *DefaultServer) updateLocalResolver(update map[string][]nbdns.SimpleRecord) {
 	for key := range s.localResolver.registeredMap {
 		_, found := update[key]
 		if !found {
@@ -382,10 +400,10 @@ func (s *DefaultServer) updateLocalResolver(update map[string]nbdns.SimpleRecord
 	}
 
 	updatedMap := make(registrationMap)
-	for key, record := range update {
-		err := s.localResolver.registerRecord(record)
+	for key, records := range update {
+		err := s.localResolver.registerRecords(records...)
 		if err != nil {
-			log.Warnf("got an error while registering the record (%s), error: %v", record.String(), err)
+			log.Warnf("got an error while registering the records (%s), error: %v", key, err)
 		}
 		updatedMap[key] = struct{}{}
 	}
@@ -459,6 +477,7 @@ func (s *DefaultServer) upstreamCallbacks(
 
 func (s *DefaultServer) addHostRootZone() {
 	handler := newUpstreamResolver(s.ctx)
+	handler.cache = s.cache
 	handler.upstreamServers = make([]string, len(s.hostsDnsList))
 	for n, ua := range s.hostsDnsList {
 		handler.upstreamServers[n] = fmt.Sprintf("%s:53", ua)
@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/netip"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -474,7 +475,7 @@ func TestDNSServerStartStop(t *testing.T) {
 			}
 			time.Sleep(100 * time.Millisecond)
 			defer dnsServer.Stop()
-			err = dnsServer.localResolver.registerRecord(zoneRecords[0])
+			err = dnsServer.localResolver.registerRecords(zoneRecords[0])
 			if err != nil {
 				t.Error(err)
 			}
@@ -585,6 +586,63 @@ func TestDNSServerUpstreamDeactivateCallback(t *testing.T) {
 	}
 }
 
+func TestDNSServerSearchDomains(t *testing.T) {
+	var hostConfig hostDNSConfig
+	applied := 0
+	server := newDefaultServer(context.Background(), &mocWGIface{}, newServiceViaMemory(&mocWGIface{}))
+	server.hostManager = &mockHostConfigurator{
+		applyDNSConfigFunc: func(config hostDNSConfig) error {
+			hostConfig = config
+			applied++
+			return nil
+		},
+		supportCustomPortFunc: func() bool { return true },
+	}
+
+	nameServers := []nbdns.NameServer{{IP: netip.MustParseAddr("8.8.8.8"), NSType: nbdns.UDPNameServerType, Port: 53}}
+	update := nbdns.Config{
+		CustomZones: []nbdns.CustomZone{
+			{Domain: "netbird.cloud.", Records: zoneRecords, SearchDomainEnabled: true},
+			{Domain: "64.100.in-addr.arpa.", Records: []nbdns.SimpleRecord{
+				{Name: "1.0.64.100.in-addr.arpa.", Type: 12, Class: nbdns.DefaultClass, TTL: 300, RData: "peera.netbird.cloud."},
+			}},
+		},
+		NameServerGroups: []*nbdns.NameServerGroup{
+			{Domains: []string{"search.example.com"}, NameServers: nameServers, SearchDomainsEnabled: true},
+			{Domains: []string{"match.example.com"}, NameServers: nameServers},
+		},
+	}
+
+	if err := server.UpdateDNSServer(1, update); err != nil {
+		t.Fatalf("update dns server should not fail, got error: %v", err)
+	}
+	if applied != 1 {
+		t.Fatalf("the host configuration should be applied once, got %d", applied)
+	}
+	expected := []domainConfig{
+		{domain: "search.example.com", matchOnly: false},
+		{domain: "match.example.com", matchOnly: true},
+		{domain: "netbird.cloud", matchOnly: false},
+		{domain: "64.100.in-addr.arpa", matchOnly: true},
+	}
+	if !reflect.DeepEqual(expected, hostConfig.domains) {
+		t.Fatalf("unexpected host domains, want %+v, got %+v", expected, hostConfig.domains)
+	}
+
+	// disabling the peer zone search domain has to reach the host manager even if nothing else changed
+	update.CustomZones[0].SearchDomainEnabled = false
+	if err := server.UpdateDNSServer(2, update); err != nil {
+		t.Fatalf("update dns server should not fail, got error: %v", err)
+	}
+	if applied != 2 {
+		t.Fatalf("the host configuration should be applied again, got %d", applied)
+	}
+	expected[2].matchOnly = true
+	if !reflect.DeepEqual(expected, hostConfig.domains) {
+		t.Fatalf("unexpected host domains, want %+v, got %+v", expected, hostConfig.domains)
+	}
+}
+
 func TestDNSPermanent_updateHostDNS_emptyUpstream(t *testing.T) {
 	wgIFace, err := createWgInterfaceWithBind(t)
 	if err != nil {
@@ -12,6 +12,8 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/miekg/dns"
 	log "github.com/sirupsen/logrus"
+
+	nbdns "github.com/netbirdio/netbird/dns"
 )
 
 const (
@@ -36,6 +38,12 @@ type upstreamResolver struct {
 	reactivatePeriod time.Duration
 	upstreamTimeout  time.Duration
 
+	// encryptedClients are the DNS over TLS and DNS over HTTPS transports keyed by upstream,
+	// the upstreams without an encrypted transport are queried with the upstreamClient
+	encryptedClients map[string]upstreamClient
+	// cache is shared by the upstream resolvers of the server, nil if the responses are not cached
+	cache *responseCache
+
 	deactivate func()
 	reactivate func()
 }
@@ -46,6 +54,7 @@ func newUpstreamResolver(parentCTX context.Context) *upstreamResolver {
 		ctx:              ctx,
 		cancel:           cancel,
 		upstreamClient:   &dns.Client{},
+		encryptedClients: make(map[string]upstreamClient),
 		upstreamTimeout:  upstreamTimeout,
 		reactivatePeriod: reactivatePeriod,
 		failsTillDeact:   failsTillDeact,
@@ -55,6 +64,38 @@ func newUpstreamResolver(parentCTX context.Context) *upstreamResolver {
 func (u *upstreamResolver) stop() {
 	log.Debugf("stoping serving DNS for upstreams %s", u.upstreamServers)
 	u.cancel()
+
+	for _, client := range u.encryptedClients {
+		if doh, ok := client.(*dohClient); ok {
+			doh.close()
+		}
+	}
+}
+
+// addEncryptedUpstream adds a DNS over TLS or DNS over HTTPS nameserver to the upstream servers
+func (u *upstreamResolver) addEncryptedUpstream(ns nbdns.NameServer) error {
+	var client upstreamClient
+	switch ns.NSType {
+	case nbdns.TLSNameServerType:
+		client = newDoTClient(ns)
+	case nbdns.HTTPSNameServerType:
+		client = newDoHClient(ns)
+	default:
+		return fmt.Errorf("nameserver type %s is not encrypted", ns.NSType)
+	}
+
+	upstream := fmt.Sprintf("%s://%s", ns.NSType, getNSHostPort(ns))
+	u.encryptedClients[upstream] = client
+	u.upstreamServers = append(u.upstreamServers, upstream)
+	return nil
+}
+
+// clientFor returns the transport used to query an upstream
+func (u *upstreamResolver) clientFor(upstream string) upstreamClient {
+	if client, ok := u.encryptedClients[upstream]; ok {
+		return client
+	}
+	return u.upstreamClient
 }
 
 // ServeDNS handles a DNS request
@@ -69,9 +110,20 @@ func (u *upstreamResolver) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	default:
 	}
 
+	if u.cache != nil {
+		if cached := u.cache.get(r); cached != nil {
+			log.WithField("question", r.Question[0]).Trace("serving the upstream question from the cache")
+			err := w.WriteMsg(cached)
+			if err != nil {
+				log.WithError(err).Error("got an error while writing the cached upstream response")
+			}
+			return
+		}
+	}
+
 	for _, upstream := range u.upstreamServers {
 		ctx, cancel := context.WithTimeout(u.ctx, u.upstreamTimeout)
-		rm, t, err := u.upstreamClient.ExchangeContext(ctx, r, upstream)
+		rm, t, err := u.clientFor(upstream).ExchangeContext(ctx, r, upstream)
 
 		cancel()
 
@@ -89,6 +141,10 @@ func (u *upstreamResolver) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 
 		log.Tracef("took %s to query the upstream %s", t, upstream)
 
+		if u.cache != nil {
+			u.cache.add(r, rm)
+		}
+
 		err = w.WriteMsg(rm)
 		if err != nil {
 			log.WithError(err).Error("got an error while writing the upstream resolver response")
@@ -149,7 +205,7 @@ func (u *upstreamResolver) waitUntilResponse() {
 		var err error
 		for _, upstream := range u.upstreamServers {
 			ctx, cancel := context.WithTimeout(u.ctx, u.upstreamTimeout)
-			_, _, err = u.upstreamClient.ExchangeContext(ctx, r, upstream)
+			_, _, err = u.clientFor(upstream).ExchangeContext(ctx, r, upstream)
 
 			cancel()
 
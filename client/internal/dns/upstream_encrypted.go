@@ -0,0 +1,145 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/miekg/dns"
+
+	nbdns "github.com/netbirdio/netbird/dns"
+)
+
+const (
+	dohPath              = "/dns-query"
+	dohMediaType         = "application/dns-message"
+	dohIdleConnTimeout   = 30 * time.Second
+	dohMaxIdleConns      = 2
+	encryptedDialTimeout = 5 * time.Second
+)
+
+// serverName returns the name used to validate the nameserver certificate
+func serverName(ns nbdns.NameServer) string {
+	if ns.ServerName != "" {
+		return ns.ServerName
+	}
+	return ns.IP.String()
+}
+
+// nameserverAddress returns the IP and port to dial, IPv6 addresses are enclosed in brackets
+func nameserverAddress(ns nbdns.NameServer) string {
+	return net.JoinHostPort(ns.IP.String(), strconv.Itoa(ns.Port))
+}
+
+func newTLSConfig(ns nbdns.NameServer) *tls.Config {
+	return &tls.Config{
+		ServerName: serverName(ns),
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+// dotClient queries a DNS over TLS (RFC 7858) nameserver
+type dotClient struct {
+	address string
+	client  *dns.Client
+}
+
+func newDoTClient(ns nbdns.NameServer) *dotClient {
+	return &dotClient{
+		address: nameserverAddress(ns),
+		client: &dns.Client{
+			Net:         "tcp-tls",
+			TLSConfig:   newTLSConfig(ns),
+			DialTimeout: encryptedDialTimeout,
+		},
+	}
+}
+
+// ExchangeContext sends the query to the nameserver address, the upstream key is ignored
+func (c *dotClient) ExchangeContext(ctx context.Context, m *dns.Msg, _ string) (*dns.Msg, time.Duration, error) {
+	return c.client.ExchangeContext(ctx, m, c.address)
+}
+
+// dohClient queries a DNS over HTTPS (RFC 8484) nameserver.
+// The connections are always established to the nameserver IP while the certificate is validated against the server name
+type dohClient struct {
+	url        string
+	httpClient *http.Client
+}
+
+func newDoHClient(ns nbdns.NameServer) *dohClient {
+	address := nameserverAddress(ns)
+	dialer := &net.Dialer{Timeout: encryptedDialTimeout}
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, address)
+		},
+		TLSClientConfig:     newTLSConfig(ns),
+		TLSHandshakeTimeout: encryptedDialTimeout,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        dohMaxIdleConns,
+		IdleConnTimeout:     dohIdleConnTimeout,
+	}
+
+	dohURL := url.URL{
+		Scheme: "https",
+		Host:   net.JoinHostPort(serverName(ns), strconv.Itoa(ns.Port)),
+		Path:   dohPath,
+	}
+
+	return &dohClient{
+		url:        dohURL.String(),
+		httpClient: &http.Client{Transport: transport},
+	}
+}
+
+// ExchangeContext sends the query as a POST request to the nameserver, the upstream key is ignored
+func (c *dohClient) ExchangeContext(ctx context.Context, m *dns.Msg, _ string) (*dns.Msg, time.Duration, error) {
+	start := time.Now()
+
+	packed, err := m.Pack()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to pack the query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(packed))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", dohMediaType)
+	req.Header.Set("Accept", dohMediaType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("nameserver %s returned status %s", c.url, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, dns.MaxMsgSize))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read the response from %s: %w", c.url, err)
+	}
+
+	r := new(dns.Msg)
+	err = r.Unpack(body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to unpack the response from %s: %w", c.url, err)
+	}
+
+	return r, time.Since(start), nil
+}
+
+func (c *dohClient) close() {
+	c.httpClient.CloseIdleConnections()
+}
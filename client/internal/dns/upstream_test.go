@@ -2,11 +2,19 @@ package dns
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/miekg/dns"
+
+	nbdns "github.com/netbirdio/netbird/dns"
 )
 
 func TestUpstreamResolver_ServeDNS(t *testing.T) {
@@ -176,3 +184,131 @@ func TestUpstreamResolver_DeactivationReactivation(t *testing.T) {
 		t.Errorf("should be enabled")
 	}
 }
+
+func TestUpstreamResolver_EncryptedUpstreams(t *testing.T) {
+	answer := func(r *dns.Msg) *dns.Msg {
+		m := new(dns.Msg).SetReply(r)
+		rr, _ := dns.NewRR(r.Question[0].Name + " 300 IN A 10.0.0.1")
+		m.Answer = append(m.Answer, rr)
+		return m
+	}
+
+	dohServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.URL.Path != dohPath || r.Header.Get("Content-Type") != dohMediaType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		query := new(dns.Msg)
+		if err := query.Unpack(body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		packed, _ := answer(query).Pack()
+		w.Header().Set("Content-Type", dohMediaType)
+		_, _ = w.Write(packed)
+	}))
+	defer dohServer.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(dohServer.Certificate())
+
+	tlsListener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: dohServer.TLS.Certificates})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dotServer := &dns.Server{
+		Listener: tlsListener,
+		Net:      "tcp-tls",
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			_ = w.WriteMsg(answer(r))
+		}),
+	}
+	go func() {
+		_ = dotServer.ActivateAndServe()
+	}()
+	defer func() {
+		_ = dotServer.Shutdown()
+	}()
+
+	dohAddr := netip.MustParseAddrPort(dohServer.Listener.Addr().String())
+	dotAddr := netip.MustParseAddrPort(tlsListener.Addr().String())
+
+	testCases := []struct {
+		name        string
+		nameServer  nbdns.NameServer
+		shouldFail  bool
+		expectedKey string
+	}{
+		{
+			name:        "DNS over HTTPS",
+			nameServer:  nbdns.NameServer{IP: dohAddr.Addr(), Port: int(dohAddr.Port()), NSType: nbdns.HTTPSNameServerType},
+			expectedKey: "https://" + dohAddr.String(),
+		},
+		{
+			name:        "DNS over TLS",
+			nameServer:  nbdns.NameServer{IP: dotAddr.Addr(), Port: int(dotAddr.Port()), NSType: nbdns.TLSNameServerType},
+			expectedKey: "tls://" + dotAddr.String(),
+		},
+		{
+			name: "DNS over HTTPS With Invalid Server Name",
+			nameServer: nbdns.NameServer{IP: dohAddr.Addr(), Port: int(dohAddr.Port()), NSType: nbdns.HTTPSNameServerType,
+				ServerName: "dns.netbird.io"},
+			shouldFail: true,
+		},
+		{
+			name: "DNS over TLS With Invalid Server Name",
+			nameServer: nbdns.NameServer{IP: dotAddr.Addr(), Port: int(dotAddr.Port()), NSType: nbdns.TLSNameServerType,
+				ServerName: "dns.netbird.io"},
+			shouldFail: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resolver := newUpstreamResolver(context.Background())
+			defer resolver.stop()
+			resolver.upstreamTimeout = 2 * time.Second
+
+			err := resolver.addEncryptedUpstream(testCase.nameServer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// trust the test server certificate
+			for _, client := range resolver.encryptedClients {
+				switch c := client.(type) {
+				case *dohClient:
+					c.httpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs = rootCAs
+				case *dotClient:
+					c.client.TLSConfig.RootCAs = rootCAs
+				}
+			}
+
+			var responseMSG *dns.Msg
+			responseWriter := &mockResponseWriter{
+				WriteMsgFunc: func(m *dns.Msg) error {
+					responseMSG = m
+					return nil
+				},
+			}
+
+			resolver.ServeDNS(responseWriter, new(dns.Msg).SetQuestion("peer.netbird.cloud.", dns.TypeA))
+
+			if testCase.shouldFail {
+				if responseMSG != nil {
+					t.Errorf("the certificate validation should fail for server name %s", testCase.nameServer.ServerName)
+				}
+				return
+			}
+
+			if resolver.upstreamServers[0] != testCase.expectedKey {
+				t.Errorf("upstream should be %s, got %s", testCase.expectedKey, resolver.upstreamServers[0])
+			}
+
+			if responseMSG == nil || len(responseMSG.Answer) != 1 || !strings.Contains(responseMSG.Answer[0].String(), "10.0.0.1") {
+				t.Fatalf("should write the answer of the encrypted upstream, got %v", responseMSG)
+			}
+		})
+	}
+}
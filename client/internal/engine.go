@@ -54,6 +54,9 @@ type EngineConfig struct {
 	// WgAddr is a Wireguard local address (Netbird Network IP)
 	WgAddr string
 
+	// WgAddrV6 is the optional IPv6 overlay address of the Wireguard interface
+	WgAddrV6 string
+
 	// WgPrivateKey is a Wireguard private key of our peer (it MUST never leave the machine)
 	WgPrivateKey wgtypes.Key
 
@@ -75,6 +78,9 @@ type EngineConfig struct {
 	NATExternalIPs []string
 
 	CustomDNSAddress string
+
+	// UseExitNode enables routing all the traffic through the routing peers of a distributed default route
+	UseExitNode bool
 }
 
 // Engine is a mechanism responsible for reacting on Signal and Management stream events and managing connections to the remote peers.
@@ -109,6 +115,10 @@ type Engine struct {
 
 	// networkSerial is the latest CurrentSerial (state ID) of the network sent by the Management service
 	networkSerial uint64
+	// networkMap is the latest NetworkMap sent by the Management service, the NetworkMapDelta updates apply to it
+	networkMap *mgmProto.NetworkMap
+	// networkMapSequence is the sequence of the latest NetworkMap or NetworkMapDelta of the Sync stream
+	networkMapSequence uint64
 
 	sshServerFunc func(hostKeyPEM []byte, addr string) (nbssh.Server, error)
 	sshServer     nbssh.Server
@@ -178,7 +188,7 @@ func (e *Engine) Start() error {
 	defer e.syncMsgMux.Unlock()
 
 	wgIFaceName := e.config.WgIfaceName
-	wgAddr := e.config.WgAddr
+	wgAddr := iface.JoinAddresses(e.config.WgAddr, e.config.WgAddrV6)
 	myPrivateKey := e.config.WgPrivateKey
 	var err error
 	transportNet, err := e.newStdNet()
@@ -214,8 +224,19 @@ func (e *Engine) Start() error {
 		}
 	}
 
+	dnsServer := e.dnsServer
+	e.statusRecorder.SetDNSCacheStateProvider(func() peer.DNSCacheState {
+		stats := dnsServer.CacheStats()
+		return peer.DNSCacheState{
+			Entries: stats.Entries,
+			Hits:    stats.Hits,
+			Misses:  stats.Misses,
+		}
+	})
+
 	e.routeManager = routemanager.NewManager(e.ctx, e.config.WgPrivateKey.PublicKey().String(), e.wgInterface, e.statusRecorder, routes)
 	e.routeManager.SetRouteChangeListener(e.mobileDep.RouteListener)
+	e.routeManager.SetExitNode(e.config.UseExitNode)
 
 	if runtime.GOOS != "android" {
 		err = e.wgInterface.Create()
@@ -440,10 +461,16 @@ func (e *Engine) handleSync(update *mgmProto.SyncResponse) error {
 			return err
 		}
 
+		if e.routeManager != nil {
+			e.routeManager.SetControlPlaneEndpoints(e.stunTurnHosts())
+		}
+
 		// todo update signal
 	}
 
 	if update.GetNetworkMap() != nil {
+		e.networkMap = update.GetNetworkMap()
+		e.networkMapSequence = update.GetSequence()
 		// only apply new changes and ignore old ones
 		err := e.updateNetworkMap(update.GetNetworkMap())
 		if err != nil {
@@ -451,6 +478,91 @@ func (e *Engine) handleSync(update *mgmProto.SyncResponse) error {
 		}
 	}
 
+	if update.GetNetworkMapDelta() != nil {
+		err := e.updateNetworkMapDelta(update.GetNetworkMapDelta())
+		if err != nil {
+			// the error reconnects the Sync stream which starts with a full NetworkMap
+			e.networkMap = nil
+			return fmt.Errorf("failed applying network map delta: %w", err)
+		}
+		e.networkMapSequence = update.GetSequence()
+	}
+
+	return nil
+}
+
+// updateNetworkMapDelta applies the delta to the network map of the engine. Only the peers of the delta are
+// added, modified or removed
+func (e *Engine) updateNetworkMapDelta(delta *mgmProto.NetworkMapDelta) error {
+	if e.networkMap == nil || delta.GetBaseSequence() != e.networkMapSequence {
+		return fmt.Errorf("the delta applies to the network map with sequence %d, the current sequence is %d",
+			delta.GetBaseSequence(), e.networkMapSequence)
+	}
+	applyNetworkMapDelta(e.networkMap, delta)
+
+	if delta.GetPeerConfig() != nil {
+		err := e.updateConfig(delta.GetPeerConfig())
+		if err != nil {
+			return err
+		}
+	}
+
+	serial := delta.GetSerial()
+	log.Debugf("got peers delta from Management Service, %d peers to add or modify, %d peers to remove",
+		len(delta.GetUpsertedPeers()), len(delta.GetRemovedPeers()))
+
+	if len(delta.GetUpsertedOfflinePeers()) > 0 || len(delta.GetRemovedOfflinePeers()) > 0 {
+		e.updateOfflinePeers(e.networkMap.GetOfflinePeers())
+	}
+
+	for _, peerKey := range delta.GetRemovedPeers() {
+		err := e.removePeer(peerKey)
+		if err != nil {
+			return err
+		}
+		log.Infof("removed peer %s", peerKey)
+	}
+
+	err := e.modifyPeers(delta.GetUpsertedPeers())
+	if err != nil {
+		return err
+	}
+
+	err = e.addNewPeers(delta.GetUpsertedPeers())
+	if err != nil {
+		return err
+	}
+
+	e.statusRecorder.FinishPeerListModifications()
+
+	// update SSHServer by adding remote peer SSH keys
+	if !isNil(e.sshServer) {
+		for _, config := range delta.GetUpsertedPeers() {
+			if config.GetSshConfig() != nil && config.GetSshConfig().GetSshPubKey() != nil {
+				err := e.sshServer.AddAuthorizedKey(config.WgPubKey, string(config.GetSshConfig().GetSshPubKey()))
+				if err != nil {
+					log.Warnf("failed adding authroized key to SSH DefaultServer %v", err)
+				}
+			}
+		}
+	}
+
+	err = e.routeManager.UpdateRoutes(serial, toRoutes(e.networkMap.GetRoutes()))
+	if err != nil {
+		log.Errorf("failed to update routes, err: %v", err)
+	}
+
+	if delta.GetDNSConfig() != nil {
+		err = e.dnsServer.UpdateDNSServer(serial, toDNSConfig(delta.GetDNSConfig()))
+		if err != nil {
+			log.Errorf("failed to update dns server, err: %v", err)
+		}
+	}
+
+	if e.acl != nil {
+		e.acl.ApplyFiltering(e.networkMap)
+	}
+	e.networkSerial = serial
 	return nil
 }
 
@@ -502,15 +614,17 @@ func (e *Engine) updateSSH(sshConf *mgmProto.SSHConfig) error {
 }
 
 func (e *Engine) updateConfig(conf *mgmProto.PeerConfig) error {
-	if e.wgInterface.Address().String() != conf.Address {
-		oldAddr := e.wgInterface.Address().String()
-		log.Debugf("updating peer address from %s to %s", oldAddr, conf.Address)
-		err := e.wgInterface.UpdateAddr(conf.Address)
+	if e.wgInterface.Address().String() != conf.Address || e.wgInterface.Address().StringV6() != conf.GetAddressV6() {
+		oldAddr := iface.JoinAddresses(e.wgInterface.Address().String(), e.wgInterface.Address().StringV6())
+		newAddr := iface.JoinAddresses(conf.Address, conf.GetAddressV6())
+		log.Debugf("updating peer address from %s to %s", oldAddr, newAddr)
+		err := e.wgInterface.UpdateAddr(newAddr)
 		if err != nil {
 			return err
 		}
 		e.config.WgAddr = conf.Address
-		log.Infof("updated peer address from %s to %s", oldAddr, conf.Address)
+		e.config.WgAddrV6 = conf.GetAddressV6()
+		log.Infof("updated peer address from %s to %s", oldAddr, newAddr)
 	}
 
 	if conf.GetSshConfig() != nil {
@@ -522,6 +636,7 @@ func (e *Engine) updateConfig(conf *mgmProto.PeerConfig) error {
 
 	e.statusRecorder.UpdateLocalPeerState(peer.LocalPeerState{
 		IP:              e.config.WgAddr,
+		IPv6:            e.config.WgAddrV6,
 		PubKey:          e.config.WgPrivateKey.PublicKey().String(),
 		KernelInterface: iface.WireGuardModuleIsLoaded(),
 		FQDN:            conf.GetFqdn(),
@@ -587,6 +702,18 @@ func (e *Engine) updateTURNs(turns []*mgmProto.ProtectedHostConfig) error {
 	return nil
 }
 
+// stunTurnHosts returns the hosts of the STUN and TURN servers used by ICE
+func (e *Engine) stunTurnHosts() []string {
+	hosts := make([]string, 0, len(e.STUNs)+len(e.TURNs))
+	for _, u := range e.STUNs {
+		hosts = append(hosts, u.Host)
+	}
+	for _, u := range e.TURNs {
+		hosts = append(hosts, u.Host)
+	}
+	return hosts
+}
+
 func (e *Engine) updateNetworkMap(networkMap *mgmProto.NetworkMap) error {
 
 	// intentionally leave it before checking serial because for now it can happen that peer IP changed but serial didn't
@@ -698,6 +825,8 @@ func toDNSConfig(protoDNSConfig *mgmProto.DNSConfig) nbdns.Config {
 	for _, zone := range protoDNSConfig.GetCustomZones() {
 		dnsZone := nbdns.CustomZone{
 			Domain: zone.GetDomain(),
+			// the flag is negated on the wire, so the zones of the servers not sending it stay search domains
+			SearchDomainEnabled: !zone.GetSearchDomainDisabled(),
 		}
 		for _, record := range zone.Records {
 			dnsRecord := nbdns.SimpleRecord{
@@ -714,14 +843,16 @@ func toDNSConfig(protoDNSConfig *mgmProto.DNSConfig) nbdns.Config {
 
 	for _, nsGroup := range protoDNSConfig.GetNameServerGroups() {
 		dnsNSGroup := &nbdns.NameServerGroup{
-			Primary: nsGroup.GetPrimary(),
-			Domains: nsGroup.GetDomains(),
+			Primary:              nsGroup.GetPrimary(),
+			Domains:              nsGroup.GetDomains(),
+			SearchDomainsEnabled: nsGroup.GetSearchDomainsEnabled(),
 		}
 		for _, ns := range nsGroup.GetNameServers() {
 			dnsNS := nbdns.NameServer{
-				IP:     netip.MustParseAddr(ns.GetIP()),
-				NSType: nbdns.NameServerType(ns.GetNSType()),
-				Port:   int(ns.GetPort()),
+				IP:         netip.MustParseAddr(ns.GetIP()),
+				NSType:     nbdns.NameServerType(ns.GetNSType()),
+				Port:       int(ns.GetPort()),
+				ServerName: ns.GetServerName(),
 			}
 			dnsNSGroup.NameServers = append(dnsNSGroup.NameServers, dnsNS)
 		}
@@ -1042,6 +1173,7 @@ func (e *Engine) close() {
 	}
 
 	if e.dnsServer != nil {
+		e.statusRecorder.SetDNSCacheStateProvider(nil)
 		e.dnsServer.Stop()
 	}
 
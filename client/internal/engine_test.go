@@ -653,6 +653,8 @@ func TestEngine_UpdateNetworkMapWithDNSUpdate(t *testing.T) {
 			expectedZones: []nbdns.CustomZone{
 				{
 					Domain: "netbird.cloud.",
+					// the zones are search domains unless the management server disables it
+					SearchDomainEnabled: true,
 					Records: []nbdns.SimpleRecord{
 						{
 							Name:  "peer-a.netbird.cloud.",
@@ -1043,13 +1045,13 @@ func startManagement(dataDir string) (*grpc.Server, string, error) {
 	if err != nil {
 		log.Fatalf("failed creating a store: %s: %v", config.Datadir, err)
 	}
-	peersUpdateManager := server.NewPeersUpdateManager()
+	peersUpdateManager := server.NewPeersUpdateManager(nil)
 	eventStore := &activity.InMemoryEventStore{}
 	if err != nil {
 		return nil, "", err
 	}
 	accountManager, err := server.BuildManager(store, peersUpdateManager, nil, "", "",
-		eventStore, false)
+		eventStore, false, 0, nil)
 	if err != nil {
 		return nil, "", err
 	}
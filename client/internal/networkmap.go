@@ -0,0 +1,71 @@
+package internal
+
+import (
+	mgmProto "github.com/netbirdio/netbird/management/proto"
+)
+
+// applyNetworkMapDelta applies the delta to the network map it was computed for
+func applyNetworkMapDelta(networkMap *mgmProto.NetworkMap, delta *mgmProto.NetworkMapDelta) {
+	networkMap.Serial = delta.GetSerial()
+	if delta.GetPeerConfig() != nil {
+		networkMap.PeerConfig = delta.GetPeerConfig()
+	}
+	if delta.GetDNSConfig() != nil {
+		networkMap.DNSConfig = delta.GetDNSConfig()
+	}
+
+	networkMap.RemotePeers = applyPeersDelta(networkMap.GetRemotePeers(), delta.GetUpsertedPeers(), delta.GetRemovedPeers())
+	networkMap.RemotePeersIsEmpty = len(networkMap.RemotePeers) == 0
+	networkMap.OfflinePeers = applyPeersDelta(networkMap.GetOfflinePeers(), delta.GetUpsertedOfflinePeers(), delta.GetRemovedOfflinePeers())
+
+	networkMap.Routes = delta.GetRoutes()
+
+	replacedIPs := make(map[string]struct{}, len(delta.GetFirewallRulesPeerIPs()))
+	for _, ip := range delta.GetFirewallRulesPeerIPs() {
+		replacedIPs[ip] = struct{}{}
+	}
+	rules := make([]*mgmProto.FirewallRule, 0, len(networkMap.GetFirewallRules())+len(delta.GetFirewallRules()))
+	for _, rule := range networkMap.GetFirewallRules() {
+		if _, ok := replacedIPs[rule.GetPeerIP()]; !ok {
+			rules = append(rules, rule)
+		}
+	}
+	networkMap.FirewallRules = append(rules, delta.GetFirewallRules()...)
+	networkMap.FirewallRulesIsEmpty = len(networkMap.FirewallRules) == 0
+}
+
+// applyPeersDelta returns the peers with the upserted peers replaced or added and the removed peers left out.
+// Peers are identified by their WireGuard public key
+func applyPeersDelta(peers, upserted []*mgmProto.RemotePeerConfig, removed []string) []*mgmProto.RemotePeerConfig {
+	if len(upserted) == 0 && len(removed) == 0 {
+		return peers
+	}
+
+	removedKeys := make(map[string]struct{}, len(removed))
+	for _, key := range removed {
+		removedKeys[key] = struct{}{}
+	}
+	upsertedPeers := make(map[string]*mgmProto.RemotePeerConfig, len(upserted))
+	for _, p := range upserted {
+		upsertedPeers[p.GetWgPubKey()] = p
+	}
+
+	result := make([]*mgmProto.RemotePeerConfig, 0, len(peers)+len(upserted))
+	for _, p := range peers {
+		if _, ok := removedKeys[p.GetWgPubKey()]; ok {
+			continue
+		}
+		if upsertedPeer, ok := upsertedPeers[p.GetWgPubKey()]; ok {
+			result = append(result, upsertedPeer)
+			delete(upsertedPeers, p.GetWgPubKey())
+			continue
+		}
+		result = append(result, p)
+	}
+	for _, p := range upserted {
+		if _, ok := upsertedPeers[p.GetWgPubKey()]; ok {
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mgmProto "github.com/netbirdio/netbird/management/proto"
+)
+
+func TestApplyNetworkMapDelta(t *testing.T) {
+	base := &mgmProto.NetworkMap{
+		Serial:     1,
+		PeerConfig: &mgmProto.PeerConfig{Address: "100.64.0.1/16"},
+		DNSConfig:  &mgmProto.DNSConfig{ServiceEnable: true},
+		RemotePeers: []*mgmProto.RemotePeerConfig{
+			{WgPubKey: "peer1", AllowedIps: []string{"100.64.0.2/32"}},
+			{WgPubKey: "peer2", AllowedIps: []string{"100.64.0.3/32"}},
+		},
+		OfflinePeers: []*mgmProto.RemotePeerConfig{{WgPubKey: "offline1"}},
+		Routes: []*mgmProto.Route{
+			{ID: "route1", Network: "10.0.0.0/24"},
+			{ID: "route2", Network: "10.0.1.0/24"},
+		},
+		FirewallRules: []*mgmProto.FirewallRule{
+			{PeerIP: "100.64.0.2", Protocol: mgmProto.FirewallRule_ALL},
+			{PeerIP: "100.64.0.3", Protocol: mgmProto.FirewallRule_TCP, Port: "22"},
+		},
+	}
+
+	delta := &mgmProto.NetworkMapDelta{
+		BaseSequence:         1,
+		Serial:               2,
+		UpsertedPeers:        []*mgmProto.RemotePeerConfig{{WgPubKey: "peer2", AllowedIps: []string{"100.64.0.30/32"}}, {WgPubKey: "peer3"}},
+		RemovedPeers:         []string{"peer1"},
+		RemovedOfflinePeers:  []string{"offline1"},
+		Routes:               []*mgmProto.Route{{ID: "route2", Network: "10.0.2.0/24"}},
+		FirewallRules:        []*mgmProto.FirewallRule{{PeerIP: "100.64.0.30", Protocol: mgmProto.FirewallRule_UDP}},
+		FirewallRulesPeerIPs: []string{"100.64.0.3", "100.64.0.30"},
+		DNSConfig:            &mgmProto.DNSConfig{},
+	}
+
+	peerConfig := base.PeerConfig
+	networkMap := base
+	applyNetworkMapDelta(networkMap, delta)
+
+	assert.Equal(t, uint64(2), networkMap.Serial)
+	assert.Same(t, peerConfig, networkMap.PeerConfig, "the peer config should be kept if not sent")
+	assert.False(t, networkMap.DNSConfig.ServiceEnable, "the DNS config should be replaced")
+
+	require.Len(t, networkMap.RemotePeers, 2)
+	assert.Equal(t, "peer2", networkMap.RemotePeers[0].WgPubKey)
+	assert.Equal(t, []string{"100.64.0.30/32"}, networkMap.RemotePeers[0].AllowedIps)
+	assert.Equal(t, "peer3", networkMap.RemotePeers[1].WgPubKey)
+	assert.False(t, networkMap.RemotePeersIsEmpty)
+	assert.Empty(t, networkMap.OfflinePeers)
+
+	require.Len(t, networkMap.Routes, 1)
+	assert.Equal(t, "10.0.2.0/24", networkMap.Routes[0].Network)
+
+	require.Len(t, networkMap.FirewallRules, 2)
+	assert.Equal(t, "100.64.0.2", networkMap.FirewallRules[0].PeerIP, "the rules of the other peers should be kept")
+	assert.Equal(t, "100.64.0.30", networkMap.FirewallRules[1].PeerIP)
+
+	removeAll := &mgmProto.NetworkMapDelta{
+		BaseSequence:         2,
+		Serial:               3,
+		RemovedPeers:         []string{"peer2", "peer3"},
+		FirewallRulesPeerIPs: []string{"100.64.0.2", "100.64.0.30"},
+	}
+	applyNetworkMapDelta(networkMap, removeAll)
+	assert.True(t, networkMap.RemotePeersIsEmpty)
+	assert.True(t, networkMap.FirewallRulesIsEmpty)
+	assert.Empty(t, networkMap.Routes, "the routes should be replaced")
+}
+
+func TestEngine_UpdateNetworkMapDelta_SequenceMismatch(t *testing.T) {
+	engine := &Engine{}
+	err := engine.updateNetworkMapDelta(&mgmProto.NetworkMapDelta{BaseSequence: 1, Serial: 2})
+	assert.Error(t, err, "a delta without a network map should fail")
+
+	networkMap := &mgmProto.NetworkMap{Serial: 3, RemotePeers: []*mgmProto.RemotePeerConfig{{WgPubKey: "peer1"}}}
+	engine = &Engine{networkMap: networkMap, networkMapSequence: 3}
+	err = engine.updateNetworkMapDelta(&mgmProto.NetworkMapDelta{BaseSequence: 2, Serial: 4, RemovedPeers: []string{"peer1"}})
+	assert.Error(t, err, "a delta of another network map should fail")
+	assert.Len(t, networkMap.RemotePeers, 1, "a delta of another network map should not be applied")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -398,6 +399,9 @@ func (conn *Conn) configureConnection(remoteConn net.Conn, remoteWgPort int) (ne
 	if pair.Local.Type() == ice.CandidateTypeRelay || pair.Remote.Type() == ice.CandidateTypeRelay {
 		peerState.Relayed = true
 	}
+	if !isRelayCandidate(pair.Local) {
+		peerState.RemoteEndpoint = net.JoinHostPort(pair.Remote.Address(), strconv.Itoa(pair.Remote.Port()))
+	}
 
 	err = conn.statusRecorder.UpdatePeerState(peerState)
 	if err != nil {
@@ -17,11 +17,16 @@ type State struct {
 	Direct                 bool
 	LocalIceCandidateType  string
 	RemoteIceCandidateType string
+	// RemoteEndpoint is the address the connection sends the WireGuard traffic to directly,
+	// empty when the traffic is relayed through the local TURN server
+	RemoteEndpoint string
 }
 
 // LocalPeerState contains the latest state of the local peer
 type LocalPeerState struct {
-	IP              string
+	IP string
+	// IPv6 is the IPv6 overlay address of the local peer, empty if not assigned
+	IPv6            string
 	PubKey          string
 	KernelInterface bool
 	FQDN            string
@@ -39,12 +44,20 @@ type ManagementState struct {
 	Connected bool
 }
 
+// DNSCacheState contains the counters of the DNS response cache
+type DNSCacheState struct {
+	Entries int
+	Hits    uint64
+	Misses  uint64
+}
+
 // FullStatus contains the full state held by the Status instance
 type FullStatus struct {
 	Peers           []State
 	ManagementState ManagementState
 	SignalState     SignalState
 	LocalPeerState  LocalPeerState
+	DNSCacheState   DNSCacheState
 }
 
 // Status holds a state of peers, signal and management connections
@@ -59,6 +72,10 @@ type Status struct {
 	mgmAddress      string
 	signalAddress   string
 	notifier        *notifier
+	// peersChangeNotify is closed when the state of any peer or the list of peers changes
+	peersChangeNotify chan struct{}
+	// dnsCacheState reads the counters of the DNS response cache, nil if the DNS server is not running
+	dnsCacheState func() DNSCacheState
 
 	// To reduce the number of notification invocation this bool will be true when need to call the notification
 	// Some Peer actions mostly used by in a batch when the network map has been synchronized. In these type of events
@@ -156,6 +173,7 @@ func (d *Status) UpdatePeerState(receivedState State) error {
 		peerState.Relayed = receivedState.Relayed
 		peerState.LocalIceCandidateType = receivedState.LocalIceCandidateType
 		peerState.RemoteIceCandidateType = receivedState.RemoteIceCandidateType
+		peerState.RemoteEndpoint = receivedState.RemoteEndpoint
 	}
 
 	d.peers[receivedState.PubKey] = peerState
@@ -169,6 +187,7 @@ func (d *Status) UpdatePeerState(receivedState State) error {
 		close(ch)
 		d.changeNotify[receivedState.PubKey] = nil
 	}
+	d.notifyPeersChanged()
 
 	d.notifyPeerListChanged()
 	return nil
@@ -212,6 +231,7 @@ func (d *Status) FinishPeerListModifications() {
 		return
 	}
 	d.peerListChangedForNotification = false
+	d.notifyPeersChanged()
 	d.mux.Unlock()
 
 	d.notifyPeerListChanged()
@@ -229,6 +249,16 @@ func (d *Status) GetPeerStateChangeNotifier(peer string) <-chan struct{} {
 	return ch
 }
 
+// GetPeersChangeNotifier returns a change notifier channel closed when the state of any peer or the list of peers changes
+func (d *Status) GetPeersChangeNotifier() <-chan struct{} {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	if d.peersChangeNotify == nil {
+		d.peersChangeNotify = make(chan struct{})
+	}
+	return d.peersChangeNotify
+}
+
 // UpdateLocalPeerState updates local peer status
 func (d *Status) UpdateLocalPeerState(localPeerState LocalPeerState) {
 	d.mux.Lock()
@@ -297,6 +327,13 @@ func (d *Status) MarkSignalConnected() {
 	d.signalState = true
 }
 
+// SetDNSCacheStateProvider sets the function reading the DNS response cache counters
+func (d *Status) SetDNSCacheStateProvider(provider func() DNSCacheState) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.dnsCacheState = provider
+}
+
 // GetFullStatus gets full status
 func (d *Status) GetFullStatus() FullStatus {
 	d.mux.Lock()
@@ -320,6 +357,10 @@ func (d *Status) GetFullStatus() FullStatus {
 
 	fullStatus.Peers = append(fullStatus.Peers, d.offlinePeers...)
 
+	if d.dnsCacheState != nil {
+		fullStatus.DNSCacheState = d.dnsCacheState()
+	}
+
 	return fullStatus
 }
 
@@ -356,6 +397,13 @@ func (d *Status) notifyPeerListChanged() {
 	d.notifier.peerListChanged(d.numOfPeers())
 }
 
+func (d *Status) notifyPeersChanged() {
+	if d.peersChangeNotify != nil {
+		close(d.peersChangeNotify)
+		d.peersChangeNotify = nil
+	}
+}
+
 func (d *Status) notifyAddressChanged() {
 	d.notifier.localAddressChanged(d.localPeer.FQDN, d.localPeer.IP)
 }
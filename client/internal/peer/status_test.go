@@ -98,6 +98,43 @@ func TestGetPeerStateChangeNotifierLogic(t *testing.T) {
 	}
 }
 
+func TestGetPeersChangeNotifier(t *testing.T) {
+	key := "abc"
+	status := NewRecorder("https://mgm")
+	status.peers[key] = State{PubKey: key, ConnStatus: StatusDisconnected}
+
+	ch := status.GetPeersChangeNotifier()
+	assert.NotNil(t, ch, "channel shouldn't be nil")
+
+	err := status.UpdatePeerState(State{PubKey: key, ConnStatus: StatusConnecting})
+	assert.NoError(t, err, "shouldn't return error")
+
+	select {
+	case <-ch:
+		t.Errorf("channel was closed for a skipped notification")
+	default:
+	}
+
+	err = status.UpdatePeerState(State{PubKey: key, ConnStatus: StatusConnected, RemoteEndpoint: "203.0.113.10:51820"})
+	assert.NoError(t, err, "shouldn't return error")
+
+	select {
+	case <-ch:
+	default:
+		t.Errorf("channel wasn't closed after update")
+	}
+
+	ch = status.GetPeersChangeNotifier()
+	assert.NoError(t, status.AddPeer("def", "def.netbird.cloud"))
+	status.FinishPeerListModifications()
+
+	select {
+	case <-ch:
+	default:
+		t.Errorf("channel wasn't closed after the peer list changed")
+	}
+}
+
 func TestRemovePeer(t *testing.T) {
 	key := "abc"
 	status := NewRecorder("https://mgm")
@@ -35,6 +35,8 @@ type clientNetwork struct {
 	chosenRoute         *route.Route
 	network             netip.Prefix
 	updateSerial        uint64
+	// exitNode installs the system routes when the network is the default route
+	exitNode *exitNode
 }
 
 func newClientNetworkWatcher(ctx context.Context, wgInterface *iface.WGIface, statusRecorder *peer.Status, network netip.Prefix) *clientNetwork {
@@ -176,7 +178,11 @@ func (c *clientNetwork) removeRouteFromPeerAndSystem() error {
 		if err != nil {
 			return err
 		}
-		err = removeFromRouteTableIfNonSystem(c.network, c.wgInterface.Address().IP.String())
+		if c.exitNode != nil {
+			err = c.exitNode.deactivate()
+		} else {
+			err = removeFromRouteTableIfNonSystem(c.network, c.wgInterface.Address().IP.String())
+		}
 		if err != nil {
 			return fmt.Errorf("couldn't remove route %s from system, err: %v",
 				c.network, err)
@@ -215,7 +221,11 @@ func (c *clientNetwork) recalculateRouteAndUpdatePeerAndSystem() error {
 			return err
 		}
 	} else {
-		err = addToRouteTableIfNoExists(c.network, c.wgInterface.Address().IP.String())
+		if c.exitNode != nil {
+			err = c.exitNode.activate(c.ctx)
+		} else {
+			err = addToRouteTableIfNoExists(c.network, c.wgInterface.Address().IP.String())
+		}
 		if err != nil {
 			return fmt.Errorf("route %s couldn't be added for peer %s, err: %v",
 				c.network.String(), c.wgInterface.Address().IP.String(), err)
@@ -0,0 +1,359 @@
+//go:build !android
+
+package routemanager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-netroute"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/iface"
+)
+
+const exitNodeResolveTimeout = 5 * time.Second
+
+// splitDefaultRoutes cover the whole IPv4 space while being more specific than the system default route,
+// so the original default route and gateway stay in place for the exceptions
+var splitDefaultRoutes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/1"),
+	netip.MustParsePrefix("128.0.0.0/1"),
+}
+
+// splitDefaultRoutesV6 cover the whole IPv6 space the same way, they are installed when the NetBird interface has
+// an IPv6 address
+var splitDefaultRoutesV6 = []netip.Prefix{
+	netip.MustParsePrefix("::/1"),
+	netip.MustParsePrefix("8000::/1"),
+}
+
+// exitNodeInterface is the NetBird interface the exit node routes the traffic to
+type exitNodeInterface interface {
+	Address() iface.WGAddress
+}
+
+// exitNode routes all the traffic through the NetBird interface. The control plane endpoints
+// (Management, Signal, STUN and TURN) and the WireGuard peer endpoints keep using the original default gateway,
+// otherwise the client would send its own tunnel traffic into the tunnel.
+// The IPv6 traffic is routed through the NetBird interface as well when the interface has an IPv6 address, so it
+// doesn't leave through the local uplink. Without an exit node forwarding it, the IPv6 traffic is dropped.
+type exitNode struct {
+	mux            sync.Mutex
+	statusRecorder *peer.Status
+	wgInterface    exitNodeInterface
+	controlPlane   []string
+	defaultGateway net.IP
+	// defaultGatewayV6 is the original IPv6 default gateway, empty when the IPv6 traffic is not routed through the
+	// NetBird interface. Link-local gateways are scoped to their interface, e.g. fe80::1%eth0
+	defaultGatewayV6 string
+	exceptions       map[netip.Prefix]struct{}
+	// cancel stops the exceptions refresh loop, nil when the exit node routes are not installed
+	cancel context.CancelFunc
+}
+
+func newExitNode(statusRecorder *peer.Status, wgInterface exitNodeInterface) *exitNode {
+	return &exitNode{
+		statusRecorder: statusRecorder,
+		wgInterface:    wgInterface,
+		exceptions:     make(map[netip.Prefix]struct{}),
+	}
+}
+
+// setControlPlaneEndpoints updates the list of hosts that must stay reachable outside the tunnel
+func (e *exitNode) setControlPlaneEndpoints(endpoints []string) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	e.controlPlane = endpoints
+	if e.cancel != nil {
+		e.refreshExceptions()
+	}
+}
+
+// activate installs the exceptions and then the routes sending the traffic to the NetBird interface
+func (e *exitNode) activate(ctx context.Context) error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	if e.cancel != nil {
+		return nil
+	}
+
+	gateway, err := getExistingRIBRouteGateway(netip.MustParsePrefix("0.0.0.0/0"))
+	if err != nil {
+		return fmt.Errorf("couldn't find the default gateway: %v", err)
+	}
+	e.defaultGateway = gateway
+
+	e.defaultGatewayV6 = ""
+	if e.wgInterface.Address().HasIPv6() {
+		gatewayV6, err := getDefaultGatewayV6()
+		if err != nil {
+			// without an IPv6 default route there is no IPv6 traffic to route
+			log.Debugf("not routing the IPv6 traffic through the exit node: %v", err)
+		} else {
+			e.defaultGatewayV6 = gatewayV6
+		}
+	} else {
+		log.Warnf("the NetBird interface has no IPv6 address, the IPv6 traffic is not routed through the exit node")
+	}
+
+	e.refreshExceptions()
+
+	for _, prefix := range e.splitRoutes() {
+		gw := e.wgInterface.Address().IP.String()
+		if prefix.Addr().Is6() {
+			gw = e.wgInterface.Address().IPv6.String()
+		}
+		err = addToRouteTable(prefix, gw)
+		if err != nil {
+			_ = e.removeRoutes()
+			return fmt.Errorf("couldn't add exit node route %s: %v", prefix, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+	go e.watchExceptions(ctx)
+
+	log.Infof("routing all the traffic through the exit node, original default gateway %s", gateway)
+	return nil
+}
+
+// deactivate removes the exit node routes and the exceptions
+func (e *exitNode) deactivate() error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	if e.cancel == nil {
+		return nil
+	}
+	e.cancel()
+	e.cancel = nil
+
+	err := e.removeRoutes()
+	if err != nil {
+		return err
+	}
+
+	log.Info("stopped routing all the traffic through the exit node")
+	return nil
+}
+
+// splitRoutes returns the split default routes sending the traffic to the NetBird interface
+func (e *exitNode) splitRoutes() []netip.Prefix {
+	routes := append([]netip.Prefix{}, splitDefaultRoutes...)
+	if e.defaultGatewayV6 != "" {
+		routes = append(routes, splitDefaultRoutesV6...)
+	}
+	return routes
+}
+
+func (e *exitNode) removeRoutes() error {
+	var errs []string
+	for _, prefix := range e.splitRoutes() {
+		err := removeFromRouteTable(prefix)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", prefix, err))
+		}
+	}
+
+	for prefix := range e.exceptions {
+		err := removeFromRouteTable(prefix)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", prefix, err))
+		}
+		delete(e.exceptions, prefix)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("couldn't remove exit node routes: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// watchExceptions keeps the exceptions in sync with the peer endpoints selected after the exit node was activated
+func (e *exitNode) watchExceptions(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.statusRecorder.GetPeersChangeNotifier():
+			e.mux.Lock()
+			if ctx.Err() == nil {
+				e.refreshExceptions()
+			}
+			e.mux.Unlock()
+		}
+	}
+}
+
+// refreshExceptions adds host routes through the original default gateway for the current endpoints
+// and removes the ones no longer in use
+func (e *exitNode) refreshExceptions() {
+	wanted := make(map[netip.Prefix]struct{})
+	for _, addr := range e.exceptionAddresses() {
+		wanted[netip.PrefixFrom(addr, addr.BitLen())] = struct{}{}
+	}
+
+	for prefix := range e.exceptions {
+		if _, found := wanted[prefix]; found {
+			continue
+		}
+		err := removeFromRouteTable(prefix)
+		if err != nil {
+			log.Warnf("couldn't remove exit node exception %s: %v", prefix, err)
+			continue
+		}
+		delete(e.exceptions, prefix)
+	}
+
+	for prefix := range wanted {
+		if _, found := e.exceptions[prefix]; found {
+			continue
+		}
+		gateway := e.defaultGateway.String()
+		if prefix.Addr().Is6() {
+			gateway = e.defaultGatewayV6
+		}
+		err := addToRouteTable(prefix, gateway)
+		if err != nil {
+			log.Warnf("couldn't add exit node exception %s via %s: %v", prefix, gateway, err)
+			continue
+		}
+		log.Debugf("added exit node exception %s via %s", prefix, gateway)
+		e.exceptions[prefix] = struct{}{}
+	}
+}
+
+// exceptionAddresses returns the public addresses of the control plane and the WireGuard peer endpoints.
+// The IPv6 addresses are only returned when the IPv6 traffic is routed through the NetBird interface
+func (e *exitNode) exceptionAddresses() []netip.Addr {
+	fullStatus := e.statusRecorder.GetFullStatus()
+
+	endpoints := make([]string, 0, len(e.controlPlane)+len(fullStatus.Peers)+2)
+	endpoints = append(endpoints, fullStatus.ManagementState.URL, fullStatus.SignalState.URL)
+	endpoints = append(endpoints, e.controlPlane...)
+	for _, peerState := range fullStatus.Peers {
+		endpoints = append(endpoints, peerState.RemoteEndpoint)
+	}
+
+	seen := make(map[netip.Addr]struct{})
+	var addresses []netip.Addr
+	for _, endpoint := range endpoints {
+		host := endpointHost(endpoint)
+		if host == "" {
+			continue
+		}
+		for _, addr := range resolveHost(host, e.defaultGatewayV6 != "") {
+			if _, found := seen[addr]; found || !e.needsException(addr) {
+				continue
+			}
+			seen[addr] = struct{}{}
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses
+}
+
+// needsException returns false for the addresses that are never routed through the default gateway
+func (e *exitNode) needsException(addr netip.Addr) bool {
+	if addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsUnspecified() ||
+		addr.IsMulticast() {
+		return false
+	}
+
+	address := e.wgInterface.Address()
+	if address.Network.Contains(addr.AsSlice()) {
+		return false
+	}
+	return !address.HasIPv6() || !address.NetworkV6.Contains(addr.AsSlice())
+}
+
+// endpointHost extracts the host from a URL (https://host:port), an ICE URL (stun:host:port) or a host:port pair
+func endpointHost(endpoint string) string {
+	if endpoint == "" {
+		return ""
+	}
+
+	if strings.Contains(endpoint, "://") {
+		parsed, err := url.Parse(endpoint)
+		if err != nil {
+			return ""
+		}
+		return parsed.Hostname()
+	}
+
+	for _, scheme := range []string{"stun:", "stuns:", "turn:", "turns:"} {
+		if strings.HasPrefix(endpoint, scheme) {
+			endpoint = strings.TrimPrefix(endpoint, scheme)
+			endpoint, _, _ = strings.Cut(endpoint, "?")
+			break
+		}
+	}
+
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	return host
+}
+
+// resolveHost returns the IPv4 addresses of the host, and its IPv6 addresses when withIPv6 is true
+func resolveHost(host string, withIPv6 bool) []netip.Addr {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addr = addr.Unmap()
+		if addr.Is4() || withIPv6 {
+			return []netip.Addr{addr}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exitNodeResolveTimeout)
+	defer cancel()
+
+	network := "ip4"
+	if withIPv6 {
+		network = "ip"
+	}
+	addresses, err := net.DefaultResolver.LookupNetIP(ctx, network, host)
+	if err != nil {
+		log.Warnf("couldn't resolve %s for the exit node exceptions: %v", host, err)
+		return nil
+	}
+
+	resolved := make([]netip.Addr, 0, len(addresses))
+	for _, addr := range addresses {
+		resolved = append(resolved, addr.Unmap())
+	}
+	return resolved
+}
+
+// getDefaultGatewayV6 returns the gateway of the IPv6 default route. A link-local gateway is scoped to the interface
+// of the route, as the route table requires
+func getDefaultGatewayV6() (string, error) {
+	r, err := netroute.New()
+	if err != nil {
+		return "", err
+	}
+	intf, gateway, _, err := r.Route(net.IPv6unspecified)
+	if err != nil {
+		return "", fmt.Errorf("couldn't find the IPv6 default route: %v", err)
+	}
+	if gateway == nil {
+		return "", fmt.Errorf("the IPv6 default route has no gateway")
+	}
+
+	if gateway.IsLinkLocalUnicast() && intf != nil {
+		return gateway.String() + "%" + intf.Name, nil
+	}
+	return gateway.String(), nil
+}
@@ -0,0 +1,31 @@
+package routemanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/iface"
+)
+
+// exitNodeInterface is the NetBird interface the exit node routes the traffic to
+type exitNodeInterface interface {
+	Address() iface.WGAddress
+}
+
+// exitNode is not supported on Android, the routes are handled by the VPN service of the application
+type exitNode struct{}
+
+func newExitNode(*peer.Status, exitNodeInterface) *exitNode {
+	return &exitNode{}
+}
+
+func (e *exitNode) setControlPlaneEndpoints([]string) {}
+
+func (e *exitNode) activate(context.Context) error {
+	return fmt.Errorf("exit nodes are not supported on android")
+}
+
+func (e *exitNode) deactivate() error {
+	return nil
+}
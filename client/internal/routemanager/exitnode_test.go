@@ -0,0 +1,100 @@
+//go:build !android
+
+package routemanager
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/iface"
+)
+
+func TestEndpointHost(t *testing.T) {
+	testCases := []struct {
+		endpoint string
+		expected string
+	}{
+		{endpoint: "https://api.netbird.io:443", expected: "api.netbird.io"},
+		{endpoint: "http://signal.netbird.io:10000", expected: "signal.netbird.io"},
+		{endpoint: "stun.netbird.io:5555", expected: "stun.netbird.io"},
+		{endpoint: "turn:turn.netbird.io:3478?transport=udp", expected: "turn.netbird.io"},
+		{endpoint: "203.0.113.10:51820", expected: "203.0.113.10"},
+		{endpoint: "[2001:db8::1]:51820", expected: "2001:db8::1"},
+		{endpoint: "203.0.113.10", expected: "203.0.113.10"},
+		{endpoint: "", expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.endpoint, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, endpointHost(testCase.endpoint))
+		})
+	}
+}
+
+type fakeExitNodeInterface struct {
+	address iface.WGAddress
+}
+
+func (f *fakeExitNodeInterface) Address() iface.WGAddress {
+	return f.address
+}
+
+func newFakeExitNodeInterface(t *testing.T, address, addressV6 string) *fakeExitNodeInterface {
+	t.Helper()
+	ip, network, err := net.ParseCIDR(address)
+	require.NoError(t, err)
+	wgAddress := iface.WGAddress{IP: ip, Network: network}
+	if addressV6 != "" {
+		wgAddress.IPv6, wgAddress.NetworkV6, err = net.ParseCIDR(addressV6)
+		require.NoError(t, err)
+	}
+	return &fakeExitNodeInterface{address: wgAddress}
+}
+
+func TestExitNodeNeedsException(t *testing.T) {
+	e := newExitNode(nil, newFakeExitNodeInterface(t, "100.64.0.10/10", "fd00:1234::10/64"))
+
+	testCases := []struct {
+		addr     string
+		expected bool
+	}{
+		{addr: "203.0.113.10", expected: true},
+		{addr: "8.8.8.8", expected: true},
+		{addr: "192.168.1.1", expected: false},
+		{addr: "10.0.0.1", expected: false},
+		{addr: "127.0.0.1", expected: false},
+		{addr: "169.254.1.1", expected: false},
+		{addr: "100.64.0.20", expected: false},
+		{addr: "0.0.0.0", expected: false},
+		{addr: "2001:4860:4860::8888", expected: true},
+		{addr: "fd00:1234::20", expected: false},
+		{addr: "fe80::1", expected: false},
+		{addr: "::1", expected: false},
+		{addr: "ff02::1", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.addr, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, e.needsException(netip.MustParseAddr(testCase.addr)))
+		})
+	}
+}
+
+func TestExitNodeSplitRoutes(t *testing.T) {
+	e := newExitNode(nil, newFakeExitNodeInterface(t, "100.64.0.10/10", "fd00:1234::10/64"))
+	assert.Equal(t, splitDefaultRoutes, e.splitRoutes())
+
+	e.defaultGatewayV6 = "fe80::1%eth0"
+	assert.Equal(t, append(append([]netip.Prefix{}, splitDefaultRoutes...), splitDefaultRoutesV6...), e.splitRoutes())
+}
+
+func TestResolveHost(t *testing.T) {
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("203.0.113.10")}, resolveHost("203.0.113.10", false))
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("203.0.113.10")}, resolveHost("::ffff:203.0.113.10", false))
+	assert.Empty(t, resolveHost("2001:db8::1", false))
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("2001:db8::1")}, resolveHost("2001:db8::1", true))
+}
@@ -0,0 +1,196 @@
+//go:build darwin || freebsd
+// +build darwin freebsd
+
+package routemanager
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// pfManager handles the routing rules with the pf packet filter.
+// All the rules live in a dedicated anchor that is reloaded on every change.
+type pfManager struct {
+	mux    sync.Mutex
+	ctx    context.Context
+	anchor string
+	pairs  map[string]routerPair
+	// releasePF restores the pf state found before the manager enabled it
+	releasePF func() error
+}
+
+// newFirewall returns a pf manager if pfctl is available
+func newFirewall(parentCTX context.Context) (firewallManager, error) {
+	_, err := exec.LookPath("pfctl")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't find pfctl: %v", err)
+	}
+
+	log.Debugf("creating a pf firewall manager for route rules in anchor %s", pfAnchor)
+	return &pfManager{
+		ctx:    parentCTX,
+		anchor: pfAnchor,
+		pairs:  make(map[string]routerPair),
+	}, nil
+}
+
+// RestoreOrCreateContainers enables pf and flushes rules left in the anchor by a previous run
+func (p *pfManager) RestoreOrCreateContainers() error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if p.releasePF != nil {
+		return nil
+	}
+
+	release, err := enablePF()
+	if err != nil {
+		return fmt.Errorf("couldn't enable pf: %v", err)
+	}
+	p.releasePF = release
+
+	return p.loadRules()
+}
+
+// InsertRoutingRules inserts forwarding and, for masqueraded routes, nat rules for a router pair
+func (p *pfManager) InsertRoutingRules(pair routerPair) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.pairs[pair.ID] = pair
+	err := p.loadRules()
+	if err != nil {
+		delete(p.pairs, pair.ID)
+		return fmt.Errorf("couldn't insert routing rules for %s: %v", pair.destination, err)
+	}
+	return nil
+}
+
+// RemoveRoutingRules removes the rules of a router pair
+func (p *pfManager) RemoveRoutingRules(pair routerPair) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	existing, found := p.pairs[pair.ID]
+	if !found {
+		return nil
+	}
+
+	delete(p.pairs, pair.ID)
+	err := p.loadRules()
+	if err != nil {
+		p.pairs[pair.ID] = existing
+		return fmt.Errorf("couldn't remove routing rules for %s: %v", pair.destination, err)
+	}
+	return nil
+}
+
+// CleanRoutingRules flushes the anchor and restores the pf state
+func (p *pfManager) CleanRoutingRules() {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.pairs = make(map[string]routerPair)
+	_, err := runPfctl("", "-a", p.anchor, "-F", "all")
+	if err != nil {
+		log.Errorf("failed flushing pf anchor %s: %v", p.anchor, err)
+	}
+
+	if p.releasePF != nil {
+		err = p.releasePF()
+		if err != nil {
+			log.Errorf("failed restoring pf state: %v", err)
+		}
+		p.releasePF = nil
+	}
+
+	log.Info("cleaned up routing rules from pf")
+}
+
+// loadRules replaces the anchor rules with the ones of the current router pairs
+func (p *pfManager) loadRules() error {
+	rules, err := pfRules(p.pairs, func(destination string) (string, error) {
+		intf, err := routeInterface(destination)
+		if err != nil {
+			return "", err
+		}
+		return intf.Name, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = runPfctl(rules, "-a", p.anchor, "-f", "-")
+	return err
+}
+
+// pfRules returns the anchor rules of the router pairs, egressInterface returns the name of the interface used to
+// reach a destination network. pf requires the translation rules to precede the filter rules.
+func pfRules(pairs map[string]routerPair, egressInterface func(destination string) (string, error)) (string, error) {
+	ids := make([]string, 0, len(pairs))
+	for id := range pairs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var natRules, filterRules []string
+	for _, id := range ids {
+		pair := pairs[id]
+		family, err := pairFamily(pair)
+		if err != nil {
+			return "", err
+		}
+
+		if pair.masquerade {
+			egress, err := egressInterface(pair.destination)
+			if err != nil {
+				return "", err
+			}
+			natRules = append(natRules,
+				fmt.Sprintf("nat on %s %s from %s to %s -> (%s)", egress, family, pair.source, pair.destination, egress))
+		}
+		filterRules = append(filterRules,
+			fmt.Sprintf("pass quick %s from %s to %s keep state", family, pair.source, pair.destination))
+	}
+
+	return strings.Join(append(natRules, filterRules...), "\n") + "\n", nil
+}
+
+// pairFamily returns the pf address family of a router pair
+func pairFamily(pair routerPair) (string, error) {
+	source, err := netip.ParsePrefix(pair.source)
+	if err != nil {
+		return "", err
+	}
+	destination, err := netip.ParsePrefix(pair.destination)
+	if err != nil {
+		return "", err
+	}
+
+	if source.Addr().Is4() != destination.Addr().Is4() {
+		return "", fmt.Errorf("routing %s from %s is not supported", pair.destination, pair.source)
+	}
+	if destination.Addr().Is4() {
+		return "inet", nil
+	}
+	return "inet6", nil
+}
+
+func runPfctl(stdin string, args ...string) (string, error) {
+	cmd := exec.Command("pfctl", args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("pfctl %s failed: %v, output: %s", strings.Join(args, " "), err, out)
+	}
+	return string(out), nil
+}
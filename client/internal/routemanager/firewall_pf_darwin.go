@@ -0,0 +1,31 @@
+package routemanager
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// pfAnchor is evaluated by the default macOS pf configuration through the com.apple/* anchors
+const pfAnchor = "com.apple/netbird"
+
+var pfTokenRegexp = regexp.MustCompile(`Token\s*:\s*(\d+)`)
+
+// enablePF takes a pf enable reference. The returned function releases it,
+// so pf is only disabled when no other application holds a reference.
+func enablePF() (func() error, error) {
+	out, err := runPfctl("", "-E")
+	if err != nil {
+		return nil, err
+	}
+
+	match := pfTokenRegexp.FindStringSubmatch(out)
+	if match == nil {
+		return nil, fmt.Errorf("couldn't find the pf reference token in: %s", out)
+	}
+	token := match[1]
+
+	return func() error {
+		_, err := runPfctl("", "-X", token)
+		return err
+	}, nil
+}
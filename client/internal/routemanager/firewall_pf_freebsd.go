@@ -0,0 +1,29 @@
+package routemanager
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// pfAnchor has to be referenced by the pf configuration with nat-anchor "netbird" and anchor "netbird"
+const pfAnchor = "netbird"
+
+// enablePF enables pf if it isn't enabled yet. The returned function disables it again
+// only when it was enabled by the manager.
+func enablePF() (func() error, error) {
+	log.Infof("routing rules are loaded in the pf anchor %s, make sure it is referenced by the pf configuration", pfAnchor)
+
+	out, err := runPfctl("", "-e")
+	if err != nil {
+		if strings.Contains(out, "already enabled") {
+			return func() error { return nil }, nil
+		}
+		return nil, err
+	}
+
+	return func() error {
+		_, err := runPfctl("", "-d")
+		return err
+	}, nil
+}
@@ -0,0 +1,62 @@
+//go:build darwin || freebsd
+// +build darwin freebsd
+
+package routemanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPfRules(t *testing.T) {
+	egressInterface := func(destination string) (string, error) {
+		return "en0", nil
+	}
+
+	testCases := []struct {
+		name          string
+		pairs         map[string]routerPair
+		expectedRules string
+		expectedErr   bool
+	}{
+		{
+			name:          "no pairs",
+			pairs:         map[string]routerPair{},
+			expectedRules: "\n",
+		},
+		{
+			name: "masqueraded and routed pairs",
+			pairs: map[string]routerPair{
+				"route2": {ID: "route2", source: "100.64.0.0/10", destination: "10.20.0.0/16"},
+				"route1": {ID: "route1", source: "100.64.0.0/10", destination: "192.168.1.0/24", masquerade: true},
+				"route3": {ID: "route3", source: "fd00:1234::/64", destination: "2001:db8::/32", masquerade: true},
+			},
+			expectedRules: "nat on en0 inet from 100.64.0.0/10 to 192.168.1.0/24 -> (en0)\n" +
+				"nat on en0 inet6 from fd00:1234::/64 to 2001:db8::/32 -> (en0)\n" +
+				"pass quick inet from 100.64.0.0/10 to 192.168.1.0/24 keep state\n" +
+				"pass quick inet from 100.64.0.0/10 to 10.20.0.0/16 keep state\n" +
+				"pass quick inet6 from fd00:1234::/64 to 2001:db8::/32 keep state\n",
+		},
+		{
+			name: "mixed address families",
+			pairs: map[string]routerPair{
+				"route1": {ID: "route1", source: "100.64.0.0/10", destination: "2001:db8::/32"},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rules, err := pfRules(testCase.pairs, egressInterface)
+			if testCase.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedRules, rules)
+		})
+	}
+}
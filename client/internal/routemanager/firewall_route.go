@@ -0,0 +1,34 @@
+//go:build darwin || freebsd || windows
+// +build darwin freebsd windows
+
+package routemanager
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/libp2p/go-netroute"
+)
+
+// routeInterface returns the interface used to reach a network
+func routeInterface(network string) (*net.Interface, error) {
+	prefix, err := netip.ParsePrefix(network)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := netroute.New()
+	if err != nil {
+		return nil, err
+	}
+	intf, _, _, err := r.Route(prefix.Addr().AsSlice())
+	if err != nil {
+		return nil, fmt.Errorf("couldn't find a route to %s: %v", network, err)
+	}
+	if intf == nil {
+		return nil, fmt.Errorf("couldn't find the interface routing %s", network)
+	}
+
+	return intf, nil
+}
@@ -0,0 +1,221 @@
+//go:build windows
+// +build windows
+
+package routemanager
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const winNATName = "netbird"
+
+// winNATManager handles the routing rules with the Windows NAT (WinNAT) service.
+// WinNAT translates everything leaving the internal prefix, so a single NAT instance
+// covering the NetBird network is kept while at least one masqueraded route is served.
+// The IPv4 forwarding is enabled on the WireGuard interface and on the egress interfaces of the routes only.
+type winNATManager struct {
+	mux   sync.Mutex
+	ctx   context.Context
+	pairs map[string]routerPair
+	// natPrefix is the internal prefix of the active NAT instance, empty if none is active
+	natPrefix string
+	// forwarding holds the indexes of the interfaces the manager enabled the forwarding on
+	forwarding map[int]struct{}
+}
+
+// newFirewall returns a WinNAT manager if WinNAT is available. WinNAT is only installed with the Hyper-V or the
+// Containers Windows feature
+func newFirewall(parentCTX context.Context) (firewallManager, error) {
+	_, err := exec.LookPath("powershell")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't find powershell: %v", err)
+	}
+
+	_, err = runPowershell("Get-NetNat -ErrorAction Stop | Out-Null")
+	if err != nil {
+		return nil, fmt.Errorf("WinNAT is not available, enable the Hyper-V or the Containers Windows feature "+
+			"to serve routes: %v", err)
+	}
+
+	log.Debug("creating a WinNAT firewall manager for route rules")
+	return &winNATManager{
+		ctx:        parentCTX,
+		pairs:      make(map[string]routerPair),
+		forwarding: make(map[int]struct{}),
+	}, nil
+}
+
+// RestoreOrCreateContainers removes a NAT instance left by a previous run
+func (w *winNATManager) RestoreOrCreateContainers() error {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if len(w.pairs) > 0 {
+		return nil
+	}
+
+	return removeWinNAT()
+}
+
+// InsertRoutingRules registers a router pair and makes sure the NAT instance matches the masqueraded pairs
+func (w *winNATManager) InsertRoutingRules(pair routerPair) error {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.pairs[pair.ID] = pair
+	err := w.syncNAT()
+	if err != nil {
+		delete(w.pairs, pair.ID)
+		return fmt.Errorf("couldn't insert routing rules for %s: %v", pair.destination, err)
+	}
+
+	err = w.enableForwarding(pair)
+	if err != nil {
+		return fmt.Errorf("couldn't enable forwarding for %s: %v", pair.destination, err)
+	}
+	return nil
+}
+
+// RemoveRoutingRules unregisters a router pair and removes the NAT instance when no longer needed
+func (w *winNATManager) RemoveRoutingRules(pair routerPair) error {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	existing, found := w.pairs[pair.ID]
+	if !found {
+		return nil
+	}
+
+	delete(w.pairs, pair.ID)
+	err := w.syncNAT()
+	if err != nil {
+		w.pairs[pair.ID] = existing
+		return fmt.Errorf("couldn't remove routing rules for %s: %v", pair.destination, err)
+	}
+	return nil
+}
+
+// CleanRoutingRules removes the NAT instance and disables the forwarding enabled by the manager
+func (w *winNATManager) CleanRoutingRules() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.pairs = make(map[string]routerPair)
+	err := removeWinNAT()
+	if err != nil {
+		log.Errorf("failed removing WinNAT instance %s: %v", winNATName, err)
+	}
+	w.natPrefix = ""
+
+	for index := range w.forwarding {
+		_, err = runPowershell(setForwardingCommand(index, false))
+		if err != nil {
+			log.Errorf("failed disabling forwarding on interface %d: %v", index, err)
+		}
+	}
+	w.forwarding = make(map[int]struct{})
+
+	log.Info("cleaned up routing rules from WinNAT")
+}
+
+// enableForwarding enables the forwarding on the WireGuard interface and on the egress interface of the pair.
+// The interfaces that already forward are left untouched
+func (w *winNATManager) enableForwarding(pair routerPair) error {
+	for _, network := range []string{pair.source, pair.destination} {
+		intf, err := routeInterface(network)
+		if err != nil {
+			return err
+		}
+		if _, ok := w.forwarding[intf.Index]; ok {
+			continue
+		}
+
+		out, err := runPowershell(fmt.Sprintf(
+			"(Get-NetIPInterface -InterfaceIndex %d -AddressFamily IPv4).Forwarding", intf.Index))
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(out) == "Enabled" {
+			continue
+		}
+
+		_, err = runPowershell(setForwardingCommand(intf.Index, true))
+		if err != nil {
+			return err
+		}
+		w.forwarding[intf.Index] = struct{}{}
+	}
+	return nil
+}
+
+func (w *winNATManager) syncNAT() error {
+	natPrefix := winNATPrefix(w.pairs)
+	if natPrefix == w.natPrefix {
+		return nil
+	}
+
+	if w.natPrefix != "" {
+		err := removeWinNAT()
+		if err != nil {
+			return err
+		}
+		w.natPrefix = ""
+	}
+
+	if natPrefix == "" {
+		return nil
+	}
+
+	_, err := runPowershell(newWinNATCommand(natPrefix))
+	if err != nil {
+		return err
+	}
+	w.natPrefix = natPrefix
+	return nil
+}
+
+// winNATPrefix returns the internal prefix of the NAT instance the router pairs need, empty if none is masqueraded.
+// All the pairs share the NetBird network as source
+func winNATPrefix(pairs map[string]routerPair) string {
+	for _, pair := range pairs {
+		if pair.masquerade {
+			return pair.source
+		}
+	}
+	return ""
+}
+
+// newWinNATCommand returns the command creating the NAT instance translating the traffic from the internal prefix
+func newWinNATCommand(internalPrefix string) string {
+	return fmt.Sprintf("New-NetNat -Name %s -InternalIPInterfaceAddressPrefix %s", winNATName, internalPrefix)
+}
+
+// setForwardingCommand returns the command enabling or disabling the IPv4 forwarding on an interface
+func setForwardingCommand(index int, enabled bool) string {
+	state := "Disabled"
+	if enabled {
+		state = "Enabled"
+	}
+	return fmt.Sprintf("Set-NetIPInterface -InterfaceIndex %d -AddressFamily IPv4 -Forwarding %s", index, state)
+}
+
+func removeWinNAT() error {
+	_, err := runPowershell(fmt.Sprintf(
+		"Get-NetNat -Name %s -ErrorAction SilentlyContinue | Remove-NetNat -Confirm:$false", winNATName))
+	return err
+}
+
+func runPowershell(command string) (string, error) {
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", command)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("powershell command %q failed: %v, output: %s", command, err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
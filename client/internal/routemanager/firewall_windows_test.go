@@ -0,0 +1,29 @@
+//go:build windows
+// +build windows
+
+package routemanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWinNATPrefix(t *testing.T) {
+	pairs := map[string]routerPair{
+		"route1": {ID: "route1", source: "100.64.0.0/10", destination: "10.20.0.0/16"},
+	}
+	assert.Empty(t, winNATPrefix(pairs), "no NAT instance is needed without masqueraded routes")
+
+	pairs["route2"] = routerPair{ID: "route2", source: "100.64.0.0/10", destination: "192.168.1.0/24", masquerade: true}
+	assert.Equal(t, "100.64.0.0/10", winNATPrefix(pairs))
+}
+
+func TestWinNATCommands(t *testing.T) {
+	assert.Equal(t, "New-NetNat -Name netbird -InternalIPInterfaceAddressPrefix 100.64.0.0/10",
+		newWinNATCommand("100.64.0.0/10"))
+	assert.Equal(t, "Set-NetIPInterface -InterfaceIndex 12 -AddressFamily IPv4 -Forwarding Enabled",
+		setForwardingCommand(12, true))
+	assert.Equal(t, "Set-NetIPInterface -InterfaceIndex 12 -AddressFamily IPv4 -Forwarding Disabled",
+		setForwardingCommand(12, false))
+}
@@ -18,6 +18,8 @@ type Manager interface {
 	UpdateRoutes(updateSerial uint64, newRoutes []*route.Route) error
 	SetRouteChangeListener(listener RouteListener)
 	InitialRouteRange() []string
+	SetExitNode(enabled bool)
+	SetControlPlaneEndpoints(endpoints []string)
 	Stop()
 }
 
@@ -32,6 +34,10 @@ type DefaultManager struct {
 	wgInterface    *iface.WGIface
 	pubKey         string
 	notifier       *notifier
+	// exitNode is nil unless the client opted in to route its traffic through exit nodes
+	exitNode *exitNode
+	// controlPlaneEndpoints are kept to initialize the exit node when it gets enabled
+	controlPlaneEndpoints []string
 }
 
 // NewManager returns a new route manager
@@ -100,6 +106,41 @@ func (m *DefaultManager) SetRouteChangeListener(listener RouteListener) {
 	m.notifier.setListener(listener)
 }
 
+// SetExitNode enables or disables the use of the default routes distributed by the Management service
+func (m *DefaultManager) SetExitNode(enabled bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if !enabled {
+		m.exitNode = nil
+		return
+	}
+
+	if runtime.GOOS == "android" {
+		log.Warnf("exit nodes are not supported on %s", runtime.GOOS)
+		return
+	}
+
+	if m.exitNode != nil {
+		return
+	}
+
+	m.exitNode = newExitNode(m.statusRecorder, m.wgInterface)
+	m.exitNode.setControlPlaneEndpoints(m.controlPlaneEndpoints)
+}
+
+// SetControlPlaneEndpoints sets the STUN and TURN hosts that must stay reachable outside of the tunnel
+// when the traffic is routed through an exit node. Management and Signal are taken from the status recorder.
+func (m *DefaultManager) SetControlPlaneEndpoints(endpoints []string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.controlPlaneEndpoints = endpoints
+	if m.exitNode != nil {
+		m.exitNode.setControlPlaneEndpoints(endpoints)
+	}
+}
+
 // InitialRouteRange return the list of initial routes. It used by mobile systems
 func (m *DefaultManager) InitialRouteRange() []string {
 	return m.notifier.initialRouteRanges()
@@ -120,6 +161,9 @@ func (m *DefaultManager) updateClientNetworks(updateSerial uint64, networks map[
 		clientNetworkWatcher, found := m.clientNetworks[id]
 		if !found {
 			clientNetworkWatcher = newClientNetworkWatcher(m.ctx, m.wgInterface, m.statusRecorder, routes[0].Network)
+			if routes[0].IsDefaultRoute() {
+				clientNetworkWatcher.exitNode = m.exitNode
+			}
 			m.clientNetworks[id] = clientNetworkWatcher
 			go clientNetworkWatcher.peersStateAndUpdateWatcher()
 		}
@@ -140,8 +184,7 @@ func (m *DefaultManager) classifiesRoutes(newRoutes []*route.Route) (map[string]
 		networkID := route.GetHAUniqueID(newRoute)
 		if newRoute.Peer == m.pubKey {
 			ownNetworkIDs[networkID] = true
-			// only linux is supported for now
-			if runtime.GOOS != "linux" {
+			if m.serverRouter == nil {
 				log.Warnf("received a route to manage, but agent doesn't support router mode on %s OS", runtime.GOOS)
 				continue
 			}
@@ -152,10 +195,15 @@ func (m *DefaultManager) classifiesRoutes(newRoutes []*route.Route) (map[string]
 	for _, newRoute := range newRoutes {
 		networkID := route.GetHAUniqueID(newRoute)
 		if !ownNetworkIDs[networkID] {
-			// if prefix is too small, lets assume is a possible default route which is not yet supported
-			// we skip this route management
-			if newRoute.Network.Bits() < 7 {
-				log.Errorf("this agent version: %s, doesn't support default routes, received %s, skiping this route",
+			if newRoute.IsDefaultRoute() {
+				if m.exitNode == nil || !newRoute.Network.Addr().Is4() {
+					log.Infof("skipping the default route %s of the network %s, exit nodes are not enabled for %s",
+						newRoute.Network, newRoute.NetID, newRoute.NetworkType)
+					continue
+				}
+			} else if newRoute.Network.Bits() < 7 {
+				// prefixes this wide would shadow the routes of the control plane, only the default route handles them
+				log.Errorf("this agent version: %s, doesn't support routes wider than /7, received %s, skipping this route",
 					version.NetbirdVersion(), newRoute.Network)
 				continue
 			}
@@ -36,6 +36,14 @@ func (m *MockManager) SetRouteChangeListener(listener RouteListener) {
 
 }
 
+// SetExitNode mock implementation of SetExitNode from Manager interface
+func (m *MockManager) SetExitNode(enabled bool) {
+}
+
+// SetControlPlaneEndpoints mock implementation of SetControlPlaneEndpoints from Manager interface
+func (m *MockManager) SetControlPlaneEndpoints(endpoints []string) {
+}
+
 // Stop mock implementation of Stop from Manager interface
 func (m *MockManager) Stop() {
 	if m.StopFunc != nil {
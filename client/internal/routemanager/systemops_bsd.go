@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"os/exec"
 	"syscall"
 
 	"golang.org/x/net/route"
@@ -67,6 +68,15 @@ func existsInRouteTable(prefix netip.Prefix) (bool, error) {
 	return false, nil
 }
 
+// enableIPForwarding enables IPv4 forwarding with sysctl
+func enableIPForwarding() error {
+	out, err := exec.Command("sysctl", "-w", "net.inet.ip.forwarding=1").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed enabling IP forwarding: %v, output: %s", err, out)
+	}
+	return nil
+}
+
 func toIPAddr(a route.Addr) (net.IP, error) {
 	switch t := a.(type) {
 	case *route.Inet4Addr:
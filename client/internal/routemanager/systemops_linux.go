@@ -3,9 +3,11 @@
 package routemanager
 
 import (
+	"fmt"
 	"net"
 	"net/netip"
 	"os"
+	"strings"
 	"syscall"
 	"unsafe"
 
@@ -41,6 +43,8 @@ func addToRouteTable(prefix netip.Prefix, addr string) error {
 		addrMask = "/128"
 	}
 
+	// link-local gateways are scoped to their interface, e.g. fe80::1%eth0
+	addr, zone, _ := strings.Cut(addr, "%")
 	ip, _, err := net.ParseCIDR(addr + addrMask)
 	if err != nil {
 		return err
@@ -52,6 +56,14 @@ func addToRouteTable(prefix netip.Prefix, addr string) error {
 		Gw:    ip,
 	}
 
+	if zone != "" {
+		link, err := netlink.LinkByName(zone)
+		if err != nil {
+			return fmt.Errorf("couldn't find the interface %s: %v", zone, err)
+		}
+		route.LinkIndex = link.Attrs().Index
+	}
+
 	err = netlink.RouteAdd(route)
 	if err != nil {
 		return err
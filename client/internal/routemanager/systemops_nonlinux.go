@@ -6,7 +6,6 @@ package routemanager
 import (
 	"net/netip"
 	"os/exec"
-	"runtime"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -30,8 +29,3 @@ func removeFromRouteTable(prefix netip.Prefix) error {
 	log.Debugf(string(out))
 	return nil
 }
-
-func enableIPForwarding() error {
-	log.Infof("enable IP forwarding is not implemented on %s", runtime.GOOS)
-	return nil
-}
@@ -35,3 +35,9 @@ func existsInRouteTable(prefix netip.Prefix) (bool, error) {
 	}
 	return false, nil
 }
+
+// enableIPForwarding is a no-op on Windows. The forwarding is enabled per interface by the WinNAT manager, only on
+// the WireGuard interface and on the egress interfaces of the served routes
+func enableIPForwarding() error {
+	return nil
+}
@@ -36,6 +36,9 @@ type Server struct {
 	proto.UnimplementedDaemonServiceServer
 
 	statusRecorder *peer.Status
+
+	// clientRunning is closed when the last started client connection returns
+	clientRunning chan struct{}
 }
 
 type oauthAuthFlow struct {
@@ -103,11 +106,13 @@ func (s *Server) Start() error {
 		s.statusRecorder.UpdateManagementAddress(config.ManagementURL.String())
 	}
 
-	go func() {
+	s.clientRunning = make(chan struct{})
+	go func(clientRunning chan struct{}) {
+		defer close(clientRunning)
 		if err := internal.RunClient(ctx, config, s.statusRecorder); err != nil {
 			log.Errorf("init connections: %v", err)
 		}
-	}()
+	}(s.clientRunning)
 
 	return nil
 }
@@ -174,6 +179,11 @@ func (s *Server) Login(callerCtx context.Context, msg *proto.LoginRequest) (*pro
 		s.latestConfigInput.NATExternalIPs = msg.NatExternalIPs
 	}
 
+	if msg.UseExitNode != nil {
+		useExitNode := msg.GetUseExitNode()
+		inputConfig.UseExitNode = &useExitNode
+	}
+
 	inputConfig.CustomDNSAddress = msg.CustomDNSAddress
 	s.latestConfigInput.CustomDNSAddress = msg.CustomDNSAddress
 	if string(msg.CustomDNSAddress) == "empty" {
@@ -379,14 +389,63 @@ func (s *Server) Up(callerCtx context.Context, _ *proto.UpRequest) (*proto.UpRes
 		s.statusRecorder.UpdateManagementAddress(s.config.ManagementURL.String())
 	}
 
-	go func() {
-		if err := internal.RunClient(ctx, s.config, s.statusRecorder); err != nil {
+	s.runClient(ctx)
+
+	return &proto.UpResponse{}, nil
+}
+
+// runClient starts the client connection with the current config in the background
+func (s *Server) runClient(ctx context.Context) {
+	s.clientRunning = make(chan struct{})
+	go func(config *internal.Config, clientRunning chan struct{}) {
+		defer close(clientRunning)
+		if err := internal.RunClient(ctx, config, s.statusRecorder); err != nil {
 			log.Errorf("run client connection: %v", err)
 			return
 		}
-	}()
+	}(s.config, s.clientRunning)
+}
 
-	return &proto.UpResponse{}, nil
+// SetExitNode enables or disables routing all the traffic through exit nodes.
+// A running client connection is restarted to apply the change.
+func (s *Server) SetExitNode(callerCtx context.Context, msg *proto.SetExitNodeRequest) (*proto.SetExitNodeResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	enabled := msg.GetEnabled()
+	config, err := internal.UpdateConfig(internal.ConfigInput{
+		ConfigPath:  s.latestConfigInput.ConfigPath,
+		UseExitNode: &enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restart := s.config != nil && s.config.UseExitNode != config.UseExitNode
+	s.config = config
+	if !restart || s.actCancel == nil || s.clientRunning == nil {
+		return &proto.SetExitNodeResponse{}, nil
+	}
+
+	state := internal.CtxGetState(s.rootCtx)
+	status, err := state.Status()
+	if err != nil || status == internal.StatusIdle || status == internal.StatusNeedsLogin {
+		return &proto.SetExitNodeResponse{}, nil
+	}
+
+	log.Infof("restarting the client connection to apply the exit node setting")
+	s.actCancel()
+	<-s.clientRunning
+
+	ctx, cancel := context.WithCancel(s.rootCtx)
+	md, ok := metadata.FromIncomingContext(callerCtx)
+	if ok {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	s.actCancel = cancel
+	s.runClient(ctx)
+
+	return &proto.SetExitNodeResponse{}, nil
 }
 
 // Down engine work in the daemon.
@@ -442,8 +501,10 @@ func (s *Server) GetConfig(_ context.Context, _ *proto.GetConfigRequest) (*proto
 	managementURL := s.latestConfigInput.ManagementURL
 	adminURL := s.latestConfigInput.AdminURL
 	preSharedKey := ""
+	useExitNode := false
 
 	if s.config != nil {
+		useExitNode = s.config.UseExitNode
 		if managementURL == "" && s.config.ManagementURL != nil {
 			managementURL = s.config.ManagementURL.String()
 		}
@@ -465,6 +526,7 @@ func (s *Server) GetConfig(_ context.Context, _ *proto.GetConfigRequest) (*proto
 		ConfigFile:    s.latestConfigInput.ConfigPath,
 		LogFile:       s.logFile,
 		PreSharedKey:  preSharedKey,
+		UseExitNode:   useExitNode,
 	}, nil
 }
 
@@ -474,6 +536,11 @@ func toProtoFullStatus(fullStatus peer.FullStatus) *proto.FullStatus {
 		SignalState:     &proto.SignalState{},
 		LocalPeerState:  &proto.LocalPeerState{},
 		Peers:           []*proto.PeerState{},
+		DnsCacheState: &proto.DNSCacheState{
+			Entries: int64(fullStatus.DNSCacheState.Entries),
+			Hits:    fullStatus.DNSCacheState.Hits,
+			Misses:  fullStatus.DNSCacheState.Misses,
+		},
 	}
 
 	pbFullStatus.ManagementState.URL = fullStatus.ManagementState.URL
@@ -483,6 +550,7 @@ func toProtoFullStatus(fullStatus peer.FullStatus) *proto.FullStatus {
 	pbFullStatus.SignalState.Connected = fullStatus.SignalState.Connected
 
 	pbFullStatus.LocalPeerState.IP = fullStatus.LocalPeerState.IP
+	pbFullStatus.LocalPeerState.IPv6 = fullStatus.LocalPeerState.IPv6
 	pbFullStatus.LocalPeerState.PubKey = fullStatus.LocalPeerState.PubKey
 	pbFullStatus.LocalPeerState.KernelInterface = fullStatus.LocalPeerState.KernelInterface
 	pbFullStatus.LocalPeerState.Fqdn = fullStatus.LocalPeerState.FQDN
@@ -12,6 +12,10 @@ import (
 // DeviceNameCtxKey context key for device name
 const DeviceNameCtxKey = "deviceName"
 
+// IPv6OverlayCapability is reported by the clients configuring the IPv6 overlay address on the NetBird interface.
+// The management service distributes the IPv6 addresses only to the peers reporting it
+const IPv6OverlayCapability = "ipv6_overlay"
+
 // Info is an object that contains machine information
 // Most of the code is taken from https://github.com/matishsiao/goInfo
 type Info struct {
@@ -25,6 +29,8 @@ type Info struct {
 	CPUs               int
 	WiretrusteeVersion string
 	UIVersion          string
+	// Capabilities lists the optional features supported by the client, e.g. route.RoutingPeerCapability
+	Capabilities []string
 }
 
 // extractUserAgent extracts Netbird's agent (client) name and version from the outgoing context
@@ -13,6 +13,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/netbirdio/netbird/route"
 	"github.com/netbirdio/netbird/version"
 )
 
@@ -36,6 +37,7 @@ func GetInfo(ctx context.Context) *Info {
 	gio.Hostname = extractDeviceName(ctx, systemHostname)
 	gio.WiretrusteeVersion = version.NetbirdVersion()
 	gio.UIVersion = extractUserAgent(ctx)
+	gio.Capabilities = []string{route.RoutingPeerCapability, IPv6OverlayCapability}
 
 	return gio
 }
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/netbirdio/netbird/route"
 	"github.com/netbirdio/netbird/version"
 )
 
@@ -28,6 +29,7 @@ func GetInfo(ctx context.Context) *Info {
 	gio.Hostname = extractDeviceName(ctx, systemHostname)
 	gio.WiretrusteeVersion = version.NetbirdVersion()
 	gio.UIVersion = extractUserAgent(ctx)
+	gio.Capabilities = []string{route.RoutingPeerCapability}
 
 	return gio
 }
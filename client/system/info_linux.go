@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/netbirdio/netbird/route"
 	"github.com/netbirdio/netbird/version"
 )
 
@@ -54,6 +55,7 @@ func GetInfo(ctx context.Context) *Info {
 	gio.Hostname = extractDeviceName(ctx, systemHostname)
 	gio.WiretrusteeVersion = version.NetbirdVersion()
 	gio.UIVersion = extractUserAgent(ctx)
+	gio.Capabilities = []string{route.RoutingPeerCapability, IPv6OverlayCapability}
 
 	return gio
 }
@@ -9,6 +9,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sys/windows/registry"
 
+	"github.com/netbirdio/netbird/route"
 	"github.com/netbirdio/netbird/version"
 )
 
@@ -20,6 +21,7 @@ func GetInfo(ctx context.Context) *Info {
 	gio.Hostname = extractDeviceName(ctx, systemHostname)
 	gio.WiretrusteeVersion = version.NetbirdVersion()
 	gio.UIVersion = extractUserAgent(ctx)
+	gio.Capabilities = []string{route.RoutingPeerCapability, IPv6OverlayCapability}
 
 	return gio
 }
@@ -14,10 +14,18 @@ import (
 const (
 	// DefaultDNSPort well-known port number
 	DefaultDNSPort = 53
+	// DefaultDoTPort well-known DNS over TLS port number
+	DefaultDoTPort = 853
+	// DefaultDoHPort well-known DNS over HTTPS port number
+	DefaultDoHPort = 443
 	// RootZone is a string representation of the root zone
 	RootZone = "."
 	// DefaultClass is the class supported by the system
 	DefaultClass = "IN"
+	// ReverseZoneIPv4 is the parent domain of the IPv4 reverse zones
+	ReverseZoneIPv4 = "in-addr.arpa."
+	// ReverseZoneIPv6 is the parent domain of the IPv6 reverse zones
+	ReverseZoneIPv6 = "ip6.arpa."
 )
 
 const invalidHostLabel = "[^a-zA-Z0-9-]+"
@@ -38,19 +46,22 @@ type CustomZone struct {
 	Domain string
 	// Records custom zone records
 	Records []SimpleRecord
+	// SearchDomainEnabled indicates whether to add the zone domain to the search domains list or not
+	SearchDomainEnabled bool
 }
 
-// SimpleRecord provides a simple DNS record specification for CNAME, A and AAAA records
+// SimpleRecord provides a simple DNS record specification for A, AAAA, CNAME, PTR, TXT and SRV records
 type SimpleRecord struct {
 	// Name domain name
 	Name string
-	// Type of record, 1 for A, 5 for CNAME, 28 for AAAA. see https://pkg.go.dev/github.com/miekg/dns@v1.1.41#pkg-constants
+	// Type of record, 1 for A, 5 for CNAME, 12 for PTR, 16 for TXT, 28 for AAAA, 33 for SRV. see https://pkg.go.dev/github.com/miekg/dns@v1.1.41#pkg-constants
 	Type int
 	// Class dns class, currently use the DefaultClass for all records
 	Class string
 	// TTL time-to-live for the record
 	TTL int
-	// RData is the actual value resolved in a dns query
+	// RData is the actual value resolved in a dns query in the zone file format,
+	// e.g. "quoted text" for TXT and "priority weight port target." for SRV records
 	RData string
 }
 
@@ -61,7 +72,8 @@ func (s SimpleRecord) String() string {
 	return fmt.Sprintf("%s %d %s %s %s", fqdn, s.TTL, s.Class, dns.Type(s.Type).String(), s.RData)
 }
 
-// Len returns the length of the RData field, based on its type
+// Len returns the length of the RData field, based on its type.
+// It returns 0 for TXT and SRV records, their length is computed when the record is packed
 func (s SimpleRecord) Len() uint16 {
 	emptyString := s.RData == ""
 	switch s.Type {
@@ -70,7 +82,7 @@ func (s SimpleRecord) Len() uint16 {
 			return 0
 		}
 		return net.IPv4len
-	case 5:
+	case 5, 12:
 		if emptyString || s.RData == "." {
 			return 1
 		}
@@ -85,6 +97,12 @@ func (s SimpleRecord) Len() uint16 {
 	}
 }
 
+// IsReverseZone returns true if the domain is a reverse zone used for PTR lookups
+func IsReverseZone(domain string) bool {
+	domain = strings.ToLower(dns.Fqdn(domain))
+	return dns.IsSubDomain(ReverseZoneIPv4, domain) || dns.IsSubDomain(ReverseZoneIPv6, domain)
+}
+
 // GetParsedDomainLabel returns a domain label with max 59 characters,
 // parsed for old Hosts.txt requirements, and converted to ASCII and lowercase
 func GetParsedDomainLabel(name string) (string, error) {
@@ -13,6 +13,10 @@ const (
 	InvalidNameServerType NameServerType = iota
 	// UDPNameServerType udp nameserver type
 	UDPNameServerType
+	// TLSNameServerType DNS over TLS nameserver type
+	TLSNameServerType
+	// HTTPSNameServerType DNS over HTTPS nameserver type
+	HTTPSNameServerType
 )
 
 const (
@@ -22,6 +26,10 @@ const (
 	InvalidNameServerTypeString = "invalid"
 	// UDPNameServerTypeString udp nameserver type as string
 	UDPNameServerTypeString = "udp"
+	// TLSNameServerTypeString DNS over TLS nameserver type as string
+	TLSNameServerTypeString = "tls"
+	// HTTPSNameServerTypeString DNS over HTTPS nameserver type as string
+	HTTPSNameServerTypeString = "https"
 )
 
 // NameServerType nameserver type
@@ -32,6 +40,10 @@ func (n NameServerType) String() string {
 	switch n {
 	case UDPNameServerType:
 		return UDPNameServerTypeString
+	case TLSNameServerType:
+		return TLSNameServerTypeString
+	case HTTPSNameServerType:
+		return HTTPSNameServerTypeString
 	default:
 		return InvalidNameServerTypeString
 	}
@@ -42,6 +54,10 @@ func ToNameServerType(typeString string) NameServerType {
 	switch typeString {
 	case UDPNameServerTypeString:
 		return UDPNameServerType
+	case TLSNameServerTypeString:
+		return TLSNameServerType
+	case HTTPSNameServerTypeString:
+		return HTTPSNameServerType
 	default:
 		return InvalidNameServerType
 	}
@@ -65,6 +81,8 @@ type NameServerGroup struct {
 	Domains []string
 	// Enabled group status
 	Enabled bool
+	// SearchDomainsEnabled indicates whether to add match domains to search domains list or not
+	SearchDomainsEnabled bool
 }
 
 // NameServer represents a DNS nameserver
@@ -75,6 +93,9 @@ type NameServer struct {
 	NSType NameServerType
 	// Port nameserver listening port
 	Port int
+	// ServerName is the name used to validate the certificate of the TLS and HTTPS nameservers.
+	// The IP is validated when it is empty
+	ServerName string
 }
 
 // EventMeta returns activity event meta related to the nameserver group
@@ -85,9 +106,10 @@ func (g *NameServerGroup) EventMeta() map[string]any {
 // Copy copies a nameserver object
 func (n *NameServer) Copy() *NameServer {
 	return &NameServer{
-		IP:     n.IP,
-		NSType: n.NSType,
-		Port:   n.Port,
+		IP:         n.IP,
+		NSType:     n.NSType,
+		Port:       n.Port,
+		ServerName: n.ServerName,
 	}
 }
 
@@ -95,10 +117,17 @@ func (n *NameServer) Copy() *NameServer {
 func (n *NameServer) IsEqual(other *NameServer) bool {
 	return other.IP == n.IP &&
 		other.NSType == n.NSType &&
-		other.Port == n.Port
+		other.Port == n.Port &&
+		other.ServerName == n.ServerName
 }
 
-// ParseNameServerURL parses a nameserver url in the format <type>://<ip>:<port>, e.g., udp://1.1.1.1:53
+// IsEncrypted returns true if the queries to the nameserver are encrypted
+func (n *NameServer) IsEncrypted() bool {
+	return n.NSType == TLSNameServerType || n.NSType == HTTPSNameServerType
+}
+
+// ParseNameServerURL parses a nameserver url in the format <type>://<ip>:<port>, e.g., udp://1.1.1.1:53,
+// tls://1.1.1.1:853 or https://1.1.1.1:443
 func ParseNameServerURL(nsURL string) (NameServer, error) {
 	parsedURL, err := url.Parse(nsURL)
 	if err != nil {
@@ -131,14 +160,15 @@ func ParseNameServerURL(nsURL string) (NameServer, error) {
 // Copy copies a nameserver group object
 func (g *NameServerGroup) Copy() *NameServerGroup {
 	nsGroup := &NameServerGroup{
-		ID:          g.ID,
-		Name:        g.Name,
-		Description: g.Description,
-		NameServers: make([]NameServer, len(g.NameServers)),
-		Groups:      make([]string, len(g.Groups)),
-		Enabled:     g.Enabled,
-		Primary:     g.Primary,
-		Domains:     make([]string, len(g.Domains)),
+		ID:                   g.ID,
+		Name:                 g.Name,
+		Description:          g.Description,
+		NameServers:          make([]NameServer, len(g.NameServers)),
+		Groups:               make([]string, len(g.Groups)),
+		Enabled:              g.Enabled,
+		Primary:              g.Primary,
+		Domains:              make([]string, len(g.Domains)),
+		SearchDomainsEnabled: g.SearchDomainsEnabled,
 	}
 
 	copy(nsGroup.NameServers, g.NameServers)
@@ -154,6 +184,7 @@ func (g *NameServerGroup) IsEqual(other *NameServerGroup) bool {
 		other.Name == g.Name &&
 		other.Description == g.Description &&
 		other.Primary == g.Primary &&
+		other.SearchDomainsEnabled == g.SearchDomainsEnabled &&
 		compareNameServerList(g.NameServers, other.NameServers) &&
 		compareGroupsList(g.Groups, other.Groups) &&
 		compareGroupsList(g.Domains, other.Domains)
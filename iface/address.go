@@ -3,27 +3,85 @@ package iface
 import (
 	"fmt"
 	"net"
+	"strings"
 )
 
 // WGAddress Wireguard parsed address
 type WGAddress struct {
 	IP      net.IP
 	Network *net.IPNet
+	// IPv6 is the optional IPv6 overlay address of the interface, nil if not assigned
+	IPv6      net.IP
+	NetworkV6 *net.IPNet
 }
 
-// parseWGAddress parse a string ("1.2.3.4/24") address to WG Address
+// parseWGAddress parse a string ("1.2.3.4/24") address to WG Address.
+// An IPv6 overlay address can follow the IPv4 one separated by a comma ("1.2.3.4/24,fd00::1/64")
 func parseWGAddress(address string) (WGAddress, error) {
-	ip, network, err := net.ParseCIDR(address)
+	addressV4, addressV6, _ := strings.Cut(address, ",")
+	ip, network, err := net.ParseCIDR(strings.TrimSpace(addressV4))
 	if err != nil {
 		return WGAddress{}, err
 	}
-	return WGAddress{
+	wgAddress := WGAddress{
 		IP:      ip,
 		Network: network,
-	}, nil
+	}
+
+	addressV6 = strings.TrimSpace(addressV6)
+	if addressV6 == "" {
+		return wgAddress, nil
+	}
+
+	ipV6, networkV6, err := net.ParseCIDR(addressV6)
+	if err != nil {
+		return WGAddress{}, err
+	}
+	if ipV6.To4() != nil {
+		return WGAddress{}, fmt.Errorf("%s is not an IPv6 address", addressV6)
+	}
+	wgAddress.IPv6 = ipV6
+	wgAddress.NetworkV6 = networkV6
+
+	return wgAddress, nil
+}
+
+// JoinAddresses returns the address string parsed by the interface for an IPv4 and an optional IPv6 address
+func JoinAddresses(address, addressV6 string) string {
+	if addressV6 == "" {
+		return address
+	}
+	return address + "," + addressV6
+}
+
+// parseAllowedIPs parses a comma separated list of allowed IPs ("100.64.0.1/32,fd00::1/128")
+func parseAllowedIPs(allowedIps string) ([]net.IPNet, error) {
+	var ipNets []net.IPNet
+	for _, allowedIP := range strings.Split(allowedIps, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(allowedIP))
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, *ipNet)
+	}
+	return ipNets, nil
 }
 
 func (addr WGAddress) String() string {
 	maskSize, _ := addr.Network.Mask.Size()
 	return fmt.Sprintf("%s/%d", addr.IP.String(), maskSize)
 }
+
+// HasIPv6 returns true if the interface has an IPv6 overlay address assigned
+func (addr WGAddress) HasIPv6() bool {
+	return addr.IPv6 != nil && addr.NetworkV6 != nil
+}
+
+// StringV6 returns the IPv6 overlay address in the CIDR format, empty if not assigned
+func (addr WGAddress) StringV6() string {
+	if !addr.HasIPv6() {
+		return ""
+	}
+	maskSize, _ := addr.NetworkV6.Mask.Size()
+	return fmt.Sprintf("%s/%d", addr.IPv6.String(), maskSize)
+}
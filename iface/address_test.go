@@ -0,0 +1,41 @@
+package iface
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWGAddress(t *testing.T) {
+	address, err := parseWGAddress("100.64.0.1/16")
+	require.NoError(t, err)
+	assert.Equal(t, "100.64.0.1/16", address.String())
+	assert.False(t, address.HasIPv6())
+	assert.Empty(t, address.StringV6())
+
+	address, err = parseWGAddress(JoinAddresses("100.64.0.1/16", "fd5e:1c2a:9b00::1/64"))
+	require.NoError(t, err)
+	assert.Equal(t, "100.64.0.1/16", address.String())
+	assert.True(t, address.HasIPv6())
+	assert.Equal(t, "fd5e:1c2a:9b00::1/64", address.StringV6())
+	assert.Equal(t, "fd5e:1c2a:9b00::/64", address.NetworkV6.String())
+
+	_, err = parseWGAddress("100.64.0.1/16,100.64.0.2/16")
+	assert.Error(t, err, "an IPv4 address should not be accepted as the IPv6 address")
+}
+
+func TestParseAllowedIPs(t *testing.T) {
+	ipNets, err := parseAllowedIPs("100.64.0.1/32")
+	require.NoError(t, err)
+	require.Len(t, ipNets, 1)
+	assert.Equal(t, "100.64.0.1/32", ipNets[0].String())
+
+	ipNets, err = parseAllowedIPs("100.64.0.1/32,fd5e:1c2a:9b00::1/128")
+	require.NoError(t, err)
+	require.Len(t, ipNets, 2)
+	assert.Equal(t, "fd5e:1c2a:9b00::1/128", ipNets[1].String())
+
+	_, err = parseAllowedIPs("100.64.0.1/32,invalid")
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package iface
 
 import (
 	"os/exec"
+	"strconv"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -30,5 +31,22 @@ func (c *tunDevice) assignAddr() error {
 		return err
 	}
 
+	if !c.address.HasIPv6() {
+		return nil
+	}
+
+	maskSize, _ := c.address.NetworkV6.Mask.Size()
+	cmd = exec.Command("ifconfig", c.name, "inet6", c.address.IPv6.String(), "prefixlen", strconv.Itoa(maskSize), "alias")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		log.Infof(`adding IPv6 address command "%v" failed with output %s and error: `, cmd.String(), out)
+		return err
+	}
+
+	routeCmd = exec.Command("route", "add", "-inet6", "-net", c.address.NetworkV6.String(), "-interface", c.name)
+	if out, err := routeCmd.CombinedOutput(); err != nil {
+		log.Printf(`adding IPv6 route command "%v" failed with output %s and error: `, routeCmd.String(), out)
+		return err
+	}
+
 	return nil
 }
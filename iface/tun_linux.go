@@ -106,13 +106,20 @@ func (c *tunDevice) assignAddr() error {
 		}
 	}
 
-	log.Debugf("adding address %s to interface: %s", c.address.String(), c.name)
-	addr, _ := netlink.ParseAddr(c.address.String())
-	err = netlink.AddrAdd(link, addr)
-	if os.IsExist(err) {
-		log.Infof("interface %s already has the address: %s", c.name, c.address.String())
-	} else if err != nil {
-		return err
+	addresses := []string{c.address.String()}
+	if c.address.HasIPv6() {
+		addresses = append(addresses, c.address.StringV6())
+	}
+
+	for _, address := range addresses {
+		log.Debugf("adding address %s to interface: %s", address, c.name)
+		addr, _ := netlink.ParseAddr(address)
+		err = netlink.AddrAdd(link, addr)
+		if os.IsExist(err) {
+			log.Infof("interface %s already has the address: %s", c.name, address)
+		} else if err != nil {
+			return err
+		}
 	}
 	// On linux, the link must be brought up
 	err = netlink.LinkSetUp(link)
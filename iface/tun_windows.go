@@ -162,7 +162,12 @@ func (c *tunDevice) assignAddr() error {
 	tunDev := c.netInterface.(*tun.NativeTun)
 	luid := winipcfg.LUID(tunDev.LUID())
 	log.Debugf("adding address %s to interface: %s", c.address.IP, c.name)
-	return luid.SetIPAddresses([]netip.Prefix{netip.MustParsePrefix(c.address.String())})
+	prefixes := []netip.Prefix{netip.MustParsePrefix(c.address.String())}
+	if c.address.HasIPv6() {
+		log.Debugf("adding address %s to interface: %s", c.address.IPv6, c.name)
+		prefixes = append(prefixes, netip.MustParsePrefix(c.address.StringV6()))
+	}
+	return luid.SetIPAddresses(prefixes)
 }
 
 // getUAPI returns a Listener
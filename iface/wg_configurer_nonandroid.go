@@ -45,7 +45,7 @@ func (c *wGConfigurer) configureInterface(privateKey string, port int) error {
 
 func (c *wGConfigurer) updatePeer(peerKey string, allowedIps string, keepAlive time.Duration, endpoint *net.UDPAddr, preSharedKey *wgtypes.Key) error {
 	//parse allowed ips
-	_, ipNet, err := net.ParseCIDR(allowedIps)
+	ipNets, err := parseAllowedIPs(allowedIps)
 	if err != nil {
 		return err
 	}
@@ -57,7 +57,7 @@ func (c *wGConfigurer) updatePeer(peerKey string, allowedIps string, keepAlive t
 	peer := wgtypes.PeerConfig{
 		PublicKey:                   peerKeyParsed,
 		ReplaceAllowedIPs:           true,
-		AllowedIPs:                  []net.IPNet{*ipNet},
+		AllowedIPs:                  ipNets,
 		PersistentKeepaliveInterval: &keepAlive,
 		PresharedKey:                preSharedKey,
 		Endpoint:                    endpoint,
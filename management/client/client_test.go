@@ -58,10 +58,10 @@ func startManagement(t *testing.T) (*grpc.Server, net.Listener) {
 		t.Fatal(err)
 	}
 
-	peersUpdateManager := mgmt.NewPeersUpdateManager()
+	peersUpdateManager := mgmt.NewPeersUpdateManager(nil)
 	eventStore := &activity.InMemoryEventStore{}
 	accountManager, err := mgmt.BuildManager(store, peersUpdateManager, nil, "", "",
-		eventStore, false)
+		eventStore, false, 0, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -351,6 +351,7 @@ func Test_SystemMetaDataFromClient(t *testing.T) {
 		Platform:           info.Platform,
 		OS:                 info.OS,
 		WiretrusteeVersion: info.WiretrusteeVersion,
+		Capabilities:       info.Capabilities,
 	}
 
 	assert.Equal(t, ValidKey, actualValidKey)
@@ -216,7 +216,7 @@ func (c *GrpcClient) GetNetworkMap() (*proto.NetworkMap, error) {
 }
 
 func (c *GrpcClient) connectToStream(ctx context.Context, serverPubKey wgtypes.Key) (proto.ManagementService_SyncClient, error) {
-	req := &proto.SyncRequest{}
+	req := &proto.SyncRequest{NetworkMapDeltaSupported: true}
 
 	myPrivateKey := c.key
 	myPublicKey := myPrivateKey.PublicKey()
@@ -433,5 +433,6 @@ func infoToMetaData(info *system.Info) *proto.PeerSystemMeta {
 		Kernel:             info.Kernel,
 		WiretrusteeVersion: info.WiretrusteeVersion,
 		UiVersion:          info.UIVersion,
+		Capabilities:       info.Capabilities,
 	}
 }
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/management/server"
+	"github.com/netbirdio/netbird/management/server/activity/sqlite"
+	"github.com/netbirdio/netbird/util"
+)
+
+var (
+	archiveFile    string
+	archiveDataDir string
+
+	exportCmd = &cobra.Command{
+		Use:   "export --file <archive> [--config management.json] [--datadir directory]",
+		Short: "Export accounts, installation ID and activity events into a portable archive. Stop the Management service before running this command.",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			flag.Parse()
+			err := util.InitLog(logLevel, logFile)
+			if err != nil {
+				return fmt.Errorf("failed initializing log %v", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadArchiveConfig()
+			if err != nil {
+				return err
+			}
+
+			store, err := server.NewStore(cfg.StoreConfig.Engine, cfg.Datadir, nil)
+			if err != nil {
+				return fmt.Errorf("failed opening Store: %s: %v", cfg.Datadir, err)
+			}
+			defer store.Close() //nolint
+
+			archive := server.NewArchive(store)
+
+			if cfg.DataStoreEncryptionKey == "" {
+				log.Warnf("no activity store encryption key configured, activity events won't be exported")
+			} else {
+				eventStore, err := sqlite.NewSQLiteStore(cfg.Datadir, cfg.DataStoreEncryptionKey)
+				if err != nil {
+					return fmt.Errorf("failed opening activity store: %v", err)
+				}
+				defer eventStore.Close() //nolint
+
+				for _, account := range archive.Accounts {
+					events, err := eventStore.Export(account.Id)
+					if err != nil {
+						return fmt.Errorf("failed exporting events of account %s: %v", account.Id, err)
+					}
+					archive.Events = append(archive.Events, events...)
+				}
+
+				archive.DeletedUsers, err = eventStore.ExportDeletedUsers()
+				if err != nil {
+					return fmt.Errorf("failed exporting deleted users: %v", err)
+				}
+			}
+
+			err = writeArchiveFile(archiveFile, archive)
+			if err != nil {
+				return err
+			}
+
+			log.Infof("exported %d accounts and %d events to %s", len(archive.Accounts), len(archive.Events), archiveFile)
+			return nil
+		},
+	}
+)
+
+// loadArchiveConfig reads the management config without resolving the OIDC configuration as the export and import
+// commands only need the store settings
+func loadArchiveConfig() (*server.Config, error) {
+	cfg := &server.Config{}
+	_, err := util.ReadJson(mgmtConfig, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading provided config file: %s: %v", mgmtConfig, err)
+	}
+	if archiveDataDir != "" {
+		cfg.Datadir = archiveDataDir
+	}
+	return cfg, nil
+}
+
+func writeArchiveFile(path string, archive *server.Archive) error {
+	if path == "" {
+		return fmt.Errorf("archive file path is required")
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed creating archive file: %v", err)
+	}
+
+	err = server.WriteArchive(file, archive)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed writing archive: %v", err)
+	}
+
+	return file.Close()
+}
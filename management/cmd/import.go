@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/management/server"
+	"github.com/netbirdio/netbird/management/server/activity/sqlite"
+	"github.com/netbirdio/netbird/util"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import --file <archive> [--config management.json] [--datadir directory]",
+	Short: "Import an archive created with the export command. Stop the Management service before running this command.",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		flag.Parse()
+		err := util.InitLog(logLevel, logFile)
+		if err != nil {
+			return fmt.Errorf("failed initializing log %v", err)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadArchiveConfig()
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(archiveFile)
+		if err != nil {
+			return fmt.Errorf("failed opening archive file: %v", err)
+		}
+		archive, err := server.ReadArchive(file)
+		_ = file.Close()
+		if err != nil {
+			return err
+		}
+
+		err = archive.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid archive %s: %v", archiveFile, err)
+		}
+
+		if _, err = os.Stat(cfg.Datadir); os.IsNotExist(err) {
+			// the datadir holds the store with the account secrets, only the management user should access it
+			err = os.MkdirAll(cfg.Datadir, 0700)
+			if err != nil {
+				return fmt.Errorf("failed creating datadir: %s: %v", cfg.Datadir, err)
+			}
+		}
+
+		key := cfg.DataStoreEncryptionKey
+		if key == "" {
+			key, err = sqlite.GenerateKey()
+			if err != nil {
+				return err
+			}
+			cfg.DataStoreEncryptionKey = key
+			err = updateMgmtConfig(mgmtConfig, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to write out store encryption key: %s", err)
+			}
+		}
+
+		err = importIntoDatadir(cfg.StoreConfig.Engine, cfg.Datadir, key, archive)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("imported %d accounts and %d events from %s", len(archive.Accounts), len(archive.Events), archiveFile)
+		return nil
+	},
+}
+
+// importIntoDatadir imports the archive into copies of the store and of the activity store that replace the
+// originals once all the accounts and events are written, so a failed import leaves the datadir untouched
+func importIntoDatadir(engine server.StoreEngine, datadir, key string, archive *server.Archive) error {
+	stagingDir, err := os.MkdirTemp(datadir, ".import-")
+	if err != nil {
+		return fmt.Errorf("failed creating the import staging directory: %v", err)
+	}
+	defer os.RemoveAll(stagingDir) //nolint
+
+	storeFile, err := server.StoreFile(engine, datadir)
+	if err != nil {
+		return err
+	}
+	files := []string{filepath.Base(storeFile), filepath.Base(sqlite.DatabaseFile(datadir))}
+	for _, file := range files {
+		err = stageFile(filepath.Join(datadir, file), filepath.Join(stagingDir, file))
+		if err != nil {
+			return err
+		}
+	}
+
+	store, err := server.NewStore(engine, stagingDir, nil)
+	if err != nil {
+		return fmt.Errorf("failed opening Store: %s: %v", stagingDir, err)
+	}
+	err = server.ImportArchive(store, archive)
+	if cErr := store.Close(); err == nil && cErr != nil {
+		err = fmt.Errorf("failed closing Store: %v", cErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	eventStore, err := sqlite.NewSQLiteStore(stagingDir, key)
+	if err != nil {
+		return fmt.Errorf("failed opening activity store: %v", err)
+	}
+	err = eventStore.Import(archive.Events, archive.DeletedUsers)
+	if cErr := eventStore.Close(); err == nil && cErr != nil {
+		err = fmt.Errorf("failed closing activity store: %v", cErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed importing activity events: %v", err)
+	}
+
+	for _, file := range files {
+		dst := filepath.Join(datadir, file)
+		// the write-ahead log of the replaced database has been merged into the staged copy
+		for _, suffix := range []string{"-wal", "-shm"} {
+			if err := os.Remove(dst + suffix); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed removing %s: %v", dst+suffix, err)
+			}
+		}
+		err = os.Rename(filepath.Join(stagingDir, file), dst)
+		if err != nil {
+			return fmt.Errorf("failed replacing %s with the imported data: %v", dst, err)
+		}
+	}
+
+	return nil
+}
+
+// stageFile copies the database file and its write-ahead log, if any, into the staging directory
+func stageFile(src, dst string) error {
+	for _, suffix := range []string{"", "-wal"} {
+		info, err := os.Stat(src + suffix)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = util.CopyFileContents(src+suffix, dst+suffix)
+		if err != nil {
+			return fmt.Errorf("failed copying %s into the import staging directory: %v", src+suffix, err)
+		}
+		// keep the permissions of the store holding the account secrets
+		err = os.Chmod(dst+suffix, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -32,12 +33,15 @@ import (
 	mgmtProto "github.com/netbirdio/netbird/management/proto"
 	"github.com/netbirdio/netbird/management/server"
 	"github.com/netbirdio/netbird/management/server/activity"
+	"github.com/netbirdio/netbird/management/server/activity/jsonl"
 	"github.com/netbirdio/netbird/management/server/activity/sqlite"
+	"github.com/netbirdio/netbird/management/server/activity/syslog"
 	httpapi "github.com/netbirdio/netbird/management/server/http"
 	"github.com/netbirdio/netbird/management/server/idp"
 	"github.com/netbirdio/netbird/management/server/jwtclaims"
 	"github.com/netbirdio/netbird/management/server/metrics"
 	"github.com/netbirdio/netbird/management/server/telemetry"
+	"github.com/netbirdio/netbird/management/server/webhook"
 	"github.com/netbirdio/netbird/util"
 )
 
@@ -126,11 +130,17 @@ var (
 			if err != nil {
 				return err
 			}
-			store, err := server.NewFileStore(config.Datadir, appMetrics)
+			store, err := server.NewStore(config.StoreConfig.Engine, config.Datadir, appMetrics)
 			if err != nil {
 				return fmt.Errorf("failed creating Store: %s: %v", config.Datadir, err)
 			}
-			peersUpdateManager := server.NewPeersUpdateManager()
+			if config.HAConfig != nil && config.HAConfig.Enabled {
+				store, err = server.NewHAStore(store, *config.HAConfig)
+				if err != nil {
+					return fmt.Errorf("failed creating the store shared with the other replicas: %v", err)
+				}
+			}
+			peersUpdateManager := server.NewPeersUpdateManager(appMetrics)
 
 			var idpManager idp.Manager
 			if config.IdpManagerConfig != nil {
@@ -157,11 +167,34 @@ var (
 				}
 			}
 
+			sinks, err := initEventSinks(config.EventSinksConfig)
+			if err != nil {
+				_ = eventStore.Close()
+				return fmt.Errorf("failed to initialize event sinks: %s", err)
+			}
+			if len(sinks) > 0 {
+				eventStore = activity.NewStreamingStore(eventStore, appMetrics, sinks...)
+			}
+
+			var eventsPruner *activity.Pruner
+			if config.EventsRetention.Duration > 0 {
+				eventsPruner = activity.NewPruner(eventStore, config.EventsRetention.Duration)
+				eventsPruner.Start()
+			}
+
+			webhooks, err := initWebhooks(config.Datadir, config.WebhooksConfig)
+			if err != nil {
+				return fmt.Errorf("failed to initialize webhooks: %s", err)
+			}
+
 			accountManager, err := server.BuildManager(store, peersUpdateManager, idpManager, mgmtSingleAccModeDomain,
-				dnsDomain, eventStore, userDeleteFromIDPEnabled)
+				dnsDomain, eventStore, userDeleteFromIDPEnabled, peersUpdateWindow, webhooks)
 			if err != nil {
 				return fmt.Errorf("failed to build default manager: %v", err)
 			}
+			if err = appMetrics.UpdateChannelMetrics().RegisterPeersUpdateWindow(peersUpdateWindow); err != nil {
+				return fmt.Errorf("failed to register the peers update window metric: %v", err)
+			}
 
 			turnManager := server.NewTimeBasedAuthSecretsManager(peersUpdateManager, config.TURNConfig)
 
@@ -209,6 +242,7 @@ var (
 				return fmt.Errorf("failed creating HTTP API handler: %v", err)
 			}
 
+			// with a shared store the ephemeral peers are deleted by the replica holding the leader lock only
 			ephemeralManager := server.NewEphemeralManager(store, accountManager)
 			ephemeralManager.LoadInitialPeers()
 
@@ -289,7 +323,13 @@ var (
 				_ = certManager.Listener().Close()
 			}
 			gRPCAPIHandler.Stop()
+			// drain the pending webhook deliveries into the dead letters before the stores go away
+			webhooks.Close()
+			_ = webhooks.DeadLetters().Close()
 			_ = store.Close()
+			if eventsPruner != nil {
+				eventsPruner.Stop()
+			}
 			_ = eventStore.Close()
 			log.Infof("stopped Management Service")
 
@@ -312,6 +352,110 @@ func initEventStore(dataDir string, key string) (activity.Store, string, error)
 
 }
 
+// initEventSinks creates the configured sinks the activity events are streamed to
+func initEventSinks(config *server.EventSinksConfig) ([]activity.Sink, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	var sinks []activity.Sink
+	if config.Syslog != nil {
+		tlsConfig, err := syslogTLSConfig(config.Syslog)
+		if err != nil {
+			return nil, err
+		}
+		sink, err := syslog.New(syslog.Config{
+			Network:   config.Syslog.Network,
+			Address:   config.Syslog.Address,
+			Facility:  config.Syslog.Facility,
+			AppName:   config.Syslog.AppName,
+			Hostname:  config.Syslog.Hostname,
+			TLSConfig: tlsConfig,
+			Timeout:   config.Syslog.Timeout.Duration,
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("streaming activity events to the syslog server %s", config.Syslog.Address)
+		sinks = append(sinks, sink)
+	}
+
+	if config.JSONLines != nil {
+		sink, err := jsonl.New(jsonl.Config{
+			Path:       config.JSONLines.Path,
+			MaxSizeMB:  config.JSONLines.MaxSizeMB,
+			MaxBackups: config.JSONLines.MaxBackups,
+			MaxAgeDays: config.JSONLines.MaxAgeDays,
+			Compress:   config.JSONLines.Compress,
+		})
+		if err != nil {
+			for _, s := range sinks {
+				_ = s.Close()
+			}
+			return nil, err
+		}
+		log.Infof("streaming activity events to %s", config.JSONLines.Path)
+		sinks = append(sinks, sink)
+	}
+
+	return sinks, nil
+}
+
+// syslogTLSConfig returns the TLS config of the syslog connections or nil if the network isn't TLS
+func syslogTLSConfig(config *server.SyslogSinkConfig) (*tls.Config, error) {
+	if config.Network != syslog.NetworkTLS {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+	if tlsConfig.ServerName == "" {
+		host, _, err := net.SplitHostPort(config.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid syslog server address %q: %v", config.Address, err)
+		}
+		tlsConfig.ServerName = host
+	}
+
+	if config.CACertFile != "" {
+		pem, err := os.ReadFile(config.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the syslog CA certificate: %v", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", config.CACertFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	return tlsConfig, nil
+}
+
+// initWebhooks creates the dispatcher of the account webhooks with its dead letters stored in the datadir
+func initWebhooks(dataDir string, config *server.WebhooksConfig) (*webhook.Dispatcher, error) {
+	deadLetters, err := webhook.NewSQLiteDeadLetterStore(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var dispatcherConfig webhook.Config
+	if config != nil {
+		dispatcherConfig = webhook.Config{
+			MaxAttempts:    config.MaxAttempts,
+			InitialBackoff: config.InitialBackoff.Duration,
+			MaxBackoff:     config.MaxBackoff.Duration,
+			Timeout:        config.Timeout.Duration,
+
+			AllowPrivateDestinations: config.AllowPrivateDestinations,
+		}
+	}
+	return webhook.NewDispatcher(deadLetters, dispatcherConfig), nil
+}
+
 func notifyStop(msg string) {
 	select {
 	case stopCh <- 1:
@@ -327,6 +471,14 @@ func getInstallationID(store server.Store) (string, error) {
 		return installationID, nil
 	}
 
+	// another management replica, if any, may save one meanwhile
+	unlock := store.AcquireGlobalLock()
+	defer unlock()
+	installationID = store.GetInstallationID()
+	if installationID != "" {
+		return installationID, nil
+	}
+
 	installationID = strings.ToUpper(uuid.New().String())
 	err := store.SaveInstallationID(installationID)
 	if err != nil {
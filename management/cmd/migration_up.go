@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/management/server"
+	"github.com/netbirdio/netbird/util"
+)
+
+var (
+	migrationCmd = &cobra.Command{
+		Use:   "sqlite-migration",
+		Short: "Contains sub-commands to perform JSON file store to SQLite store migration and rollback",
+		Long:  "",
+	}
+
+	upCmd = &cobra.Command{
+		Use:   "upgrade [--datadir directory] [--log-file console]",
+		Short: "Migrate JSON file store to SQLite store. Please make a backup of the JSON file before running this command.",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			flag.Parse()
+			err := util.InitLog(logLevel, logFile)
+			if err != nil {
+				return fmt.Errorf("failed initializing log %v", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := os.Stat(filepath.Join(mgmtDataDir, "store.db")); err == nil {
+				return fmt.Errorf("a SQLite store already exists in %s, remove it before running the migration", mgmtDataDir)
+			}
+
+			fileStore, err := server.NewFileStore(mgmtDataDir, nil)
+			if err != nil {
+				return fmt.Errorf("failed reading the JSON file store in %s: %v", mgmtDataDir, err)
+			}
+			defer fileStore.Close() //nolint
+
+			sqliteStore, err := server.NewSqliteStoreFromFileStore(fileStore, mgmtDataDir, nil)
+			if err != nil {
+				return fmt.Errorf("failed migrating the JSON file store to SQLite: %v", err)
+			}
+
+			accounts := len(fileStore.GetAllAccounts())
+			err = sqliteStore.Close()
+			if err != nil {
+				return fmt.Errorf("failed closing the SQLite store: %v", err)
+			}
+
+			log.Infof("migrated %d accounts from the JSON file store to SQLite in %s. "+
+				"Set StoreConfig.Engine to %q in the management config to use it", accounts, mgmtDataDir, server.SqliteStoreEngine)
+			return nil
+		},
+	}
+)
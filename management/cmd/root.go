@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -25,6 +26,7 @@ var (
 	disableSingleAccMode     bool
 	idpSignKeyRefreshEnabled bool
 	userDeleteFromIDPEnabled bool
+	peersUpdateWindow        time.Duration
 
 	rootCmd = &cobra.Command{
 		Use:          "netbird-mgmt",
@@ -58,11 +60,23 @@ func init() {
 	mgmtCmd.Flags().StringVar(&dnsDomain, "dns-domain", defaultSingleAccModeDomain, fmt.Sprintf("Domain used for peer resolution. This is appended to the peer's name, e.g. pi-server. %s. Max lenght is 192 characters to allow appending to a peer name with up to 63 characters.", defaultSingleAccModeDomain))
 	mgmtCmd.Flags().BoolVar(&idpSignKeyRefreshEnabled, "idp-sign-key-refresh-enabled", false, "Enable cache headers evaluation to determine signing key rotation period. This will refresh the signing key upon expiry.")
 	mgmtCmd.Flags().BoolVar(&userDeleteFromIDPEnabled, "user-delete-from-idp", false, "Allows to delete user from IDP when user is deleted from account")
+	mgmtCmd.Flags().DurationVar(&peersUpdateWindow, "peers-update-window", 200*time.Millisecond, "Window over which the account changes are coalesced before the network maps are sent to the peers. Set to 0 to send the network maps on every change")
 	rootCmd.MarkFlagRequired("config") //nolint
 
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "")
 	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", defaultLogFile, "sets Netbird log path. If console is specified the the log will be output to stdout")
+	for _, c := range []*cobra.Command{exportCmd, importCmd} {
+		c.Flags().StringVar(&mgmtConfig, "config", defaultMgmtConfig, "Netbird config file location")
+		c.Flags().StringVar(&archiveDataDir, "datadir", "", "server data directory location. Overrides the Datadir of the config file")
+		c.Flags().StringVar(&archiveFile, "file", "", "archive file location")
+		_ = c.MarkFlagRequired("file")
+		mgmtCmd.AddCommand(c)
+	}
 	rootCmd.AddCommand(mgmtCmd)
+
+	migrationCmd.PersistentFlags().StringVar(&mgmtDataDir, "datadir", defaultMgmtDataDir, "server data directory location")
+	migrationCmd.AddCommand(upCmd)
+	rootCmd.AddCommand(migrationCmd)
 }
 
 // SetupCloseHandler handles SIGTERM signal and exits with success
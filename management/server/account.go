@@ -11,8 +11,10 @@ import (
 	"net/netip"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/eko/gocache/v3/cache"
@@ -27,6 +29,7 @@ import (
 	"github.com/netbirdio/netbird/management/server/idp"
 	"github.com/netbirdio/netbird/management/server/jwtclaims"
 	"github.com/netbirdio/netbird/management/server/status"
+	"github.com/netbirdio/netbird/management/server/webhook"
 	"github.com/netbirdio/netbird/route"
 )
 
@@ -49,7 +52,7 @@ func cacheEntryExpiration() time.Duration {
 type AccountManager interface {
 	GetOrCreateAccountByUser(userId, domain string) (*Account, error)
 	CreateSetupKey(accountID string, keyName string, keyType SetupKeyType, expiresIn time.Duration,
-		autoGroups []string, usageLimit int, userID string, ephemeral bool) (*SetupKey, error)
+		autoGroups []string, usageLimit int, userID string, ephemeral bool, reservedIP net.IP) (*SetupKey, error)
 	SaveSetupKey(accountID string, key *SetupKey, userID string) (*SetupKey, error)
 	CreateUser(accountID, initiatorUserID string, key *UserInfo) (*UserInfo, error)
 	DeleteUser(accountID, initiatorUserID string, targetUserID string) error
@@ -66,10 +69,12 @@ type AccountManager interface {
 	MarkPeerConnected(peerKey string, connected bool) error
 	DeletePeer(accountID, peerID, userID string) error
 	UpdatePeer(accountID, userID string, peer *Peer) (*Peer, error)
+	ApprovePeer(accountID, userID, peerID string) (*Peer, error)
+	RejectPeer(accountID, userID, peerID string) error
 	GetNetworkMap(peerID string) (*NetworkMap, error)
 	GetPeerNetwork(peerID string) (*Network, error)
 	AddPeer(setupKey, userID string, peer *Peer) (*Peer, *NetworkMap, error)
-	CreatePAT(accountID string, initiatorUserID string, targetUserID string, tokenName string, expiresIn int) (*PersonalAccessTokenGenerated, error)
+	CreatePAT(accountID string, initiatorUserID string, targetUserID string, tokenName string, expiresIn int, scopes []string) (*PersonalAccessTokenGenerated, error)
 	DeletePAT(accountID string, initiatorUserID string, targetUserID string, tokenID string) error
 	GetPAT(accountID string, initiatorUserID string, targetUserID string, tokenID string) (*PersonalAccessToken, error)
 	GetAllPATs(accountID string, initiatorUserID string, targetUserID string) ([]*PersonalAccessToken, error)
@@ -85,22 +90,36 @@ type AccountManager interface {
 	SavePolicy(accountID, userID string, policy *Policy) error
 	DeletePolicy(accountID, policyID, userID string) error
 	ListPolicies(accountID, userID string) ([]*Policy, error)
+	GetPostureChecks(accountID, postureChecksID, userID string) (*PostureChecks, error)
+	SavePostureChecks(accountID, userID string, postureChecks *PostureChecks) error
+	DeletePostureChecks(accountID, postureChecksID, userID string) error
+	ListPostureChecks(accountID, userID string) ([]*PostureChecks, error)
+	GetWebhook(accountID, webhookID, userID string) (*Webhook, error)
+	SaveWebhook(accountID, userID string, hook *Webhook) error
+	DeleteWebhook(accountID, webhookID, userID string) error
+	ListWebhooks(accountID, userID string) ([]*Webhook, error)
+	ListWebhookDeadLetters(accountID, webhookID, userID string) ([]*webhook.DeadLetter, error)
 	GetRoute(accountID, routeID, userID string) (*route.Route, error)
 	CreateRoute(accountID, prefix, peerID string, peerGroupIDs []string, description, netID string, masquerade bool, metric int, groups []string, enabled bool, userID string) (*route.Route, error)
 	SaveRoute(accountID, userID string, route *route.Route) error
 	DeleteRoute(accountID, routeID, userID string) error
 	ListRoutes(accountID, userID string) ([]*route.Route, error)
 	GetNameServerGroup(accountID, nsGroupID string) (*nbdns.NameServerGroup, error)
-	CreateNameServerGroup(accountID string, name, description string, nameServerList []nbdns.NameServer, groups []string, primary bool, domains []string, enabled bool, userID string) (*nbdns.NameServerGroup, error)
+	CreateNameServerGroup(accountID string, name, description string, nameServerList []nbdns.NameServer, groups []string, primary bool, domains []string, enabled bool, userID string, searchDomainsEnabled bool) (*nbdns.NameServerGroup, error)
 	SaveNameServerGroup(accountID, userID string, nsGroupToSave *nbdns.NameServerGroup) error
 	DeleteNameServerGroup(accountID, nsGroupID, userID string) error
 	ListNameServerGroups(accountID string) ([]*nbdns.NameServerGroup, error)
+	GetDNSZone(accountID, zoneID, userID string) (*DNSZone, error)
+	SaveDNSZone(accountID, userID string, zone *DNSZone) error
+	DeleteDNSZone(accountID, zoneID, userID string) error
+	ListDNSZones(accountID, userID string) ([]*DNSZone, error)
 	GetDNSDomain() string
-	GetEvents(accountID, userID string) ([]*activity.Event, error)
+	GetEvents(accountID, userID string, filter activity.Filter) ([]*activity.Event, string, error)
 	GetDNSSettings(accountID string, userID string) (*DNSSettings, error)
 	SaveDNSSettings(accountID string, userID string, dnsSettingsToSave *DNSSettings) error
 	GetPeer(accountID, peerID, userID string) (*Peer, error)
 	UpdateAccountSettings(accountID, userID string, newSettings *Settings) (*Account, error)
+	UpdateAccountNetworkRange(accountID, userID string, networkRange netip.Prefix) (*Account, error)
 	LoginPeer(login PeerLogin) (*Peer, *NetworkMap, error) // used by peer gRPC API
 	SyncPeer(sync PeerSync) (*Peer, *NetworkMap, error)    // used by peer gRPC API
 }
@@ -126,9 +145,24 @@ type DefaultAccountManager struct {
 	// dnsDomain is used for peer resolution. This is appended to the peer's name
 	dnsDomain       string
 	peerLoginExpiry Scheduler
+	// peerLoginExpiryLeader is false while another management replica holds the leader lock and expires the peers
+	peerLoginExpiryLeader atomic.Bool
 
 	// userDeleteFromIDPEnabled allows to delete user from IDP when user is deleted from account
 	userDeleteFromIDPEnabled bool
+
+	// peersUpdateWindow is the window over which the account changes are coalesced before the network maps are sent
+	// to the peers. The network maps are sent on every change when it is 0
+	peersUpdateWindow time.Duration
+	// pendingUpdates are the network map updates waiting for the end of the window, indexed by account ID
+	pendingUpdates    map[string]*pendingPeersUpdate
+	pendingUpdatesMux sync.Mutex
+
+	// accountChanges broadcasts the account changes to the other management replicas, nil with a single replica
+	accountChanges AccountChangesBroadcaster
+
+	// webhooks delivers the activity events to the webhooks of the accounts, nil when the deliveries are disabled
+	webhooks *webhook.Dispatcher
 }
 
 // Settings represents Account settings structure that can be modified via API and Dashboard
@@ -149,6 +183,10 @@ type Settings struct {
 
 	// JWTGroupsClaimName from which we extract groups name to add it to account groups
 	JWTGroupsClaimName string
+
+	// PeerApprovalEnabled puts newly added peers in a pending state until an admin approves them.
+	// Pending peers are excluded from the network maps of other peers and receive an empty network map
+	PeerApprovalEnabled bool
 }
 
 // Copy copies the Settings struct
@@ -159,6 +197,7 @@ func (s *Settings) Copy() *Settings {
 		JWTGroupsEnabled:           s.JWTGroupsEnabled,
 		JWTGroupsClaimName:         s.JWTGroupsClaimName,
 		GroupsPropagationEnabled:   s.GroupsPropagationEnabled,
+		PeerApprovalEnabled:        s.PeerApprovalEnabled,
 	}
 }
 
@@ -180,6 +219,9 @@ type Account struct {
 	Routes                 map[string]*route.Route
 	NameServerGroups       map[string]*nbdns.NameServerGroup
 	DNSSettings            *DNSSettings
+	PostureChecks          []*PostureChecks
+	Webhooks               []*Webhook
+	DNSZones               map[string]*DNSZone
 	// Settings is a dictionary of Account settings
 	Settings *Settings
 }
@@ -306,6 +348,13 @@ func (a *Account) GetGroup(groupID string) *Group {
 
 // GetPeerNetworkMap returns a group by ID if exists, nil otherwise
 func (a *Account) GetPeerNetworkMap(peerID, dnsDomain string) *NetworkMap {
+	// peers pending approval are not connected to the network
+	if peer := a.GetPeer(peerID); peer != nil && peer.PendingApproval() {
+		return &NetworkMap{
+			Network: a.Network.Copy(),
+		}
+	}
+
 	aclPeers, firewallRules := a.getPeerConnectionResources(peerID)
 	// exclude expired peers
 	var peersToConnect []*Peer
@@ -323,7 +372,7 @@ func (a *Account) GetPeerNetworkMap(peerID, dnsDomain string) *NetworkMap {
 
 	takePeer := func(id string) (*Peer, bool) {
 		peer := a.GetPeer(id)
-		if peer == nil || peer.Meta.GoOS != "linux" {
+		if peer == nil || !peer.SupportsRouting() || peer.PendingApproval() {
 			return nil, false
 		}
 		return peer, true
@@ -375,6 +424,8 @@ func (a *Account) GetPeerNetworkMap(peerID, dnsDomain string) *NetworkMap {
 		if peersCustomZone.Domain != "" {
 			zones = append(zones, peersCustomZone)
 		}
+		zones = append(zones, getPeersReverseZones(a, dnsDomain)...)
+		zones = append(zones, getPeerDNSZones(a, peerID)...)
 		dnsUpdate.CustomZones = zones
 		dnsUpdate.NameServerGroups = getPeerNSGroups(a, peerID)
 	}
@@ -571,15 +622,65 @@ func (a *Account) getSetupKeyGroups(setupKey string) ([]string, error) {
 	return key.AutoGroups, nil
 }
 
+// getTakenIPs returns the IPs of the peers and the IPs reserved by the setup keys that can still be used
 func (a *Account) getTakenIPs() []net.IP {
 	var takenIps []net.IP
 	for _, existingPeer := range a.Peers {
 		takenIps = append(takenIps, existingPeer.IP)
 	}
 
+	for _, key := range a.SetupKeys {
+		if key.ReservedIP != nil && key.IsValid() {
+			takenIps = append(takenIps, key.ReservedIP)
+		}
+	}
+
 	return takenIps
 }
 
+func (a *Account) getTakenIPv6s() []net.IP {
+	var takenIps []net.IP
+	for _, existingPeer := range a.Peers {
+		if existingPeer.IPv6 != nil {
+			takenIps = append(takenIps, existingPeer.IPv6)
+		}
+	}
+
+	return takenIps
+}
+
+// addIPv6Addresses assigns an IPv6 overlay network to accounts created before IPv6 support
+// and an IPv6 overlay address to the peers without one. Returns true if the account was changed
+func addIPv6Addresses(account *Account) (bool, error) {
+	if account.Network == nil {
+		return false, nil
+	}
+
+	changed := false
+	if !account.Network.HasIPv6() {
+		account.Network.NetV6 = newULANetwork(rand.New(rand.NewSource(time.Now().UnixNano())))
+		changed = true
+	}
+
+	for _, peer := range account.Peers {
+		if peer.IPv6 != nil {
+			continue
+		}
+		ip, err := AllocatePeerIPv6(account.Network.NetV6, account.getTakenIPv6s())
+		if err != nil {
+			return changed, err
+		}
+		peer.IPv6 = ip
+		changed = true
+	}
+
+	if changed {
+		account.Network.IncSerial()
+	}
+
+	return changed, nil
+}
+
 func (a *Account) getPeerDNSLabels() lookupMap {
 	existingLabels := make(lookupMap)
 	for _, peer := range a.Peers {
@@ -636,6 +737,21 @@ func (a *Account) Copy() *Account {
 		dnsSettings = a.DNSSettings.Copy()
 	}
 
+	var postureChecks []*PostureChecks
+	for _, pc := range a.PostureChecks {
+		postureChecks = append(postureChecks, pc.Copy())
+	}
+
+	var webhooks []*Webhook
+	for _, hook := range a.Webhooks {
+		webhooks = append(webhooks, hook.Copy())
+	}
+
+	dnsZones := map[string]*DNSZone{}
+	for id, zone := range a.DNSZones {
+		dnsZones[id] = zone.Copy()
+	}
+
 	var settings *Settings
 	if a.Settings != nil {
 		settings = a.Settings.Copy()
@@ -657,6 +773,9 @@ func (a *Account) Copy() *Account {
 		Routes:                 routes,
 		NameServerGroups:       nsGroups,
 		DNSSettings:            dnsSettings,
+		PostureChecks:          postureChecks,
+		Webhooks:               webhooks,
+		DNSZones:               dnsZones,
 		Settings:               settings,
 	}
 }
@@ -781,9 +900,45 @@ func (a *Account) UserGroupsRemoveFromPeers(userID string, groups ...string) {
 	}
 }
 
+// migrateAccount adds the 'all' group and the IPv6 overlay addresses to an account missing them. The account is read
+// again under its lock as the other management replicas, if any, may change it concurrently
+func migrateAccount(store Store, accountID string) error {
+	unlock := store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	shouldSave := false
+	_, err = account.GetGroupAll()
+	if err != nil {
+		if err := addAllGroup(account); err != nil {
+			return err
+		}
+		shouldSave = true
+	}
+
+	ipv6Added, err := addIPv6Addresses(account)
+	if err != nil {
+		return err
+	}
+	if ipv6Added {
+		log.Infof("assigned IPv6 overlay addresses in account %s network %s", account.Id, account.Network.NetV6.String())
+		shouldSave = true
+	}
+
+	if !shouldSave {
+		return nil
+	}
+	return store.SaveAccount(account)
+}
+
 // BuildManager creates a new DefaultAccountManager with a provided Store
 func BuildManager(store Store, peersUpdateManager *PeersUpdateManager, idpManager idp.Manager,
 	singleAccountModeDomain string, dnsDomain string, eventStore activity.Store, userDeleteFromIDPEnabled bool,
+	peersUpdateWindow time.Duration, webhooks *webhook.Dispatcher,
 ) (*DefaultAccountManager, error) {
 	am := &DefaultAccountManager{
 		Store:                    store,
@@ -796,7 +951,11 @@ func BuildManager(store Store, peersUpdateManager *PeersUpdateManager, idpManage
 		eventStore:               eventStore,
 		peerLoginExpiry:          NewDefaultScheduler(),
 		userDeleteFromIDPEnabled: userDeleteFromIDPEnabled,
+		peersUpdateWindow:        peersUpdateWindow,
+		pendingUpdates:           map[string]*pendingPeersUpdate{},
+		webhooks:                 webhooks,
 	}
+	am.peerLoginExpiryLeader.Store(true)
 	allAccounts := store.GetAllAccounts()
 	// enable single account mode only if configured by user and number of existing accounts is not grater than 1
 	am.singleAccountMode = singleAccountModeDomain != "" && len(allAccounts) <= 1
@@ -814,21 +973,9 @@ func BuildManager(store Store, peersUpdateManager *PeersUpdateManager, idpManage
 	// we create 'all' group and add all peers into it
 	// also we create default rule with source as destination
 	for _, account := range allAccounts {
-		shouldSave := false
-
-		_, err := account.GetGroupAll()
+		err := migrateAccount(store, account.Id)
 		if err != nil {
-			if err := addAllGroup(account); err != nil {
-				return nil, err
-			}
-			shouldSave = true
-		}
-
-		if shouldSave {
-			err = store.SaveAccount(account)
-			if err != nil {
-				return nil, err
-			}
+			return nil, err
 		}
 	}
 
@@ -848,6 +995,16 @@ func BuildManager(store Store, peersUpdateManager *PeersUpdateManager, idpManage
 		}()
 	}
 
+	if broadcaster, ok := store.(AccountChangesBroadcaster); ok {
+		am.accountChanges = broadcaster
+		go broadcaster.WatchAccountChanges(am.ctx, am.handleAccountChange)
+	}
+
+	if elector, ok := store.(LeaderElector); ok {
+		am.peerLoginExpiryLeader.Store(false)
+		go elector.RunAsLeader(am.ctx, am.expirePeersAsLeader)
+	}
+
 	return am, nil
 }
 
@@ -899,6 +1056,14 @@ func (am *DefaultAccountManager) UpdateAccountSettings(accountID, userID string,
 		am.checkAndSchedulePeerLoginExpiration(account)
 	}
 
+	if oldSettings.PeerApprovalEnabled != newSettings.PeerApprovalEnabled {
+		event := activity.AccountPeerApprovalEnabled
+		if !newSettings.PeerApprovalEnabled {
+			event = activity.AccountPeerApprovalDisabled
+		}
+		am.storeEvent(userID, accountID, accountID, event, nil)
+	}
+
 	updatedAccount := account.UpdateSettings(newSettings)
 
 	err = am.Store.SaveAccount(account)
@@ -909,8 +1074,116 @@ func (am *DefaultAccountManager) UpdateAccountSettings(accountID, userID string,
 	return updatedAccount, nil
 }
 
+// UpdateAccountNetworkRange changes the overlay network of the account and re-addresses all its peers and the IPs
+// reserved by its valid setup keys in a single operation. The addresses keep their host part when it fits in the new
+// network range, the others get a random one. The peers receive their new addresses with the next network map.
+// Only users with role UserRoleAdmin can change the network range.
+// Returns an updated Account
+func (am *DefaultAccountManager) UpdateAccountNetworkRange(accountID, userID string, networkRange netip.Prefix) (*Account, error) {
+	if !networkRange.IsValid() || !networkRange.Addr().Is4() {
+		return nil, status.Errorf(status.InvalidArgument, "network range should be an IPv4 CIDR")
+	}
+
+	if networkRange.Bits() < MinNetworkRangeSize || networkRange.Bits() > MaxNetworkRangeSize {
+		return nil, status.Errorf(status.InvalidArgument, "network range prefix length should be between /%d and /%d",
+			MinNetworkRangeSize, MaxNetworkRangeSize)
+	}
+	networkRange = networkRange.Masked()
+
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAdmin() {
+		return nil, status.Errorf(status.PermissionDenied, "user is not allowed to update account")
+	}
+
+	newNet := net.IPNet{IP: networkRange.Addr().AsSlice(), Mask: net.CIDRMask(networkRange.Bits(), 32)}
+	oldNet := account.Network.Net
+	if newNet.String() == oldNet.String() {
+		return account, nil
+	}
+
+	for _, r := range account.Routes {
+		if r.Network.Overlaps(networkRange) {
+			return nil, status.Errorf(status.InvalidArgument, "network range %s overlaps with route %s network %s",
+				networkRange, r.NetID, r.Network)
+		}
+	}
+
+	peers := account.GetPeers()
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].ID < peers[j].ID
+	})
+
+	var reservingKeys []*SetupKey
+	for _, key := range account.SetupKeys {
+		if key.ReservedIP != nil && key.IsValid() {
+			reservingKeys = append(reservingKeys, key)
+		}
+	}
+
+	currentIPs := make([]net.IP, 0, len(peers)+len(reservingKeys))
+	for _, peer := range peers {
+		currentIPs = append(currentIPs, peer.IP)
+	}
+	for _, key := range reservingKeys {
+		currentIPs = append(currentIPs, key.ReservedIP)
+	}
+
+	ips, err := remapPeerIPs(oldNet, newNet, currentIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	oldIPs := make(map[string]net.IP, len(peers))
+	for i, peer := range peers {
+		oldIPs[peer.ID] = peer.IP
+		peer.IP = ips[i]
+	}
+	for i, key := range reservingKeys {
+		key.ReservedIP = ips[len(peers)+i]
+	}
+
+	account.Network.Net = newNet
+	account.Network.IncSerial()
+
+	err = am.Store.SaveAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	am.storeEvent(userID, accountID, accountID, activity.AccountNetworkRangeUpdated,
+		map[string]any{"old_range": oldNet.String(), "new_range": newNet.String()})
+	for _, peer := range peers {
+		if peer.IP.Equal(oldIPs[peer.ID]) {
+			continue
+		}
+		meta := peer.EventMeta(am.GetDNSDomain())
+		meta["old_ip"] = oldIPs[peer.ID].String()
+		am.storeEvent(userID, peer.ID, accountID, activity.PeerIPUpdated, meta)
+	}
+
+	am.updateAccountPeers(account)
+
+	return account, nil
+}
+
 func (am *DefaultAccountManager) peerLoginExpirationJob(accountID string) func() (time.Duration, bool) {
 	return func() (time.Duration, bool) {
+		if !am.peerLoginExpiryLeader.Load() {
+			return 0, false
+		}
+
 		unlock := am.Store.AcquireAccountLock(accountID)
 		defer unlock()
 
@@ -938,12 +1211,52 @@ func (am *DefaultAccountManager) peerLoginExpirationJob(accountID string) func()
 }
 
 func (am *DefaultAccountManager) checkAndSchedulePeerLoginExpiration(account *Account) {
+	if !am.peerLoginExpiryLeader.Load() {
+		// the peers are expired by the replica holding the leader lock
+		return
+	}
 	am.peerLoginExpiry.Cancel([]string{account.Id})
 	if nextRun, ok := account.GetNextPeerExpiration(); ok {
 		go am.peerLoginExpiry.Schedule(nextRun, account.Id, am.peerLoginExpirationJob(account.Id))
 	}
 }
 
+// expirePeersAsLeader schedules the peer login expiration of all the accounts while the replica holds the leader
+// lock. The accounts changed by the other replicas are rescheduled every leaderSyncInterval
+func (am *DefaultAccountManager) expirePeersAsLeader(ctx context.Context) {
+	am.peerLoginExpiryLeader.Store(true)
+
+	ticker := time.NewTicker(leaderSyncInterval)
+	defer ticker.Stop()
+
+	scheduled := make(map[string]struct{})
+	for {
+		for _, account := range am.Store.GetAllAccounts() {
+			if !account.Settings.PeerLoginExpirationEnabled {
+				if _, ok := scheduled[account.Id]; ok {
+					am.peerLoginExpiry.Cancel([]string{account.Id})
+					delete(scheduled, account.Id)
+				}
+				continue
+			}
+			am.checkAndSchedulePeerLoginExpiration(account)
+			scheduled[account.Id] = struct{}{}
+		}
+
+		select {
+		case <-ctx.Done():
+			am.peerLoginExpiryLeader.Store(false)
+			accountIDs := make([]string, 0, len(scheduled))
+			for accountID := range scheduled {
+				accountIDs = append(accountIDs, accountID)
+			}
+			am.peerLoginExpiry.Cancel(accountIDs)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // newAccount creates a new Account with a generated ID and generated default setup keys.
 // If ID is already in use (due to collision) we try one more time before returning error
 func (am *DefaultAccountManager) newAccount(userID, domain string) (*Account, error) {
@@ -1462,6 +1775,22 @@ func (am *DefaultAccountManager) GetAccountFromToken(claims jwtclaims.Authorizat
 					}
 				}
 
+				// the groups are set on the account read again under its lock, the lock is taken only when they change
+				if !account.Copy().SetJWTGroups(claims.UserId, groupsNames) {
+					return account, user, nil
+				}
+				unlock := am.Store.AcquireAccountLock(account.Id)
+				defer unlock()
+
+				account, err = am.Store.GetAccount(account.Id)
+				if err != nil {
+					return nil, nil, err
+				}
+				user = account.Users[claims.UserId]
+				if user == nil {
+					return nil, nil, status.Errorf(status.NotFound, "user %s not found", claims.UserId)
+				}
+
 				oldGroups := make([]string, len(user.AutoGroups))
 				copy(oldGroups, user.AutoGroups)
 				// if groups were added or modified, save the account
@@ -1476,7 +1805,8 @@ func (am *DefaultAccountManager) GetAccountFromToken(claims jwtclaims.Authorizat
 							if err := am.Store.SaveAccount(account); err != nil {
 								log.Errorf("failed to save account: %v", err)
 							} else {
-								am.updateAccountPeers(account)
+								changedGroups := append(addNewGroups, removeOldGroups...)
+								am.updateAffectedPeersDNS(account, account.getPeersAffectedByUserGroups(user.Id, changedGroups...))
 								for _, g := range addNewGroups {
 									if group := account.GetGroup(g); group != nil {
 										am.storeEvent(user.Id, user.Id, account.Id, activity.GroupAddedToUser,
@@ -1636,9 +1966,7 @@ func newAccountWithId(accountID, userID, domain string) *Account {
 	setupKeys := map[string]*SetupKey{}
 	nameServersGroups := make(map[string]*nbdns.NameServerGroup)
 	users[userID] = NewAdminUser(userID)
-	dnsSettings := &DNSSettings{
-		DisabledManagementGroups: make([]string, 0),
-	}
+	dnsSettings := NewDNSSettings()
 	log.Debugf("created new account %s", accountID)
 
 	acc := &Account{
@@ -1651,6 +1979,7 @@ func newAccountWithId(accountID, userID, domain string) *Account {
 		Domain:           domain,
 		Routes:           routes,
 		NameServerGroups: nameServersGroups,
+		DNSZones:         make(map[string]*DNSZone),
 		DNSSettings:      dnsSettings,
 		Settings: &Settings{
 			PeerLoginExpirationEnabled: true,
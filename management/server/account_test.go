@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"net/netip"
 	"reflect"
 	"sync"
 	"testing"
@@ -256,7 +257,7 @@ func TestAccountManager_GetOrCreateAccountByUser(t *testing.T) {
 	}
 
 	// check the corresponding events that should have been generated
-	ev := getEvent(t, account.Id, manager, activity.AccountCreated)
+	ev := getEvent(t, account.Id, userID, manager, activity.AccountCreated)
 
 	assert.NotNil(t, ev)
 	assert.Equal(t, account.Id, ev.AccountID)
@@ -759,7 +760,7 @@ func TestAccountManager_AddPeer(t *testing.T) {
 
 	serial := account.Network.CurrentSerial() // should be 0
 
-	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userID, false)
+	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userID, false, nil)
 	if err != nil {
 		t.Fatal("error creating setup key")
 		return
@@ -808,7 +809,7 @@ func TestAccountManager_AddPeer(t *testing.T) {
 	if account.Network.CurrentSerial() != 1 {
 		t.Errorf("expecting Network Serial=%d to be incremented by 1 and be equal to %d when adding new peer to account", serial, account.Network.CurrentSerial())
 	}
-	ev := getEvent(t, account.Id, manager, activity.PeerAddedWithSetupKey)
+	ev := getEvent(t, account.Id, userID, manager, activity.PeerAddedWithSetupKey)
 
 	assert.NotNil(t, ev)
 	assert.Equal(t, account.Id, ev.AccountID)
@@ -877,7 +878,7 @@ func TestAccountManager_AddPeerWithUserID(t *testing.T) {
 		t.Errorf("expecting Network Serial=%d to be incremented by 1 and be equal to %d when adding new peer to account", serial, account.Network.CurrentSerial())
 	}
 
-	ev := getEvent(t, account.Id, manager, activity.PeerAddedByUser)
+	ev := getEvent(t, account.Id, userID, manager, activity.PeerAddedByUser)
 
 	assert.NotNil(t, ev)
 	assert.Equal(t, account.Id, ev.AccountID)
@@ -902,7 +903,7 @@ func TestAccountManager_NetworkUpdates(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userID, false)
+	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userID, false, nil)
 	if err != nil {
 		t.Fatal("error creating setup key")
 		return
@@ -1061,7 +1062,7 @@ func TestAccountManager_NetworkUpdates(t *testing.T) {
 		// clean policy is pre requirement for delete group
 		_ = manager.DeletePolicy(account.Id, policy.ID, userID)
 
-		if err := manager.DeleteGroup(account.Id, "", group.ID); err != nil {
+		if err := manager.DeleteGroup(account.Id, userID, group.ID); err != nil {
 			t.Errorf("delete group: %v", err)
 			return
 		}
@@ -1082,7 +1083,7 @@ func TestAccountManager_DeletePeer(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userID, false)
+	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userID, false, nil)
 	if err != nil {
 		t.Fatal("error creating setup key")
 		return
@@ -1120,7 +1121,7 @@ func TestAccountManager_DeletePeer(t *testing.T) {
 		t.Errorf("expecting Network Serial=%d to be incremented and be equal to 2 after adding and deleteing a peer", account.Network.CurrentSerial())
 	}
 
-	ev := getEvent(t, account.Id, manager, activity.PeerRemovedByUser)
+	ev := getEvent(t, account.Id, userID, manager, activity.PeerRemovedByUser)
 
 	assert.NotNil(t, ev)
 	assert.Equal(t, account.Id, ev.AccountID)
@@ -1131,13 +1132,13 @@ func TestAccountManager_DeletePeer(t *testing.T) {
 	assert.Equal(t, peer.IP.String(), fmt.Sprint(ev.Meta["ip"]))
 }
 
-func getEvent(t *testing.T, accountID string, manager AccountManager, eventType activity.Activity) *activity.Event {
+func getEvent(t *testing.T, accountID, userID string, manager AccountManager, eventType activity.Activity) *activity.Event {
 	for {
 		select {
 		case <-time.After(time.Second):
 			t.Fatal("no PeerAddedWithSetupKey event was generated")
 		default:
-			events, err := manager.GetEvents(accountID, userID)
+			events, _, err := manager.GetEvents(accountID, userID, activity.Filter{Descending: true})
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -1375,7 +1376,27 @@ func TestAccount_Copy(t *testing.T) {
 			},
 		},
 		DNSSettings: &DNSSettings{DisabledManagementGroups: []string{}},
-		Settings:    &Settings{},
+		PostureChecks: []*PostureChecks{
+			{
+				ID:             "postureChecks1",
+				NBVersionCheck: &NBVersionCheck{MinVersion: "0.23.0"},
+				OSCheck:        &OSCheck{AllowedOS: []string{"linux"}},
+			},
+		},
+		Webhooks: []*Webhook{
+			{
+				ID:     "webhook1",
+				Events: []string{"user.peer.add"},
+			},
+		},
+		DNSZones: map[string]*DNSZone{
+			"zone1": {
+				ID:                 "zone1",
+				Records:            []*DNSRecord{{Name: "corp.example.com", Type: DNSRecordTypeTXT, TTL: 300, Content: "text"}},
+				DistributionGroups: []string{"group1"},
+			},
+		},
+		Settings: &Settings{},
 	}
 	err := hasNilField(account)
 	if err != nil {
@@ -1605,6 +1626,143 @@ func TestDefaultAccountManager_UpdateAccountSettings(t *testing.T) {
 	require.Error(t, err, "expecting to fail when providing PeerLoginExpiration more than 180 days")
 }
 
+func TestDefaultAccountManager_UpdateAccountNetworkRange(t *testing.T) {
+	manager, err := createManager(t)
+	require.NoError(t, err, "unable to create account manager")
+
+	account, err := createAccount(manager, "test_account", userID, "")
+	require.NoError(t, err)
+
+	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userID, false, nil)
+	require.NoError(t, err)
+	reservingKey, err := manager.CreateSetupKey(account.Id, "reserving-key", SetupKeyOneOff, time.Hour, nil, 0, userID, false,
+		reservedTestIP(account.Network.Net, 20))
+	require.NoError(t, err)
+
+	var peers []*Peer
+	for _, hostname := range []string{"peer-1", "peer-2", "peer-3"} {
+		wgKey, err := wgtypes.GeneratePrivateKey()
+		require.NoError(t, err)
+		peer, _, err := manager.AddPeer(setupKey.Key, "", &Peer{
+			Key:  wgKey.PublicKey().String(),
+			Meta: PeerSystemMeta{Hostname: hostname},
+		})
+		require.NoError(t, err)
+		peers = append(peers, peer)
+	}
+
+	account, err = manager.Store.GetAccount(account.Id)
+	require.NoError(t, err)
+	account.Routes["route"] = &route.Route{ID: "route", NetID: "office", Network: netip.MustParsePrefix("10.10.0.0/16")}
+	require.NoError(t, manager.Store.SaveAccount(account))
+	serial := account.Network.CurrentSerial()
+
+	updMsg := manager.peersUpdateManager.CreateChannel(peers[0].ID)
+	defer manager.peersUpdateManager.CloseChannel(peers[0].ID)
+
+	_, err = manager.UpdateAccountNetworkRange(account.Id, userID, netip.MustParsePrefix("10.0.0.0/8"))
+	assert.Error(t, err, "a network range larger than /16 should be rejected")
+
+	_, err = manager.UpdateAccountNetworkRange(account.Id, userID, netip.MustParsePrefix("fd00::/64"))
+	assert.Error(t, err, "an IPv6 network range should be rejected")
+
+	_, err = manager.UpdateAccountNetworkRange(account.Id, userID, netip.MustParsePrefix("10.10.1.0/24"))
+	assert.Error(t, err, "a network range overlapping with a route should be rejected")
+
+	_, err = manager.UpdateAccountNetworkRange(account.Id, userID, netip.MustParsePrefix("10.20.0.0/29"))
+	assert.Error(t, err, "a network range longer than /28 should be rejected")
+
+	updated, err := manager.UpdateAccountNetworkRange(account.Id, userID, netip.MustParsePrefix("10.20.0.1/24"))
+	require.NoError(t, err)
+	assert.Equal(t, "10.20.0.0/24", updated.Network.Net.String())
+	assert.Equal(t, serial+1, updated.Network.CurrentSerial(), "the network serial should be incremented once")
+
+	account, err = manager.Store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "10.20.0.0/24", account.Network.Net.String())
+
+	var takenIPs []net.IP
+	for _, peer := range peers {
+		newIP := account.Peers[peer.ID].IP
+		assert.NoError(t, validatePeerIP(account.Network.Net, newIP, takenIPs), "the peer should get a new IP in the range")
+		takenIPs = append(takenIPs, newIP)
+	}
+	reservedIP := account.SetupKeys[reservingKey.Key].ReservedIP
+	assert.NoError(t, validatePeerIP(account.Network.Net, reservedIP, takenIPs), "the reserved IP should be moved to the range")
+
+	event := getEvent(t, account.Id, userID, manager, activity.AccountNetworkRangeUpdated)
+	assert.Equal(t, "10.20.0.0/24", event.Meta["new_range"])
+
+	events, _, err := manager.GetEvents(account.Id, userID, activity.Filter{Activities: []activity.Activity{activity.PeerIPUpdated}})
+	require.NoError(t, err)
+	assert.Len(t, events, len(peers), "an event should be stored for every re-addressed peer")
+
+	select {
+	case message := <-updMsg:
+		assert.Equal(t, account.Peers[peers[0].ID].IP.String()+"/24", message.Update.GetNetworkMap().GetPeerConfig().GetAddress(),
+			"the new address should be pushed to the peer")
+	case <-time.After(time.Second):
+		t.Fatal("the peer should receive an update")
+	}
+
+	_, err = manager.UpdateAccountNetworkRange(account.Id, userID, netip.MustParsePrefix("10.20.0.0/24"))
+	require.NoError(t, err)
+	account, err = manager.Store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.Equal(t, serial+1, account.Network.CurrentSerial(), "the same network range should not re-address the peers")
+}
+
+func TestDefaultAccountManager_UpdateAccountNetworkRange_KeepsHostPart(t *testing.T) {
+	manager, err := createManager(t)
+	require.NoError(t, err, "unable to create account manager")
+
+	account, err := createAccount(manager, "test_account", userID, "")
+	require.NoError(t, err)
+
+	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userID, false, nil)
+	require.NoError(t, err)
+	reservingKey, err := manager.CreateSetupKey(account.Id, "reserving-key", SetupKeyOneOff, time.Hour, nil, 0, userID, false,
+		reservedTestIP(account.Network.Net, 20))
+	require.NoError(t, err)
+
+	var peers []*Peer
+	for _, hostname := range []string{"static", "outside", "random"} {
+		wgKey, err := wgtypes.GeneratePrivateKey()
+		require.NoError(t, err)
+		peer, _, err := manager.AddPeer(setupKey.Key, "", &Peer{
+			Key:  wgKey.PublicKey().String(),
+			Meta: PeerSystemMeta{Hostname: hostname},
+		})
+		require.NoError(t, err)
+		peers = append(peers, peer)
+	}
+
+	// the host part of the static IP fits in a /24, the host part of the outside IP doesn't
+	staticIP := reservedTestIP(account.Network.Net, 10)
+	_, err = manager.UpdatePeer(account.Id, userID, &Peer{ID: peers[0].ID, Name: peers[0].Name, IP: staticIP})
+	require.NoError(t, err)
+	outsideIP := reservedTestIP(account.Network.Net, 10)
+	outsideIP[2] = 1
+	_, err = manager.UpdatePeer(account.Id, userID, &Peer{ID: peers[1].ID, Name: peers[1].Name, IP: outsideIP})
+	require.NoError(t, err)
+
+	_, err = manager.UpdateAccountNetworkRange(account.Id, userID, netip.MustParsePrefix("10.20.0.0/24"))
+	require.NoError(t, err)
+
+	account, err = manager.Store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "10.20.0.10", account.Peers[peers[0].ID].IP.String(), "the static IP should keep its host part")
+	assert.Equal(t, "10.20.0.20", account.SetupKeys[reservingKey.Key].ReservedIP.String(),
+		"the reserved IP should keep its host part")
+
+	var takenIPs []net.IP
+	for _, peer := range peers {
+		newIP := account.Peers[peer.ID].IP
+		assert.NoError(t, validatePeerIP(account.Network.Net, newIP, takenIPs), "the peer should get a new IP in the range")
+		takenIPs = append(takenIPs, newIP)
+	}
+}
+
 func TestAccount_GetExpiredPeers(t *testing.T) {
 	type test struct {
 		name          string
@@ -2040,7 +2198,7 @@ func createManager(t *testing.T) (*DefaultAccountManager, error) {
 		return nil, err
 	}
 	eventStore := &activity.InMemoryEventStore{}
-	return BuildManager(store, NewPeersUpdateManager(), nil, "", "netbird.cloud", eventStore, false)
+	return BuildManager(store, NewPeersUpdateManager(nil), nil, "", "netbird.cloud", eventStore, false, 0, nil)
 }
 
 func createStore(t *testing.T) (Store, error) {
@@ -114,6 +114,40 @@ const (
 	PeerLoginExpired
 	// DashboardLogin indicates that the user logged in to the dashboard
 	DashboardLogin
+	// PostureCheckCreated indicates that a user created a posture check
+	PostureCheckCreated
+	// PostureCheckUpdated indicates that a user updated a posture check
+	PostureCheckUpdated
+	// PostureCheckDeleted indicates that a user deleted a posture check
+	PostureCheckDeleted
+	// PeerApproved indicates that a user approved a peer pending approval
+	PeerApproved
+	// PeerRejected indicates that a user rejected a peer pending approval and the peer was removed
+	PeerRejected
+	// AccountPeerApprovalEnabled indicates that a user enabled the approval of new peers for the account
+	AccountPeerApprovalEnabled
+	// AccountPeerApprovalDisabled indicates that a user disabled the approval of new peers for the account
+	AccountPeerApprovalDisabled
+	// WebhookCreated indicates that a user created a webhook
+	WebhookCreated
+	// WebhookUpdated indicates that a user updated a webhook
+	WebhookUpdated
+	// WebhookDeleted indicates that a user deleted a webhook
+	WebhookDeleted
+	// PeerIPUpdated indicates that a user changed the overlay IP of a peer
+	PeerIPUpdated
+	// AccountNetworkRangeUpdated indicates that a user changed the overlay network range of the account
+	AccountNetworkRangeUpdated
+	// DNSZoneCreated indicates that a user created a custom DNS zone
+	DNSZoneCreated
+	// DNSZoneUpdated indicates that a user updated a custom DNS zone
+	DNSZoneUpdated
+	// DNSZoneDeleted indicates that a user deleted a custom DNS zone
+	DNSZoneDeleted
+	// PeerZoneSearchDomainEnabled indicates that a user added the peer DNS zone to the search domains of the peers
+	PeerZoneSearchDomainEnabled
+	// PeerZoneSearchDomainDisabled indicates that a user removed the peer DNS zone from the search domains of the peers
+	PeerZoneSearchDomainDisabled
 )
 
 var activityMap = map[Activity]Code{
@@ -169,6 +203,23 @@ var activityMap = map[Activity]Code{
 	UserLoggedInPeer:                          {"User logged in peer", "user.peer.login"},
 	PeerLoginExpired:                          {"Peer login expired", "peer.login.expire"},
 	DashboardLogin:                            {"Dashboard login", "dashboard.login"},
+	PostureCheckCreated:                       {"Posture check created", "posture.check.create"},
+	PostureCheckUpdated:                       {"Posture check updated", "posture.check.update"},
+	PostureCheckDeleted:                       {"Posture check deleted", "posture.check.delete"},
+	PeerApproved:                              {"Peer approved", "peer.approve"},
+	PeerRejected:                              {"Peer rejected", "peer.reject"},
+	AccountPeerApprovalEnabled:                {"Account peer approval enabled", "account.setting.peer.approval.enable"},
+	AccountPeerApprovalDisabled:               {"Account peer approval disabled", "account.setting.peer.approval.disable"},
+	WebhookCreated:                            {"Webhook created", "webhook.create"},
+	WebhookUpdated:                            {"Webhook updated", "webhook.update"},
+	WebhookDeleted:                            {"Webhook deleted", "webhook.delete"},
+	PeerIPUpdated:                             {"Peer IP updated", "peer.ip.update"},
+	AccountNetworkRangeUpdated:                {"Account network range updated", "account.setting.network.range.update"},
+	DNSZoneCreated:                            {"DNS zone created", "dns.zone.create"},
+	DNSZoneUpdated:                            {"DNS zone updated", "dns.zone.update"},
+	DNSZoneDeleted:                            {"DNS zone deleted", "dns.zone.delete"},
+	PeerZoneSearchDomainEnabled:               {"Peer DNS zone search domain enabled", "dns.setting.peer.zone.search.domain.enable"},
+	PeerZoneSearchDomainDisabled:              {"Peer DNS zone search domain disabled", "dns.setting.peer.zone.search.domain.disable"},
 }
 
 // StringCode returns a string code of the activity
@@ -186,3 +237,19 @@ func (a Activity) Message() string {
 	}
 	return "UNKNOWN_ACTIVITY"
 }
+
+// IsKnownCode returns true if the string code belongs to an activity
+func IsKnownCode(code string) bool {
+	_, ok := ParseCode(code)
+	return ok
+}
+
+// ParseCode returns the activity of the string code
+func ParseCode(code string) (Activity, bool) {
+	for a, activityCode := range activityMap {
+		if activityCode.code == code {
+			return a, true
+		}
+	}
+	return 0, false
+}
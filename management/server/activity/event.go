@@ -51,3 +51,10 @@ func (e *Event) Copy() *Event {
 		Meta:           meta,
 	}
 }
+
+// DeletedUser holds the details of a deleted user that are still referenced by the activity events
+type DeletedUser struct {
+	ID    string
+	Email string
+	Name  string
+}
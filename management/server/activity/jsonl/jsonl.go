@@ -0,0 +1,87 @@
+package jsonl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/netbirdio/netbird/management/server/activity"
+)
+
+const (
+	defaultMaxSizeMB  = 100
+	defaultMaxBackups = 10
+)
+
+// Config of the JSON lines sink
+type Config struct {
+	// Path of the file the events are appended to
+	Path string
+	// MaxSizeMB is the size the file is rotated at. Defaults to 100 megabytes
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files kept. Defaults to 10
+	MaxBackups int
+	// MaxAgeDays is the number of days the rotated files are kept. They are kept regardless of their age when 0
+	MaxAgeDays int
+	// Compress the rotated files with gzip
+	Compress bool
+}
+
+// Sink is the implementation of the activity.Sink interface appending the events as newline-delimited JSON encoded
+// activity.Record to a file rotated by size
+type Sink struct {
+	mu     sync.Mutex
+	logger *lumberjack.Logger
+}
+
+// New creates a JSON lines Sink writing to the configured path. The directory of the path is created if missing
+func New(config Config) (*Sink, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("events file path shouldn't be empty")
+	}
+	if config.MaxSizeMB <= 0 {
+		config.MaxSizeMB = defaultMaxSizeMB
+	}
+	if config.MaxBackups <= 0 {
+		config.MaxBackups = defaultMaxBackups
+	}
+
+	if err := os.MkdirAll(filepath.Dir(config.Path), 0750); err != nil {
+		return nil, err
+	}
+
+	return &Sink{
+		logger: &lumberjack.Logger{
+			Filename:   config.Path,
+			MaxSize:    config.MaxSizeMB,
+			MaxBackups: config.MaxBackups,
+			MaxAge:     config.MaxAgeDays,
+			Compress:   config.Compress,
+		},
+	}, nil
+}
+
+// Emit appends the event to the file
+func (s *Sink) Emit(event *activity.Event) error {
+	line, err := json.Marshal(activity.NewRecord(event))
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.logger.Write(line)
+	return err
+}
+
+// Close the file
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logger.Close()
+}
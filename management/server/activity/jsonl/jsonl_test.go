@@ -0,0 +1,91 @@
+package jsonl
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/management/server/activity"
+)
+
+func TestSink_Emit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "events.jsonl")
+	sink, err := New(Config{Path: path})
+	require.NoError(t, err)
+
+	events := []*activity.Event{
+		{
+			Timestamp:   time.Now().UTC(),
+			Activity:    activity.PeerAddedByUser,
+			ID:          1,
+			InitiatorID: "user",
+			TargetID:    "peer",
+			AccountID:   "account",
+			Meta:        map[string]any{"name": "peer\nname"},
+		},
+		{
+			Timestamp:   time.Now().UTC(),
+			Activity:    activity.UserJoined,
+			ID:          2,
+			InitiatorID: "user",
+			TargetID:    "user",
+			AccountID:   "account",
+		},
+	}
+	for _, event := range events {
+		require.NoError(t, sink.Emit(event))
+	}
+	require.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var records []activity.Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record activity.Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, records, len(events), "every event should be written on its own line")
+	for i, event := range events {
+		assert.Equal(t, *activity.NewRecord(event), records[i])
+	}
+}
+
+func TestSink_Rotate(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := New(Config{Path: filepath.Join(dir, "events.jsonl"), MaxSizeMB: 1, MaxBackups: 2})
+	require.NoError(t, err)
+
+	event := &activity.Event{
+		Timestamp: time.Now().UTC(),
+		Activity:  activity.PeerAddedByUser,
+		AccountID: "account",
+		Meta:      map[string]any{"padding": strings.Repeat("a", 10*1024)},
+	}
+	for i := 0; i < 250; i++ {
+		event.ID = uint64(i)
+		require.NoError(t, sink.Emit(event))
+	}
+	require.NoError(t, sink.Close())
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 3, "the file should be rotated once it exceeds the max size")
+}
+
+func TestNew_EmptyPath(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+}
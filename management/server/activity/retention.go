@@ -0,0 +1,76 @@
+package activity
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultPruneInterval is the interval the expired events are deleted at
+const defaultPruneInterval = time.Hour
+
+// Pruner deletes the events older than the retention period from a Store on start and then periodically
+type Pruner struct {
+	store     Store
+	retention time.Duration
+	interval  time.Duration
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	stopped bool
+}
+
+// NewPruner creates a Pruner of the store with the retention period
+func NewPruner(store Store, retention time.Duration) *Pruner {
+	return &Pruner{
+		store:     store,
+		retention: retention,
+		interval:  defaultPruneInterval,
+	}
+}
+
+// Start deletes the expired events and schedules the next deletions until Stop is called
+func (p *Pruner) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.timer != nil || p.stopped {
+		return
+	}
+	p.timer = time.AfterFunc(0, p.run)
+}
+
+// Stop the scheduled deletions
+func (p *Pruner) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stopped = true
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+}
+
+// Prune deletes the events older than the retention period
+func (p *Pruner) Prune() {
+	before := time.Now().UTC().Add(-p.retention)
+	deleted, err := p.store.DeleteBefore(before)
+	if err != nil {
+		log.Errorf("failed to delete the events older than %s: %v", before.Format(time.RFC3339), err)
+		return
+	}
+	if deleted > 0 {
+		log.Infof("deleted %d events older than %s", deleted, before.Format(time.RFC3339))
+	}
+}
+
+func (p *Pruner) run() {
+	p.Prune()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.stopped {
+		p.timer.Reset(p.interval)
+	}
+}
@@ -0,0 +1,35 @@
+package activity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruner(t *testing.T) {
+	store := &InMemoryEventStore{}
+	for _, age := range []time.Duration{48 * time.Hour, time.Minute} {
+		_, err := store.Save(&Event{Timestamp: time.Now().UTC().Add(-age), Activity: PeerAddedByUser, AccountID: "account"})
+		require.NoError(t, err)
+	}
+
+	pruner := NewPruner(store, 24*time.Hour)
+	pruner.interval = 10 * time.Millisecond
+	pruner.Start()
+	defer pruner.Stop()
+
+	assert.Eventually(t, func() bool {
+		events, err := store.Find("account", Filter{})
+		return err == nil && len(events) == 1
+	}, time.Second, 10*time.Millisecond, "the expired event should be deleted")
+
+	_, err := store.Save(&Event{Timestamp: time.Now().UTC().Add(-48 * time.Hour), Activity: PeerAddedByUser, AccountID: "account"})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		events, err := store.Find("account", Filter{})
+		return err == nil && len(events) == 1
+	}, time.Second, 10*time.Millisecond, "the events expired after the start should be deleted on the next run")
+}
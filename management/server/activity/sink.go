@@ -0,0 +1,191 @@
+package activity
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/management/server/telemetry"
+)
+
+// Record is the structured representation of an Event emitted to the external systems
+type Record struct {
+	// ID of the event
+	ID string `json:"id"`
+	// Timestamp of the event
+	Timestamp time.Time `json:"timestamp"`
+	// Activity is the human-readable description of the event
+	Activity string `json:"activity"`
+	// ActivityCode is the string code of the activity
+	ActivityCode string `json:"activity_code"`
+	// InitiatorID is the ID of the object that initiated the event, e.g. a user
+	InitiatorID string `json:"initiator_id"`
+	// TargetID is the ID of the object affected by the event, e.g. a peer
+	TargetID string `json:"target_id"`
+	// AccountID is the ID of the account where the event happened
+	AccountID string `json:"account_id"`
+	// Meta of the event, e.g. the name and IP of an added peer
+	Meta map[string]any `json:"meta"`
+}
+
+// NewRecord returns the structured representation of the event
+func NewRecord(event *Event) *Record {
+	meta := event.Meta
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	return &Record{
+		ID:           strconv.FormatUint(event.ID, 10),
+		Timestamp:    event.Timestamp,
+		Activity:     event.Activity.Message(),
+		ActivityCode: event.Activity.StringCode(),
+		InitiatorID:  event.InitiatorID,
+		TargetID:     event.TargetID,
+		AccountID:    event.AccountID,
+		Meta:         meta,
+	}
+}
+
+// Sink streams events to an external system, e.g. a SIEM
+type Sink interface {
+	// Emit sends the event to the sink
+	Emit(event *Event) error
+	// Close the sink flushing events if necessary
+	Close() error
+}
+
+const (
+	// SinkQueueSize is the number of events buffered for each sink before the Save waits for the sink
+	SinkQueueSize = 1000
+	// SinkQueueTimeout is how long the Save waits for room in the queue of a sink before the event is dropped
+	SinkQueueTimeout = 5 * time.Second
+)
+
+// StreamingStore is a Store that emits the saved events to sinks. Each sink has a buffered queue drained in order
+// by a single writer. When the queue of a slow sink is full, the Save waits for the sink up to SinkQueueTimeout.
+// Events still not fitting in the queue are dropped, every drop is logged and counted in the metrics.
+// Events that fail to be emitted are logged, neither fail the Save
+type StreamingStore struct {
+	Store
+	sinks        []*sinkQueue
+	metrics      telemetry.AppMetrics
+	queueTimeout time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// sinkQueue holds the events waiting to be emitted to a sink
+type sinkQueue struct {
+	sink    Sink
+	name    string
+	events  chan *Event
+	dropped atomic.Uint64
+}
+
+// NewStreamingStore returns a Store that saves the events in the store and emits them to the sinks
+func NewStreamingStore(store Store, metrics telemetry.AppMetrics, sinks ...Sink) *StreamingStore {
+	s := &StreamingStore{Store: store, metrics: metrics, queueTimeout: SinkQueueTimeout}
+	for _, sink := range sinks {
+		q := &sinkQueue{
+			sink:   sink,
+			name:   fmt.Sprintf("%T", sink),
+			events: make(chan *Event, SinkQueueSize),
+		}
+		s.sinks = append(s.sinks, q)
+
+		s.wg.Add(1)
+		go s.emit(q)
+	}
+	return s
+}
+
+// Save an event in the store and queue it for the sinks
+func (s *StreamingStore) Save(event *Event) (*Event, error) {
+	saved, err := s.Store.Save(event)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return saved, nil
+	}
+
+	for _, q := range s.sinks {
+		s.enqueue(q, saved)
+	}
+
+	return saved, nil
+}
+
+// enqueue queues the event for the sink, waiting for room in the queue up to the queue timeout
+func (s *StreamingStore) enqueue(q *sinkQueue, event *Event) {
+	select {
+	case q.events <- event:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(s.queueTimeout)
+	defer timer.Stop()
+	select {
+	case q.events <- event:
+	case <-timer.C:
+		s.drop(q, event)
+	}
+}
+
+// Dropped returns the number of events dropped by the sinks because their queue stayed full
+func (s *StreamingStore) Dropped() uint64 {
+	var dropped uint64
+	for _, q := range s.sinks {
+		dropped += q.dropped.Load()
+	}
+	return dropped
+}
+
+func (s *StreamingStore) drop(q *sinkQueue, event *Event) {
+	dropped := q.dropped.Add(1)
+	log.Errorf("the %s sink queue is full for %s, dropped event %d of account %s, %d events dropped so far",
+		q.name, s.queueTimeout, event.ID, event.AccountID, dropped)
+	if s.metrics != nil {
+		s.metrics.ActivityMetrics().CountDroppedEvent(q.name)
+	}
+}
+
+// emit sends the queued events to the sink in order until the queue is closed
+func (s *StreamingStore) emit(q *sinkQueue) {
+	defer s.wg.Done()
+	for event := range q.events {
+		if err := q.sink.Emit(event); err != nil {
+			log.Errorf("failed to emit event %d to the %s sink: %v", event.ID, q.name, err)
+		}
+	}
+}
+
+// Close drains the queued events to the sinks, closes the sinks and the store
+func (s *StreamingStore) Close() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		for _, q := range s.sinks {
+			close(q.events)
+		}
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+
+	for _, q := range s.sinks {
+		if err := q.sink.Close(); err != nil {
+			log.Errorf("failed to close the %s sink: %v", q.name, err)
+		}
+	}
+	return s.Store.Close()
+}
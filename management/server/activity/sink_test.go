@@ -0,0 +1,156 @@
+package activity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSink struct {
+	events []*Event
+	err    error
+	closed bool
+}
+
+func (s *testSink) Emit(event *Event) error {
+	s.events = append(s.events, event)
+	return s.err
+}
+
+func (s *testSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestStreamingStore(t *testing.T) {
+	failing := &testSink{err: errors.New("unreachable")}
+	sink := &testSink{}
+	store := NewStreamingStore(&InMemoryEventStore{}, nil, failing, sink)
+
+	var saved []*Event
+	for i := 0; i < 3; i++ {
+		event, err := store.Save(&Event{
+			Timestamp:   time.Now().UTC(),
+			Activity:    PeerAddedByUser,
+			InitiatorID: "user",
+			TargetID:    "peer",
+			AccountID:   "account",
+		})
+		require.NoError(t, err, "a failing sink shouldn't fail the save")
+		saved = append(saved, event)
+	}
+
+	events, err := store.Get("account", 0, 10, false)
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+
+	require.NoError(t, store.Close(), "closing the store should drain the queued events")
+	assert.Equal(t, saved, sink.events, "the stored events should be emitted in order")
+	assert.Len(t, failing.events, 3)
+	assert.True(t, sink.closed)
+	assert.True(t, failing.closed)
+}
+
+type blockingSink struct {
+	testSink
+	unblock chan struct{}
+}
+
+func (s *blockingSink) Emit(event *Event) error {
+	<-s.unblock
+	return s.testSink.Emit(event)
+}
+
+func TestStreamingStore_WaitsForTheSink(t *testing.T) {
+	blocked := &blockingSink{unblock: make(chan struct{})}
+	store := NewStreamingStore(&InMemoryEventStore{}, nil, blocked)
+
+	// the writer of the blocked sink holds one event, the queue holds SinkQueueSize more
+	total := SinkQueueSize + 2
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < total; i++ {
+			_, err := store.Save(&Event{Timestamp: time.Now().UTC(), Activity: PeerAddedByUser, AccountID: "account"})
+			assert.NoError(t, err)
+		}
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("the save should wait for a sink with a full queue")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(blocked.unblock)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the save should continue once the sink catches up")
+	}
+
+	require.NoError(t, store.Close())
+	assert.Zero(t, store.Dropped())
+	assert.Len(t, blocked.events, total, "no event should be dropped")
+	for i := 1; i < len(blocked.events); i++ {
+		assert.Less(t, blocked.events[i-1].ID, blocked.events[i].ID, "the events should be emitted in order")
+	}
+}
+
+func TestStreamingStore_DropsWhenQueueStaysFull(t *testing.T) {
+	blocked := &blockingSink{unblock: make(chan struct{})}
+	store := NewStreamingStore(&InMemoryEventStore{}, nil, blocked)
+	store.queueTimeout = 10 * time.Millisecond
+
+	total := SinkQueueSize + 10
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < total; i++ {
+			_, err := store.Save(&Event{Timestamp: time.Now().UTC(), Activity: PeerAddedByUser, AccountID: "account"})
+			assert.NoError(t, err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a blocked sink shouldn't block the save longer than the queue timeout")
+	}
+
+	// the writer of the blocked sink holds one event, the queue holds SinkQueueSize more
+	assert.GreaterOrEqual(t, store.Dropped(), uint64(total-SinkQueueSize-1))
+	assert.LessOrEqual(t, store.Dropped(), uint64(total-SinkQueueSize))
+
+	close(blocked.unblock)
+	require.NoError(t, store.Close())
+	assert.Len(t, blocked.events, total-int(store.Dropped()))
+	for i := 1; i < len(blocked.events); i++ {
+		assert.Less(t, blocked.events[i-1].ID, blocked.events[i].ID, "the events should be emitted in order")
+	}
+}
+
+func TestNewRecord(t *testing.T) {
+	record := NewRecord(&Event{
+		Timestamp:   time.Date(2023, 5, 5, 10, 4, 37, 0, time.UTC),
+		Activity:    PeerAddedByUser,
+		ID:          42,
+		InitiatorID: "user",
+		TargetID:    "peer",
+		AccountID:   "account",
+	})
+
+	assert.Equal(t, &Record{
+		ID:           "42",
+		Timestamp:    time.Date(2023, 5, 5, 10, 4, 37, 0, time.UTC),
+		Activity:     PeerAddedByUser.Message(),
+		ActivityCode: PeerAddedByUser.StringCode(),
+		InitiatorID:  "user",
+		TargetID:     "peer",
+		AccountID:    "account",
+		Meta:         map[string]any{},
+	}, record)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -25,6 +26,13 @@ const (
 		"meta TEXT," +
 		" target_id TEXT);"
 
+	// createIndexesQuery creates the indexes of the account events filters and of the deletion of the expired events
+	createIndexesQuery = `CREATE INDEX IF NOT EXISTS idx_events_account_timestamp ON events (account_id, timestamp, id);
+		CREATE INDEX IF NOT EXISTS idx_events_account_activity ON events (account_id, activity, timestamp);
+		CREATE INDEX IF NOT EXISTS idx_events_account_initiator ON events (account_id, initiator_id, timestamp);
+		CREATE INDEX IF NOT EXISTS idx_events_account_target ON events (account_id, target_id, timestamp);
+		CREATE INDEX IF NOT EXISTS idx_events_timestamp ON events (timestamp);`
+
 	creatTableDeletedUsersQuery = `CREATE TABLE IF NOT EXISTS deleted_users (id TEXT NOT NULL, email TEXT NOT NULL, name TEXT);`
 
 	selectDescQuery = `SELECT events.id, activity, timestamp, initiator_id, i.name as "initiator_name", i.email as "initiator_email", target_id, t.name as "target_name", t.email as "target_email", account_id, meta
@@ -41,6 +49,12 @@ const (
 		WHERE account_id = ? 
 		ORDER BY timestamp ASC LIMIT ? OFFSET ?;`
 
+	selectFilteredQuery = `SELECT events.id, activity, timestamp, initiator_id, i.name as "initiator_name", i.email as "initiator_email", target_id, t.name as "target_name", t.email as "target_email", account_id, meta
+		FROM events
+		LEFT JOIN deleted_users i ON events.initiator_id = i.id
+		LEFT JOIN deleted_users t ON events.target_id = t.id
+		WHERE `
+
 	insertQuery = "INSERT INTO events(activity, timestamp, initiator_id, target_id, account_id, meta) " +
 		"VALUES(?, ?, ?, ?, ?, ?)"
 
@@ -58,9 +72,14 @@ type Store struct {
 	deleteUserStmt      *sql.Stmt
 }
 
+// DatabaseFile returns the path of the events database in the data directory
+func DatabaseFile(dataDir string) string {
+	return filepath.Join(dataDir, eventSinkDB)
+}
+
 // NewSQLiteStore creates a new Store with an event table if not exists.
 func NewSQLiteStore(dataDir string, encryptionKey string) (*Store, error) {
-	dbFile := filepath.Join(dataDir, eventSinkDB)
+	dbFile := DatabaseFile(dataDir)
 	db, err := sql.Open("sqlite3", dbFile)
 	if err != nil {
 		return nil, err
@@ -78,6 +97,12 @@ func NewSQLiteStore(dataDir string, encryptionKey string) (*Store, error) {
 		return nil, err
 	}
 
+	_, err = db.Exec(createIndexesQuery)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
 	_, err = db.Exec(creatTableDeletedUsersQuery)
 	if err != nil {
 		_ = db.Close()
@@ -223,6 +248,77 @@ func (store *Store) Get(accountID string, offset, limit int, descending bool) ([
 	return store.processResult(result)
 }
 
+// Find returns the events of an account matching the filter ordered descending or ascending by a timestamp
+func (store *Store) Find(accountID string, filter activity.Filter) ([]*activity.Event, error) {
+	conditions := []string{"account_id = ?"}
+	args := []any{accountID}
+
+	if len(filter.Activities) > 0 {
+		placeholders := make([]string, len(filter.Activities))
+		for i, a := range filter.Activities {
+			placeholders[i] = "?"
+			args = append(args, a)
+		}
+		conditions = append(conditions, "activity IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.InitiatorID != "" {
+		conditions = append(conditions, "initiator_id = ?")
+		args = append(args, filter.InitiatorID)
+	}
+	if filter.TargetID != "" {
+		conditions = append(conditions, "target_id = ?")
+		args = append(args, filter.TargetID)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "timestamp < ?")
+		args = append(args, filter.To.UTC())
+	}
+
+	order := "ASC"
+	comparison := ">"
+	if filter.Descending {
+		order = "DESC"
+		comparison = "<"
+	}
+
+	if filter.Cursor != "" {
+		timestamp, id, err := activity.ParseCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, fmt.Sprintf("(timestamp %s ? OR (timestamp = ? AND events.id %s ?))", comparison, comparison))
+		args = append(args, timestamp, timestamp, id)
+	}
+
+	query := selectFilteredQuery + strings.Join(conditions, " AND ") +
+		fmt.Sprintf(" ORDER BY timestamp %s, events.id %s", order, order)
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	result, err := store.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer result.Close() //nolint
+	return store.processResult(result)
+}
+
+// DeleteBefore deletes the events of all the accounts older than the given time
+func (store *Store) DeleteBefore(before time.Time) (int64, error) {
+	result, err := store.db.Exec(`DELETE FROM events WHERE timestamp < ?;`, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 // Save an event in the SQLite events table end encrypt the "email" element in meta map
 func (store *Store) Save(event *activity.Event) (*activity.Event, error) {
 	var jsonMeta string
@@ -330,3 +426,117 @@ func updateDeletedUsersTable(db *sql.DB) error {
 	_, err = db.Exec(`ALTER TABLE deleted_users ADD COLUMN name TEXT;`)
 	return err
 }
+
+// Export returns all the events of an account ordered ascending by a timestamp, as they are persisted, without resolving
+// the details of the deleted users
+func (store *Store) Export(accountID string) ([]*activity.Event, error) {
+	rows, err := store.db.Query(`SELECT id, activity, timestamp, initiator_id, target_id, account_id, meta
+		FROM events WHERE account_id = ? ORDER BY timestamp ASC, id ASC;`, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint
+
+	events := make([]*activity.Event, 0)
+	for rows.Next() {
+		var id int64
+		var jsonMeta string
+		event := &activity.Event{}
+		err = rows.Scan(&id, &event.Activity, &event.Timestamp, &event.InitiatorID, &event.TargetID, &event.AccountID, &jsonMeta)
+		if err != nil {
+			return nil, err
+		}
+		event.ID = uint64(id)
+		if jsonMeta != "" {
+			err = json.Unmarshal([]byte(jsonMeta), &event.Meta)
+			if err != nil {
+				return nil, err
+			}
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// ExportDeletedUsers returns the decrypted details of all the deleted users
+func (store *Store) ExportDeletedUsers() ([]*activity.DeletedUser, error) {
+	rows, err := store.db.Query(`SELECT id, email, name FROM deleted_users;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint
+
+	users := make([]*activity.DeletedUser, 0)
+	for rows.Next() {
+		var id, encryptedEmail string
+		var encryptedName sql.NullString
+		err = rows.Scan(&id, &encryptedEmail, &encryptedName)
+		if err != nil {
+			return nil, err
+		}
+
+		user := &activity.DeletedUser{ID: id}
+		user.Email, err = store.fieldEncrypt.Decrypt(encryptedEmail)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt email address of deleted user %s: %v", id, err)
+		}
+		if encryptedName.Valid {
+			user.Name, err = store.fieldEncrypt.Decrypt(encryptedName.String)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt username of deleted user %s: %v", id, err)
+			}
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// Import stores events and deleted users exported with Export and ExportDeletedUsers in a single transaction.
+// Events keep their timestamps and meta but get new IDs. Deleted users are encrypted with the key of this store
+// and skipped when already present.
+func (store *Store) Import(events []*activity.Event, deletedUsers []*activity.DeletedUser) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, user := range deletedUsers {
+		var existing int
+		err = tx.QueryRow(`SELECT COUNT(*) FROM deleted_users WHERE id = ?;`, user.ID).Scan(&existing)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if existing > 0 {
+			continue
+		}
+
+		_, err = tx.Exec(insertDeleteUserQuery, user.ID, store.fieldEncrypt.Encrypt(user.Email), store.fieldEncrypt.Encrypt(user.Name))
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	for _, event := range events {
+		var jsonMeta string
+		if event.Meta != nil {
+			metaBytes, err := json.Marshal(event.Meta)
+			if err != nil {
+				_ = tx.Rollback()
+				return err
+			}
+			jsonMeta = string(metaBytes)
+		}
+
+		_, err = tx.Exec(insertQuery, event.Activity, event.Timestamp, event.InitiatorID, event.TargetID, event.AccountID, jsonMeta)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -54,3 +54,215 @@ func TestNewSQLiteStore(t *testing.T) {
 	assert.Len(t, result, 5)
 	assert.True(t, result[0].Timestamp.After(result[len(result)-1].Timestamp))
 }
+
+func TestStore_ExportImport(t *testing.T) {
+	key, _ := GenerateKey()
+	source, err := NewSQLiteStore(t.TempDir(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer source.Close() //nolint
+
+	accountID := "account_1"
+	_, err = source.Save(&activity.Event{
+		Timestamp:   time.Now().UTC(),
+		Activity:    activity.UserJoined,
+		InitiatorID: "user_1",
+		TargetID:    "user_2",
+		AccountID:   accountID,
+		Meta:        map[string]any{"email": "user2@example.com", "name": "User 2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := source.Export(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, events, 1)
+
+	deletedUsers, err := source.ExportDeletedUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, deletedUsers, 1)
+	assert.Equal(t, "user2@example.com", deletedUsers[0].Email)
+
+	otherKey, _ := GenerateKey()
+	target, err := NewSQLiteStore(t.TempDir(), otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close() //nolint
+
+	err = target.Import(events, deletedUsers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// importing twice must not duplicate the deleted users
+	err = target.Import(nil, deletedUsers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := target.Get(accountID, 0, 10, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "user2@example.com", result[0].Meta["email"])
+	assert.Equal(t, "User 2", result[0].Meta["username"])
+}
+
+func TestStore_Find(t *testing.T) {
+	key, _ := GenerateKey()
+	store, err := NewSQLiteStore(t.TempDir(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close() //nolint
+
+	accountID := "account_1"
+	start := time.Date(2023, 5, 5, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		event := &activity.Event{
+			// every pair of events shares a timestamp to paginate over equal timestamps
+			Timestamp:   start.Add(time.Duration(i/2) * time.Minute),
+			Activity:    activity.PeerAddedByUser,
+			InitiatorID: "user_" + fmt.Sprint(i%2),
+			TargetID:    "peer_" + fmt.Sprint(i),
+			AccountID:   accountID,
+		}
+		if i%3 == 0 {
+			event.Activity = activity.PeerRemovedByUser
+		}
+		_, err = store.Save(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = store.Save(&activity.Event{
+		Timestamp:   start,
+		Activity:    activity.PeerAddedByUser,
+		InitiatorID: "user_0",
+		AccountID:   "account_2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name    string
+		filter  activity.Filter
+		targets []string
+	}{
+		{
+			name:    "Activity",
+			filter:  activity.Filter{Activities: []activity.Activity{activity.PeerRemovedByUser}},
+			targets: []string{"peer_0", "peer_3", "peer_6", "peer_9"},
+		},
+		{
+			name:    "Initiator",
+			filter:  activity.Filter{InitiatorID: "user_1", Descending: true},
+			targets: []string{"peer_9", "peer_7", "peer_5", "peer_3", "peer_1"},
+		},
+		{
+			name:    "Target",
+			filter:  activity.Filter{TargetID: "peer_4"},
+			targets: []string{"peer_4"},
+		},
+		{
+			name:    "Time Window",
+			filter:  activity.Filter{From: start.Add(time.Minute), To: start.Add(3 * time.Minute)},
+			targets: []string{"peer_2", "peer_3", "peer_4", "peer_5"},
+		},
+		{
+			name:    "Limit",
+			filter:  activity.Filter{Limit: 3, Descending: true},
+			targets: []string{"peer_9", "peer_8", "peer_7"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := store.Find(accountID, tc.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targets := make([]string, 0, len(events))
+			for _, event := range events {
+				targets = append(targets, event.TargetID)
+			}
+			assert.Equal(t, tc.targets, targets)
+		})
+	}
+
+	for _, descending := range []bool{false, true} {
+		var targets []string
+		filter := activity.Filter{Limit: 3, Descending: descending}
+		for {
+			events, err := store.Find(accountID, filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, event := range events {
+				targets = append(targets, event.TargetID)
+			}
+			if len(events) < filter.Limit {
+				break
+			}
+			filter.Cursor = activity.NewCursor(events[len(events)-1])
+		}
+		assert.Len(t, targets, 10, "the pages should contain every event once")
+		if descending {
+			assert.Equal(t, "peer_9", targets[0])
+		} else {
+			assert.Equal(t, "peer_0", targets[0])
+		}
+	}
+
+	_, err = store.Find(accountID, activity.Filter{Cursor: "invalid"})
+	assert.Error(t, err)
+}
+
+func TestStore_DeleteBefore(t *testing.T) {
+	key, _ := GenerateKey()
+	store, err := NewSQLiteStore(t.TempDir(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close() //nolint
+
+	now := time.Now().UTC()
+	for i, accountID := range []string{"account_1", "account_2"} {
+		for _, timestamp := range []time.Time{now.Add(-48 * time.Hour), now} {
+			_, err = store.Save(&activity.Event{
+				Timestamp:   timestamp,
+				Activity:    activity.PeerAddedByUser,
+				InitiatorID: "user_" + fmt.Sprint(i),
+				AccountID:   accountID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	deleted, err := store.DeleteBefore(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(2), deleted)
+
+	for _, accountID := range []string{"account_1", "account_2"} {
+		events, err := store.Get(accountID, 0, 10, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, events, 1)
+		assert.Equal(t, now.UnixNano(), events[0].Timestamp.UnixNano())
+	}
+}
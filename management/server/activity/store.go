@@ -1,6 +1,14 @@
 package activity
 
-import "sync"
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
 
 // Store provides an interface to store or stream events.
 type Store interface {
@@ -8,10 +16,98 @@ type Store interface {
 	Save(event *Event) (*Event, error)
 	// Get returns "limit" number of events from the "offset" index ordered descending or ascending by a timestamp
 	Get(accountID string, offset, limit int, descending bool) ([]*Event, error)
+	// Find returns the events of an account matching the filter ordered descending or ascending by a timestamp
+	Find(accountID string, filter Filter) ([]*Event, error)
+	// DeleteBefore deletes the events of all the accounts older than the given time and returns their number
+	DeleteBefore(before time.Time) (int64, error)
 	// Close the sink flushing events if necessary
 	Close() error
 }
 
+// Filter of the events returned by Store.Find. Zero values of the fields don't filter the events
+type Filter struct {
+	// Activities the events should have one of
+	Activities []Activity
+	// InitiatorID the events should be initiated by
+	InitiatorID string
+	// TargetID the events should target
+	TargetID string
+	// From is the time the events should happen at or after
+	From time.Time
+	// To is the time the events should happen before
+	To time.Time
+	// Cursor of the last event of the previous page returned by NewCursor. Only the events after it in the requested
+	// order are returned
+	Cursor string
+	// Limit is the maximum number of events returned
+	Limit int
+	// Descending orders the events from the newest to the oldest
+	Descending bool
+}
+
+// NewCursor returns the opaque pagination cursor pointing to the event
+func NewCursor(event *Event) string {
+	position := strconv.FormatInt(event.Timestamp.UnixNano(), 10) + ":" + strconv.FormatUint(event.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(position))
+}
+
+// ParseCursor returns the timestamp and the ID of the event the cursor returned by NewCursor points to
+func ParseCursor(cursor string) (time.Time, uint64, error) {
+	position, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	timestamp, id, found := strings.Cut(string(position), ":")
+	if !found {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	nanos, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	eventID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	return time.Unix(0, nanos).UTC(), eventID, nil
+}
+
+// Matches returns true if the event matches the activities, initiator, target and time window of the filter. The
+// cursor and the limit aren't taken into account
+func (f *Filter) Matches(event *Event) bool {
+	if len(f.Activities) > 0 {
+		found := false
+		for _, a := range f.Activities {
+			if a == event.Activity {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.InitiatorID != "" && f.InitiatorID != event.InitiatorID {
+		return false
+	}
+	if f.TargetID != "" && f.TargetID != event.TargetID {
+		return false
+	}
+	if !f.From.IsZero() && event.Timestamp.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !event.Timestamp.Before(f.To) {
+		return false
+	}
+
+	return true
+}
+
 // InMemoryEventStore implements the Store interface storing data in-memory
 type InMemoryEventStore struct {
 	mu     sync.Mutex
@@ -45,6 +141,78 @@ func (store *InMemoryEventStore) Get(accountID string, offset, limit int, descen
 	return events, nil
 }
 
+// Find returns the events of an account matching the filter
+func (store *InMemoryEventStore) Find(accountID string, filter Filter) ([]*Event, error) {
+	var cursorTime time.Time
+	var cursorID uint64
+	if filter.Cursor != "" {
+		var err error
+		cursorTime, cursorID, err = ParseCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	events := make([]*Event, 0)
+	for _, event := range store.events {
+		if event.AccountID != accountID || !filter.Matches(event) {
+			continue
+		}
+		if filter.Cursor != "" && !isAfter(event, cursorTime, cursorID, filter.Descending) {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if filter.Descending {
+			return isAfter(events[i], events[j].Timestamp, events[j].ID, false)
+		}
+		return isAfter(events[j], events[i].Timestamp, events[i].ID, false)
+	})
+
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+
+	return events, nil
+}
+
+// DeleteBefore deletes the events older than the given time
+func (store *InMemoryEventStore) DeleteBefore(before time.Time) (int64, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	kept := make([]*Event, 0, len(store.events))
+	for _, event := range store.events {
+		if event.Timestamp.Before(before) {
+			continue
+		}
+		kept = append(kept, event)
+	}
+	deleted := int64(len(store.events) - len(kept))
+	store.events = kept
+
+	return deleted, nil
+}
+
+// isAfter returns true if the event comes after the position in the ascending or descending order of the events
+func isAfter(event *Event, timestamp time.Time, id uint64, descending bool) bool {
+	if event.Timestamp.Equal(timestamp) {
+		if descending {
+			return event.ID < id
+		}
+		return event.ID > id
+	}
+	if descending {
+		return event.Timestamp.Before(timestamp)
+	}
+	return event.Timestamp.After(timestamp)
+}
+
 // Close cleans up the event list
 func (store *InMemoryEventStore) Close() error {
 	store.mu.Lock()
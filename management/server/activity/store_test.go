@@ -0,0 +1,57 @@
+package activity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryEventStore_Find(t *testing.T) {
+	store := &InMemoryEventStore{}
+	start := time.Date(2023, 5, 5, 10, 0, 0, 0, time.UTC)
+	for i, a := range []Activity{PeerAddedByUser, PeerRemovedByUser, PeerAddedByUser, UserJoined} {
+		_, err := store.Save(&Event{
+			Timestamp:   start.Add(time.Duration(i) * time.Minute),
+			Activity:    a,
+			InitiatorID: "user",
+			AccountID:   "account",
+		})
+		require.NoError(t, err)
+	}
+
+	events, err := store.Find("account", Filter{Activities: []Activity{PeerAddedByUser}, Descending: true})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, uint64(2), events[0].ID)
+	assert.Equal(t, uint64(0), events[1].ID)
+
+	events, err = store.Find("account", Filter{From: start.Add(time.Minute), To: start.Add(3 * time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, uint64(1), events[0].ID)
+
+	events, err = store.Find("account", Filter{Cursor: NewCursor(events[0]), Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, uint64(2), events[0].ID)
+
+	deleted, err := store.DeleteBefore(start.Add(2 * time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
+
+func TestParseCursor(t *testing.T) {
+	event := &Event{Timestamp: time.Date(2023, 5, 5, 10, 0, 0, 42, time.UTC), ID: 7}
+
+	timestamp, id, err := ParseCursor(NewCursor(event))
+	require.NoError(t, err)
+	assert.Equal(t, event.Timestamp, timestamp)
+	assert.Equal(t, event.ID, id)
+
+	for _, cursor := range []string{"not base64!", "bm8gc2VwYXJhdG9y", "YTpi"} {
+		_, _, err = ParseCursor(cursor)
+		assert.Error(t, err, cursor)
+	}
+}
@@ -0,0 +1,230 @@
+package syslog
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/management/server/activity"
+)
+
+const (
+	// NetworkTCP sends the messages over plain TCP
+	NetworkTCP = "tcp"
+	// NetworkTLS sends the messages over TLS, see RFC 5425
+	NetworkTLS = "tls"
+
+	// severityNotice is the severity of the audit events: normal but significant condition
+	severityNotice = 5
+	// msgID identifies the type of the messages sent by the sink
+	msgID = "audit"
+	// sdID is the ID of the structured data element holding the event fields. 32473 is the private enterprise number
+	// reserved for documentation, see RFC 5612
+	sdID = "netbird@32473"
+	// bom marks the message as UTF-8 as required by RFC 5424
+	bom = "\xef\xbb\xbf"
+
+	defaultAppName  = "netbird"
+	defaultFacility = 13 // log audit
+	defaultTimeout  = 5 * time.Second
+)
+
+// facilities maps the facility names to their RFC 5424 codes
+var facilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7, "uucp": 8, "cron": 9,
+	"authpriv": 10, "ftp": 11, "ntp": 12, "audit": 13, "alert": 14, "clock": 15, "local0": 16, "local1": 17,
+	"local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// Config of the syslog sink
+type Config struct {
+	// Network is either NetworkTCP or NetworkTLS
+	Network string
+	// Address of the syslog server, host:port
+	Address string
+	// Facility name of the messages, e.g. audit or local0. Defaults to audit
+	Facility string
+	// AppName of the messages. Defaults to netbird
+	AppName string
+	// Hostname of the messages. Defaults to the hostname of the machine
+	Hostname string
+	// TLSConfig of the NetworkTLS connections
+	TLSConfig *tls.Config
+	// Timeout of connecting and writing a message
+	Timeout time.Duration
+}
+
+// Sink is the implementation of the activity.Sink interface sending RFC 5424 messages to a syslog server over a
+// TCP or TLS stream with octet counting framing, see RFC 6587. The connection is reestablished when a write fails
+type Sink struct {
+	config   Config
+	priority int
+	procID   string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// New creates a syslog Sink. An unreachable server doesn't fail the creation, the connection is retried on every event
+func New(config Config) (*Sink, error) {
+	if config.Network != NetworkTCP && config.Network != NetworkTLS {
+		return nil, fmt.Errorf("unsupported syslog network %q, supported values are %s and %s",
+			config.Network, NetworkTCP, NetworkTLS)
+	}
+	if config.Address == "" {
+		return nil, fmt.Errorf("syslog server address shouldn't be empty")
+	}
+
+	facility := defaultFacility
+	if config.Facility != "" {
+		code, ok := facilities[strings.ToLower(config.Facility)]
+		if !ok {
+			return nil, fmt.Errorf("unknown syslog facility %q", config.Facility)
+		}
+		facility = code
+	}
+	if config.AppName == "" {
+		config.AppName = defaultAppName
+	}
+	if config.Hostname == "" {
+		config.Hostname, _ = os.Hostname()
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	s := &Sink{
+		config:   config,
+		priority: facility*8 + severityNotice,
+		procID:   strconv.Itoa(os.Getpid()),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.connect(); err != nil {
+		log.Warnf("failed to connect to the syslog server %s, will retry on the next event: %v", config.Address, err)
+	}
+
+	return s, nil
+}
+
+// Emit sends the event to the syslog server
+func (s *Sink) Emit(event *activity.Event) error {
+	message, err := s.format(event)
+	if err != nil {
+		return err
+	}
+	frame := []byte(strconv.Itoa(len(message)) + " " + message)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// a write to a connection closed by the server may only fail on the next one, so retry once on a new connection
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if err = s.connect(); err != nil {
+				return err
+			}
+		}
+		if err = s.write(frame); err == nil {
+			return nil
+		}
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+
+	return err
+}
+
+// Close the connection to the syslog server
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *Sink) connect() error {
+	dialer := &net.Dialer{Timeout: s.config.Timeout}
+	var conn net.Conn
+	var err error
+	if s.config.Network == NetworkTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.config.Address, s.config.TLSConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", s.config.Address)
+	}
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	return nil
+}
+
+func (s *Sink) write(frame []byte) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.config.Timeout)); err != nil {
+		return err
+	}
+	_, err := s.conn.Write(frame)
+	return err
+}
+
+// format returns the RFC 5424 message of the event. The event fields are held by a structured data element and the
+// message is the JSON encoded activity.Record
+func (s *Sink) format(event *activity.Event) (string, error) {
+	record := activity.NewRecord(event)
+	body, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	var sd strings.Builder
+	sd.WriteString("[" + sdID)
+	for _, param := range [][2]string{
+		{"id", record.ID},
+		{"activity_code", record.ActivityCode},
+		{"initiator_id", record.InitiatorID},
+		{"target_id", record.TargetID},
+		{"account_id", record.AccountID},
+	} {
+		sd.WriteString(" " + param[0] + `="` + escapeParamValue(param[1]) + `"`)
+	}
+	sd.WriteString("]")
+
+	timestamp := event.Timestamp.UTC().Format("2006-01-02T15:04:05.000000Z07:00")
+	return fmt.Sprintf("<%d>1 %s %s %s %s %s %s %s%s", s.priority, timestamp, headerField(s.config.Hostname, 255),
+		headerField(s.config.AppName, 48), headerField(s.procID, 128), msgID, sd.String(), bom, body), nil
+}
+
+// headerField returns the header field as printable ASCII without spaces truncated to maxLen, or the nil value
+func headerField(value string, maxLen int) string {
+	field := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, value)
+	if len(field) > maxLen {
+		field = field[:maxLen]
+	}
+	if field == "" {
+		return "-"
+	}
+	return field
+}
+
+// escapeParamValue escapes the characters that have to be escaped in the structured data parameter values
+func escapeParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
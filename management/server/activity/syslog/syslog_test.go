@@ -0,0 +1,164 @@
+package syslog
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/management/server/activity"
+)
+
+func testEvent() *activity.Event {
+	return &activity.Event{
+		Timestamp:   time.Date(2023, 5, 5, 10, 4, 37, 473542000, time.UTC),
+		Activity:    activity.PeerAddedByUser,
+		ID:          10,
+		InitiatorID: "user",
+		TargetID:    "peer",
+		AccountID:   "account",
+		Meta:        map[string]any{"name": `peer "one"`, "ip": "100.64.0.1"},
+	}
+}
+
+// serve accepts connections on the listener and sends the octet counted messages it receives to the returned channel
+func serve(t *testing.T, listener net.Listener) chan string {
+	t.Helper()
+	messages := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					length, err := reader.ReadString(' ')
+					if err != nil {
+						return
+					}
+					size, err := strconv.Atoi(strings.TrimSpace(length))
+					if err != nil {
+						t.Errorf("invalid message length %q", length)
+						return
+					}
+					message := make([]byte, size)
+					if _, err = io.ReadFull(reader, message); err != nil {
+						return
+					}
+					messages <- string(message)
+				}
+			}()
+		}
+	}()
+	return messages
+}
+
+func receive(t *testing.T, messages chan string) string {
+	t.Helper()
+	select {
+	case message := <-messages:
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("the message should be received")
+		return ""
+	}
+}
+
+func TestSink_TCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	messages := serve(t, listener)
+
+	sink, err := New(Config{Network: NetworkTCP, Address: listener.Addr().String(), Hostname: "management 1"})
+	require.NoError(t, err)
+	defer sink.Close() //nolint
+
+	require.NoError(t, sink.Emit(testEvent()))
+	message := receive(t, messages)
+
+	header, body, found := strings.Cut(message, bom)
+	require.True(t, found, "the message should be marked as UTF-8")
+
+	expectedHeader := "<109>1 2023-05-05T10:04:37.473542Z management1 netbird " + sink.procID + " audit " +
+		`[netbird@32473 id="10" activity_code="user.peer.add" initiator_id="user" target_id="peer" account_id="account"] `
+	assert.Equal(t, expectedHeader, header)
+
+	var record activity.Record
+	require.NoError(t, json.Unmarshal([]byte(body), &record))
+	assert.Equal(t, *activity.NewRecord(testEvent()), record)
+}
+
+func TestSink_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: server.TLS.Certificates})
+	require.NoError(t, err)
+	defer listener.Close()
+	messages := serve(t, listener)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	sink, err := New(Config{
+		Network:   NetworkTLS,
+		Address:   listener.Addr().String(),
+		Facility:  "local0",
+		TLSConfig: &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12},
+	})
+	require.NoError(t, err)
+	defer sink.Close() //nolint
+
+	require.NoError(t, sink.Emit(testEvent()))
+	assert.True(t, strings.HasPrefix(receive(t, messages), "<133>1 "), "the priority should use the local0 facility")
+}
+
+func TestSink_Reconnect(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	sink, err := New(Config{Network: NetworkTCP, Address: address, Timeout: time.Second})
+	require.NoError(t, err, "an unreachable server should not fail the creation")
+	defer sink.Close() //nolint
+
+	assert.Error(t, sink.Emit(testEvent()), "the event should fail while the server is unreachable")
+
+	listener, err = net.Listen("tcp", address)
+	require.NoError(t, err)
+	defer listener.Close()
+	messages := serve(t, listener)
+
+	require.NoError(t, sink.Emit(testEvent()))
+	receive(t, messages)
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, err := New(Config{Network: "udp", Address: "127.0.0.1:514"})
+	assert.Error(t, err, "udp should not be supported")
+
+	_, err = New(Config{Network: NetworkTCP})
+	assert.Error(t, err, "the address should be required")
+
+	_, err = New(Config{Network: NetworkTCP, Address: "127.0.0.1:514", Facility: "unknown"})
+	assert.Error(t, err, "unknown facilities should be rejected")
+}
+
+func TestEscapeParamValue(t *testing.T) {
+	assert.Equal(t, `a\"b\\c\]d`, escapeParamValue(`a"b\c]d`))
+}
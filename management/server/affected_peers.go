@@ -0,0 +1,166 @@
+package server
+
+import (
+	nbdns "github.com/netbirdio/netbird/dns"
+	"github.com/netbirdio/netbird/route"
+)
+
+// add adds the keys of the other lookup maps
+func (m lookupMap) add(others ...lookupMap) {
+	for _, other := range others {
+		for key := range other {
+			m[key] = struct{}{}
+		}
+	}
+}
+
+// getGroupsPeers returns the IDs of the peers that belong to any of the groups
+func (a *Account) getGroupsPeers(groupIDs ...string) lookupMap {
+	peers := make(lookupMap)
+	for _, groupID := range groupIDs {
+		group := a.GetGroup(groupID)
+		if group == nil {
+			continue
+		}
+		for _, peerID := range group.Peers {
+			peers[peerID] = struct{}{}
+		}
+	}
+	return peers
+}
+
+// getPeersAffectedByPolicy returns the IDs of the peers whose network map depends on the policy,
+// i.e. the peers of the source and destination groups of its rules
+func (a *Account) getPeersAffectedByPolicy(policy *Policy) lookupMap {
+	peers := make(lookupMap)
+	if policy == nil {
+		return peers
+	}
+	for _, rule := range policy.Rules {
+		peers.add(a.getGroupsPeers(rule.Sources...), a.getGroupsPeers(rule.Destinations...))
+	}
+	return peers
+}
+
+// getPeersAffectedByPostureChecks returns the IDs of the peers whose network map depends on the posture checks
+func (a *Account) getPeersAffectedByPostureChecks(postureChecksID string) lookupMap {
+	peers := make(lookupMap)
+	for _, policy := range a.Policies {
+		for _, id := range policy.SourcePostureChecks {
+			if id == postureChecksID {
+				peers.add(a.getPeersAffectedByPolicy(policy))
+				break
+			}
+		}
+	}
+	return peers
+}
+
+// getPeersAffectedByRoute returns the IDs of the peers whose network map depends on the route:
+// its routing peers, the routing peers of the same HA group and the peers of its distribution groups
+func (a *Account) getPeersAffectedByRoute(r *route.Route) lookupMap {
+	peers := make(lookupMap)
+	if r == nil {
+		return peers
+	}
+	for _, other := range a.Routes {
+		if other.ID != r.ID && route.GetHAUniqueID(other) != route.GetHAUniqueID(r) {
+			continue
+		}
+		if other.Peer != "" {
+			peers[other.Peer] = struct{}{}
+		}
+		peers.add(a.getGroupsPeers(other.PeerGroups...))
+	}
+	if r.Peer != "" {
+		peers[r.Peer] = struct{}{}
+	}
+	peers.add(a.getGroupsPeers(r.PeerGroups...), a.getGroupsPeers(r.Groups...))
+	return peers
+}
+
+// getPeersAffectedByNameServerGroup returns the IDs of the peers whose DNS configuration depends on the nameserver group
+func (a *Account) getPeersAffectedByNameServerGroup(nsGroup *nbdns.NameServerGroup) lookupMap {
+	if nsGroup == nil {
+		return make(lookupMap)
+	}
+	return a.getGroupsPeers(nsGroup.Groups...)
+}
+
+// getPeersAffectedByDNSZone returns the IDs of the peers the custom DNS zone is distributed to
+func (a *Account) getPeersAffectedByDNSZone(zone *DNSZone) lookupMap {
+	if zone == nil {
+		return make(lookupMap)
+	}
+	return a.getGroupsPeers(zone.DistributionGroups...)
+}
+
+// getPeersAffectedByGroups returns the IDs of the peers affected by a change of the groups membership:
+// the members of the groups and the peers of the policies and routes that reference the groups.
+// Peers that are removed from the groups have to be added by the caller
+func (a *Account) getPeersAffectedByGroups(groupIDs ...string) lookupMap {
+	groups := make(lookupMap, len(groupIDs))
+	for _, groupID := range groupIDs {
+		groups[groupID] = struct{}{}
+	}
+
+	peers := a.getGroupsPeers(groupIDs...)
+	for _, policy := range a.Policies {
+		for _, rule := range policy.Rules {
+			if containsAny(groups, rule.Sources) || containsAny(groups, rule.Destinations) {
+				peers.add(a.getPeersAffectedByPolicy(policy))
+				break
+			}
+		}
+	}
+	for _, r := range a.Routes {
+		if containsAny(groups, r.PeerGroups) || containsAny(groups, r.Groups) {
+			peers.add(a.getPeersAffectedByRoute(r))
+		}
+	}
+	return peers
+}
+
+// getPeersAffectedByPeer returns the IDs of the peers affected by a change of the peer that doesn't modify the
+// DNS records of the account: the peer itself, the peers of the policies it belongs to and the peers of the routes
+// it is a routing peer of
+func (a *Account) getPeersAffectedByPeer(peerID string) lookupMap {
+	peers := lookupMap{peerID: struct{}{}}
+	peerGroups := a.getPeerGroups(peerID)
+
+	for _, policy := range a.Policies {
+		for _, rule := range policy.Rules {
+			if containsAny(peerGroups, rule.Sources) || containsAny(peerGroups, rule.Destinations) {
+				peers.add(a.getPeersAffectedByPolicy(policy))
+				break
+			}
+		}
+	}
+	for _, r := range a.Routes {
+		if r.Peer == peerID || containsAny(peerGroups, r.PeerGroups) {
+			peers.add(a.getPeersAffectedByRoute(r))
+		}
+	}
+	return peers
+}
+
+// getPeersAffectedByUserGroups returns the IDs of the peers affected by a change of the groups propagated to the
+// peers of the user
+func (a *Account) getPeersAffectedByUserGroups(userID string, groupIDs ...string) lookupMap {
+	peers := a.getPeersAffectedByGroups(groupIDs...)
+	for _, peer := range a.Peers {
+		if peer.UserID == userID {
+			peers[peer.ID] = struct{}{}
+		}
+	}
+	return peers
+}
+
+func containsAny(m lookupMap, keys []string) bool {
+	for _, key := range keys {
+		if _, ok := m[key]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/route"
+)
+
+// newSyntheticAccount returns an account with the given number of peers split into groups of groupSize peers.
+// The peers of each group are connected to the peers of the next group by a policy, and the first peer of each
+// group routes a network distributed to its group
+func newSyntheticAccount(peers, groupSize int) *Account {
+	account := newAccountWithId("account", "user", "netbird.io")
+	account.Policies = nil
+
+	allGroup, _ := account.GetGroupAll()
+	groups := (peers + groupSize - 1) / groupSize
+	for g := 0; g < groups; g++ {
+		groupID := fmt.Sprintf("group%d", g)
+		account.Groups[groupID] = &Group{ID: groupID, Name: groupID}
+	}
+
+	for i := 0; i < peers; i++ {
+		peer := &Peer{
+			ID:       fmt.Sprintf("peer%d", i),
+			Key:      fmt.Sprintf("key%d", i),
+			IP:       net.IPv4(100, 64+byte(i>>16), byte(i>>8), byte(i)),
+			Name:     fmt.Sprintf("peer%d", i),
+			DNSLabel: fmt.Sprintf("peer%d", i),
+			UserID:   "user",
+			Status:   &PeerStatus{Connected: true, LastSeen: time.Now().UTC()},
+		}
+		account.Peers[peer.ID] = peer
+		allGroup.Peers = append(allGroup.Peers, peer.ID)
+		group := account.Groups[fmt.Sprintf("group%d", i/groupSize)]
+		group.Peers = append(group.Peers, peer.ID)
+	}
+
+	for g := 0; g < groups; g++ {
+		groupID := fmt.Sprintf("group%d", g)
+		account.Policies = append(account.Policies, &Policy{
+			ID:      fmt.Sprintf("policy%d", g),
+			Name:    fmt.Sprintf("policy%d", g),
+			Enabled: true,
+			Rules: []*PolicyRule{{
+				ID:            fmt.Sprintf("rule%d", g),
+				Enabled:       true,
+				Action:        PolicyTrafficActionAccept,
+				Protocol:      PolicyRuleProtocolALL,
+				Bidirectional: true,
+				Sources:       []string{groupID},
+				Destinations:  []string{fmt.Sprintf("group%d", (g+1)%groups)},
+			}},
+		})
+		account.Routes[fmt.Sprintf("route%d", g)] = &route.Route{
+			ID:          fmt.Sprintf("route%d", g),
+			Network:     netip.MustParsePrefix(fmt.Sprintf("10.%d.%d.0/24", g>>8, g&0xff)),
+			NetworkType: route.IPv4Network,
+			NetID:       fmt.Sprintf("net%d", g),
+			Peer:        account.Groups[groupID].Peers[0],
+			Metric:      route.MaxMetric,
+			Enabled:     true,
+			Groups:      []string{groupID},
+		}
+	}
+
+	return account
+}
+
+func sortedKeys(m lookupMap) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestAccount_GetAffectedPeers(t *testing.T) {
+	// 4 groups of 2 peers: group0 <-> group1 <-> group2 <-> group3 <-> group0
+	account := newSyntheticAccount(8, 2)
+
+	tt := []struct {
+		name     string
+		affected lookupMap
+		expected []string
+	}{
+		{
+			name:     "policy",
+			affected: account.getPeersAffectedByPolicy(account.getPolicy("policy1")),
+			expected: []string{"peer2", "peer3", "peer4", "peer5"},
+		},
+		{
+			name:     "route",
+			affected: account.getPeersAffectedByRoute(account.Routes["route2"]),
+			expected: []string{"peer4", "peer5"},
+		},
+		{
+			name:     "groups",
+			affected: account.getPeersAffectedByGroups("group0"),
+			expected: []string{"peer0", "peer1", "peer2", "peer3", "peer6", "peer7"},
+		},
+		{
+			name:     "peer",
+			affected: account.getPeersAffectedByPeer("peer5"),
+			expected: []string{"peer2", "peer3", "peer4", "peer5", "peer6", "peer7"},
+		},
+		{
+			name:     "unknown policy",
+			affected: account.getPeersAffectedByPolicy(account.getPolicy("unknown")),
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, sortedKeys(tc.affected))
+		})
+	}
+}
+
+func TestAccount_GetPeersAffectedByRoute_HAGroup(t *testing.T) {
+	account := newSyntheticAccount(8, 2)
+
+	haRoute := account.Routes["route0"].Copy()
+	haRoute.ID = "route0-ha"
+	haRoute.Peer = "peer7"
+	account.Routes[haRoute.ID] = haRoute
+
+	assert.Equal(t, []string{"peer0", "peer1", "peer7"}, sortedKeys(account.getPeersAffectedByRoute(account.Routes["route0"])),
+		"the routing peers of the same HA group should be affected")
+}
+
+func TestDefaultAccountManager_UpdateAffectedPeersOnly(t *testing.T) {
+	manager, err := createManager(t)
+	require.NoError(t, err)
+
+	account := newSyntheticAccount(8, 2)
+	require.NoError(t, manager.Store.SaveAccount(account))
+
+	updates := make(map[string]chan *UpdateMessage)
+	for peerID := range account.Peers {
+		updates[peerID] = manager.peersUpdateManager.CreateChannel(peerID)
+	}
+
+	updatedPeers := func() []string {
+		var peers []string
+		for peerID, ch := range updates {
+			select {
+			case <-ch:
+				peers = append(peers, peerID)
+			default:
+			}
+		}
+		sort.Strings(peers)
+		return peers
+	}
+
+	r := account.Routes["route2"].Copy()
+	r.Description = "updated"
+	require.NoError(t, manager.SaveRoute(account.Id, "user", r))
+	assert.Equal(t, []string{"peer4", "peer5"}, updatedPeers())
+
+	group := account.Groups["group3"].Copy()
+	group.Peers = group.Peers[:1]
+	require.NoError(t, manager.SaveGroup(account.Id, "user", group))
+	assert.Equal(t, []string{"peer0", "peer1", "peer4", "peer5", "peer6", "peer7"}, updatedPeers(),
+		"the removed peer and the peers connected to the group should be updated")
+
+	_, err = manager.UpdatePeer(account.Id, "user", &Peer{ID: "peer0", Name: "renamed"})
+	require.NoError(t, err)
+	assert.Len(t, updatedPeers(), len(account.Peers), "all the peers should be updated when a peer's DNS label changes")
+}
+
+func benchmarkUpdatePeers(b *testing.B, peers int, update func(am *DefaultAccountManager, account *Account)) {
+	b.Helper()
+	am := &DefaultAccountManager{peersUpdateManager: NewPeersUpdateManager(nil), dnsDomain: "netbird.cloud"}
+	account := newSyntheticAccount(peers, 10)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		update(am, account)
+	}
+}
+
+// BenchmarkUpdatePeers compares updating all the peers of large accounts with updating only the peers affected by
+// the edition of a route, a policy, a group or a peer
+func BenchmarkUpdatePeers(b *testing.B) {
+	for _, peers := range []int{500, 2000} {
+		b.Run(fmt.Sprintf("all/%d", peers), func(b *testing.B) {
+			benchmarkUpdatePeers(b, peers, func(am *DefaultAccountManager, account *Account) {
+				am.updateAccountPeers(account)
+			})
+		})
+		b.Run(fmt.Sprintf("route/%d", peers), func(b *testing.B) {
+			benchmarkUpdatePeers(b, peers, func(am *DefaultAccountManager, account *Account) {
+				am.updateAffectedPeers(account, account.getPeersAffectedByRoute(account.Routes["route1"]))
+			})
+		})
+		b.Run(fmt.Sprintf("policy/%d", peers), func(b *testing.B) {
+			benchmarkUpdatePeers(b, peers, func(am *DefaultAccountManager, account *Account) {
+				am.updateAffectedPeers(account, account.getPeersAffectedByPolicy(account.getPolicy("policy1")))
+			})
+		})
+		b.Run(fmt.Sprintf("group/%d", peers), func(b *testing.B) {
+			benchmarkUpdatePeers(b, peers, func(am *DefaultAccountManager, account *Account) {
+				am.updateAffectedPeers(account, account.getPeersAffectedByGroups("group1"))
+			})
+		})
+		b.Run(fmt.Sprintf("peer/%d", peers), func(b *testing.B) {
+			benchmarkUpdatePeers(b, peers, func(am *DefaultAccountManager, account *Account) {
+				am.updateAffectedPeers(account, account.getPeersAffectedByPeer("peer15"))
+			})
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package server
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/netbirdio/netbird/management/server/activity"
+	"github.com/netbirdio/netbird/version"
+)
+
+// ArchiveVersion is the version of the export archive format produced by this release
+const ArchiveVersion = 1
+
+// Archive is a store-agnostic, portable snapshot of the management data used for backups and migrations
+type Archive struct {
+	// Version of the archive format
+	Version int
+	// CreatedAt is the time the archive was created
+	CreatedAt time.Time
+	// NetbirdVersion is the version of the management service that created the archive
+	NetbirdVersion string
+	// StoreEngine is the engine of the store the archive was exported from
+	StoreEngine StoreEngine
+	// InstallationID of the exported management service
+	InstallationID string
+	// Accounts holds all the accounts of the exported store
+	Accounts []*Account
+	// Events holds the activity events of the exported accounts
+	Events []*activity.Event
+	// DeletedUsers holds the details of the deleted users referenced by the activity events
+	DeletedUsers []*activity.DeletedUser
+}
+
+// NewArchive creates an Archive holding all the accounts and the installation ID of the store
+func NewArchive(store Store) *Archive {
+	return &Archive{
+		Version:        ArchiveVersion,
+		CreatedAt:      time.Now().UTC(),
+		NetbirdVersion: version.NetbirdVersion(),
+		StoreEngine:    store.GetStoreEngine(),
+		InstallationID: store.GetInstallationID(),
+		Accounts:       store.GetAllAccounts(),
+		Events:         make([]*activity.Event, 0),
+		DeletedUsers:   make([]*activity.DeletedUser, 0),
+	}
+}
+
+// WriteArchive writes a gzip compressed JSON encoded archive
+func WriteArchive(w io.Writer, archive *Archive) error {
+	gz := gzip.NewWriter(w)
+	err := json.NewEncoder(gz).Encode(archive)
+	if err != nil {
+		_ = gz.Close()
+		return err
+	}
+
+	return gz.Close()
+}
+
+// ReadArchive reads a gzip compressed JSON encoded archive
+func ReadArchive(r io.Reader) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening archive: %v", err)
+	}
+	defer gz.Close()
+
+	archive := &Archive{}
+	err = json.NewDecoder(gz).Decode(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding archive: %v", err)
+	}
+
+	return archive, nil
+}
+
+// Validate checks that the archive can be imported by this release and that every account in it is consistent
+func (a *Archive) Validate() error {
+	if a.Version < 1 || a.Version > ArchiveVersion {
+		return fmt.Errorf("unsupported archive version %d, supported up to %d", a.Version, ArchiveVersion)
+	}
+
+	accounts := make(map[string]struct{}, len(a.Accounts))
+	peers := make(map[string]string)
+	peerKeys := make(map[string]string)
+	users := make(map[string]string)
+	for _, account := range a.Accounts {
+		if account == nil || account.Id == "" {
+			return fmt.Errorf("archive contains an account without an ID")
+		}
+		if _, ok := accounts[account.Id]; ok {
+			return fmt.Errorf("account %s is present more than once", account.Id)
+		}
+		accounts[account.Id] = struct{}{}
+
+		err := account.validateReferences()
+		if err != nil {
+			return fmt.Errorf("account %s: %v", account.Id, err)
+		}
+
+		for _, peer := range account.Peers {
+			if owner, ok := peers[peer.ID]; ok {
+				return fmt.Errorf("peer %s belongs to accounts %s and %s", peer.ID, owner, account.Id)
+			}
+			peers[peer.ID] = account.Id
+			if owner, ok := peerKeys[peer.Key]; ok {
+				return fmt.Errorf("peer key %s is used in accounts %s and %s", peer.Key, owner, account.Id)
+			}
+			peerKeys[peer.Key] = account.Id
+		}
+		for _, user := range account.Users {
+			if owner, ok := users[user.Id]; ok {
+				return fmt.Errorf("user %s belongs to accounts %s and %s", user.Id, owner, account.Id)
+			}
+			users[user.Id] = account.Id
+		}
+	}
+
+	for _, event := range a.Events {
+		if _, ok := accounts[event.AccountID]; !ok {
+			return fmt.Errorf("event %d references account %s that is not part of the archive", event.ID, event.AccountID)
+		}
+	}
+
+	return nil
+}
+
+// validateReferences checks that all the objects referenced by the account resources exist in the account
+func (a *Account) validateReferences() error {
+	if a.Network == nil {
+		return fmt.Errorf("network is missing")
+	}
+
+	for id, peer := range a.Peers {
+		if peer == nil || peer.ID != id {
+			return fmt.Errorf("peer %s is stored under a mismatching ID", id)
+		}
+	}
+
+	for id, user := range a.Users {
+		if user == nil || user.Id != id {
+			return fmt.Errorf("user %s is stored under a mismatching ID", id)
+		}
+		for _, groupID := range user.AutoGroups {
+			if _, ok := a.Groups[groupID]; !ok {
+				return fmt.Errorf("user %s references group %s that doesn't exist", id, groupID)
+			}
+		}
+	}
+
+	for key, setupKey := range a.SetupKeys {
+		for _, groupID := range setupKey.AutoGroups {
+			if _, ok := a.Groups[groupID]; !ok {
+				return fmt.Errorf("setup key %s references group %s that doesn't exist", setupKey.Name, groupID)
+			}
+		}
+		if setupKey.Key != key {
+			return fmt.Errorf("setup key %s is stored under a mismatching key", setupKey.Name)
+		}
+	}
+
+	for id, group := range a.Groups {
+		for _, peerID := range group.Peers {
+			if _, ok := a.Peers[peerID]; !ok {
+				return fmt.Errorf("group %s references peer %s that doesn't exist", id, peerID)
+			}
+		}
+	}
+
+	for id, r := range a.Routes {
+		if r.Peer != "" {
+			if _, ok := a.Peers[r.Peer]; !ok {
+				return fmt.Errorf("route %s references peer %s that doesn't exist", id, r.Peer)
+			}
+		}
+		for _, groupID := range r.PeerGroups {
+			if _, ok := a.Groups[groupID]; !ok {
+				return fmt.Errorf("route %s references peer group %s that doesn't exist", id, groupID)
+			}
+		}
+		for _, groupID := range r.Groups {
+			if _, ok := a.Groups[groupID]; !ok {
+				return fmt.Errorf("route %s references distribution group %s that doesn't exist", id, groupID)
+			}
+		}
+	}
+
+	for _, policy := range a.Policies {
+		for _, postureChecksID := range policy.SourcePostureChecks {
+			if a.getPostureChecks(postureChecksID) == nil {
+				return fmt.Errorf("policy %s references posture checks %s that don't exist", policy.ID, postureChecksID)
+			}
+		}
+		for _, rule := range policy.Rules {
+			for _, groupID := range rule.Sources {
+				if _, ok := a.Groups[groupID]; !ok {
+					return fmt.Errorf("policy %s references source group %s that doesn't exist", policy.ID, groupID)
+				}
+			}
+			for _, groupID := range rule.Destinations {
+				if _, ok := a.Groups[groupID]; !ok {
+					return fmt.Errorf("policy %s references destination group %s that doesn't exist", policy.ID, groupID)
+				}
+			}
+		}
+	}
+
+	for id, nsGroup := range a.NameServerGroups {
+		for _, groupID := range nsGroup.Groups {
+			if _, ok := a.Groups[groupID]; !ok {
+				return fmt.Errorf("nameserver group %s references group %s that doesn't exist", id, groupID)
+			}
+		}
+	}
+
+	for id, zone := range a.DNSZones {
+		if zone == nil || zone.ID != id {
+			return fmt.Errorf("DNS zone %s is stored under a mismatching ID", id)
+		}
+		for _, groupID := range zone.DistributionGroups {
+			if _, ok := a.Groups[groupID]; !ok {
+				return fmt.Errorf("DNS zone %s references distribution group %s that doesn't exist", id, groupID)
+			}
+		}
+	}
+
+	for _, hook := range a.Webhooks {
+		for _, event := range hook.Events {
+			if !activity.IsKnownCode(event) {
+				return fmt.Errorf("webhook %s references event type %s that doesn't exist", hook.ID, event)
+			}
+		}
+	}
+
+	if a.DNSSettings != nil {
+		for _, groupID := range a.DNSSettings.DisabledManagementGroups {
+			if _, ok := a.Groups[groupID]; !ok {
+				return fmt.Errorf("DNS settings reference group %s that doesn't exist", groupID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ImportArchive validates the archive and writes its accounts and installation ID into the store.
+// Nothing is written when the archive is invalid or any of its accounts already exists in the store.
+func ImportArchive(store Store, archive *Archive) error {
+	err := archive.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid archive: %v", err)
+	}
+
+	for _, account := range archive.Accounts {
+		if _, err := store.GetAccount(account.Id); err == nil {
+			return fmt.Errorf("account %s already exists in the store", account.Id)
+		}
+		for _, user := range account.Users {
+			if _, err := store.GetAccountByUser(user.Id); err == nil {
+				return fmt.Errorf("user %s of account %s already exists in the store", user.Id, account.Id)
+			}
+		}
+		for _, peer := range account.Peers {
+			if _, err := store.GetAccountByPeerPubKey(peer.Key); err == nil {
+				return fmt.Errorf("peer %s of account %s already exists in the store", peer.ID, account.Id)
+			}
+		}
+	}
+
+	if store.GetInstallationID() == "" && archive.InstallationID != "" {
+		err = store.SaveInstallationID(archive.InstallationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, account := range archive.Accounts {
+		err = store.SaveAccount(account)
+		if err != nil {
+			return fmt.Errorf("failed saving account %s: %v", account.Id, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"bytes"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/management/server/activity"
+	"github.com/netbirdio/netbird/route"
+)
+
+func newArchiveTestAccount(accountID, userID string) *Account {
+	account := newAccountWithId(accountID, userID, "")
+	account.Peers[accountID+"-peer"] = &Peer{
+		ID:     accountID + "-peer",
+		Key:    accountID + "-peer-key",
+		IP:     net.IP{100, 64, 0, 1},
+		Status: &PeerStatus{},
+	}
+	account.Groups["group1"] = &Group{ID: "group1", Name: "group1", Peers: []string{accountID + "-peer"}}
+	account.Routes["route1"] = &route.Route{
+		ID:         "route1",
+		Network:    netip.MustParsePrefix("10.0.0.0/24"),
+		PeerGroups: []string{"group1"},
+		Groups:     []string{"group1"},
+		Enabled:    true,
+	}
+	account.Policies = append(account.Policies, &Policy{
+		ID:      "policy1",
+		Name:    "policy1",
+		Enabled: true,
+		Rules: []*PolicyRule{{
+			ID:           "policy1",
+			Enabled:      true,
+			Sources:      []string{"group1"},
+			Destinations: []string{"group1"},
+		}},
+	})
+	return account
+}
+
+func TestArchive_WriteRead(t *testing.T) {
+	store := newStore(t)
+	require.NoError(t, store.SaveAccount(newArchiveTestAccount("account1", "user1")))
+	require.NoError(t, store.SaveInstallationID("installation"))
+
+	archive := NewArchive(store)
+	archive.Events = append(archive.Events, &activity.Event{
+		Timestamp: time.Now().UTC(),
+		Activity:  activity.PeerAddedByUser,
+		AccountID: "account1",
+		Meta:      map[string]any{},
+	})
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteArchive(buf, archive))
+
+	restored, err := ReadArchive(buf)
+	require.NoError(t, err)
+	require.NoError(t, restored.Validate())
+
+	assert.Equal(t, ArchiveVersion, restored.Version)
+	assert.Equal(t, "installation", restored.InstallationID)
+	assert.Equal(t, FileStoreEngine, restored.StoreEngine)
+	require.Len(t, restored.Accounts, 1)
+	assert.Len(t, restored.Accounts[0].Peers, 1)
+	assert.Len(t, restored.Events, 1)
+}
+
+func TestArchive_Validate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mutate func(archive *Archive)
+	}{
+		{
+			name: "unsupported version",
+			mutate: func(archive *Archive) {
+				archive.Version = ArchiveVersion + 1
+			},
+		},
+		{
+			name: "group references missing peer",
+			mutate: func(archive *Archive) {
+				archive.Accounts[0].Groups["group1"].Peers = append(archive.Accounts[0].Groups["group1"].Peers, "missing")
+			},
+		},
+		{
+			name: "route references missing peer group",
+			mutate: func(archive *Archive) {
+				archive.Accounts[0].Routes["route1"].PeerGroups = []string{"missing"}
+			},
+		},
+		{
+			name: "policy references missing group",
+			mutate: func(archive *Archive) {
+				archive.Accounts[0].Policies[0].Rules[0].Destinations = []string{"missing"}
+			},
+		},
+		{
+			name: "DNS zone references missing distribution group",
+			mutate: func(archive *Archive) {
+				archive.Accounts[0].DNSZones["zone1"] = &DNSZone{
+					ID:                 "zone1",
+					Name:               "example.internal",
+					DistributionGroups: []string{"missing"},
+				}
+			},
+		},
+		{
+			name: "webhook references unknown event type",
+			mutate: func(archive *Archive) {
+				archive.Accounts[0].Webhooks = append(archive.Accounts[0].Webhooks, &Webhook{
+					ID:     "hook1",
+					Events: []string{"missing.event"},
+				})
+			},
+		},
+		{
+			name: "duplicated account",
+			mutate: func(archive *Archive) {
+				archive.Accounts = append(archive.Accounts, archive.Accounts[0])
+			},
+		},
+		{
+			name: "event of unknown account",
+			mutate: func(archive *Archive) {
+				archive.Events = append(archive.Events, &activity.Event{AccountID: "missing"})
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			archive := &Archive{
+				Version:  ArchiveVersion,
+				Accounts: []*Account{newArchiveTestAccount("account1", "user1")},
+			}
+			require.NoError(t, archive.Validate())
+
+			testCase.mutate(archive)
+			assert.Error(t, archive.Validate())
+		})
+	}
+}
+
+func TestImportArchive(t *testing.T) {
+	source := newStore(t)
+	require.NoError(t, source.SaveAccount(newArchiveTestAccount("account1", "user1")))
+	require.NoError(t, source.SaveInstallationID("installation"))
+	archive := NewArchive(source)
+
+	target := newSqliteStore(t)
+
+	invalid := &Archive{Version: ArchiveVersion, Accounts: []*Account{newArchiveTestAccount("account2", "user2")}}
+	invalid.Accounts[0].Groups["group1"].Peers = []string{"missing"}
+	require.Error(t, ImportArchive(target, invalid))
+	assert.Len(t, target.GetAllAccounts(), 0, "nothing should be written for an invalid archive")
+
+	require.NoError(t, ImportArchive(target, archive))
+	assert.Equal(t, "installation", target.GetInstallationID())
+
+	account, err := target.GetAccount("account1")
+	require.NoError(t, err)
+	assert.Len(t, account.Peers, 1)
+	assert.Len(t, account.Routes, 1)
+	assert.Len(t, account.Policies, 2)
+
+	require.Error(t, ImportArchive(target, archive), "importing an existing account should fail")
+}
@@ -45,6 +45,17 @@ type Config struct {
 	DeviceAuthorizationFlow *DeviceAuthorizationFlow
 
 	PKCEAuthorizationFlow *PKCEAuthorizationFlow
+
+	StoreConfig StoreConfig
+
+	HAConfig *HAConfig
+
+	WebhooksConfig *WebhooksConfig
+
+	EventSinksConfig *EventSinksConfig
+
+	// EventsRetention is the period after which the activity events are deleted. Events are kept forever when 0
+	EventsRetention util.Duration
 }
 
 // GetAuthAudiences returns the audience from the http config and device authorization flow config
@@ -87,6 +98,83 @@ type HttpServerConfig struct {
 	IdpSignKeyRefreshEnabled bool
 }
 
+// StoreConfig contains Store configuration
+type StoreConfig struct {
+	// Engine is the Store implementation to use, jsonfile (default), sqlite or postgres. The connection string of the
+	// postgres engine is read from the NETBIRD_STORE_ENGINE_POSTGRES_DSN environment variable
+	Engine StoreEngine
+}
+
+// HAConfig contains the configuration of the management replicas sharing the same store
+type HAConfig struct {
+	// Enabled makes the replica coordinate with the other replicas through the store with distributed locks and
+	// account change notifications. Requires the postgres store engine with a database shared by all the replicas
+	Enabled bool
+	// ReplicaID identifies the replica, a random one is generated when empty
+	ReplicaID string
+	// LeaderCheckInterval is the interval of checking that the connection holding the leader lock is alive. The jobs
+	// of the leader are stopped when it isn't
+	LeaderCheckInterval util.Duration
+}
+
+// WebhooksConfig contains the delivery settings of the account webhooks. Zero values are replaced with the defaults
+type WebhooksConfig struct {
+	// MaxAttempts is the number of delivery attempts made before a delivery is stored as a dead letter
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt. The delay doubles on every attempt
+	InitialBackoff util.Duration
+	// MaxBackoff is the maximum delay between two attempts
+	MaxBackoff util.Duration
+	// Timeout of a single delivery attempt
+	Timeout util.Duration
+	// AllowPrivateDestinations allows the webhook endpoints on loopback, private and link-local addresses.
+	// Only meant for self-hosted setups with the endpoints in the same private network
+	AllowPrivateDestinations bool
+}
+
+// EventSinksConfig contains the external systems the activity events are streamed to in addition to the events store
+type EventSinksConfig struct {
+	Syslog    *SyslogSinkConfig
+	JSONLines *JSONLinesSinkConfig
+}
+
+// SyslogSinkConfig is a config of the sink sending the activity events to a syslog server in the RFC 5424 format
+type SyslogSinkConfig struct {
+	// Network is either tcp or tls
+	Network string
+	// Address of the syslog server, host:port
+	Address string
+	// Facility of the messages, e.g. audit or local0. Defaults to audit
+	Facility string
+	// AppName of the messages. Defaults to netbird
+	AppName string
+	// Hostname of the messages. Defaults to the hostname of the machine
+	Hostname string
+	// CACertFile is the PEM file of the CA the server certificate is verified with. The system roots are used when empty
+	CACertFile string
+	// ServerName the server certificate is verified against. Defaults to the host of the address
+	ServerName string
+	// InsecureSkipVerify disables the verification of the server certificate
+	InsecureSkipVerify bool
+	// Timeout of connecting and sending a message
+	Timeout util.Duration
+}
+
+// JSONLinesSinkConfig is a config of the sink appending the activity events as newline-delimited JSON to a file
+// rotated by size
+type JSONLinesSinkConfig struct {
+	// Path of the file
+	Path string
+	// MaxSizeMB is the size the file is rotated at. Defaults to 100
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files kept. Defaults to 10
+	MaxBackups int
+	// MaxAgeDays is the number of days the rotated files are kept. They are kept regardless of their age when 0
+	MaxAgeDays int
+	// Compress the rotated files with gzip
+	Compress bool
+}
+
 // Host represents a Wiretrustee host (e.g. STUN, TURN, Signal)
 type Host struct {
 	Proto Protocol
@@ -1,8 +1,12 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/miekg/dns"
 	log "github.com/sirupsen/logrus"
@@ -21,13 +25,33 @@ type lookupMap map[string]struct{}
 type DNSSettings struct {
 	// DisabledManagementGroups groups whose DNS management is disabled
 	DisabledManagementGroups []string
+	// PeerZoneSearchDomainEnabled indicates that the peer DNS zone is added to the search domains of the peers.
+	// Enabled by default
+	PeerZoneSearchDomainEnabled bool
+}
+
+// NewDNSSettings returns the default DNS settings of an account
+func NewDNSSettings() *DNSSettings {
+	return &DNSSettings{
+		DisabledManagementGroups:    make([]string, 0),
+		PeerZoneSearchDomainEnabled: true,
+	}
+}
+
+// UnmarshalJSON keeps the peer DNS zone in the search domains of the settings stored before the setting was added
+func (d *DNSSettings) UnmarshalJSON(data []byte) error {
+	type dnsSettings DNSSettings
+	settings := dnsSettings{PeerZoneSearchDomainEnabled: true}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+	*d = DNSSettings(settings)
+	return nil
 }
 
 // Copy returns a copy of the DNS settings
 func (d *DNSSettings) Copy() *DNSSettings {
-	settings := &DNSSettings{
-		DisabledManagementGroups: make([]string, 0),
-	}
+	settings := NewDNSSettings()
 
 	if d == nil {
 		return settings
@@ -36,6 +60,7 @@ func (d *DNSSettings) Copy() *DNSSettings {
 	if d.DisabledManagementGroups != nil && len(d.DisabledManagementGroups) > 0 {
 		settings.DisabledManagementGroups = d.DisabledManagementGroups[:]
 	}
+	settings.PeerZoneSearchDomainEnabled = d.PeerZoneSearchDomainEnabled
 
 	return settings
 }
@@ -55,12 +80,12 @@ func (am *DefaultAccountManager) GetDNSSettings(accountID string, userID string)
 		return nil, err
 	}
 
-	if !user.IsAdmin() {
-		return nil, status.Errorf(status.PermissionDenied, "only admins are allowed to view DNS settings")
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view DNS settings")
 	}
 
 	if account.DNSSettings == nil {
-		return &DNSSettings{}, nil
+		return NewDNSSettings(), nil
 	}
 
 	return account.DNSSettings.Copy(), nil
@@ -81,8 +106,8 @@ func (am *DefaultAccountManager) SaveDNSSettings(accountID string, userID string
 		return err
 	}
 
-	if !user.IsAdmin() {
-		return status.Errorf(status.PermissionDenied, "only admins are allowed to update DNS settings")
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to update DNS settings")
 	}
 
 	if dnsSettingsToSave == nil {
@@ -96,7 +121,7 @@ func (am *DefaultAccountManager) SaveDNSSettings(accountID string, userID string
 		}
 	}
 
-	oldSettings := &DNSSettings{}
+	oldSettings := NewDNSSettings()
 	if account.DNSSettings != nil {
 		oldSettings = account.DNSSettings.Copy()
 	}
@@ -122,7 +147,17 @@ func (am *DefaultAccountManager) SaveDNSSettings(accountID string, userID string
 		am.storeEvent(userID, accountID, accountID, activity.GroupRemovedFromDisabledManagementGroups, meta)
 	}
 
-	am.updateAccountPeers(account)
+	if oldSettings.PeerZoneSearchDomainEnabled != dnsSettingsToSave.PeerZoneSearchDomainEnabled {
+		event := activity.PeerZoneSearchDomainDisabled
+		if dnsSettingsToSave.PeerZoneSearchDomainEnabled {
+			event = activity.PeerZoneSearchDomainEnabled
+		}
+		am.storeEvent(userID, accountID, accountID, event, nil)
+		am.updateAccountPeers(account)
+		return nil
+	}
+
+	am.updateAffectedPeersDNS(account, account.getGroupsPeers(append(addedGroups, removedGroups...)...))
 
 	return nil
 }
@@ -131,7 +166,11 @@ func toProtocolDNSConfig(update nbdns.Config) *proto.DNSConfig {
 	protoUpdate := &proto.DNSConfig{ServiceEnable: update.ServiceEnable}
 
 	for _, zone := range update.CustomZones {
-		protoZone := &proto.CustomZone{Domain: zone.Domain}
+		protoZone := &proto.CustomZone{
+			Domain: zone.Domain,
+			// the flag is negated on the wire, so the zones of the servers not sending it stay search domains
+			SearchDomainDisabled: !zone.SearchDomainEnabled,
+		}
 		for _, record := range zone.Records {
 			protoZone.Records = append(protoZone.Records, &proto.SimpleRecord{
 				Name:  record.Name,
@@ -146,14 +185,16 @@ func toProtocolDNSConfig(update nbdns.Config) *proto.DNSConfig {
 
 	for _, nsGroup := range update.NameServerGroups {
 		protoGroup := &proto.NameServerGroup{
-			Primary: nsGroup.Primary,
-			Domains: nsGroup.Domains,
+			Primary:              nsGroup.Primary,
+			Domains:              nsGroup.Domains,
+			SearchDomainsEnabled: nsGroup.SearchDomainsEnabled,
 		}
 		for _, ns := range nsGroup.NameServers {
 			protoNS := &proto.NameServer{
-				IP:     ns.IP.String(),
-				Port:   int64(ns.Port),
-				NSType: int64(ns.NSType),
+				IP:         ns.IP.String(),
+				Port:       int64(ns.Port),
+				NSType:     int64(ns.NSType),
+				ServerName: ns.ServerName,
 			}
 			protoGroup.NameServers = append(protoGroup.NameServers, protoNS)
 		}
@@ -170,10 +211,15 @@ func getPeersCustomZone(account *Account, dnsDomain string) nbdns.CustomZone {
 	}
 
 	customZone := nbdns.CustomZone{
-		Domain: dns.Fqdn(dnsDomain),
+		Domain:              dns.Fqdn(dnsDomain),
+		SearchDomainEnabled: account.DNSSettings == nil || account.DNSSettings.PeerZoneSearchDomainEnabled,
 	}
 
 	for _, peer := range account.Peers {
+		if peer.PendingApproval() {
+			continue
+		}
+
 		if peer.DNSLabel == "" {
 			log.Errorf("found a peer with empty dns label. It was probably caused by a invalid character in its name. Peer Name: %s", peer.Name)
 			continue
@@ -186,11 +232,109 @@ func getPeersCustomZone(account *Account, dnsDomain string) nbdns.CustomZone {
 			TTL:   defaultTTL,
 			RData: peer.IP.String(),
 		})
+
+		if peer.SupportsIPv6() {
+			customZone.Records = append(customZone.Records, nbdns.SimpleRecord{
+				Name:  dns.Fqdn(peer.DNSLabel + "." + dnsDomain),
+				Type:  int(dns.TypeAAAA),
+				Class: nbdns.DefaultClass,
+				TTL:   defaultTTL,
+				RData: peer.IPv6.String(),
+			})
+		}
 	}
 
 	return customZone
 }
 
+// getPeersReverseZones returns the in-addr.arpa and ip6.arpa zones with the PTR records of the account peers.
+// Only the zones holding at least one peer are returned, sorted by domain
+func getPeersReverseZones(account *Account, dnsDomain string) []nbdns.CustomZone {
+	if dnsDomain == "" {
+		return nil
+	}
+
+	zones := make(map[string]*nbdns.CustomZone)
+	addRecord := func(ipNet net.IPNet, ip net.IP, target string) {
+		name, domain, err := reverseZone(ipNet, ip)
+		if err != nil {
+			log.Debugf("skipping the reverse record of %s: %v", ip, err)
+			return
+		}
+
+		zone, ok := zones[domain]
+		if !ok {
+			zone = &nbdns.CustomZone{Domain: domain}
+			zones[domain] = zone
+		}
+
+		zone.Records = append(zone.Records, nbdns.SimpleRecord{
+			Name:  name,
+			Type:  int(dns.TypePTR),
+			Class: nbdns.DefaultClass,
+			TTL:   defaultTTL,
+			RData: target,
+		})
+	}
+
+	for _, peer := range account.Peers {
+		if peer.PendingApproval() || peer.DNSLabel == "" {
+			continue
+		}
+
+		target := dns.Fqdn(peer.DNSLabel + "." + dnsDomain)
+		addRecord(account.Network.Net, peer.IP, target)
+
+		if peer.SupportsIPv6() && account.Network.HasIPv6() {
+			addRecord(account.Network.NetV6, peer.IPv6, target)
+		}
+	}
+
+	reverseZones := make([]nbdns.CustomZone, 0, len(zones))
+	for _, zone := range zones {
+		sort.Slice(zone.Records, func(i, j int) bool {
+			return zone.Records[i].Name < zone.Records[j].Name
+		})
+		reverseZones = append(reverseZones, *zone)
+	}
+	sort.Slice(reverseZones, func(i, j int) bool {
+		return reverseZones[i].Domain < reverseZones[j].Domain
+	})
+
+	return reverseZones
+}
+
+// reverseZone returns the PTR record name of the IP and the domain of the reverse zone holding it.
+// Zones are delegated on label boundaries, octets for IPv4 and nibbles for IPv6, so the network is split in the
+// smallest zones that don't cover addresses outside of it, e.g. 100.64.0.0/10 in 64 zones from 64.100.in-addr.arpa
+// to 127.100.in-addr.arpa. IPv4 networks smaller than a /24 use the zone of their /24
+func reverseZone(ipNet net.IPNet, ip net.IP) (string, string, error) {
+	if ipNet.IP == nil || !ipNet.Contains(ip) {
+		return "", "", fmt.Errorf("address is not part of the network %s", ipNet.String())
+	}
+
+	name, err := dns.ReverseAddr(ip.String())
+	if err != nil {
+		return "", "", err
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	labelBits := 4
+	if ip.To4() != nil {
+		labelBits = 8
+	}
+
+	zoneBits := (ones + labelBits - 1) / labelBits * labelBits
+	if labelBits == 8 && zoneBits > 24 {
+		zoneBits = 24
+	}
+
+	labels := dns.SplitDomainName(name)
+	hostLabels := (bits - zoneBits) / labelBits
+
+	return name, dns.Fqdn(strings.Join(labels[hostLabels:], ".")), nil
+}
+
 func getPeerNSGroups(account *Account, peerID string) []*nbdns.NameServerGroup {
 	groupList := account.getPeerGroups(peerID)
 
@@ -217,7 +361,7 @@ func getPeerNSGroups(account *Account, peerID string) []*nbdns.NameServerGroup {
 // peerIsNameserver returns true if the peer is a nameserver for a nsGroup
 func peerIsNameserver(peer *Peer, nsGroup *nbdns.NameServerGroup) bool {
 	for _, ns := range nsGroup.NameServers {
-		if peer.IP.Equal(ns.IP.AsSlice()) {
+		if peer.IP.Equal(ns.IP.AsSlice()) || (peer.IPv6 != nil && peer.IPv6.Equal(ns.IP.AsSlice())) {
 			return true
 		}
 	}
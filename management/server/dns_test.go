@@ -1,9 +1,12 @@
 package server
 
 import (
+	"encoding/json"
+	"net"
 	"net/netip"
 	"testing"
 
+	miekgdns "github.com/miekg/dns"
 	"github.com/stretchr/testify/require"
 
 	"github.com/netbirdio/netbird/dns"
@@ -140,6 +143,43 @@ func TestSaveDNSSettings(t *testing.T) {
 	}
 }
 
+func TestSaveDNSSettings_PeerZoneSearchDomain(t *testing.T) {
+	am, err := createDNSManager(t)
+	require.NoError(t, err)
+
+	account, err := initTestDNSAccount(t, am)
+	require.NoError(t, err)
+
+	peer2, err := account.FindPeerByPubKey(dnsPeer2Key)
+	require.NoError(t, err)
+
+	networkMap, err := am.GetNetworkMap(peer2.ID)
+	require.NoError(t, err)
+	peerZones := forwardZones(networkMap.DNSConfig.CustomZones)
+	require.Len(t, peerZones, 1)
+	require.True(t, peerZones[0].SearchDomainEnabled, "the peer zone should be a search domain by default")
+
+	err = am.SaveDNSSettings(account.Id, dnsAdminUserID, &DNSSettings{PeerZoneSearchDomainEnabled: false})
+	require.NoError(t, err)
+	getEvent(t, account.Id, dnsAdminUserID, am, activity.PeerZoneSearchDomainDisabled)
+
+	networkMap, err = am.GetNetworkMap(peer2.ID)
+	require.NoError(t, err)
+	peerZones = forwardZones(networkMap.DNSConfig.CustomZones)
+	require.Len(t, peerZones, 1)
+	require.False(t, peerZones[0].SearchDomainEnabled, "the peer zone should not be a search domain once disabled")
+
+	protoConfig := toProtocolDNSConfig(networkMap.DNSConfig)
+	for _, zone := range protoConfig.GetCustomZones() {
+		if zone.GetDomain() == peerZones[0].Domain {
+			require.True(t, zone.GetSearchDomainDisabled(), "the search domain status should be sent to the peers")
+		}
+	}
+	for _, nsGroup := range protoConfig.GetNameServerGroups() {
+		require.False(t, nsGroup.GetSearchDomainsEnabled())
+	}
+}
+
 func TestGetNetworkMap_DNSConfigSync(t *testing.T) {
 
 	am, err := createDNSManager(t)
@@ -164,7 +204,8 @@ func TestGetNetworkMap_DNSConfigSync(t *testing.T) {
 
 	newAccountDNSConfig, err := am.GetNetworkMap(peer1.ID)
 	require.NoError(t, err)
-	require.Len(t, newAccountDNSConfig.DNSConfig.CustomZones, 1, "default DNS config should have one custom zone for peers")
+	require.Len(t, forwardZones(newAccountDNSConfig.DNSConfig.CustomZones), 1, "default DNS config should have one custom zone for peers")
+	require.NotEmpty(t, reverseZones(newAccountDNSConfig.DNSConfig.CustomZones), "default DNS config should have the reverse zones of the peers")
 	require.True(t, newAccountDNSConfig.DNSConfig.ServiceEnable, "default DNS config should have local DNS service enabled")
 	require.Len(t, newAccountDNSConfig.DNSConfig.NameServerGroups, 0, "updated DNS config should have no nameserver groups since peer 1 is NS for the only existing NS group")
 
@@ -180,18 +221,93 @@ func TestGetNetworkMap_DNSConfigSync(t *testing.T) {
 	require.False(t, updatedAccountDNSConfig.DNSConfig.ServiceEnable, "updated DNS config should have local DNS service disabled when peer belongs to a disabled group")
 	peer2AccountDNSConfig, err := am.GetNetworkMap(peer2.ID)
 	require.NoError(t, err)
-	require.Len(t, peer2AccountDNSConfig.DNSConfig.CustomZones, 1, "DNS config should have one custom zone for peers not in the disabled group")
+	require.Len(t, forwardZones(peer2AccountDNSConfig.DNSConfig.CustomZones), 1, "DNS config should have one custom zone for peers not in the disabled group")
 	require.True(t, peer2AccountDNSConfig.DNSConfig.ServiceEnable, "DNS config should have DNS service enabled for peers not in the disabled group")
 	require.Len(t, peer2AccountDNSConfig.DNSConfig.NameServerGroups, 1, "updated DNS config should have 1 nameserver groups since peer 2 is part of the group All")
 }
 
+func TestGetPeersReverseZones(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("100.64.0.0/10")
+	require.NoError(t, err)
+	_, ipNetV6, err := net.ParseCIDR("fd00:1234:5678:9abc::/64")
+	require.NoError(t, err)
+
+	account := &Account{
+		Network: &Network{Net: *ipNet, NetV6: *ipNetV6},
+		Peers: map[string]*Peer{
+			"peer1": {ID: "peer1", DNSLabel: "peer1", IP: net.ParseIP("100.64.0.10"),
+				IPv6: net.ParseIP("fd00:1234:5678:9abc::a"), Meta: PeerSystemMeta{Capabilities: []string{IPv6OverlayCapability}}},
+			"peer2": {ID: "peer2", DNSLabel: "peer2", IP: net.ParseIP("100.64.1.20"), IPv6: net.ParseIP("fd00:1234:5678:9abc::b")},
+			"peer3": {ID: "peer3", DNSLabel: "peer3", IP: net.ParseIP("100.100.2.30")},
+			"peer4": {ID: "peer4", DNSLabel: "", IP: net.ParseIP("100.100.2.40")},
+		},
+	}
+
+	zones := getPeersReverseZones(account, "netbird.cloud")
+	expected := []dns.CustomZone{
+		{
+			Domain: "100.100.in-addr.arpa.",
+			Records: []dns.SimpleRecord{
+				{Name: "30.2.100.100.in-addr.arpa.", Type: int(miekgdns.TypePTR), Class: dns.DefaultClass, TTL: defaultTTL, RData: "peer3.netbird.cloud."},
+			},
+		},
+		{
+			Domain: "64.100.in-addr.arpa.",
+			Records: []dns.SimpleRecord{
+				{Name: "10.0.64.100.in-addr.arpa.", Type: int(miekgdns.TypePTR), Class: dns.DefaultClass, TTL: defaultTTL, RData: "peer1.netbird.cloud."},
+				{Name: "20.1.64.100.in-addr.arpa.", Type: int(miekgdns.TypePTR), Class: dns.DefaultClass, TTL: defaultTTL, RData: "peer2.netbird.cloud."},
+			},
+		},
+		{
+			Domain: "c.b.a.9.8.7.6.5.4.3.2.1.0.0.d.f.ip6.arpa.",
+			Records: []dns.SimpleRecord{
+				{Name: "a.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.c.b.a.9.8.7.6.5.4.3.2.1.0.0.d.f.ip6.arpa.", Type: int(miekgdns.TypePTR),
+					Class: dns.DefaultClass, TTL: defaultTTL, RData: "peer1.netbird.cloud."},
+			},
+		},
+	}
+	require.Equal(t, expected, zones)
+
+	_, ipNet, err = net.ParseCIDR("10.20.30.32/28")
+	require.NoError(t, err)
+	name, domain, err := reverseZone(*ipNet, net.ParseIP("10.20.30.40"))
+	require.NoError(t, err)
+	require.Equal(t, "40.30.20.10.in-addr.arpa.", name)
+	require.Equal(t, "30.20.10.in-addr.arpa.", domain, "networks smaller than a /24 should use the zone of their /24")
+
+	_, _, err = reverseZone(*ipNet, net.ParseIP("10.20.30.10"))
+	require.Error(t, err, "addresses outside of the network should not get a reverse record")
+}
+
+// forwardZones returns the zones that are not reverse zones
+func forwardZones(zones []dns.CustomZone) []dns.CustomZone {
+	var forward []dns.CustomZone
+	for _, zone := range zones {
+		if !dns.IsReverseZone(zone.Domain) {
+			forward = append(forward, zone)
+		}
+	}
+	return forward
+}
+
+// reverseZones returns the in-addr.arpa and ip6.arpa zones
+func reverseZones(zones []dns.CustomZone) []dns.CustomZone {
+	var reverse []dns.CustomZone
+	for _, zone := range zones {
+		if dns.IsReverseZone(zone.Domain) {
+			reverse = append(reverse, zone)
+		}
+	}
+	return reverse
+}
+
 func createDNSManager(t *testing.T) (*DefaultAccountManager, error) {
 	store, err := createDNSStore(t)
 	if err != nil {
 		return nil, err
 	}
 	eventStore := &activity.InMemoryEventStore{}
-	return BuildManager(store, NewPeersUpdateManager(), nil, "", "netbird.test", eventStore, false)
+	return BuildManager(store, NewPeersUpdateManager(nil), nil, "", "netbird.test", eventStore, false, 0, nil)
 }
 
 func createDNSStore(t *testing.T) (Store, error) {
@@ -313,3 +429,12 @@ func initTestDNSAccount(t *testing.T, am *DefaultAccountManager) (*Account, erro
 
 	return am.Store.GetAccount(account.Id)
 }
+
+func TestDNSSettings_UnmarshalJSON(t *testing.T) {
+	var settings DNSSettings
+	require.NoError(t, json.Unmarshal([]byte(`{"DisabledManagementGroups":["group"]}`), &settings))
+	require.True(t, settings.PeerZoneSearchDomainEnabled, "the peer zone should stay a search domain for stored settings")
+
+	require.NoError(t, json.Unmarshal([]byte(`{"PeerZoneSearchDomainEnabled":false}`), &settings))
+	require.False(t, settings.PeerZoneSearchDomainEnabled)
+}
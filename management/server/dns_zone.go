@@ -0,0 +1,446 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	nbdns "github.com/netbirdio/netbird/dns"
+	"github.com/netbirdio/netbird/management/server/activity"
+	"github.com/netbirdio/netbird/management/server/status"
+)
+
+const (
+	// DNSRecordTypeA is an IPv4 address record
+	DNSRecordTypeA = "A"
+	// DNSRecordTypeAAAA is an IPv6 address record
+	DNSRecordTypeAAAA = "AAAA"
+	// DNSRecordTypeCNAME is a canonical name record
+	DNSRecordTypeCNAME = "CNAME"
+	// DNSRecordTypeTXT is a text record
+	DNSRecordTypeTXT = "TXT"
+	// DNSRecordTypeSRV is a service locator record
+	DNSRecordTypeSRV = "SRV"
+
+	// maxTXTRecordLength is the maximum length of the text of a TXT record
+	maxTXTRecordLength = 4000
+	// maxTXTStringLength is the maximum length of a character string of a TXT record, see RFC 1035
+	maxTXTStringLength = 255
+)
+
+// DNSZone is a custom DNS zone of the account distributed to the peers of its distribution groups
+type DNSZone struct {
+	// ID of the zone
+	ID string
+
+	// Name is the domain of the zone, e.g. corp.example.com
+	Name string
+
+	// Description of the zone visible in the UI
+	Description string
+
+	// Records of the zone
+	Records []*DNSRecord
+
+	// DistributionGroups are the IDs of the groups whose peers resolve the zone
+	DistributionGroups []string
+
+	// Enabled status of the zone
+	Enabled bool
+
+	// SearchDomainEnabled indicates that the zone domain is also added to the search domains of the peers
+	SearchDomainEnabled bool
+}
+
+// DNSRecord is a record of a custom DNS zone
+type DNSRecord struct {
+	// Name of the record, the zone name or a subdomain of it
+	Name string
+
+	// Type of the record, one of A, AAAA, CNAME, TXT and SRV
+	Type string
+
+	// TTL of the record in seconds
+	TTL int
+
+	// Content of the record: the IP address of A and AAAA records, the target domain of CNAME records,
+	// the text of TXT records and "priority weight port target" of SRV records
+	Content string
+}
+
+// Copy returns a copy of the zone
+func (z *DNSZone) Copy() *DNSZone {
+	c := *z
+	c.Records = make([]*DNSRecord, 0, len(z.Records))
+	for _, record := range z.Records {
+		r := *record
+		c.Records = append(c.Records, &r)
+	}
+	c.DistributionGroups = make([]string, len(z.DistributionGroups))
+	copy(c.DistributionGroups, z.DistributionGroups)
+	return &c
+}
+
+// EventMeta returns activity event meta related to the zone
+func (z *DNSZone) EventMeta() map[string]any {
+	return map[string]any{"name": z.Name}
+}
+
+// normalize lowercases the zone and record names and removes their trailing dots
+func (z *DNSZone) normalize() {
+	z.Name = normalizeDomain(z.Name)
+	for _, record := range z.Records {
+		record.Name = normalizeDomain(record.Name)
+		record.Type = strings.ToUpper(record.Type)
+		record.Content = strings.TrimSpace(record.Content)
+	}
+}
+
+// validate returns an error if the zone or any of its records is invalid
+func (z *DNSZone) validate() error {
+	if err := validateDomain(z.Name); err != nil {
+		return status.Errorf(status.InvalidArgument, "invalid DNS zone name %s: %v", z.Name, err)
+	}
+
+	types := make(map[string][]string)
+	for _, record := range z.Records {
+		if err := record.validate(z.Name); err != nil {
+			return err
+		}
+		types[record.Name] = append(types[record.Name], record.Type)
+	}
+
+	// a name with a CNAME record can't have any other record, see RFC 1034
+	for name, nameTypes := range types {
+		for _, recordType := range nameTypes {
+			if recordType != DNSRecordTypeCNAME {
+				continue
+			}
+			if name == z.Name {
+				return status.Errorf(status.InvalidArgument, "the zone name %s can't have a CNAME record", name)
+			}
+			if len(nameTypes) > 1 {
+				return status.Errorf(status.InvalidArgument, "%s has a CNAME record and can't have any other record", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate returns an error if the record doesn't belong to the zone or its content doesn't match its type
+func (r *DNSRecord) validate(zoneName string) error {
+	if _, ok := dns.IsDomainName(r.Name); !ok || !dns.IsSubDomain(zoneName, r.Name) {
+		return status.Errorf(status.InvalidArgument, "record name %s should be the zone name or a subdomain of it", r.Name)
+	}
+
+	if r.TTL <= 0 || r.TTL > math.MaxInt32 {
+		return status.Errorf(status.InvalidArgument, "record %s TTL should be between 1 and %d", r.Name, math.MaxInt32)
+	}
+
+	switch r.Type {
+	case DNSRecordTypeA:
+		ip, err := netip.ParseAddr(r.Content)
+		if err != nil || !ip.Is4() {
+			return status.Errorf(status.InvalidArgument, "A record %s content %q should be an IPv4 address", r.Name, r.Content)
+		}
+	case DNSRecordTypeAAAA:
+		ip, err := netip.ParseAddr(r.Content)
+		if err != nil || !ip.Is6() || ip.Is4In6() {
+			return status.Errorf(status.InvalidArgument, "AAAA record %s content %q should be an IPv6 address", r.Name, r.Content)
+		}
+	case DNSRecordTypeCNAME:
+		if _, ok := dns.IsDomainName(r.Content); !ok || normalizeDomain(r.Content) == "" {
+			return status.Errorf(status.InvalidArgument, "CNAME record %s content %q should be a domain name", r.Name, r.Content)
+		}
+	case DNSRecordTypeTXT:
+		if r.Content == "" || len(r.Content) > maxTXTRecordLength {
+			return status.Errorf(status.InvalidArgument, "TXT record %s content should be between 1 and %d characters",
+				r.Name, maxTXTRecordLength)
+		}
+	case DNSRecordTypeSRV:
+		if _, err := formatSRVContent(r.Content); err != nil {
+			return status.Errorf(status.InvalidArgument, "SRV record %s content %q should be \"priority weight port target\": %v",
+				r.Name, r.Content, err)
+		}
+	default:
+		return status.Errorf(status.InvalidArgument, "record %s has an unsupported type %q, supported types are %s",
+			r.Name, r.Type, strings.Join([]string{DNSRecordTypeA, DNSRecordTypeAAAA, DNSRecordTypeCNAME, DNSRecordTypeTXT,
+				DNSRecordTypeSRV}, ", "))
+	}
+
+	if _, err := dns.NewRR(r.toSimpleRecord().String()); err != nil {
+		return status.Errorf(status.InvalidArgument, "invalid %s record %s: %v", r.Type, r.Name, err)
+	}
+
+	return nil
+}
+
+// toSimpleRecord returns the record with its content in the zone file format expected by the peers
+func (r *DNSRecord) toSimpleRecord() nbdns.SimpleRecord {
+	rData := r.Content
+	switch r.Type {
+	case DNSRecordTypeCNAME:
+		rData = dns.Fqdn(normalizeDomain(r.Content))
+	case DNSRecordTypeTXT:
+		rData = formatTXTContent(r.Content)
+	case DNSRecordTypeSRV:
+		rData, _ = formatSRVContent(r.Content)
+	}
+
+	return nbdns.SimpleRecord{
+		Name:  dns.Fqdn(r.Name),
+		Type:  int(dns.StringToType[r.Type]),
+		Class: nbdns.DefaultClass,
+		TTL:   r.TTL,
+		RData: rData,
+	}
+}
+
+// toCustomZone returns the zone in the format distributed to the peers
+func (z *DNSZone) toCustomZone() nbdns.CustomZone {
+	customZone := nbdns.CustomZone{
+		Domain:              dns.Fqdn(z.Name),
+		SearchDomainEnabled: z.SearchDomainEnabled,
+	}
+	for _, record := range z.Records {
+		customZone.Records = append(customZone.Records, record.toSimpleRecord())
+	}
+	return customZone
+}
+
+// formatTXTContent splits the text in quoted character strings of at most 255 bytes
+func formatTXTContent(text string) string {
+	var chunks []string
+	for len(text) > maxTXTStringLength {
+		chunks = append(chunks, text[:maxTXTStringLength])
+		text = text[maxTXTStringLength:]
+	}
+	chunks = append(chunks, text)
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	for i, chunk := range chunks {
+		chunks[i] = `"` + escaper.Replace(chunk) + `"`
+	}
+	return strings.Join(chunks, " ")
+}
+
+// formatSRVContent parses the "priority weight port target" content of an SRV record and returns it with a fully
+// qualified target
+func formatSRVContent(content string) (string, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 4 {
+		return "", fmt.Errorf("expected 4 fields, got %d", len(fields))
+	}
+
+	for i, field := range []string{"priority", "weight", "port"} {
+		if _, err := strconv.ParseUint(fields[i], 10, 16); err != nil {
+			return "", fmt.Errorf("%s should be a number between 0 and 65535", field)
+		}
+	}
+
+	target := normalizeDomain(fields[3])
+	if _, ok := dns.IsDomainName(fields[3]); !ok {
+		return "", errors.New("target should be a domain name")
+	}
+
+	return strings.Join(append(fields[:3:3], dns.Fqdn(target)), " "), nil
+}
+
+// normalizeDomain returns the lowercase domain without the trailing dot
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+}
+
+// getPeerDNSZones returns the enabled custom DNS zones with records that are distributed to the peer
+func getPeerDNSZones(account *Account, peerID string) []nbdns.CustomZone {
+	peerGroups := account.getPeerGroups(peerID)
+
+	var zones []nbdns.CustomZone
+	for _, zone := range account.DNSZones {
+		if !zone.Enabled || len(zone.Records) == 0 {
+			continue
+		}
+		for _, groupID := range zone.DistributionGroups {
+			if _, ok := peerGroups[groupID]; ok {
+				zones = append(zones, zone.toCustomZone())
+				break
+			}
+		}
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].Domain < zones[j].Domain
+	})
+
+	return zones
+}
+
+// GetDNSZone returns the custom DNS zone with the given ID
+func (am *DefaultAccountManager) GetDNSZone(accountID, zoneID, userID string) (*DNSZone, error) {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view DNS zones")
+	}
+
+	zone, ok := account.DNSZones[zoneID]
+	if !ok {
+		return nil, status.Errorf(status.NotFound, "DNS zone with ID %s not found", zoneID)
+	}
+
+	return zone.Copy(), nil
+}
+
+// SaveDNSZone creates or updates the custom DNS zone of the account and updates the peers it is distributed to
+func (am *DefaultAccountManager) SaveDNSZone(accountID, userID string, zone *DNSZone) error {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	if zone == nil {
+		return status.Errorf(status.InvalidArgument, "DNS zone provided is nil")
+	}
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to update DNS zones")
+	}
+
+	zone.normalize()
+	if err = zone.validate(); err != nil {
+		return err
+	}
+
+	// the peer names are served from the account DNS domain, a zone on it, under it or above it would shadow them
+	peerDomain := dns.Fqdn(normalizeDomain(am.dnsDomain))
+	if zoneFqdn := dns.Fqdn(zone.Name); dns.IsSubDomain(peerDomain, zoneFqdn) || dns.IsSubDomain(zoneFqdn, peerDomain) {
+		return status.Errorf(status.InvalidArgument, "DNS zone %s overlaps the domain %s reserved for the peer names", zone.Name, normalizeDomain(am.dnsDomain))
+	}
+
+	for _, z := range account.DNSZones {
+		if z.Name == zone.Name && z.ID != zone.ID {
+			return status.Errorf(status.AlreadyExists, "DNS zone %s already exists", zone.Name)
+		}
+	}
+
+	if err = validateGroups(zone.DistributionGroups, account.Groups); err != nil {
+		return err
+	}
+
+	if account.DNSZones == nil {
+		account.DNSZones = make(map[string]*DNSZone)
+	}
+
+	existing, exists := account.DNSZones[zone.ID]
+	affectedPeers := account.getPeersAffectedByDNSZone(existing)
+	affectedPeers.add(account.getPeersAffectedByDNSZone(zone))
+	account.DNSZones[zone.ID] = zone
+
+	account.Network.IncSerial()
+	if err = am.Store.SaveAccount(account); err != nil {
+		return err
+	}
+
+	am.updateAffectedPeersDNS(account, affectedPeers)
+
+	action := activity.DNSZoneCreated
+	if exists {
+		action = activity.DNSZoneUpdated
+	}
+	am.storeEvent(userID, zone.ID, accountID, action, zone.EventMeta())
+
+	return nil
+}
+
+// DeleteDNSZone removes the custom DNS zone from the account and updates the peers it was distributed to
+func (am *DefaultAccountManager) DeleteDNSZone(accountID, zoneID, userID string) error {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to delete DNS zones")
+	}
+
+	zone, ok := account.DNSZones[zoneID]
+	if !ok {
+		return status.Errorf(status.NotFound, "DNS zone with ID %s doesn't exist", zoneID)
+	}
+	delete(account.DNSZones, zoneID)
+
+	account.Network.IncSerial()
+	if err = am.Store.SaveAccount(account); err != nil {
+		return err
+	}
+
+	am.updateAffectedPeersDNS(account, account.getPeersAffectedByDNSZone(zone))
+
+	am.storeEvent(userID, zone.ID, accountID, activity.DNSZoneDeleted, zone.EventMeta())
+
+	return nil
+}
+
+// ListDNSZones returns all the custom DNS zones of the account sorted by name
+func (am *DefaultAccountManager) ListDNSZones(accountID, userID string) ([]*DNSZone, error) {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view DNS zones")
+	}
+
+	zones := make([]*DNSZone, 0, len(account.DNSZones))
+	for _, zone := range account.DNSZones {
+		zones = append(zones, zone.Copy())
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].Name < zones[j].Name
+	})
+
+	return zones, nil
+}
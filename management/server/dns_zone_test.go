@@ -0,0 +1,225 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	nbdns "github.com/netbirdio/netbird/dns"
+	"github.com/netbirdio/netbird/management/server/activity"
+)
+
+func testDNSZone() *DNSZone {
+	return &DNSZone{
+		ID:   "zone",
+		Name: "corp.example.com",
+		Records: []*DNSRecord{
+			{Name: "corp.example.com", Type: DNSRecordTypeTXT, TTL: 300, Content: "v=spf1 -all"},
+			{Name: "db.corp.example.com", Type: DNSRecordTypeA, TTL: 300, Content: "10.0.0.10"},
+			{Name: "db.corp.example.com", Type: DNSRecordTypeAAAA, TTL: 300, Content: "fd00::10"},
+			{Name: "www.corp.example.com", Type: DNSRecordTypeCNAME, TTL: 300, Content: "db.corp.example.com"},
+			{Name: "_sip._tcp.corp.example.com", Type: DNSRecordTypeSRV, TTL: 300, Content: "10 5 5060 sip.corp.example.com"},
+		},
+		DistributionGroups: []string{"group"},
+		Enabled:            true,
+	}
+}
+
+func TestDNSZone_Validate(t *testing.T) {
+	tt := []struct {
+		name   string
+		modify func(zone *DNSZone)
+		err    bool
+	}{
+		{name: "valid", modify: func(zone *DNSZone) {}},
+		{name: "no records", modify: func(zone *DNSZone) { zone.Records = nil }},
+		{name: "invalid zone name", modify: func(zone *DNSZone) { zone.Name = "corp" }, err: true},
+		{name: "record outside of the zone", modify: func(zone *DNSZone) { zone.Records[1].Name = "db.example.com" }, err: true},
+		{name: "zero TTL", modify: func(zone *DNSZone) { zone.Records[1].TTL = 0 }, err: true},
+		{name: "unsupported type", modify: func(zone *DNSZone) { zone.Records[1].Type = "MX" }, err: true},
+		{name: "IPv6 A record", modify: func(zone *DNSZone) { zone.Records[1].Content = "fd00::10" }, err: true},
+		{name: "IPv4 AAAA record", modify: func(zone *DNSZone) { zone.Records[2].Content = "10.0.0.10" }, err: true},
+		{name: "invalid CNAME target", modify: func(zone *DNSZone) { zone.Records[3].Content = "" }, err: true},
+		{name: "empty TXT record", modify: func(zone *DNSZone) { zone.Records[0].Content = "" }, err: true},
+		{name: "SRV record without target", modify: func(zone *DNSZone) { zone.Records[4].Content = "10 5 5060" }, err: true},
+		{name: "SRV record with invalid port", modify: func(zone *DNSZone) { zone.Records[4].Content = "10 5 70000 sip.corp.example.com" }, err: true},
+		{name: "CNAME with other records", modify: func(zone *DNSZone) { zone.Records[3].Name = "db.corp.example.com" }, err: true},
+		{name: "CNAME at the zone name", modify: func(zone *DNSZone) {
+			zone.Records = []*DNSRecord{{Name: "corp.example.com", Type: DNSRecordTypeCNAME, TTL: 300, Content: "example.com"}}
+		}, err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			zone := testDNSZone()
+			tc.modify(zone)
+			zone.normalize()
+			if tc.err {
+				assert.Error(t, zone.validate())
+			} else {
+				assert.NoError(t, zone.validate())
+			}
+		})
+	}
+}
+
+func TestDNSZone_ToCustomZone(t *testing.T) {
+	zone := testDNSZone()
+	zone.Records = append(zone.Records, &DNSRecord{
+		Name: "long.corp.example.com", Type: DNSRecordTypeTXT, TTL: 300, Content: strings.Repeat("a", 300) + `"quoted"`,
+	})
+
+	customZone := zone.toCustomZone()
+	assert.Equal(t, "corp.example.com.", customZone.Domain)
+	assert.False(t, customZone.SearchDomainEnabled, "a zone should only be a match domain by default")
+
+	zone.SearchDomainEnabled = true
+	assert.True(t, zone.toCustomZone().SearchDomainEnabled)
+	require.Len(t, customZone.Records, len(zone.Records))
+
+	expected := []nbdns.SimpleRecord{
+		{Name: "corp.example.com.", Type: int(dns.TypeTXT), Class: nbdns.DefaultClass, TTL: 300, RData: `"v=spf1 -all"`},
+		{Name: "db.corp.example.com.", Type: int(dns.TypeA), Class: nbdns.DefaultClass, TTL: 300, RData: "10.0.0.10"},
+		{Name: "db.corp.example.com.", Type: int(dns.TypeAAAA), Class: nbdns.DefaultClass, TTL: 300, RData: "fd00::10"},
+		{Name: "www.corp.example.com.", Type: int(dns.TypeCNAME), Class: nbdns.DefaultClass, TTL: 300, RData: "db.corp.example.com."},
+		{Name: "_sip._tcp.corp.example.com.", Type: int(dns.TypeSRV), Class: nbdns.DefaultClass, TTL: 300,
+			RData: "10 5 5060 sip.corp.example.com."},
+	}
+	assert.Equal(t, expected, customZone.Records[:len(expected)])
+
+	// the long TXT record is split in character strings of at most 255 bytes, the quotes are kept escaped in the
+	// presentation format of the strings
+	rr, err := dns.NewRR(customZone.Records[len(expected)].String())
+	require.NoError(t, err)
+	txt := rr.(*dns.TXT).Txt
+	require.Len(t, txt, 2)
+	assert.Len(t, txt[0], 255)
+	assert.Equal(t, strings.Repeat("a", 300)+`\"quoted\"`, strings.Join(txt, ""))
+}
+
+func TestDefaultAccountManager_DNSZones(t *testing.T) {
+	am, err := createManager(t)
+	require.NoError(t, err)
+
+	account, err := createAccount(am, "account", "admin", "")
+	require.NoError(t, err)
+
+	setupKey, err := am.CreateSetupKey(account.Id, "key", SetupKeyReusable, time.Hour, nil, 999, "admin", false, nil)
+	require.NoError(t, err)
+	wgKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+	peer, _, err := am.AddPeer(setupKey.Key, "", &Peer{Key: wgKey.PublicKey().String(), Meta: PeerSystemMeta{Hostname: "peer"}})
+	require.NoError(t, err)
+
+	account, err = am.Store.GetAccount(account.Id)
+	require.NoError(t, err)
+	account.Groups["group"] = &Group{ID: "group", Name: "group", Peers: []string{peer.ID}}
+	account.Users["user"] = NewRegularUser("user")
+	require.NoError(t, am.Store.SaveAccount(account))
+
+	zone := testDNSZone()
+	require.Error(t, am.SaveDNSZone(account.Id, "user", zone.Copy()), "only network managers should be allowed to save zones")
+
+	reserved := zone.Copy()
+	reserved.Name = "Netbird.Cloud."
+	reserved.Records = nil
+	require.Error(t, am.SaveDNSZone(account.Id, "admin", reserved), "the peers zone should be reserved")
+
+	for _, name := range []string{"sub.netbird.cloud", "a.b.Netbird.Cloud.", "cloud"} {
+		overlapping := zone.Copy()
+		overlapping.Name = name
+		overlapping.Records = nil
+		require.Errorf(t, am.SaveDNSZone(account.Id, "admin", overlapping), "zone %s overlaps the peers zone and should be rejected", name)
+	}
+
+	sibling := zone.Copy()
+	sibling.ID = "sibling"
+	sibling.Name = "notnetbird.cloud"
+	sibling.Records = nil
+	require.NoError(t, am.SaveDNSZone(account.Id, "admin", sibling), "a zone that only shares a suffix with the peers zone should be allowed")
+	require.NoError(t, am.DeleteDNSZone(account.Id, sibling.ID, "admin"))
+
+	unknownGroup := zone.Copy()
+	unknownGroup.DistributionGroups = []string{"unknown"}
+	require.Error(t, am.SaveDNSZone(account.Id, "admin", unknownGroup), "the distribution groups should exist")
+
+	updMsg := am.peersUpdateManager.CreateChannel(peer.ID)
+	defer am.peersUpdateManager.CloseChannel(peer.ID)
+
+	require.NoError(t, am.SaveDNSZone(account.Id, "admin", zone.Copy()))
+	getEvent(t, account.Id, "admin", am, activity.DNSZoneCreated)
+
+	select {
+	case message := <-updMsg:
+		var domains []string
+		for _, customZone := range message.Update.GetNetworkMap().GetDNSConfig().GetCustomZones() {
+			domains = append(domains, customZone.GetDomain())
+		}
+		assert.Contains(t, domains, "corp.example.com.", "the zone should be pushed to the peers of the distribution groups")
+	case <-time.After(time.Second):
+		t.Fatal("the peer should receive an update")
+	}
+
+	duplicate := zone.Copy()
+	duplicate.ID = "duplicate"
+	require.Error(t, am.SaveDNSZone(account.Id, "admin", duplicate), "the zone names should be unique")
+
+	err = am.DeleteGroup(account.Id, "admin", "group")
+	require.Error(t, err, "a group distributing a zone should not be deleted")
+
+	updated := zone.Copy()
+	updated.Enabled = false
+	require.NoError(t, am.SaveDNSZone(account.Id, "admin", updated))
+	getEvent(t, account.Id, "admin", am, activity.DNSZoneUpdated)
+
+	networkMap, err := am.GetNetworkMap(peer.ID)
+	require.NoError(t, err)
+	for _, customZone := range networkMap.DNSConfig.CustomZones {
+		assert.NotEqual(t, "corp.example.com.", customZone.Domain, "a disabled zone should not be distributed")
+	}
+
+	zones, err := am.ListDNSZones(account.Id, "admin")
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.False(t, zones[0].Enabled)
+
+	require.Error(t, am.DeleteDNSZone(account.Id, zone.ID, "user"), "only network managers should be allowed to delete zones")
+	require.NoError(t, am.DeleteDNSZone(account.Id, zone.ID, "admin"))
+	getEvent(t, account.Id, "admin", am, activity.DNSZoneDeleted)
+
+	_, err = am.GetDNSZone(account.Id, zone.ID, "admin")
+	require.Error(t, err)
+}
+
+func TestGetPeerDNSZones(t *testing.T) {
+	enabled := testDNSZone()
+	disabled := testDNSZone()
+	disabled.ID = "disabled"
+	disabled.Name = "disabled.example.com"
+	disabled.Enabled = false
+	empty := testDNSZone()
+	empty.ID = "empty"
+	empty.Name = "empty.example.com"
+	empty.Records = nil
+	other := testDNSZone()
+	other.ID = "other"
+	other.Name = "other.example.com"
+	other.DistributionGroups = []string{"other"}
+
+	account := &Account{
+		Groups: map[string]*Group{
+			"group": {ID: "group", Peers: []string{"peer"}},
+			"other": {ID: "other", Peers: []string{"other-peer"}},
+		},
+		DNSZones: map[string]*DNSZone{enabled.ID: enabled, disabled.ID: disabled, empty.ID: empty, other.ID: other},
+	}
+
+	zones := getPeerDNSZones(account, "peer")
+	require.Len(t, zones, 1)
+	assert.Equal(t, "corp.example.com.", zones[0].Domain)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -29,10 +30,19 @@ type ephemeralPeer struct {
 
 // EphemeralManager keep a list of ephemeral peers. After ephemeralLifeTime inactivity the peer will be deleted
 // automatically. Inactivity means the peer disconnected from the Management server.
+// When the store is shared by several management replicas, the peers are deleted by the replica holding the leader
+// lock only.
 type EphemeralManager struct {
 	store          Store
 	accountManager AccountManager
 
+	// elector is set when the store is shared by several management replicas
+	elector LeaderElector
+	// stopLeader stops running for the leader lock, nil without elector
+	stopLeader context.CancelFunc
+	// active is false while another management replica holds the leader lock
+	active bool
+
 	headPeer  *ephemeralPeer
 	tailPeer  *ephemeralPeer
 	peersLock sync.Mutex
@@ -41,19 +51,32 @@ type EphemeralManager struct {
 
 // NewEphemeralManager instantiate new EphemeralManager
 func NewEphemeralManager(store Store, accountManager AccountManager) *EphemeralManager {
-	return &EphemeralManager{
+	e := &EphemeralManager{
 		store:          store,
 		accountManager: accountManager,
+		active:         true,
+	}
+	if elector, ok := store.(LeaderElector); ok {
+		e.elector = elector
+		e.active = false
 	}
+	return e
 }
 
 // LoadInitialPeers load from the database the ephemeral type of peers and schedule a cleanup procedure to the head
 // of the linked list (to the most deprecated peer). At the end of cleanup it schedules the next cleanup to the new
-// head.
+// head. With a shared store the peers are loaded every time the replica acquires the leader lock.
 func (e *EphemeralManager) LoadInitialPeers() {
 	e.peersLock.Lock()
 	defer e.peersLock.Unlock()
 
+	if e.elector != nil {
+		var ctx context.Context
+		ctx, e.stopLeader = context.WithCancel(context.Background())
+		go e.elector.RunAsLeader(ctx, e.runAsLeader)
+		return
+	}
+
 	e.loadEphemeralPeers()
 	if e.headPeer != nil {
 		e.timer = time.AfterFunc(ephemeralLifeTime, e.cleanup)
@@ -65,6 +88,9 @@ func (e *EphemeralManager) Stop() {
 	e.peersLock.Lock()
 	defer e.peersLock.Unlock()
 
+	if e.stopLeader != nil {
+		e.stopLeader()
+	}
 	if e.timer != nil {
 		e.timer.Stop()
 	}
@@ -109,7 +135,7 @@ func (e *EphemeralManager) OnPeerDisconnected(peer *Peer) {
 	e.peersLock.Lock()
 	defer e.peersLock.Unlock()
 
-	if e.isPeerOnList(peer.ID) {
+	if !e.active || e.isPeerOnList(peer.ID) {
 		return
 	}
 
@@ -119,6 +145,91 @@ func (e *EphemeralManager) OnPeerDisconnected(peer *Peer) {
 	}
 }
 
+// runAsLeader deletes the ephemeral peers of all the replicas while the replica holds the leader lock. The peers
+// disconnected from the other replicas are not reported to the manager, so they are picked up from the store every
+// leaderSyncInterval
+func (e *EphemeralManager) runAsLeader(ctx context.Context) {
+	e.peersLock.Lock()
+	e.active = true
+	e.loadEphemeralPeers()
+	e.scheduleCleanup()
+	e.peersLock.Unlock()
+
+	ticker := time.NewTicker(leaderSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			e.peersLock.Lock()
+			e.active = false
+			e.headPeer, e.tailPeer = nil, nil
+			if e.timer != nil {
+				e.timer.Stop()
+				e.timer = nil
+			}
+			e.peersLock.Unlock()
+			return
+		case <-ticker.C:
+			e.syncPeers()
+		}
+	}
+}
+
+// syncPeers adds the disconnected ephemeral peers missing from the list, e.g. the peers disconnected from the other
+// replicas
+func (e *EphemeralManager) syncPeers() {
+	accounts := e.store.GetAllAccounts()
+
+	e.peersLock.Lock()
+	defer e.peersLock.Unlock()
+
+	if !e.active {
+		return
+	}
+
+	listed := make(map[string]struct{})
+	for p := e.headPeer; p != nil; p = p.next {
+		listed[p.id] = struct{}{}
+	}
+
+	t := newDeadLine()
+	count := 0
+	for _, a := range accounts {
+		for id, p := range a.Peers {
+			if _, ok := listed[id]; ok || !p.Ephemeral || (p.Status != nil && p.Status.Connected) {
+				continue
+			}
+			count++
+			e.addPeer(id, a, t)
+		}
+	}
+	if count > 0 {
+		log.Debugf("synced ephemeral peer(s): %d", count)
+	}
+
+	if e.timer == nil {
+		e.scheduleCleanup()
+	}
+}
+
+// scheduleCleanup schedules the cleanup to the deadline of the head of the list, if any.
+// Should be called with the peers lock held
+func (e *EphemeralManager) scheduleCleanup() {
+	if e.headPeer != nil {
+		e.timer = time.AfterFunc(e.headPeer.deadline.Sub(timeNow()), e.cleanup)
+	}
+}
+
+// isPeerConnected checks whether the peer has been connected to another replica since it has been added to the list
+func (e *EphemeralManager) isPeerConnected(id string) bool {
+	a, err := e.store.GetAccountByPeerID(id)
+	if err != nil {
+		return false
+	}
+	p := a.GetPeer(id)
+	return p != nil && p.Status != nil && p.Status.Connected
+}
+
 func (e *EphemeralManager) loadEphemeralPeers() {
 	accounts := e.store.GetAllAccounts()
 	t := newDeadLine()
@@ -139,6 +250,10 @@ func (e *EphemeralManager) cleanup() {
 	deletePeers := make(map[string]*ephemeralPeer)
 
 	e.peersLock.Lock()
+	if !e.active {
+		e.peersLock.Unlock()
+		return
+	}
 	now := timeNow()
 	for p := e.headPeer; p != nil; p = p.next {
 		if now.Before(p.deadline) {
@@ -161,6 +276,10 @@ func (e *EphemeralManager) cleanup() {
 	e.peersLock.Unlock()
 
 	for id, p := range deletePeers {
+		if e.elector != nil && e.isPeerConnected(id) {
+			// the peer will be added again by syncPeers once disconnected
+			continue
+		}
 		log.Debugf("delete ephemeral peer: %s", id)
 		err := e.accountManager.DeletePeer(p.account.Id, id, activity.SystemInitiator)
 		if err != nil {
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -119,6 +120,63 @@ func TestNewManagerPeerDisconnected(t *testing.T) {
 	}
 }
 
+func TestNewManagerSharedStore(t *testing.T) {
+	startTime := time.Now()
+	timeNow = func() time.Time {
+		return startTime
+	}
+
+	store := &MockStore{}
+	am := MocAccountManager{
+		store: store,
+	}
+
+	numberOfPeers := 5
+	numberOfEphemeralPeers := 3
+	seedPeers(store, numberOfPeers, numberOfEphemeralPeers)
+
+	mgr := NewEphemeralManager(store, am)
+	// the store is shared with another replica holding the leader lease
+	mgr.elector = &mockLeaderElector{}
+	mgr.active = false
+
+	mgr.OnPeerDisconnected(store.account.Peers["ephemeral_peer_0"])
+	if mgr.headPeer != nil {
+		t.Fatal("the peers should not be added to the list without the leader lease")
+	}
+
+	// the replica acquires the leader lease, ephemeral_peer_0 is connected to the other replica
+	mgr.active = true
+	mgr.loadEphemeralPeers()
+	store.account.Peers["ephemeral_peer_0"].Status = &PeerStatus{Connected: true}
+
+	startTime = startTime.Add(ephemeralLifeTime + 1)
+	mgr.cleanup()
+
+	expected := numberOfPeers + 1
+	if len(store.account.Peers) != expected {
+		t.Errorf("failed to cleanup ephemeral peers, expected: %d, result: %d", expected, len(store.account.Peers))
+	}
+
+	// ephemeral_peer_0 disconnects from the other replica
+	store.account.Peers["ephemeral_peer_0"].Status = &PeerStatus{Connected: false}
+	mgr.syncPeers()
+	mgr.timer.Stop()
+
+	startTime = startTime.Add(ephemeralLifeTime + 1)
+	mgr.cleanup()
+
+	if len(store.account.Peers) != numberOfPeers {
+		t.Errorf("failed to cleanup ephemeral peers, expected: %d, result: %d", numberOfPeers, len(store.account.Peers))
+	}
+}
+
+type mockLeaderElector struct{}
+
+func (e *mockLeaderElector) RunAsLeader(ctx context.Context, job func(ctx context.Context)) {
+	job(ctx)
+}
+
 func seedPeers(store *MockStore, numberOfPeers int, numberOfEphemeralPeers int) {
 	store.account = newAccountWithId("my account", "", "")
 
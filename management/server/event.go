@@ -7,13 +7,42 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/netbirdio/netbird/management/server/activity"
+	"github.com/netbirdio/netbird/management/server/status"
 )
 
-// GetEvents returns a list of activity events of an account
-func (am *DefaultAccountManager) GetEvents(accountID, userID string) ([]*activity.Event, error) {
-	events, err := am.eventStore.Get(accountID, 0, 10000, true)
+// maxEventsLimit is the maximum number of events of a page returned by GetEvents
+const maxEventsLimit = 10000
+
+// GetEvents returns the activity events of an account matching the filter and the cursor of the next page. The cursor
+// is empty when there are no more events. The limit defaults to maxEventsLimit and can't exceed it
+func (am *DefaultAccountManager) GetEvents(accountID, userID string, filter activity.Filter) ([]*activity.Event, string, error) {
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !user.HasReadAllAccess() {
+		return nil, "", status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view events")
+	}
+
+	if filter.Limit <= 0 || filter.Limit > maxEventsLimit {
+		filter.Limit = maxEventsLimit
+	}
+
+	events, err := am.eventStore.Find(accountID, filter)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	// the cursor points to the last event of the page before the duplicates are removed to not return them again
+	nextCursor := ""
+	if filter.Limit > 0 && len(events) == filter.Limit {
+		nextCursor = activity.NewCursor(events[len(events)-1])
 	}
 
 	// this is a workaround for duplicate activity.UserJoined events that might occur when a user redeems invite.
@@ -33,25 +62,29 @@ func (am *DefaultAccountManager) GetEvents(accountID, userID string) ([]*activit
 		filtered = append(filtered, event)
 	}
 
-	return filtered, nil
+	return filtered, nextCursor, nil
 }
 
 func (am *DefaultAccountManager) storeEvent(initiatorID, targetID, accountID string, activityID activity.Activity,
 	meta map[string]any) {
 
 	go func() {
-		_, err := am.eventStore.Save(&activity.Event{
+		event := &activity.Event{
 			Timestamp:   time.Now().UTC(),
 			Activity:    activityID,
 			InitiatorID: initiatorID,
 			TargetID:    targetID,
 			AccountID:   accountID,
 			Meta:        meta,
-		})
+		}
+		stored, err := am.eventStore.Save(event)
 		if err != nil {
 			// todo add metric
 			log.Errorf("received an error while storing an activity event, error: %s", err)
+		} else {
+			event = stored
 		}
+		am.dispatchWebhooks(event)
 	}()
 
 }
@@ -32,9 +32,13 @@ func TestDefaultAccountManager_GetEvents(t *testing.T) {
 	}
 
 	accountID := "accountID"
+	_, err = createAccount(manager, accountID, userID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	t.Run("get empty events list", func(t *testing.T) {
-		events, err := manager.GetEvents(accountID, userID)
+		events, _, err := manager.GetEvents(accountID, userID, activity.Filter{Descending: true})
 		if err != nil {
 			return
 		}
@@ -44,7 +48,7 @@ func TestDefaultAccountManager_GetEvents(t *testing.T) {
 
 	t.Run("get events", func(t *testing.T) {
 		generateAndStoreEvents(t, manager, activity.PeerAddedByUser, userID, "peer", accountID, 10)
-		events, err := manager.GetEvents(accountID, userID)
+		events, _, err := manager.GetEvents(accountID, userID, activity.Filter{Descending: true})
 		if err != nil {
 			return
 		}
@@ -55,11 +59,52 @@ func TestDefaultAccountManager_GetEvents(t *testing.T) {
 
 	t.Run("get events without duplicates", func(t *testing.T) {
 		generateAndStoreEvents(t, manager, activity.UserJoined, userID, "", accountID, 10)
-		events, err := manager.GetEvents(accountID, userID)
+		events, _, err := manager.GetEvents(accountID, userID, activity.Filter{Descending: true})
 		if err != nil {
 			return
 		}
 		assert.Len(t, events, 1)
 		_ = manager.eventStore.Close() //nolint
 	})
+
+	t.Run("get events pages", func(t *testing.T) {
+		generateAndStoreEvents(t, manager, activity.PeerAddedByUser, userID, "peer", accountID, 10)
+		generateAndStoreEvents(t, manager, activity.GroupCreated, userID, "group", accountID, 3)
+
+		filter := activity.Filter{Activities: []activity.Activity{activity.PeerAddedByUser}, Limit: 4, Descending: true}
+		var pages [][]*activity.Event
+		for {
+			events, cursor, err := manager.GetEvents(accountID, userID, filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pages = append(pages, events)
+			if cursor == "" {
+				break
+			}
+			filter.Cursor = cursor
+		}
+
+		assert.Len(t, pages, 3)
+		assert.Len(t, pages[2], 2, "the last page should contain the remaining events")
+		_ = manager.eventStore.Close() //nolint
+	})
+
+	t.Run("cap the events of a request without parameters", func(t *testing.T) {
+		generateAndStoreEvents(t, manager, activity.PeerAddedByUser, userID, "peer", accountID, maxEventsLimit+1)
+		events, cursor, err := manager.GetEvents(accountID, userID, activity.Filter{Descending: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, events, maxEventsLimit, "a request without parameters should be capped at the page limit")
+		assert.NotEmpty(t, cursor)
+
+		events, cursor, err = manager.GetEvents(accountID, userID, activity.Filter{TargetID: "peer", Descending: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, events, maxEventsLimit, "a filtered request should default to the page limit")
+		assert.NotEmpty(t, cursor)
+		_ = manager.eventStore.Close() //nolint
+	})
 }
@@ -76,6 +76,16 @@ func (am *DefaultAccountManager) SaveGroup(accountID, userID string, newGroup *G
 	if err != nil {
 		return err
 	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to update groups")
+	}
+
 	oldGroup, exists := account.Groups[newGroup.ID]
 	account.Groups[newGroup.ID] = newGroup
 
@@ -84,7 +94,14 @@ func (am *DefaultAccountManager) SaveGroup(accountID, userID string, newGroup *G
 		return err
 	}
 
-	am.updateAccountPeers(account)
+	affectedPeers := account.getPeersAffectedByGroups(newGroup.ID)
+	if exists {
+		// the peers removed from the group are no longer its members
+		for _, peerID := range oldGroup.Peers {
+			affectedPeers[peerID] = struct{}{}
+		}
+	}
+	am.updateAffectedPeersDNS(account, affectedPeers)
 
 	// the following snippet tracks the activity and stores the group events in the event store.
 	// It has to happen after all the operations have been successfully performed.
@@ -152,6 +169,15 @@ func (am *DefaultAccountManager) DeleteGroup(accountId, userId, groupID string)
 		return err
 	}
 
+	user, err := account.FindUser(userId)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to delete groups")
+	}
+
 	g, ok := account.Groups[groupID]
 	if !ok {
 		return nil
@@ -175,6 +201,15 @@ func (am *DefaultAccountManager) DeleteGroup(accountId, userId, groupID string)
 		}
 	}
 
+	// check DNS zone links
+	for _, zone := range account.DNSZones {
+		for _, g := range zone.DistributionGroups {
+			if g == groupID {
+				return &GroupLinkError{"DNS zone", zone.Name}
+			}
+		}
+	}
+
 	// check ACL links
 	for _, policy := range account.Policies {
 		for _, rule := range policy.Rules {
@@ -217,6 +252,7 @@ func (am *DefaultAccountManager) DeleteGroup(accountId, userId, groupID string)
 		}
 	}
 
+	affectedPeers := account.getPeersAffectedByGroups(groupID)
 	delete(account.Groups, groupID)
 
 	account.Network.IncSerial()
@@ -226,7 +262,7 @@ func (am *DefaultAccountManager) DeleteGroup(accountId, userId, groupID string)
 
 	am.storeEvent(userId, groupID, accountId, activity.GroupDeleted, g.EventMeta())
 
-	am.updateAccountPeers(account)
+	am.updateAffectedPeersDNS(account, affectedPeers)
 
 	return nil
 }
@@ -280,7 +316,7 @@ func (am *DefaultAccountManager) GroupAddPeer(accountID, groupID, peerID string)
 		return err
 	}
 
-	am.updateAccountPeers(account)
+	am.updateAffectedPeersDNS(account, account.getPeersAffectedByGroups(groupID))
 
 	return nil
 }
@@ -310,7 +346,9 @@ func (am *DefaultAccountManager) GroupDeletePeer(accountID, groupID, peerID stri
 		}
 	}
 
-	am.updateAccountPeers(account)
+	affectedPeers := account.getPeersAffectedByGroups(groupID)
+	affectedPeers[peerID] = struct{}{}
+	am.updateAffectedPeersDNS(account, affectedPeers)
 
 	return nil
 }
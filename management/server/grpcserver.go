@@ -131,7 +131,13 @@ func (s *GRPCServer) Sync(req *proto.EncryptedMessage, srv proto.ManagementServi
 		return mapError(err)
 	}
 
-	err = s.sendInitialSync(peerKey, peer, netMap, srv)
+	// the network map sent on the stream, nil if the peer doesn't support network map deltas
+	var mapStream *networkMapStream
+	if syncReq.GetNetworkMapDeltaSupported() {
+		mapStream = &networkMapStream{}
+	}
+
+	err = s.sendInitialSync(peerKey, peer, netMap, mapStream, srv)
 	if err != nil {
 		log.Debugf("error while sending initial sync for %s: %v", peerKey.String(), err)
 		return err
@@ -171,7 +177,12 @@ func (s *GRPCServer) Sync(req *proto.EncryptedMessage, srv proto.ManagementServi
 			}
 			log.Debugf("recevied an update for peer %s", peerKey.String())
 
-			encryptedResp, err := encryption.EncryptMessage(peerKey, s.wgKey, update.Update)
+			resp := update.Update
+			if mapStream != nil {
+				resp = mapStream.toResponse(update)
+			}
+
+			encryptedResp, err := encryption.EncryptMessage(peerKey, s.wgKey, resp)
 			if err != nil {
 				s.cancelPeerRoutines(peer)
 				return status.Errorf(codes.Internal, "failed processing update message")
@@ -245,14 +256,15 @@ func mapError(err error) error {
 
 func extractPeerMeta(loginReq *proto.LoginRequest) PeerSystemMeta {
 	return PeerSystemMeta{
-		Hostname:  loginReq.GetMeta().GetHostname(),
-		GoOS:      loginReq.GetMeta().GetGoOS(),
-		Kernel:    loginReq.GetMeta().GetKernel(),
-		Core:      loginReq.GetMeta().GetCore(),
-		Platform:  loginReq.GetMeta().GetPlatform(),
-		OS:        loginReq.GetMeta().GetOS(),
-		WtVersion: loginReq.GetMeta().GetWiretrusteeVersion(),
-		UIVersion: loginReq.GetMeta().GetUiVersion(),
+		Hostname:     loginReq.GetMeta().GetHostname(),
+		GoOS:         loginReq.GetMeta().GetGoOS(),
+		Kernel:       loginReq.GetMeta().GetKernel(),
+		Core:         loginReq.GetMeta().GetCore(),
+		Platform:     loginReq.GetMeta().GetPlatform(),
+		OS:           loginReq.GetMeta().GetOS(),
+		WtVersion:    loginReq.GetMeta().GetWiretrusteeVersion(),
+		UIVersion:    loginReq.GetMeta().GetUiVersion(),
+		Capabilities: loginReq.GetMeta().GetCapabilities(),
 	}
 }
 
@@ -416,20 +428,31 @@ func toWiretrusteeConfig(config *Config, turnCredentials *TURNCredentials) *prot
 func toPeerConfig(peer *Peer, network *Network, dnsName string) *proto.PeerConfig {
 	netmask, _ := network.Net.Mask.Size()
 	fqdn := peer.FQDN(dnsName)
+	var addressV6 string
+	if peer.SupportsIPv6() && network.HasIPv6() {
+		netmaskV6, _ := network.NetV6.Mask.Size()
+		addressV6 = fmt.Sprintf("%s/%d", peer.IPv6.String(), netmaskV6)
+	}
 	return &proto.PeerConfig{
 		Address:   fmt.Sprintf("%s/%d", peer.IP.String(), netmask), // take it from the network
+		AddressV6: addressV6,
 		SshConfig: &proto.SSHConfig{SshEnabled: peer.SSHEnabled},
 		Fqdn:      fqdn,
 	}
 }
 
-func toRemotePeerConfig(peers []*Peer, dnsName string) []*proto.RemotePeerConfig {
+// toRemotePeerConfig converts the remote peers, their IPv6 overlay addresses are included only if ipv6 is true
+func toRemotePeerConfig(peers []*Peer, dnsName string, ipv6 bool) []*proto.RemotePeerConfig {
 	remotePeers := []*proto.RemotePeerConfig{}
 	for _, rPeer := range peers {
 		fqdn := rPeer.FQDN(dnsName)
+		allowedIPs := []string{fmt.Sprintf(AllowedIPsFormat, rPeer.IP)}
+		if ipv6 && rPeer.SupportsIPv6() {
+			allowedIPs = append(allowedIPs, fmt.Sprintf(AllowedIPsV6Format, rPeer.IPv6))
+		}
 		remotePeers = append(remotePeers, &proto.RemotePeerConfig{
 			WgPubKey:   rPeer.Key,
-			AllowedIps: []string{fmt.Sprintf(AllowedIPsFormat, rPeer.IP)},
+			AllowedIps: allowedIPs,
 			SshConfig:  &proto.SSHConfig{SshPubKey: []byte(rPeer.SSHKey)},
 			Fqdn:       fqdn,
 		})
@@ -442,13 +465,13 @@ func toSyncResponse(config *Config, peer *Peer, turnCredentials *TURNCredentials
 
 	pConfig := toPeerConfig(peer, networkMap.Network, dnsName)
 
-	remotePeers := toRemotePeerConfig(networkMap.Peers, dnsName)
+	remotePeers := toRemotePeerConfig(networkMap.Peers, dnsName, peer.SupportsIPv6())
 
 	routesUpdate := toProtocolRoutes(networkMap.Routes)
 
 	dnsUpdate := toProtocolDNSConfig(networkMap.DNSConfig)
 
-	offlinePeers := toRemotePeerConfig(networkMap.OfflinePeers, dnsName)
+	offlinePeers := toRemotePeerConfig(networkMap.OfflinePeers, dnsName, peer.SupportsIPv6())
 
 	firewallRules := toProtocolFirewallRules(networkMap.FirewallRules)
 
@@ -476,8 +499,9 @@ func (s *GRPCServer) IsHealthy(ctx context.Context, req *proto.Empty) (*proto.Em
 	return &proto.Empty{}, nil
 }
 
-// sendInitialSync sends initial proto.SyncResponse to the peer requesting synchronization
-func (s *GRPCServer) sendInitialSync(peerKey wgtypes.Key, peer *Peer, networkMap *NetworkMap, srv proto.ManagementService_SyncServer) error {
+// sendInitialSync sends initial proto.SyncResponse to the peer requesting synchronization.
+// The mapStream is nil if the peer doesn't support network map deltas
+func (s *GRPCServer) sendInitialSync(peerKey wgtypes.Key, peer *Peer, networkMap *NetworkMap, mapStream *networkMapStream, srv proto.ManagementService_SyncServer) error {
 	// make secret time based TURN credentials optional
 	var turnCredentials *TURNCredentials
 	if s.config.TURNConfig.TimeBasedCredentials {
@@ -487,6 +511,12 @@ func (s *GRPCServer) sendInitialSync(peerKey wgtypes.Key, peer *Peer, networkMap
 		turnCredentials = nil
 	}
 	plainResp := toSyncResponse(s.config, peer, turnCredentials, networkMap, s.accountManager.GetDNSDomain())
+	if mapStream != nil {
+		plainResp = mapStream.toResponse(&UpdateMessage{
+			Update:           plainResp,
+			networkMapUpdate: &networkMapUpdate{peer: peer, networkMap: networkMap, dnsName: s.accountManager.GetDNSDomain()},
+		})
+	}
 
 	encryptedResp, err := encryption.EncryptMessage(peerKey, s.wgKey, plainResp)
 	if err != nil {
@@ -0,0 +1,487 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/management/server/status"
+)
+
+const (
+	defaultHALeaderCheckInterval = 10 * time.Second
+	// haLockRetryInterval is the interval between two attempts to acquire a lock after a database error
+	haLockRetryInterval = time.Second
+	// haUnlockTimeout bounds the release of a lock, the connection holding it is closed when it can't be released
+	haUnlockTimeout = 5 * time.Second
+	// haListenerPingInterval is the interval of checking the connection listening to the account changes
+	haListenerPingInterval = 90 * time.Second
+	haGlobalLockName       = "global"
+	// haLeaderLockName is the lock held by the replica running the jobs that have to run once across the replicas
+	haLeaderLockName = "leader"
+	// accountChangesChannel is the Postgres notification channel the account changes are published on
+	accountChangesChannel = "netbird_account_changes"
+	// maxAccountChangePayload keeps the account changes below the 8000 bytes limit of the Postgres notifications
+	maxAccountChangePayload = 7900
+	// leaderSyncInterval is the interval at which the jobs of the leader pick up the changes of the other replicas
+	leaderSyncInterval = time.Minute
+)
+
+// haSchema holds the statements creating the tables the replicas coordinate through
+var haSchema = []string{
+	// the token of a lock is incremented every time a replica acquires the lock
+	`CREATE TABLE IF NOT EXISTS ha_locks (name TEXT PRIMARY KEY, token BIGINT NOT NULL);`,
+}
+
+// AccountChangesBroadcaster is implemented by the stores shared by several management replicas.
+// It lets every replica push the network map updates to the peers connected to it
+type AccountChangesBroadcaster interface {
+	// PublishAccountChange notifies the other replicas that the given peers of the account have to be updated.
+	// A nil peerIDs means all the peers of the account
+	PublishAccountChange(accountID string, peerIDs []string) error
+	// WatchAccountChanges calls the handler with the account changes published by the other replicas until
+	// the context is done
+	WatchAccountChanges(ctx context.Context, handler func(accountID string, peerIDs []string))
+}
+
+// LeaderElector is implemented by the stores shared by several management replicas. A single replica at a time holds
+// the leader lock and runs the jobs that have to run once across the replicas, e.g. deleting the ephemeral peers
+type LeaderElector interface {
+	// RunAsLeader calls the job every time the replica acquires the leader lock until ctx is done. The context passed
+	// to the job is done when the replica loses the lock, the job is expected to return then
+	RunAsLeader(ctx context.Context, job func(ctx context.Context))
+}
+
+// HAStore is a Postgres SqlStore shared by several management replicas. The account and global locks are Postgres
+// advisory locks held across the replicas by a dedicated connection, Postgres releases them when the connection is
+// lost. Every lock acquisition increments the fencing token of the lock and the writes are rejected once the token
+// of the lock they are made under is stale, i.e. once another replica acquired the lock.
+// The account changes are broadcast with Postgres notifications
+type HAStore struct {
+	*SqlStore
+
+	replicaID           string
+	leaderCheckInterval time.Duration
+	// closed is done when the store is closed
+	closed context.Context
+	close  context.CancelFunc
+	// campaignDone is closed when the replica stopped running for the leader lock
+	campaignDone chan struct{}
+
+	// heldLocks maps the names of the locks held by the replica to their *haLock
+	heldLocks sync.Map
+
+	leaderMu sync.Mutex
+	// leaderCtx is done when the replica loses the leader lock, nil while another replica holds it
+	leaderCtx    context.Context
+	leaderCancel context.CancelFunc
+	// leaderChanged is closed and replaced when the replica acquires or loses the leader lock
+	leaderChanged chan struct{}
+}
+
+// haLock is a lock held by the replica
+type haLock struct {
+	conn  *sql.Conn
+	token int64
+}
+
+// accountChange is the payload of the account change notifications
+type accountChange struct {
+	ReplicaID string   `json:"replica_id"`
+	AccountID string   `json:"account_id"`
+	PeerIDs   []string `json:"peer_ids"`
+}
+
+// NewHAStore returns a store shared with the other management replicas. The store has to be a SqlStore backed by the
+// Postgres database shared by all the replicas
+func NewHAStore(store Store, config HAConfig) (*HAStore, error) {
+	sqlStore, ok := store.(*SqlStore)
+	if !ok || sqlStore.GetStoreEngine() != PostgresStoreEngine {
+		return nil, fmt.Errorf("running multiple replicas requires the %s store engine, got %s",
+			PostgresStoreEngine, store.GetStoreEngine())
+	}
+
+	for _, stmt := range haSchema {
+		if _, err := sqlStore.db.Exec(stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	closed, closeFunc := context.WithCancel(context.Background())
+	haStore := &HAStore{
+		SqlStore:            sqlStore,
+		closed:              closed,
+		close:               closeFunc,
+		campaignDone:        make(chan struct{}),
+		replicaID:           config.ReplicaID,
+		leaderCheckInterval: config.LeaderCheckInterval.Duration,
+		leaderChanged:       make(chan struct{}),
+	}
+	if haStore.replicaID == "" {
+		hostname, _ := os.Hostname()
+		haStore.replicaID = hostname + "-" + xid.New().String()
+	}
+	if haStore.leaderCheckInterval <= 0 {
+		haStore.leaderCheckInterval = defaultHALeaderCheckInterval
+	}
+
+	sqlStore.fence = haStore.checkFence
+	go haStore.campaign()
+
+	log.Infof("running as the management replica %s", haStore.replicaID)
+	return haStore, nil
+}
+
+// Close stops watching the account changes, releases the leader lock and closes the store
+func (s *HAStore) Close() error {
+	s.close()
+	<-s.campaignDone
+	return s.SqlStore.Close()
+}
+
+// RunAsLeader calls the job every time the replica acquires the leader lock until ctx is done or the store is closed.
+// The context passed to the job is done when the replica loses the lock
+func (s *HAStore) RunAsLeader(ctx context.Context, job func(ctx context.Context)) {
+	for {
+		s.leaderMu.Lock()
+		leaderCtx, changed := s.leaderCtx, s.leaderChanged
+		s.leaderMu.Unlock()
+
+		if leaderCtx != nil {
+			jobCtx, cancel := context.WithCancel(ctx)
+			go func() {
+				select {
+				case <-leaderCtx.Done():
+				case <-jobCtx.Done():
+				}
+				cancel()
+			}()
+			job(jobCtx)
+			cancel()
+
+			// the job runs once per leadership, wait for the lock to be lost before running it again
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.closed.Done():
+				return
+			case <-leaderCtx.Done():
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.closed.Done():
+			return
+		case <-changed:
+		}
+	}
+}
+
+// campaign waits for the leader lock and holds it while its connection is alive, until the store is closed
+func (s *HAStore) campaign() {
+	defer close(s.campaignDone)
+	for {
+		lock, err := s.lock(haLeaderLockName)
+		if err != nil {
+			if s.closed.Err() != nil {
+				return
+			}
+			log.Errorf("failed to acquire the leader lock: %v", err)
+			select {
+			case <-s.closed.Done():
+				return
+			case <-time.After(haLockRetryInterval):
+			}
+			continue
+		}
+
+		s.setLeader(true)
+		s.holdLeaderLock(lock)
+		s.setLeader(false)
+		s.unlock(haLeaderLockName, lock)
+	}
+}
+
+// holdLeaderLock returns when the store is closed or the connection holding the leader lock is lost, Postgres
+// releases the lock then
+func (s *HAStore) holdLeaderLock(lock *haLock) {
+	ticker := time.NewTicker(s.leaderCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closed.Done():
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(s.closed, s.leaderCheckInterval)
+		err := lock.conn.PingContext(ctx)
+		cancel()
+		if err != nil {
+			if s.closed.Err() == nil {
+				log.Errorf("lost the connection holding the leader lock: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// setLeader updates the leadership of the replica and notifies the RunAsLeader loops about the change
+func (s *HAStore) setLeader(leader bool) {
+	s.leaderMu.Lock()
+	defer s.leaderMu.Unlock()
+
+	if leader == (s.leaderCtx != nil) {
+		return
+	}
+
+	if leader {
+		s.leaderCtx, s.leaderCancel = context.WithCancel(context.Background())
+		log.Infof("the management replica %s is the leader", s.replicaID)
+	} else {
+		s.leaderCancel()
+		s.leaderCtx, s.leaderCancel = nil, nil
+		log.Infof("the management replica %s is no longer the leader", s.replicaID)
+	}
+
+	close(s.leaderChanged)
+	s.leaderChanged = make(chan struct{})
+}
+
+// AcquireGlobalLock acquires global lock across all the accounts and replicas and returns a function that releases
+// the lock
+func (s *HAStore) AcquireGlobalLock() (unlock func()) {
+	unlockLocal := s.SqlStore.AcquireGlobalLock()
+	unlockShared := s.acquireSharedLock(haGlobalLockName)
+	return func() {
+		unlockShared()
+		unlockLocal()
+	}
+}
+
+// AcquireAccountLock acquires account lock across all the replicas and returns a function that releases the lock
+func (s *HAStore) AcquireAccountLock(accountID string) (unlock func()) {
+	unlockLocal := s.SqlStore.AcquireAccountLock(accountID)
+	unlockShared := s.acquireSharedLock(accountLockName(accountID))
+	return func() {
+		unlockShared()
+		unlockLocal()
+	}
+}
+
+func accountLockName(accountID string) string {
+	return "account/" + accountID
+}
+
+// acquireSharedLock waits until the lock is released by the other replicas and holds it. The lock is acquired by a
+// single goroutine of the replica at a time, the local lock is held first. When the store is closed meanwhile the
+// lock isn't held, the writes made under it are rejected
+func (s *HAStore) acquireSharedLock(name string) (unlock func()) {
+	start := time.Now()
+	for {
+		lock, err := s.lock(name)
+		if err == nil {
+			log.Debugf("took %v to acquire the shared lock %s", time.Since(start), name)
+			s.heldLocks.Store(name, lock)
+			return func() {
+				s.heldLocks.Delete(name)
+				s.unlock(name, lock)
+			}
+		}
+		if s.closed.Err() != nil {
+			return func() {}
+		}
+
+		log.Errorf("failed to acquire the shared lock %s: %v", name, err)
+		select {
+		case <-s.closed.Done():
+		case <-time.After(haLockRetryInterval):
+		}
+	}
+}
+
+// lock waits for the advisory lock on a dedicated connection and increments its fencing token
+func (s *HAStore) lock(name string) (*haLock, error) {
+	conn, err := s.db.DB.Conn(s.closed)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = conn.ExecContext(s.closed, `SELECT pg_advisory_lock(hashtextextended($1, 0))`, name)
+	if err != nil {
+		discardConn(conn)
+		return nil, err
+	}
+
+	lock := &haLock{conn: conn}
+	err = conn.QueryRowContext(s.closed, `INSERT INTO ha_locks (name, token) VALUES ($1, 1)
+		ON CONFLICT (name) DO UPDATE SET token = ha_locks.token + 1 RETURNING token`, name).Scan(&lock.token)
+	if err != nil {
+		discardConn(conn)
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+// unlock releases the advisory lock and returns its connection to the pool. The connection is closed instead when the
+// lock can't be released, Postgres releases the lock then
+func (s *HAStore) unlock(name string, lock *haLock) {
+	ctx, cancel := context.WithTimeout(context.Background(), haUnlockTimeout)
+	defer cancel()
+
+	var unlocked bool
+	err := lock.conn.QueryRowContext(ctx, `SELECT pg_advisory_unlock(hashtextextended($1, 0))`, name).Scan(&unlocked)
+	if err != nil || !unlocked {
+		log.Warnf("failed to release the shared lock %s, closing its connection: %v", name, err)
+		discardConn(lock.conn)
+		return
+	}
+	_ = lock.conn.Close()
+}
+
+// discardConn closes the connection instead of returning it to the pool
+func discardConn(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+	_ = conn.Close()
+}
+
+// checkFence rejects the write to the account unless the replica holds the lock of the account, or the global lock,
+// and no other replica acquired the lock since. The token row is locked until the write transaction ends, so another
+// replica acquiring the lock waits for the write
+func (s *HAStore) checkFence(tx *sqlTx, accountID string) error {
+	name := accountLockName(accountID)
+	value, ok := s.heldLocks.Load(name)
+	if accountID == "" || !ok {
+		name = haGlobalLockName
+		value, ok = s.heldLocks.Load(name)
+	}
+	if !ok {
+		return status.Errorf(status.Internal, "write to account %s rejected: the replica doesn't hold its lock", accountID)
+	}
+
+	var token int64
+	err := tx.QueryRow(`SELECT token FROM ha_locks WHERE name = ? FOR SHARE`, name).Scan(&token)
+	if err != nil {
+		return err
+	}
+	if token != value.(*haLock).token {
+		return status.Errorf(status.Internal, "write to account %s rejected: the lock %s was acquired by another replica",
+			accountID, name)
+	}
+
+	return nil
+}
+
+// PublishAccountChange notifies the other replicas that the given peers of the account have to be updated.
+// A nil peerIDs means all the peers of the account
+func (s *HAStore) PublishAccountChange(accountID string, peerIDs []string) error {
+	payload, err := json.Marshal(accountChange{ReplicaID: s.replicaID, AccountID: accountID, PeerIDs: peerIDs})
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxAccountChangePayload {
+		// too many peers for a notification, all the peers of the account are updated
+		payload, err = json.Marshal(accountChange{ReplicaID: s.replicaID, AccountID: accountID})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.db.Exec(`SELECT pg_notify(?, ?)`, accountChangesChannel, string(payload))
+	return err
+}
+
+// WatchAccountChanges listens to the account changes published by the other replicas and calls the handler with them
+// until the context is done or the store is closed. All the peers of all the accounts are updated when the listening
+// connection is restored, as the changes published meanwhile are lost
+func (s *HAStore) WatchAccountChanges(ctx context.Context, handler func(accountID string, peerIDs []string)) {
+	listener := pq.NewListener(s.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Errorf("the connection listening to the account changes failed: %v", err)
+		}
+	})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-s.closed.Done():
+		}
+		_ = listener.Close()
+	}()
+
+	if err := listener.Listen(accountChangesChannel); err != nil {
+		log.Errorf("failed to listen to the account changes of the other replicas: %v", err)
+		return
+	}
+
+	ticker := time.NewTicker(haListenerPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			go func() {
+				if err := listener.Ping(); err != nil {
+					log.Warnf("the connection listening to the account changes is lost: %v", err)
+				}
+			}()
+		case notification, ok := <-listener.Notify:
+			if !ok {
+				return
+			}
+			if notification == nil {
+				s.handleLostAccountChanges(handler)
+				continue
+			}
+
+			var change accountChange
+			if err := json.Unmarshal([]byte(notification.Extra), &change); err != nil {
+				log.Errorf("failed to read an account change of the other replicas: %v", err)
+				continue
+			}
+			if change.ReplicaID == s.replicaID {
+				continue
+			}
+			handler(change.AccountID, change.PeerIDs)
+		}
+	}
+}
+
+// handleLostAccountChanges calls the handler with all the peers of all the accounts
+func (s *HAStore) handleLostAccountChanges(handler func(accountID string, peerIDs []string)) {
+	log.Warnf("the account changes of the other replicas may have been lost, updating all the peers")
+
+	rows, err := s.db.Query(`SELECT id FROM accounts`)
+	if err != nil {
+		log.Errorf("failed to list the accounts: %v", err)
+		return
+	}
+	var accountIDs []string
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			log.Errorf("failed to read an account id: %v", err)
+			continue
+		}
+		accountIDs = append(accountIDs, id)
+	}
+	_ = rows.Close()
+
+	// the handler runs after the rows are closed as it uses the store too
+	for _, accountID := range accountIDs {
+		handler(accountID, nil)
+	}
+}
@@ -0,0 +1,247 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/management/server/activity"
+	"github.com/netbirdio/netbird/util"
+)
+
+// newTestHAStores returns two replicas of a store sharing the same Postgres database, see newTestPostgresDSN
+func newTestHAStores(t *testing.T) (*HAStore, *HAStore) {
+	t.Helper()
+	dsn := newTestPostgresDSN(t)
+
+	newReplica := func(replicaID string) *HAStore {
+		sqlStore, err := NewPostgresStore(dsn, nil)
+		require.NoError(t, err)
+
+		store, err := NewHAStore(sqlStore, HAConfig{
+			Enabled:             true,
+			ReplicaID:           replicaID,
+			LeaderCheckInterval: util.Duration{Duration: 100 * time.Millisecond},
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = store.Close()
+		})
+		return store
+	}
+
+	return newReplica("replica1"), newReplica("replica2")
+}
+
+func TestNewHAStore_RequiresPostgres(t *testing.T) {
+	_, err := NewHAStore(newSqliteStore(t), HAConfig{Enabled: true})
+	assert.Error(t, err)
+}
+
+func TestHAStore_AccountLock(t *testing.T) {
+	store1, store2 := newTestHAStores(t)
+
+	unlock := store1.AcquireAccountLock("account")
+
+	acquired := make(chan struct{})
+	go func() {
+		unlock := store2.AcquireAccountLock("account")
+		close(acquired)
+		unlock()
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("the lock held by another replica should not be acquired")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// locks of other accounts are independent
+	unlockOther := store2.AcquireAccountLock("other")
+	unlockOther()
+
+	unlock()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the released lock should be acquired by the other replica")
+	}
+}
+
+func TestHAStore_LostLock(t *testing.T) {
+	store1, store2 := newTestHAStores(t)
+
+	account := newAccountWithId("account", "user", "")
+	unlock := store1.AcquireAccountLock(account.Id)
+	require.NoError(t, store1.SaveAccount(account))
+
+	// the connection holding the lock is lost, Postgres releases the lock
+	value, ok := store1.heldLocks.Load(accountLockName(account.Id))
+	require.True(t, ok)
+	var pid int
+	require.NoError(t, value.(*haLock).conn.QueryRowContext(context.Background(), `SELECT pg_backend_pid()`).Scan(&pid))
+	_, err := store2.db.Exec(`SELECT pg_terminate_backend(?)`, pid)
+	require.NoError(t, err)
+
+	acquired := make(chan func())
+	go func() {
+		acquired <- store2.AcquireAccountLock(account.Id)
+	}()
+	var unlock2 func()
+	select {
+	case unlock2 = <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the lock of the lost connection should be acquired by the other replica")
+	}
+	defer unlock2()
+
+	assert.NoError(t, store2.SaveAccount(account), "the replica holding the lock should write")
+	assert.Error(t, store1.SaveAccount(account), "the write made under the lost lock should be rejected")
+	unlock()
+}
+
+func TestHAStore_WriteWithoutLock(t *testing.T) {
+	store, _ := newTestHAStores(t)
+
+	account := newAccountWithId("account", "user", "")
+	assert.Error(t, store.SaveAccount(account), "the write made without the account lock should be rejected")
+
+	unlock := store.AcquireGlobalLock()
+	defer unlock()
+	assert.NoError(t, store.SaveAccount(account), "the write made under the global lock should be accepted")
+}
+
+func TestHAStore_AccountChanges(t *testing.T) {
+	store1, store2 := newTestHAStores(t)
+
+	type change struct {
+		accountID string
+		peerIDs   []string
+	}
+	var mu sync.Mutex
+	var received []change
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go store1.WatchAccountChanges(ctx, func(accountID string, peerIDs []string) {
+		t.Errorf("the replica should not receive its own changes, got %s", accountID)
+	})
+	go store2.WatchAccountChanges(ctx, func(accountID string, peerIDs []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, change{accountID: accountID, peerIDs: peerIDs})
+	})
+	// let the watchers listen to the changes
+	time.Sleep(200 * time.Millisecond)
+
+	require.NoError(t, store1.PublishAccountChange("account1", nil))
+	require.NoError(t, store1.PublishAccountChange("account2", []string{"peer1", "peer2"}))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, change{accountID: "account1"}, received[0], "a nil peers list should mean all the peers")
+	assert.Equal(t, change{accountID: "account2", peerIDs: []string{"peer1", "peer2"}}, received[1])
+}
+
+func TestDefaultAccountManager_HAReplicas(t *testing.T) {
+	store1, store2 := newTestHAStores(t)
+
+	newManager := func(store Store) *DefaultAccountManager {
+		manager, err := BuildManager(store, NewPeersUpdateManager(nil), nil, "", "netbird.cloud",
+			&activity.InMemoryEventStore{}, false, 0, nil)
+		require.NoError(t, err)
+		return manager
+	}
+	manager1 := newManager(store1)
+	manager2 := newManager(store2)
+
+	// 4 groups of 2 peers: group0 <-> group1 <-> group2 <-> group3 <-> group0
+	account := newSyntheticAccount(8, 2)
+	unlock := store1.AcquireAccountLock(account.Id)
+	require.NoError(t, store1.SaveAccount(account))
+	unlock()
+	// let the watchers listen to the changes
+	time.Sleep(200 * time.Millisecond)
+
+	// peer4 is connected to the second replica
+	updates := manager2.peersUpdateManager.CreateChannel("peer4")
+
+	r := account.Routes["route2"].Copy()
+	r.Description = "updated"
+	require.NoError(t, manager1.SaveRoute(account.Id, "user", r))
+
+	select {
+	case update := <-updates:
+		require.NotNil(t, update.Update.NetworkMap)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the peer connected to the other replica should be updated")
+	}
+
+	require.NoError(t, manager1.DeletePeer(account.Id, "peer4", "user"))
+	require.Eventually(t, func() bool {
+		_, connected := manager2.peersUpdateManager.GetAllConnectedPeers()["peer4"]
+		return !connected
+	}, 5*time.Second, 10*time.Millisecond, "the peer deleted by the other replica should be disconnected")
+}
+
+func TestHAStore_RunAsLeader(t *testing.T) {
+	store1, store2 := newTestHAStores(t)
+
+	var mu sync.Mutex
+	var leaders []string
+	running := make(map[string]bool)
+	job := func(replicaID string) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			mu.Lock()
+			leaders = append(leaders, replicaID)
+			running[replicaID] = true
+			mu.Unlock()
+
+			<-ctx.Done()
+
+			mu.Lock()
+			running[replicaID] = false
+			mu.Unlock()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store1.RunAsLeader(ctx, job(store1.replicaID))
+	go store2.RunAsLeader(ctx, job(store2.replicaID))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(leaders) == 1
+	}, 5*time.Second, 10*time.Millisecond, "a replica should acquire the leader lock")
+
+	// the lock is held while its connection is alive
+	time.Sleep(5 * store1.leaderCheckInterval)
+	mu.Lock()
+	require.Len(t, leaders, 1, "a single replica should run the job")
+	first := leaders[0]
+	mu.Unlock()
+
+	leader, follower := store1, store2
+	if first == store2.replicaID {
+		leader, follower = store2, store1
+	}
+	require.NoError(t, leader.Close())
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(leaders) == 2 && leaders[1] == follower.replicaID && running[follower.replicaID] &&
+			!running[leader.replicaID]
+	}, 5*time.Second, 10*time.Millisecond, "the other replica should take over the released leader lock")
+}
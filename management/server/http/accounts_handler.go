@@ -3,6 +3,7 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+	"net/netip"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -40,7 +41,7 @@ func (h *AccountsHandler) GetAllAccounts(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if !user.IsAdmin() {
+	if !user.HasReadAllAccess() {
 		util.WriteError(status.Errorf(status.PermissionDenied, "the user has no permission to access account data"), w)
 		return
 	}
@@ -86,6 +87,23 @@ func (h *AccountsHandler) UpdateAccount(w http.ResponseWriter, r *http.Request)
 	if req.Settings.JwtGroupsClaimName != nil {
 		settings.JWTGroupsClaimName = *req.Settings.JwtGroupsClaimName
 	}
+	if req.Settings.PeerApprovalEnabled != nil {
+		settings.PeerApprovalEnabled = *req.Settings.PeerApprovalEnabled
+	}
+
+	if req.Settings.NetworkRange != nil {
+		networkRange, err := netip.ParsePrefix(*req.Settings.NetworkRange)
+		if err != nil {
+			util.WriteError(status.Errorf(status.InvalidArgument, "invalid network range %q", *req.Settings.NetworkRange), w)
+			return
+		}
+
+		_, err = h.accountManager.UpdateAccountNetworkRange(accountID, user.Id, networkRange)
+		if err != nil {
+			util.WriteError(err, w)
+			return
+		}
+	}
 
 	updatedAccount, err := h.accountManager.UpdateAccountSettings(accountID, user.Id, settings)
 	if err != nil {
@@ -99,6 +117,12 @@ func (h *AccountsHandler) UpdateAccount(w http.ResponseWriter, r *http.Request)
 }
 
 func toAccountResponse(account *server.Account) *api.Account {
+	var networkRange *string
+	if account.Network != nil && account.Network.Net.IP != nil {
+		value := account.Network.Net.String()
+		networkRange = &value
+	}
+
 	return &api.Account{
 		Id: account.Id,
 		Settings: api.AccountSettings{
@@ -107,6 +131,8 @@ func toAccountResponse(account *server.Account) *api.Account {
 			GroupsPropagationEnabled:   &account.Settings.GroupsPropagationEnabled,
 			JwtGroupsEnabled:           &account.Settings.JWTGroupsEnabled,
 			JwtGroupsClaimName:         &account.Settings.JWTGroupsClaimName,
+			PeerApprovalEnabled:        &account.Settings.PeerApprovalEnabled,
+			NetworkRange:               networkRange,
 		},
 	}
 }
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"testing"
 	"time"
 
@@ -39,6 +41,14 @@ func initAccountsTestData(account *server.Account, admin *server.User) *Accounts
 				accCopy.UpdateSettings(newSettings)
 				return accCopy, nil
 			},
+			UpdateAccountNetworkRangeFunc: func(accountID, userID string, networkRange netip.Prefix) (*server.Account, error) {
+				if networkRange.Bits() < server.MinNetworkRangeSize || networkRange.Bits() > server.MaxNetworkRangeSize {
+					return nil, status.Errorf(status.InvalidArgument, "network range prefix length should be between /16 and /28")
+				}
+
+				account.Network.Net = net.IPNet{IP: networkRange.Addr().AsSlice(), Mask: net.CIDRMask(networkRange.Bits(), 32)}
+				return account.Copy(), nil
+			},
 		},
 		claimsExtractor: jwtclaims.NewClaimsExtractor(
 			jwtclaims.WithFromRequestContext(func(r *http.Request) jwtclaims.AuthorizationClaims {
@@ -62,7 +72,7 @@ func TestAccounts_AccountsHandler(t *testing.T) {
 	handler := initAccountsTestData(&server.Account{
 		Id:      accountID,
 		Domain:  "hotmail.com",
-		Network: server.NewNetwork(),
+		Network: &server.Network{Net: net.IPNet{IP: net.IP{100, 64, 0, 0}, Mask: net.CIDRMask(16, 32)}},
 		Users: map[string]*server.User{
 			adminUser.Id: adminUser,
 		},
@@ -95,6 +105,8 @@ func TestAccounts_AccountsHandler(t *testing.T) {
 				GroupsPropagationEnabled:   br(false),
 				JwtGroupsClaimName:         sr(""),
 				JwtGroupsEnabled:           br(false),
+				PeerApprovalEnabled:        br(false),
+				NetworkRange:               sr("100.64.0.0/16"),
 			},
 			expectedArray: true,
 			expectedID:    accountID,
@@ -112,6 +124,8 @@ func TestAccounts_AccountsHandler(t *testing.T) {
 				GroupsPropagationEnabled:   br(false),
 				JwtGroupsClaimName:         sr(""),
 				JwtGroupsEnabled:           br(false),
+				PeerApprovalEnabled:        br(false),
+				NetworkRange:               sr("100.64.0.0/16"),
 			},
 			expectedArray: false,
 			expectedID:    accountID,
@@ -129,6 +143,8 @@ func TestAccounts_AccountsHandler(t *testing.T) {
 				GroupsPropagationEnabled:   br(false),
 				JwtGroupsClaimName:         sr("roles"),
 				JwtGroupsEnabled:           br(true),
+				PeerApprovalEnabled:        br(false),
+				NetworkRange:               sr("100.64.0.0/16"),
 			},
 			expectedArray: false,
 			expectedID:    accountID,
@@ -138,7 +154,7 @@ func TestAccounts_AccountsHandler(t *testing.T) {
 			expectedBody:   true,
 			requestType:    http.MethodPut,
 			requestPath:    "/api/accounts/" + accountID,
-			requestBody:    bytes.NewBufferString("{\"settings\": {\"peer_login_expiration\": 554400,\"peer_login_expiration_enabled\": true,\"jwt_groups_enabled\":true,\"jwt_groups_claim_name\":\"groups\",\"groups_propagation_enabled\":true}}"),
+			requestBody:    bytes.NewBufferString("{\"settings\": {\"peer_login_expiration\": 554400,\"peer_login_expiration_enabled\": true,\"jwt_groups_enabled\":true,\"jwt_groups_claim_name\":\"groups\",\"groups_propagation_enabled\":true,\"peer_approval_enabled\":true}}"),
 			expectedStatus: http.StatusOK,
 			expectedSettings: api.AccountSettings{
 				PeerLoginExpiration:        554400,
@@ -146,6 +162,8 @@ func TestAccounts_AccountsHandler(t *testing.T) {
 				GroupsPropagationEnabled:   br(true),
 				JwtGroupsClaimName:         sr("groups"),
 				JwtGroupsEnabled:           br(true),
+				PeerApprovalEnabled:        br(true),
+				NetworkRange:               sr("100.64.0.0/16"),
 			},
 			expectedArray: false,
 			expectedID:    accountID,
@@ -168,6 +186,43 @@ func TestAccounts_AccountsHandler(t *testing.T) {
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedArray:  false,
 		},
+		{
+			name:           "Update account failure with invalid network_range",
+			expectedBody:   true,
+			requestType:    http.MethodPut,
+			requestPath:    "/api/accounts/" + accountID,
+			requestBody:    bytes.NewBufferString("{\"settings\": {\"peer_login_expiration\": 3600,\"peer_login_expiration_enabled\": true,\"network_range\":\"10.0.0.0\"}}"),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedArray:  false,
+		},
+		{
+			name:           "Update account failure with too large network_range",
+			expectedBody:   true,
+			requestType:    http.MethodPut,
+			requestPath:    "/api/accounts/" + accountID,
+			requestBody:    bytes.NewBufferString("{\"settings\": {\"peer_login_expiration\": 3600,\"peer_login_expiration_enabled\": true,\"network_range\":\"10.0.0.0/8\"}}"),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedArray:  false,
+		},
+		{
+			name:           "PutAccount OK with network_range",
+			expectedBody:   true,
+			requestType:    http.MethodPut,
+			requestPath:    "/api/accounts/" + accountID,
+			requestBody:    bytes.NewBufferString("{\"settings\": {\"peer_login_expiration\": 3600,\"peer_login_expiration_enabled\": true,\"network_range\":\"10.20.0.0/24\"}}"),
+			expectedStatus: http.StatusOK,
+			expectedSettings: api.AccountSettings{
+				PeerLoginExpiration:        3600,
+				PeerLoginExpirationEnabled: true,
+				GroupsPropagationEnabled:   br(false),
+				JwtGroupsClaimName:         sr(""),
+				JwtGroupsEnabled:           br(false),
+				PeerApprovalEnabled:        br(false),
+				NetworkRange:               sr("10.20.0.0/24"),
+			},
+			expectedArray: false,
+			expectedID:    accountID,
+		},
 	}
 
 	for _, tc := range tt {
@@ -12,6 +12,15 @@ const (
 	TokenAuthScopes  = "TokenAuth.Scopes"
 )
 
+// Defines values for DNSRecordType.
+const (
+	DNSRecordTypeA     DNSRecordType = "A"
+	DNSRecordTypeAAAA  DNSRecordType = "AAAA"
+	DNSRecordTypeCNAME DNSRecordType = "CNAME"
+	DNSRecordTypeSRV   DNSRecordType = "SRV"
+	DNSRecordTypeTXT   DNSRecordType = "TXT"
+)
+
 // Defines values for EventActivityCode.
 const (
 	EventActivityCodeAccountCreate                            EventActivityCode = "account.create"
@@ -65,7 +74,9 @@ const (
 
 // Defines values for NameserverNsType.
 const (
-	NameserverNsTypeUdp NameserverNsType = "udp"
+	NameserverNsTypeHttps NameserverNsType = "https"
+	NameserverNsTypeTls   NameserverNsType = "tls"
+	NameserverNsTypeUdp   NameserverNsType = "udp"
 )
 
 // Defines values for PolicyRuleAction.
@@ -140,6 +151,12 @@ type AccountSettings struct {
 	// JwtGroupsEnabled Allows extract groups from JWT claim and add it to account groups.
 	JwtGroupsEnabled *bool `json:"jwt_groups_enabled,omitempty"`
 
+	// NetworkRange IPv4 overlay network of the account in CIDR notation, between /16 and /28. Changing it re-addresses all the peers of the account.
+	NetworkRange *string `json:"network_range,omitempty"`
+
+	// PeerApprovalEnabled Puts newly added peers in a pending state until an admin approves them. Pending peers are not connected to other peers.
+	PeerApprovalEnabled *bool `json:"peer_approval_enabled,omitempty"`
+
 	// PeerLoginExpiration Period of time after which peer login expires (seconds).
 	PeerLoginExpiration int `json:"peer_login_expiration"`
 
@@ -147,10 +164,88 @@ type AccountSettings struct {
 	PeerLoginExpirationEnabled bool `json:"peer_login_expiration_enabled"`
 }
 
+// Checks List of objects that perform the actual checks
+type Checks struct {
+	// KernelVersionCheck Posture check requiring the peer kernel version to be within a range. Any of the boundaries can be omitted
+	KernelVersionCheck *KernelVersionCheck `json:"kernel_version_check,omitempty"`
+
+	// NbVersionCheck Posture check requiring a minimum NetBird client version
+	NbVersionCheck *NBVersionCheck `json:"nb_version_check,omitempty"`
+
+	// OsCheck Posture check requiring one of the allowed operating systems
+	OsCheck *OSCheck `json:"os_check,omitempty"`
+}
+
+// DNSRecord defines model for DNSRecord.
+type DNSRecord struct {
+	// Content Record content: the IP address of A and AAAA records, the target domain of CNAME records, the text of TXT records and "priority weight port target" of SRV records
+	Content string `json:"content"`
+
+	// Name Record name, the zone name or a subdomain of it
+	Name string `json:"name"`
+
+	// Ttl Record time-to-live in seconds
+	Ttl int `json:"ttl"`
+
+	// Type Record type
+	Type DNSRecordType `json:"type"`
+}
+
+// DNSRecordType Record type
+type DNSRecordType string
+
 // DNSSettings defines model for DNSSettings.
 type DNSSettings struct {
 	// DisabledManagementGroups Groups whose DNS management is disabled
 	DisabledManagementGroups []string `json:"disabled_management_groups"`
+
+	// PeerZoneSearchDomainEnabled Adds the peer DNS zone to the search domains of the peers, so the peer names don't have to be fully qualified. Enabled by default
+	PeerZoneSearchDomainEnabled *bool `json:"peer_zone_search_domain_enabled,omitempty"`
+}
+
+// DNSZone defines model for DNSZone.
+type DNSZone struct {
+	// Description DNS zone description
+	Description string `json:"description"`
+
+	// DistributionGroups Group IDs whose peers resolve the zone
+	DistributionGroups []string `json:"distribution_groups"`
+
+	// Enabled DNS zone status
+	Enabled bool `json:"enabled"`
+
+	// Id DNS zone ID
+	Id string `json:"id"`
+
+	// Name DNS zone domain
+	Name string `json:"name"`
+
+	// Records DNS zone records
+	Records []DNSRecord `json:"records"`
+
+	// SearchDomainEnabled Search domain status of the zone, when enabled the zone domain is also added to the search domains of the peers
+	SearchDomainEnabled *bool `json:"search_domain_enabled,omitempty"`
+}
+
+// DNSZoneRequest defines model for DNSZoneRequest.
+type DNSZoneRequest struct {
+	// Description DNS zone description
+	Description *string `json:"description,omitempty"`
+
+	// DistributionGroups Group IDs whose peers resolve the zone
+	DistributionGroups []string `json:"distribution_groups"`
+
+	// Enabled DNS zone status
+	Enabled bool `json:"enabled"`
+
+	// Name DNS zone domain
+	Name string `json:"name"`
+
+	// Records DNS zone records
+	Records []DNSRecord `json:"records"`
+
+	// SearchDomainEnabled Search domain status of the zone, when enabled the zone domain is also added to the search domains of the peers
+	SearchDomainEnabled *bool `json:"search_domain_enabled,omitempty"`
 }
 
 // Event defines model for Event.
@@ -228,19 +323,37 @@ type GroupRequest struct {
 	Peers *[]string `json:"peers,omitempty"`
 }
 
+// KernelVersionCheck Posture check requiring the peer kernel version to be within a range. Any of the boundaries can be omitted
+type KernelVersionCheck struct {
+	// MaxVersion Maximum acceptable kernel version
+	MaxVersion *string `json:"max_version,omitempty"`
+
+	// MinVersion Minimum acceptable kernel version
+	MinVersion *string `json:"min_version,omitempty"`
+}
+
+// NBVersionCheck Posture check requiring a minimum NetBird client version
+type NBVersionCheck struct {
+	// MinVersion Minimum acceptable NetBird version
+	MinVersion string `json:"min_version"`
+}
+
 // Nameserver defines model for Nameserver.
 type Nameserver struct {
 	// Ip Nameserver IP
 	Ip string `json:"ip"`
 
-	// NsType Nameserver Type
+	// NsType Nameserver Type. The tls and https types send the queries over DNS over TLS and DNS over HTTPS.
 	NsType NameserverNsType `json:"ns_type"`
 
 	// Port Nameserver Port
 	Port int `json:"port"`
+
+	// ServerName Name used to validate the certificate of a tls or https nameserver, the IP is validated when it is not set
+	ServerName *string `json:"server_name,omitempty"`
 }
 
-// NameserverNsType Nameserver Type
+// NameserverNsType Nameserver Type. The tls and https types send the queries over DNS over TLS and DNS over HTTPS.
 type NameserverNsType string
 
 // NameserverGroup defines model for NameserverGroup.
@@ -268,6 +381,9 @@ type NameserverGroup struct {
 
 	// Primary Nameserver group primary status
 	Primary bool `json:"primary"`
+
+	// SearchDomainsEnabled Search domain status for the domains of the nameserver group, when enabled the domains are also added to the search domains of the peers
+	SearchDomainsEnabled *bool `json:"search_domains_enabled,omitempty"`
 }
 
 // NameserverGroupRequest defines model for NameserverGroupRequest.
@@ -292,16 +408,31 @@ type NameserverGroupRequest struct {
 
 	// Primary Nameserver group primary status
 	Primary bool `json:"primary"`
+
+	// SearchDomainsEnabled Search domain status for the domains of the nameserver group, when enabled the domains are also added to the search domains of the peers
+	SearchDomainsEnabled *bool `json:"search_domains_enabled,omitempty"`
+}
+
+// OSCheck Posture check requiring one of the allowed operating systems
+type OSCheck struct {
+	// AllowedOs List of allowed operating systems as reported by the peers, e.g. linux, darwin, windows, freebsd, android or ios
+	AllowedOs []string `json:"allowed_os"`
 }
 
 // Peer defines model for Peer.
 type Peer struct {
+	// ApprovalRequired Indicates whether the peer is pending an admin approval before it is connected to other peers
+	ApprovalRequired bool `json:"approval_required"`
+
 	// Connected Peer to Management connection status
 	Connected bool `json:"connected"`
 
 	// DnsLabel Peer's DNS label is the parsed peer name for domain resolution. It is used to form an FQDN by appending the account's domain to the peer label. e.g. peer-dns-label.netbird.cloud
 	DnsLabel string `json:"dns_label"`
 
+	// FailedPostureChecks IDs of the posture checks the peer doesn't satisfy
+	FailedPostureChecks *[]string `json:"failed_posture_checks,omitempty"`
+
 	// Groups Groups that the peer belongs to
 	Groups []GroupMinimum `json:"groups"`
 
@@ -314,6 +445,9 @@ type Peer struct {
 	// Ip Peer's IP address
 	Ip string `json:"ip"`
 
+	// Ipv6 Peer's IPv6 overlay address in the account's unique local IPv6 network
+	Ipv6 *string `json:"ipv6,omitempty"`
+
 	// LastLogin Last time this peer performed log in (authentication). E.g., user authenticated.
 	LastLogin time.Time `json:"last_login"`
 
@@ -332,6 +466,9 @@ type Peer struct {
 	// Os Peer's operating system and version
 	Os string `json:"os"`
 
+	// PostureChecksCompliant Indicates whether the peer satisfies the posture checks of the policies it is a source of
+	PostureChecksCompliant bool `json:"posture_checks_compliant"`
+
 	// SshEnabled Indicates whether SSH server is enabled on this peer
 	SshEnabled bool `json:"ssh_enabled"`
 
@@ -356,9 +493,11 @@ type PeerMinimum struct {
 
 // PeerRequest defines model for PeerRequest.
 type PeerRequest struct {
-	LoginExpirationEnabled bool   `json:"login_expiration_enabled"`
-	Name                   string `json:"name"`
-	SshEnabled             bool   `json:"ssh_enabled"`
+	// Ip Peer's IP address in the account network. The IP is kept when omitted
+	Ip                     *string `json:"ip,omitempty"`
+	LoginExpirationEnabled bool    `json:"login_expiration_enabled"`
+	Name                   string  `json:"name"`
+	SshEnabled             bool    `json:"ssh_enabled"`
 }
 
 // PersonalAccessToken defines model for PersonalAccessToken.
@@ -380,6 +519,9 @@ type PersonalAccessToken struct {
 
 	// Name Name of the token
 	Name string `json:"name"`
+
+	// Scopes Scopes the token is restricted to, a token without scopes has all the permissions of its user
+	Scopes *[]string `json:"scopes,omitempty"`
 }
 
 // PersonalAccessTokenGenerated defines model for PersonalAccessTokenGenerated.
@@ -397,6 +539,9 @@ type PersonalAccessTokenRequest struct {
 
 	// Name Name of the token
 	Name string `json:"name"`
+
+	// Scopes Scopes to restrict the token to, in the resource:read or resource:write form. The resource is one of accounts, users, peers, setup-keys, groups, policies, rules, posture-checks, routes, dns, events or webhooks
+	Scopes *[]string `json:"scopes,omitempty"`
 }
 
 // Policy defines model for Policy.
@@ -418,6 +563,9 @@ type Policy struct {
 
 	// Rules Policy rule object for policy UI editor
 	Rules []PolicyRule `json:"rules"`
+
+	// SourcePostureChecks IDs of the posture checks the source peers of the policy have to satisfy
+	SourcePostureChecks *[]string `json:"source_posture_checks,omitempty"`
 }
 
 // PolicyMinimum defines model for PolicyMinimum.
@@ -436,6 +584,9 @@ type PolicyMinimum struct {
 
 	// Query Policy Rego query
 	Query string `json:"query"`
+
+	// SourcePostureChecks IDs of the posture checks the source peers of the policy have to satisfy
+	SourcePostureChecks *[]string `json:"source_posture_checks,omitempty"`
 }
 
 // PolicyRule defines model for PolicyRule.
@@ -461,7 +612,7 @@ type PolicyRule struct {
 	// Name Policy rule name identifier
 	Name string `json:"name"`
 
-	// Ports Policy rule affected ports or it ranges list
+	// Ports Policy rule affected ports or it ranges list, a range of ports is defined as start-end, e.g. 8000-8100
 	Ports *[]string `json:"ports,omitempty"`
 
 	// Protocol Policy rule type of the traffic
@@ -497,7 +648,7 @@ type PolicyRuleMinimum struct {
 	// Name Policy rule name identifier
 	Name string `json:"name"`
 
-	// Ports Policy rule affected ports or it ranges list
+	// Ports Policy rule affected ports or it ranges list, a range of ports is defined as start-end, e.g. 8000-8100
 	Ports *[]string `json:"ports,omitempty"`
 
 	// Protocol Policy rule type of the traffic
@@ -533,7 +684,7 @@ type PolicyRuleUpdate struct {
 	// Name Policy rule name identifier
 	Name string `json:"name"`
 
-	// Ports Policy rule affected ports or it ranges list
+	// Ports Policy rule affected ports or it ranges list, a range of ports is defined as start-end, e.g. 8000-8100
 	Ports *[]string `json:"ports,omitempty"`
 
 	// Protocol Policy rule type of the traffic
@@ -568,6 +719,36 @@ type PolicyUpdate struct {
 
 	// Rules Policy rule object for policy UI editor
 	Rules []PolicyRuleUpdate `json:"rules"`
+
+	// SourcePostureChecks IDs of the posture checks the source peers of the policy have to satisfy
+	SourcePostureChecks *[]string `json:"source_posture_checks,omitempty"`
+}
+
+// PostureCheck defines model for PostureCheck.
+type PostureCheck struct {
+	// Checks List of objects that perform the actual checks
+	Checks Checks `json:"checks"`
+
+	// Description Posture check friendly description
+	Description string `json:"description"`
+
+	// Id Posture check ID
+	Id string `json:"id"`
+
+	// Name Posture check name identifier
+	Name string `json:"name"`
+}
+
+// PostureCheckUpdate defines model for PostureCheckUpdate.
+type PostureCheckUpdate struct {
+	// Checks List of objects that perform the actual checks
+	Checks Checks `json:"checks"`
+
+	// Description Posture check friendly description
+	Description string `json:"description"`
+
+	// Name Posture check name identifier
+	Name string `json:"name"`
 }
 
 // Route defines model for Route.
@@ -590,7 +771,7 @@ type Route struct {
 	// Metric Route metric number. Lowest number has higher priority
 	Metric int `json:"metric"`
 
-	// Network Network range in CIDR format
+	// Network Network range in CIDR format, 0.0.0.0/0 turns the routing peers into exit nodes for the peers that opted in to use them
 	Network string `json:"network"`
 
 	// NetworkId Route network identifier, to group HA routes
@@ -623,7 +804,7 @@ type RouteRequest struct {
 	// Metric Route metric number. Lowest number has higher priority
 	Metric int `json:"metric"`
 
-	// Network Network range in CIDR format
+	// Network Network range in CIDR format, 0.0.0.0/0 turns the routing peers into exit nodes for the peers that opted in to use them
 	Network string `json:"network"`
 
 	// NetworkId Route network identifier, to group HA routes
@@ -719,6 +900,9 @@ type SetupKey struct {
 	// Name Setup key name identifier
 	Name string `json:"name"`
 
+	// ReservedIp IP assigned to the peer registered with this key
+	ReservedIp *string `json:"reserved_ip,omitempty"`
+
 	// Revoked Setup key revocation status
 	Revoked bool `json:"revoked"`
 
@@ -755,6 +939,9 @@ type SetupKeyRequest struct {
 	// Name Setup Key name
 	Name string `json:"name"`
 
+	// ReservedIp IP to assign to the peer registered with this key. Only single use keys can reserve an IP
+	ReservedIp *string `json:"reserved_ip,omitempty"`
+
 	// Revoked Setup key revocation status
 	Revoked bool `json:"revoked"`
 
@@ -791,7 +978,7 @@ type User struct {
 	// Name User's name from idp provider
 	Name string `json:"name"`
 
-	// Role User's NetBird account role
+	// Role User's NetBird account role, one of admin, network_admin, auditor or user
 	Role string `json:"role"`
 
 	// Status User's status
@@ -815,7 +1002,7 @@ type UserCreateRequest struct {
 	// Name User's full name
 	Name *string `json:"name,omitempty"`
 
-	// Role User's NetBird account role
+	// Role User's NetBird account role, one of admin, network_admin, auditor or user
 	Role string `json:"role"`
 }
 
@@ -827,10 +1014,91 @@ type UserRequest struct {
 	// IsBlocked If set to true then user is blocked and can't use the system
 	IsBlocked bool `json:"is_blocked"`
 
-	// Role User's NetBird account role
+	// Role User's NetBird account role, one of admin, network_admin, auditor or user
 	Role string `json:"role"`
 }
 
+// Webhook defines model for Webhook.
+type Webhook struct {
+	// Enabled Webhook status
+	Enabled bool `json:"enabled"`
+
+	// Events Activity codes of the events delivered to the endpoint. All the events are delivered when empty
+	Events []string `json:"events"`
+
+	// Id Webhook ID
+	Id string `json:"id"`
+
+	// Name Webhook name identifier
+	Name string `json:"name"`
+
+	// Url URL of the endpoint the events are posted to
+	Url string `json:"url"`
+}
+
+// WebhookDeadLetter defines model for WebhookDeadLetter.
+type WebhookDeadLetter struct {
+	// Attempts Number of delivery attempts made
+	Attempts int `json:"attempts"`
+
+	// CreatedAt The date and time when the delivery was given up
+	CreatedAt time.Time `json:"created_at"`
+
+	// EventType Activity code of the event
+	EventType string `json:"event_type"`
+
+	// Id Delivery ID, sent in the X-NetBird-Delivery header
+	Id string `json:"id"`
+
+	// LastError Error of the last delivery attempt
+	LastError string `json:"last_error"`
+
+	// Payload Body of the delivery
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// WebhookRequest defines model for WebhookRequest.
+type WebhookRequest struct {
+	// Enabled Webhook status
+	Enabled bool `json:"enabled"`
+
+	// Events Activity codes of the events delivered to the endpoint. All the events are delivered when empty
+	Events []string `json:"events"`
+
+	// Name Webhook name identifier
+	Name string `json:"name"`
+
+	// Secret Secret the deliveries are signed with, at least 16 characters long. The X-NetBird-Signature header of a delivery holds "sha256=" followed by the hex encoded HMAC-SHA256 of the X-NetBird-Timestamp header and the body joined with a dot. Required on creation, the current secret is kept when omitted on update
+	Secret *string `json:"secret,omitempty"`
+
+	// Url URL of the endpoint the events are posted to
+	Url string `json:"url"`
+}
+
+// GetApiEventsParams defines parameters for GetApiEvents.
+type GetApiEventsParams struct {
+	// ActivityCode Filters events by activity code. Repeat the parameter or separate the codes with commas to match any of them
+	ActivityCode *[]string `form:"activity_code,omitempty" json:"activity_code,omitempty"`
+
+	// InitiatorId Filters events by the ID of the initiator
+	InitiatorId *string `form:"initiator_id,omitempty" json:"initiator_id,omitempty"`
+
+	// TargetId Filters events by the ID of the target
+	TargetId *string `form:"target_id,omitempty" json:"target_id,omitempty"`
+
+	// From Filters events that occurred at or after the time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Filters events that occurred before the time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Cursor Cursor of the page to return as provided in the Link header of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of events to return. Defaults to 10000
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetApiUsersParams defines parameters for GetApiUsers.
 type GetApiUsersParams struct {
 	// ServiceUser Filters users and returns either regular users or service users
@@ -849,6 +1117,12 @@ type PutApiDnsNameserversNsgroupIdJSONRequestBody = NameserverGroupRequest
 // PutApiDnsSettingsJSONRequestBody defines body for PutApiDnsSettings for application/json ContentType.
 type PutApiDnsSettingsJSONRequestBody = DNSSettings
 
+// PostApiDnsZonesJSONRequestBody defines body for PostApiDnsZones for application/json ContentType.
+type PostApiDnsZonesJSONRequestBody = DNSZoneRequest
+
+// PutApiDnsZonesZoneIdJSONRequestBody defines body for PutApiDnsZonesZoneId for application/json ContentType.
+type PutApiDnsZonesZoneIdJSONRequestBody = DNSZoneRequest
+
 // PostApiGroupsJSONRequestBody defines body for PostApiGroups for application/json ContentType.
 type PostApiGroupsJSONRequestBody = GroupRequest
 
@@ -864,6 +1138,12 @@ type PostApiPoliciesJSONRequestBody = PolicyUpdate
 // PutApiPoliciesPolicyIdJSONRequestBody defines body for PutApiPoliciesPolicyId for application/json ContentType.
 type PutApiPoliciesPolicyIdJSONRequestBody = PolicyUpdate
 
+// PostApiPostureChecksJSONRequestBody defines body for PostApiPostureChecks for application/json ContentType.
+type PostApiPostureChecksJSONRequestBody = PostureCheckUpdate
+
+// PutApiPostureChecksPostureCheckIdJSONRequestBody defines body for PutApiPostureChecksPostureCheckId for application/json ContentType.
+type PutApiPostureChecksPostureCheckIdJSONRequestBody = PostureCheckUpdate
+
 // PostApiRoutesJSONRequestBody defines body for PostApiRoutes for application/json ContentType.
 type PostApiRoutesJSONRequestBody = RouteRequest
 
@@ -890,3 +1170,9 @@ type PutApiUsersUserIdJSONRequestBody = UserRequest
 
 // PostApiUsersUserIdTokensJSONRequestBody defines body for PostApiUsersUserIdTokens for application/json ContentType.
 type PostApiUsersUserIdTokensJSONRequestBody = PersonalAccessTokenRequest
+
+// PostApiWebhooksJSONRequestBody defines body for PostApiWebhooks for application/json ContentType.
+type PostApiWebhooksJSONRequestBody = WebhookRequest
+
+// PutApiWebhooksWebhookIdJSONRequestBody defines body for PutApiWebhooksWebhookId for application/json ContentType.
+type PutApiWebhooksWebhookIdJSONRequestBody = WebhookRequest
@@ -46,7 +46,8 @@ func (h *DNSSettingsHandler) GetDNSSettings(w http.ResponseWriter, r *http.Reque
 	}
 
 	apiDNSSettings := &api.DNSSettings{
-		DisabledManagementGroups: dnsSettings.DisabledManagementGroups,
+		DisabledManagementGroups:    dnsSettings.DisabledManagementGroups,
+		PeerZoneSearchDomainEnabled: &dnsSettings.PeerZoneSearchDomainEnabled,
 	}
 
 	util.WriteJSONObject(w, apiDNSSettings)
@@ -69,7 +70,14 @@ func (h *DNSSettingsHandler) UpdateDNSSettings(w http.ResponseWriter, r *http.Re
 	}
 
 	updateDNSSettings := &server.DNSSettings{
-		DisabledManagementGroups: req.DisabledManagementGroups,
+		DisabledManagementGroups:    req.DisabledManagementGroups,
+		PeerZoneSearchDomainEnabled: true,
+	}
+	// the peer zone search domain is kept unchanged when the request doesn't set it
+	if req.PeerZoneSearchDomainEnabled != nil {
+		updateDNSSettings.PeerZoneSearchDomainEnabled = *req.PeerZoneSearchDomainEnabled
+	} else if account.DNSSettings != nil {
+		updateDNSSettings.PeerZoneSearchDomainEnabled = account.DNSSettings.PeerZoneSearchDomainEnabled
 	}
 
 	err = h.accountManager.SaveDNSSettings(account.Id, user.Id, updateDNSSettings)
@@ -79,7 +87,8 @@ func (h *DNSSettingsHandler) UpdateDNSSettings(w http.ResponseWriter, r *http.Re
 	}
 
 	resp := api.DNSSettings{
-		DisabledManagementGroups: updateDNSSettings.DisabledManagementGroups,
+		DisabledManagementGroups:    updateDNSSettings.DisabledManagementGroups,
+		PeerZoneSearchDomainEnabled: &updateDNSSettings.PeerZoneSearchDomainEnabled,
 	}
 
 	util.WriteJSONObject(w, &resp)
@@ -27,7 +27,8 @@ const (
 )
 
 var baseExistingDNSSettings = &server.DNSSettings{
-	DisabledManagementGroups: []string{testDNSSettingsExistingGroup},
+	DisabledManagementGroups:    []string{testDNSSettingsExistingGroup},
+	PeerZoneSearchDomainEnabled: true,
 }
 
 var testingDNSSettingsAccount = &server.Account{
@@ -68,6 +69,7 @@ func initDNSSettingsTestData() *DNSSettingsHandler {
 }
 
 func TestDNSSettingsHandlers(t *testing.T) {
+	boolean := func(b bool) *bool { return &b }
 	tt := []struct {
 		name                string
 		expectedStatus      int
@@ -84,7 +86,8 @@ func TestDNSSettingsHandlers(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody:   true,
 			expectedDNSSettings: &api.DNSSettings{
-				DisabledManagementGroups: baseExistingDNSSettings.DisabledManagementGroups,
+				DisabledManagementGroups:    baseExistingDNSSettings.DisabledManagementGroups,
+				PeerZoneSearchDomainEnabled: boolean(true),
 			},
 		},
 		{
@@ -96,7 +99,21 @@ func TestDNSSettingsHandlers(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody:   true,
 			expectedDNSSettings: &api.DNSSettings{
-				DisabledManagementGroups: []string{"group1", "group2"},
+				DisabledManagementGroups:    []string{"group1", "group2"},
+				PeerZoneSearchDomainEnabled: boolean(true),
+			},
+		},
+		{
+			name:        "Update DNS Settings Peer Zone Search Domain",
+			requestType: http.MethodPut,
+			requestPath: "/api/dns/settings",
+			requestBody: bytes.NewBuffer(
+				[]byte("{\"disabled_management_groups\":[],\"peer_zone_search_domain_enabled\":false}")),
+			expectedStatus: http.StatusOK,
+			expectedBody:   true,
+			expectedDNSSettings: &api.DNSSettings{
+				DisabledManagementGroups:    []string{},
+				PeerZoneSearchDomainEnabled: boolean(false),
 			},
 		},
 		{
@@ -107,7 +124,7 @@ func TestDNSSettingsHandlers(t *testing.T) {
 				[]byte("{}")),
 			expectedStatus:      http.StatusOK,
 			expectedBody:        true,
-			expectedDNSSettings: &api.DNSSettings{},
+			expectedDNSSettings: &api.DNSSettings{PeerZoneSearchDomainEnabled: boolean(true)},
 		},
 	}
 
@@ -0,0 +1,210 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/xid"
+
+	"github.com/netbirdio/netbird/management/server"
+	"github.com/netbirdio/netbird/management/server/http/api"
+	"github.com/netbirdio/netbird/management/server/http/util"
+	"github.com/netbirdio/netbird/management/server/jwtclaims"
+	"github.com/netbirdio/netbird/management/server/status"
+)
+
+// DNSZonesHandler is a handler that returns the custom DNS zones of the account
+type DNSZonesHandler struct {
+	accountManager  server.AccountManager
+	claimsExtractor *jwtclaims.ClaimsExtractor
+}
+
+// NewDNSZonesHandler creates a new DNS zones handler
+func NewDNSZonesHandler(accountManager server.AccountManager, authCfg AuthCfg) *DNSZonesHandler {
+	return &DNSZonesHandler{
+		accountManager: accountManager,
+		claimsExtractor: jwtclaims.NewClaimsExtractor(
+			jwtclaims.WithAudience(authCfg.Audience),
+			jwtclaims.WithUserIDClaim(authCfg.UserIDClaim),
+		),
+	}
+}
+
+// GetAllDNSZones list for the account
+func (h *DNSZonesHandler) GetAllDNSZones(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	accountZones, err := h.accountManager.ListDNSZones(account.Id, user.Id)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	zones := []*api.DNSZone{}
+	for _, zone := range accountZones {
+		zones = append(zones, toDNSZoneResponse(zone))
+	}
+
+	util.WriteJSONObject(w, zones)
+}
+
+// UpdateDNSZone handles update to a DNS zone identified by a given ID
+func (h *DNSZonesHandler) UpdateDNSZone(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	zoneID := mux.Vars(r)["zoneId"]
+	if len(zoneID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid DNS zone ID"), w)
+		return
+	}
+
+	if _, ok := account.DNSZones[zoneID]; !ok {
+		util.WriteError(status.Errorf(status.NotFound, "couldn't find DNS zone id %s", zoneID), w)
+		return
+	}
+
+	h.saveDNSZone(w, r, account, user, zoneID)
+}
+
+// CreateDNSZone handles DNS zone creation request
+func (h *DNSZonesHandler) CreateDNSZone(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	h.saveDNSZone(w, r, account, user, xid.New().String())
+}
+
+// GetDNSZone handles a DNS zone Get request identified by ID
+func (h *DNSZonesHandler) GetDNSZone(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	zoneID := mux.Vars(r)["zoneId"]
+	if len(zoneID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid DNS zone ID"), w)
+		return
+	}
+
+	zone, err := h.accountManager.GetDNSZone(account.Id, zoneID, user.Id)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, toDNSZoneResponse(zone))
+}
+
+// DeleteDNSZone handles DNS zone deletion request
+func (h *DNSZonesHandler) DeleteDNSZone(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	zoneID := mux.Vars(r)["zoneId"]
+	if len(zoneID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid DNS zone ID"), w)
+		return
+	}
+
+	if err = h.accountManager.DeleteDNSZone(account.Id, zoneID, user.Id); err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, emptyObject{})
+}
+
+// saveDNSZone handles DNS zone creation and update
+func (h *DNSZonesHandler) saveDNSZone(
+	w http.ResponseWriter,
+	r *http.Request,
+	account *server.Account,
+	user *server.User,
+	zoneID string,
+) {
+	var req api.DNSZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.WriteErrorResponse("couldn't parse JSON request", http.StatusBadRequest, w)
+		return
+	}
+
+	zone := &server.DNSZone{
+		ID:                 zoneID,
+		Name:               req.Name,
+		Records:            make([]*server.DNSRecord, 0, len(req.Records)),
+		DistributionGroups: req.DistributionGroups,
+		Enabled:            req.Enabled,
+	}
+	if req.Description != nil {
+		zone.Description = *req.Description
+	}
+	// the search domain status is kept unchanged when an update doesn't set it
+	if req.SearchDomainEnabled != nil {
+		zone.SearchDomainEnabled = *req.SearchDomainEnabled
+	} else if existing, ok := account.DNSZones[zoneID]; ok {
+		zone.SearchDomainEnabled = existing.SearchDomainEnabled
+	}
+	for _, record := range req.Records {
+		zone.Records = append(zone.Records, &server.DNSRecord{
+			Name:    record.Name,
+			Type:    string(record.Type),
+			TTL:     record.Ttl,
+			Content: record.Content,
+		})
+	}
+
+	if err := h.accountManager.SaveDNSZone(account.Id, user.Id, zone); err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, toDNSZoneResponse(zone))
+}
+
+func toDNSZoneResponse(zone *server.DNSZone) *api.DNSZone {
+	records := make([]api.DNSRecord, 0, len(zone.Records))
+	for _, record := range zone.Records {
+		records = append(records, api.DNSRecord{
+			Name:    record.Name,
+			Type:    api.DNSRecordType(record.Type),
+			Ttl:     record.TTL,
+			Content: record.Content,
+		})
+	}
+
+	groups := make([]string, len(zone.DistributionGroups))
+	copy(groups, zone.DistributionGroups)
+
+	searchDomainEnabled := zone.SearchDomainEnabled
+	return &api.DNSZone{
+		Id:                  zone.ID,
+		Name:                zone.Name,
+		Description:         zone.Description,
+		Records:             records,
+		DistributionGroups:  groups,
+		Enabled:             zone.Enabled,
+		SearchDomainEnabled: &searchDomainEnabled,
+	}
+}
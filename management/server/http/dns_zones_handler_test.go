@@ -0,0 +1,248 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/management/server"
+	"github.com/netbirdio/netbird/management/server/http/api"
+	"github.com/netbirdio/netbird/management/server/jwtclaims"
+	"github.com/netbirdio/netbird/management/server/mock_server"
+	"github.com/netbirdio/netbird/management/server/status"
+)
+
+func initDNSZonesTestData(zones ...*server.DNSZone) (*DNSZonesHandler, map[string]*server.DNSZone) {
+	testZones := make(map[string]*server.DNSZone, len(zones))
+	for _, zone := range zones {
+		testZones[zone.ID] = zone
+	}
+
+	return &DNSZonesHandler{
+		accountManager: &mock_server.MockAccountManager{
+			GetDNSZoneFunc: func(_, zoneID, _ string) (*server.DNSZone, error) {
+				zone, ok := testZones[zoneID]
+				if !ok {
+					return nil, status.Errorf(status.NotFound, "DNS zone not found")
+				}
+				return zone, nil
+			},
+			SaveDNSZoneFunc: func(_, _ string, zone *server.DNSZone) error {
+				for _, record := range zone.Records {
+					if record.Type != server.DNSRecordTypeA {
+						return status.Errorf(status.InvalidArgument, "unsupported record type")
+					}
+				}
+				testZones[zone.ID] = zone
+				return nil
+			},
+			DeleteDNSZoneFunc: func(_, zoneID, _ string) error {
+				if _, ok := testZones[zoneID]; !ok {
+					return status.Errorf(status.NotFound, "DNS zone not found")
+				}
+				delete(testZones, zoneID)
+				return nil
+			},
+			ListDNSZonesFunc: func(_, _ string) ([]*server.DNSZone, error) {
+				accountZones := make([]*server.DNSZone, 0, len(testZones))
+				for _, zone := range testZones {
+					accountZones = append(accountZones, zone)
+				}
+				return accountZones, nil
+			},
+			GetAccountFromTokenFunc: func(claims jwtclaims.AuthorizationClaims) (*server.Account, *server.User, error) {
+				user := server.NewAdminUser("test_user")
+				return &server.Account{
+					Id:       claims.AccountId,
+					Domain:   "hotmail.com",
+					DNSZones: testZones,
+					Users: map[string]*server.User{
+						"test_user": user,
+					},
+				}, user, nil
+			},
+		},
+		claimsExtractor: jwtclaims.NewClaimsExtractor(
+			jwtclaims.WithFromRequestContext(func(r *http.Request) jwtclaims.AuthorizationClaims {
+				return jwtclaims.AuthorizationClaims{
+					UserId:    "test_user",
+					Domain:    "hotmail.com",
+					AccountId: "test_id",
+				}
+			}),
+		),
+	}, testZones
+}
+
+func TestDNSZonesHandlers(t *testing.T) {
+	existing := &server.DNSZone{
+		ID:                  "zone",
+		Name:                "corp.example.com",
+		Description:         "internal services",
+		Records:             []*server.DNSRecord{{Name: "db.corp.example.com", Type: server.DNSRecordTypeA, TTL: 300, Content: "10.0.0.10"}},
+		DistributionGroups:  []string{"group"},
+		Enabled:             true,
+		SearchDomainEnabled: true,
+	}
+
+	boolean := func(b bool) *bool { return &b }
+	tt := []struct {
+		name           string
+		requestType    string
+		requestPath    string
+		requestBody    io.Reader
+		expectedStatus int
+		expectedZone   *api.DNSZone
+	}{
+		{
+			name:           "Get DNS Zone",
+			requestType:    http.MethodGet,
+			requestPath:    "/api/dns/zones/zone",
+			expectedStatus: http.StatusOK,
+			expectedZone: &api.DNSZone{
+				Id:                  "zone",
+				Name:                "corp.example.com",
+				Description:         "internal services",
+				Records:             []api.DNSRecord{{Name: "db.corp.example.com", Type: api.DNSRecordTypeA, Ttl: 300, Content: "10.0.0.10"}},
+				DistributionGroups:  []string{"group"},
+				Enabled:             true,
+				SearchDomainEnabled: boolean(true),
+			},
+		},
+		{
+			name:           "Get Not Existing DNS Zone",
+			requestType:    http.MethodGet,
+			requestPath:    "/api/dns/zones/not-exists",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "Update DNS Zone",
+			requestType: http.MethodPut,
+			requestPath: "/api/dns/zones/zone",
+			requestBody: bytes.NewBufferString(`{
+				"name": "corp.example.com",
+				"records": [{"name": "web.corp.example.com", "type": "A", "ttl": 60, "content": "10.0.0.20"}],
+				"distribution_groups": ["group"],
+				"enabled": false
+			}`),
+			expectedStatus: http.StatusOK,
+			expectedZone: &api.DNSZone{
+				Id:                  "zone",
+				Name:                "corp.example.com",
+				Records:             []api.DNSRecord{{Name: "web.corp.example.com", Type: api.DNSRecordTypeA, Ttl: 60, Content: "10.0.0.20"}},
+				DistributionGroups:  []string{"group"},
+				SearchDomainEnabled: boolean(true),
+			},
+		},
+		{
+			name:        "Update DNS Zone Search Domain",
+			requestType: http.MethodPut,
+			requestPath: "/api/dns/zones/zone",
+			requestBody: bytes.NewBufferString(`{
+				"name": "corp.example.com",
+				"records": [{"name": "web.corp.example.com", "type": "A", "ttl": 60, "content": "10.0.0.20"}],
+				"distribution_groups": ["group"],
+				"enabled": false,
+				"search_domain_enabled": false
+			}`),
+			expectedStatus: http.StatusOK,
+			expectedZone: &api.DNSZone{
+				Id:                  "zone",
+				Name:                "corp.example.com",
+				Records:             []api.DNSRecord{{Name: "web.corp.example.com", Type: api.DNSRecordTypeA, Ttl: 60, Content: "10.0.0.20"}},
+				DistributionGroups:  []string{"group"},
+				SearchDomainEnabled: boolean(false),
+			},
+		},
+		{
+			name:        "Update Not Existing DNS Zone",
+			requestType: http.MethodPut,
+			requestPath: "/api/dns/zones/not-exists",
+			requestBody: bytes.NewBufferString(`{
+				"name": "corp.example.com",
+				"records": [],
+				"distribution_groups": ["group"],
+				"enabled": true
+			}`),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "Create DNS Zone with invalid record",
+			requestType: http.MethodPost,
+			requestPath: "/api/dns/zones",
+			requestBody: bytes.NewBufferString(`{
+				"name": "other.example.com",
+				"records": [{"name": "other.example.com", "type": "MX", "ttl": 60, "content": "10 mail.example.com"}],
+				"distribution_groups": ["group"],
+				"enabled": true
+			}`),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Create DNS Zone invalid JSON",
+			requestType:    http.MethodPost,
+			requestPath:    "/api/dns/zones",
+			requestBody:    bytes.NewBufferString(`{"name": `),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Delete DNS Zone",
+			requestType:    http.MethodDelete,
+			requestPath:    "/api/dns/zones/zone",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	h, testZones := initDNSZonesTestData(existing)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/dns/zones", h.GetAllDNSZones).Methods("GET")
+	router.HandleFunc("/api/dns/zones", h.CreateDNSZone).Methods("POST")
+	router.HandleFunc("/api/dns/zones/{zoneId}", h.GetDNSZone).Methods("GET")
+	router.HandleFunc("/api/dns/zones/{zoneId}", h.UpdateDNSZone).Methods("PUT")
+	router.HandleFunc("/api/dns/zones/{zoneId}", h.DeleteDNSZone).Methods("DELETE")
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(tc.requestType, tc.requestPath, tc.requestBody))
+			require.Equal(t, tc.expectedStatus, recorder.Code)
+
+			if tc.expectedZone == nil {
+				return
+			}
+
+			var got api.DNSZone
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(&got))
+			assert.Equal(t, *tc.expectedZone, got)
+		})
+	}
+
+	assert.Empty(t, testZones, "the zone should be deleted")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/dns/zones", bytes.NewBufferString(`{
+		"name": "corp.example.com",
+		"records": [],
+		"distribution_groups": ["group"],
+		"enabled": true
+	}`)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/dns/zones", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var zones []api.DNSZone
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&zones))
+	require.Len(t, zones, 1)
+	assert.NotEmpty(t, zones[0].Id, "the ID of a new zone should be generated")
+	assert.Equal(t, []api.DNSRecord{}, zones[0].Records)
+}
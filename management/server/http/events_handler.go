@@ -3,6 +3,10 @@ package http
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -11,6 +15,7 @@ import (
 	"github.com/netbirdio/netbird/management/server/http/api"
 	"github.com/netbirdio/netbird/management/server/http/util"
 	"github.com/netbirdio/netbird/management/server/jwtclaims"
+	"github.com/netbirdio/netbird/management/server/status"
 )
 
 // EventsHandler HTTP handler
@@ -40,7 +45,13 @@ func (h *EventsHandler) GetAllEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accountEvents, err := h.accountManager.GetEvents(account.Id, user.Id)
+	filter, err := parseEventsFilter(r.URL.Query())
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	accountEvents, nextCursor, err := h.accountManager.GetEvents(account.Id, user.Id, filter)
 	if err != nil {
 		util.WriteError(err, w)
 		return
@@ -56,9 +67,69 @@ func (h *EventsHandler) GetAllEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if nextCursor != "" {
+		query := r.URL.Query()
+		query.Set("cursor", nextCursor)
+		next := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.String()))
+	}
+
 	util.WriteJSONObject(w, events)
 }
 
+// parseEventsFilter returns the events filter of the query parameters. The events are ordered from the newest to the
+// oldest
+func parseEventsFilter(query url.Values) (activity.Filter, error) {
+	filter := activity.Filter{
+		InitiatorID: query.Get("initiator_id"),
+		TargetID:    query.Get("target_id"),
+		Cursor:      query.Get("cursor"),
+		Descending:  true,
+	}
+
+	for _, value := range query["activity_code"] {
+		for _, code := range strings.Split(value, ",") {
+			a, ok := activity.ParseCode(strings.TrimSpace(code))
+			if !ok {
+				return filter, status.Errorf(status.InvalidArgument, "unknown activity_code %q", code)
+			}
+			filter.Activities = append(filter.Activities, a)
+		}
+	}
+
+	var err error
+	if from := query.Get("from"); from != "" {
+		filter.From, err = time.Parse(time.RFC3339, from)
+		if err != nil {
+			return filter, status.Errorf(status.InvalidArgument, "invalid from query parameter, RFC 3339 time is expected")
+		}
+	}
+	if to := query.Get("to"); to != "" {
+		filter.To, err = time.Parse(time.RFC3339, to)
+		if err != nil {
+			return filter, status.Errorf(status.InvalidArgument, "invalid to query parameter, RFC 3339 time is expected")
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return filter, status.Errorf(status.InvalidArgument, "from query parameter should be before to")
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 1 {
+			return filter, status.Errorf(status.InvalidArgument, "invalid limit query parameter, a positive number is expected")
+		}
+	}
+
+	if filter.Cursor != "" {
+		if _, _, err = activity.ParseCursor(filter.Cursor); err != nil {
+			return filter, status.Errorf(status.InvalidArgument, "invalid cursor query parameter")
+		}
+	}
+
+	return filter, nil
+}
+
 func (h *EventsHandler) fillEventsWithUserInfo(events []*api.Event, accountId, userId string) error {
 	// build email, name maps based on users
 	userInfos, err := h.accountManager.GetUsersFromAccount(accountId, userId)
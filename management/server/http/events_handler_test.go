@@ -2,9 +2,11 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"testing"
 	"time"
@@ -22,11 +24,14 @@ import (
 func initEventsTestData(account string, user *server.User, events ...*activity.Event) *EventsHandler {
 	return &EventsHandler{
 		accountManager: &mock_server.MockAccountManager{
-			GetEventsFunc: func(accountID, userID string) ([]*activity.Event, error) {
-				if accountID == account {
-					return events, nil
+			GetEventsFunc: func(accountID, userID string, filter activity.Filter) ([]*activity.Event, string, error) {
+				if accountID != account {
+					return []*activity.Event{}, "", nil
 				}
-				return []*activity.Event{}, nil
+				if filter.Limit > 0 && filter.Limit < len(events) {
+					return events[:filter.Limit], activity.NewCursor(events[filter.Limit-1]), nil
+				}
+				return events, "", nil
 			},
 			GetAccountFromTokenFunc: func(claims jwtclaims.AuthorizationClaims) (*server.Account, *server.User, error) {
 				return &server.Account{
@@ -251,3 +256,99 @@ func TestEvents_GetEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestEvents_GetEventsPage(t *testing.T) {
+	accountID := "test_account"
+	adminUser := server.NewAdminUser("test_user")
+	events := generateEvents(accountID, adminUser.Id)
+	handler := initEventsTestData(accountID, adminUser, events...)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/events/?limit=2&initiator_id=test_user", nil)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/events/", handler.GetAllEvents).Methods("GET")
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []*api.Event
+	err := json.Unmarshal(recorder.Body.Bytes(), &got)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	expectedLink := fmt.Sprintf("</api/events/?cursor=%s&initiator_id=test_user&limit=2>; rel=\"next\"",
+		activity.NewCursor(events[1]))
+	assert.Equal(t, expectedLink, recorder.Header().Get("Link"))
+}
+
+func TestParseEventsFilter(t *testing.T) {
+	tt := []struct {
+		name          string
+		query         string
+		expected      activity.Filter
+		expectedError bool
+	}{
+		{
+			name:     "No Filter",
+			query:    "",
+			expected: activity.Filter{Descending: true},
+		},
+		{
+			name:  "All Filters",
+			query: "activity_code=user.peer.add,user.join&activity_code=group.add&initiator_id=user&target_id=peer&from=2023-05-05T10:00:00Z&to=2023-05-06T10:00:00%2B02:00&limit=10",
+			expected: activity.Filter{
+				Activities:  []activity.Activity{activity.PeerAddedByUser, activity.UserJoined, activity.GroupCreated},
+				InitiatorID: "user",
+				TargetID:    "peer",
+				From:        time.Date(2023, 5, 5, 10, 0, 0, 0, time.UTC),
+				To:          time.Date(2023, 5, 6, 8, 0, 0, 0, time.UTC),
+				Limit:       10,
+				Descending:  true,
+			},
+		},
+		{
+			name:          "Unknown Activity Code",
+			query:         "activity_code=unknown",
+			expectedError: true,
+		},
+		{
+			name:          "Invalid Time",
+			query:         "from=yesterday",
+			expectedError: true,
+		},
+		{
+			name:          "Empty Time Window",
+			query:         "from=2023-05-05T10:00:00Z&to=2023-05-05T10:00:00Z",
+			expectedError: true,
+		},
+		{
+			name:          "Invalid Limit",
+			query:         "limit=0",
+			expectedError: true,
+		},
+		{
+			name:          "Invalid Cursor",
+			query:         "cursor=invalid",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tc.query)
+			assert.NoError(t, err)
+
+			filter, err := parseEventsFilter(query)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tc.expected.From.Equal(filter.From))
+			assert.True(t, tc.expected.To.Equal(filter.To))
+			tc.expected.From, tc.expected.To = filter.From, filter.To
+			assert.Equal(t, tc.expected, filter)
+		})
+	}
+}
@@ -65,9 +65,12 @@ func APIHandler(accountManager s.AccountManager, jwtValidator jwtclaims.JWTValid
 	api.addSetupKeysEndpoint()
 	api.addRulesEndpoint()
 	api.addPoliciesEndpoint()
+	api.addPostureChecksEndpoint()
+	api.addWebhooksEndpoint()
 	api.addGroupsEndpoint()
 	api.addRoutesEndpoint()
 	api.addDNSNameserversEndpoint()
+	api.addDNSZonesEndpoint()
 	api.addDNSSettingEndpoint()
 	api.addEventsEndpoint()
 
@@ -106,6 +109,8 @@ func (apiHandler *apiHandler) addPeersEndpoint() {
 	apiHandler.Router.HandleFunc("/peers", peersHandler.GetAllPeers).Methods("GET", "OPTIONS")
 	apiHandler.Router.HandleFunc("/peers/{peerId}", peersHandler.HandlePeer).
 		Methods("GET", "PUT", "DELETE", "OPTIONS")
+	apiHandler.Router.HandleFunc("/peers/{peerId}/approve", peersHandler.ApprovePeer).Methods("POST", "OPTIONS")
+	apiHandler.Router.HandleFunc("/peers/{peerId}/reject", peersHandler.RejectPeer).Methods("POST", "OPTIONS")
 }
 
 func (apiHandler *apiHandler) addUsersEndpoint() {
@@ -151,6 +156,25 @@ func (apiHandler *apiHandler) addPoliciesEndpoint() {
 	apiHandler.Router.HandleFunc("/policies/{policyId}", policiesHandler.DeletePolicy).Methods("DELETE", "OPTIONS")
 }
 
+func (apiHandler *apiHandler) addWebhooksEndpoint() {
+	webhooksHandler := NewWebhooksHandler(apiHandler.AccountManager, apiHandler.AuthCfg)
+	apiHandler.Router.HandleFunc("/webhooks", webhooksHandler.GetAllWebhooks).Methods("GET", "OPTIONS")
+	apiHandler.Router.HandleFunc("/webhooks", webhooksHandler.CreateWebhook).Methods("POST", "OPTIONS")
+	apiHandler.Router.HandleFunc("/webhooks/{webhookId}", webhooksHandler.UpdateWebhook).Methods("PUT", "OPTIONS")
+	apiHandler.Router.HandleFunc("/webhooks/{webhookId}", webhooksHandler.GetWebhook).Methods("GET", "OPTIONS")
+	apiHandler.Router.HandleFunc("/webhooks/{webhookId}", webhooksHandler.DeleteWebhook).Methods("DELETE", "OPTIONS")
+	apiHandler.Router.HandleFunc("/webhooks/{webhookId}/dead-letters", webhooksHandler.GetWebhookDeadLetters).Methods("GET", "OPTIONS")
+}
+
+func (apiHandler *apiHandler) addPostureChecksEndpoint() {
+	postureChecksHandler := NewPostureChecksHandler(apiHandler.AccountManager, apiHandler.AuthCfg)
+	apiHandler.Router.HandleFunc("/posture-checks", postureChecksHandler.GetAllPostureChecks).Methods("GET", "OPTIONS")
+	apiHandler.Router.HandleFunc("/posture-checks", postureChecksHandler.CreatePostureCheck).Methods("POST", "OPTIONS")
+	apiHandler.Router.HandleFunc("/posture-checks/{postureCheckId}", postureChecksHandler.UpdatePostureCheck).Methods("PUT", "OPTIONS")
+	apiHandler.Router.HandleFunc("/posture-checks/{postureCheckId}", postureChecksHandler.GetPostureCheck).Methods("GET", "OPTIONS")
+	apiHandler.Router.HandleFunc("/posture-checks/{postureCheckId}", postureChecksHandler.DeletePostureCheck).Methods("DELETE", "OPTIONS")
+}
+
 func (apiHandler *apiHandler) addGroupsEndpoint() {
 	groupsHandler := NewGroupsHandler(apiHandler.AccountManager, apiHandler.AuthCfg)
 	apiHandler.Router.HandleFunc("/groups", groupsHandler.GetAllGroups).Methods("GET", "OPTIONS")
@@ -169,6 +193,15 @@ func (apiHandler *apiHandler) addRoutesEndpoint() {
 	apiHandler.Router.HandleFunc("/routes/{routeId}", routesHandler.DeleteRoute).Methods("DELETE", "OPTIONS")
 }
 
+func (apiHandler *apiHandler) addDNSZonesEndpoint() {
+	zonesHandler := NewDNSZonesHandler(apiHandler.AccountManager, apiHandler.AuthCfg)
+	apiHandler.Router.HandleFunc("/dns/zones", zonesHandler.GetAllDNSZones).Methods("GET", "OPTIONS")
+	apiHandler.Router.HandleFunc("/dns/zones", zonesHandler.CreateDNSZone).Methods("POST", "OPTIONS")
+	apiHandler.Router.HandleFunc("/dns/zones/{zoneId}", zonesHandler.UpdateDNSZone).Methods("PUT", "OPTIONS")
+	apiHandler.Router.HandleFunc("/dns/zones/{zoneId}", zonesHandler.GetDNSZone).Methods("GET", "OPTIONS")
+	apiHandler.Router.HandleFunc("/dns/zones/{zoneId}", zonesHandler.DeleteDNSZone).Methods("DELETE", "OPTIONS")
+}
+
 func (apiHandler *apiHandler) addDNSNameserversEndpoint() {
 	nameserversHandler := NewNameserversHandler(apiHandler.AccountManager, apiHandler.AuthCfg)
 	apiHandler.Router.HandleFunc("/dns/nameservers", nameserversHandler.GetAllNameservers).Methods("GET", "OPTIONS")
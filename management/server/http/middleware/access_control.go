@@ -16,7 +16,13 @@ import (
 // GetUser function defines a function to fetch user from Account by jwtclaims.AuthorizationClaims
 type GetUser func(claims jwtclaims.AuthorizationClaims) (*server.User, error)
 
-// AccessControl middleware to restrict to make POST/PUT/DELETE requests by admin only
+var (
+	tokenPathRegexp   = regexp.MustCompile(`^.*/api/users/.*/tokens.*$`)
+	networkPathRegexp = regexp.MustCompile(`^.*/api/(groups|policies|rules|posture-checks|routes|dns)(/.*)?$`)
+)
+
+// AccessControl middleware to restrict to make POST/PUT/DELETE requests by admin only.
+// Network admins can also modify the network configuration: groups, policies, routes and DNS
 type AccessControl struct {
 	claimsExtract jwtclaims.ClaimsExtractor
 	getUser       GetUser
@@ -54,14 +60,13 @@ func (a *AccessControl) Handler(h http.Handler) http.Handler {
 			switch r.Method {
 			case http.MethodDelete, http.MethodPost, http.MethodPatch, http.MethodPut:
 
-				ok, err := regexp.MatchString(`^.*/api/users/.*/tokens.*$`, r.URL.Path)
-				if err != nil {
-					log.Debugf("regex failed")
-					util.WriteError(status.Errorf(status.Internal, ""), w)
+				if tokenPathRegexp.MatchString(r.URL.Path) {
+					log.Debugf("valid Path")
+					h.ServeHTTP(w, r)
 					return
 				}
-				if ok {
-					log.Debugf("valid Path")
+
+				if user.CanManageNetwork() && networkPathRegexp.MatchString(r.URL.Path) {
 					h.ServeHTTP(w, r)
 					return
 				}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/netbirdio/netbird/management/server"
+	"github.com/netbirdio/netbird/management/server/jwtclaims"
+)
+
+func TestAccessControl_Roles(t *testing.T) {
+	tt := []struct {
+		name           string
+		role           server.UserRole
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{"admin updates users", server.UserRoleAdmin, http.MethodPut, "/api/users/user", http.StatusOK},
+		{"network admin updates groups", server.UserRoleNetworkAdmin, http.MethodPut, "/api/groups/group", http.StatusOK},
+		{"network admin creates policies", server.UserRoleNetworkAdmin, http.MethodPost, "/api/policies", http.StatusOK},
+		{"network admin deletes routes", server.UserRoleNetworkAdmin, http.MethodDelete, "/api/routes/route", http.StatusOK},
+		{"network admin updates DNS settings", server.UserRoleNetworkAdmin, http.MethodPut, "/api/dns/settings", http.StatusOK},
+		{"network admin updates users", server.UserRoleNetworkAdmin, http.MethodPut, "/api/users/user", http.StatusForbidden},
+		{"network admin creates setup keys", server.UserRoleNetworkAdmin, http.MethodPost, "/api/setup-keys", http.StatusForbidden},
+		{"auditor reads events", server.UserRoleAuditor, http.MethodGet, "/api/events", http.StatusOK},
+		{"auditor updates groups", server.UserRoleAuditor, http.MethodPut, "/api/groups/group", http.StatusForbidden},
+		{"auditor creates own tokens", server.UserRoleAuditor, http.MethodPost, "/api/users/user/tokens", http.StatusOK},
+		{"user updates groups", server.UserRoleUser, http.MethodPut, "/api/groups/group", http.StatusForbidden},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			accessControl := &AccessControl{
+				claimsExtract: *jwtclaims.NewClaimsExtractor(
+					jwtclaims.WithFromRequestContext(func(r *http.Request) jwtclaims.AuthorizationClaims {
+						return jwtclaims.AuthorizationClaims{UserId: "user"}
+					}),
+				),
+				getUser: func(claims jwtclaims.AuthorizationClaims) (*server.User, error) {
+					return server.NewUser(claims.UserId, tc.role, false, "", nil), nil
+				},
+			}
+
+			handler := accessControl.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(tc.method, tc.path, nil))
+
+			if recorder.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, recorder.Code)
+			}
+		})
+	}
+}
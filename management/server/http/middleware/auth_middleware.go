@@ -71,6 +71,10 @@ func (m *AuthMiddleware) Handler(h http.Handler) http.Handler {
 			err := m.CheckPATFromRequest(w, r)
 			if err != nil {
 				log.Debugf("Error when validating PAT claims: %s", err.Error())
+				if e, ok := status.FromError(err); ok && e.Type() == status.PermissionDenied {
+					util.WriteError(err, w)
+					return
+				}
 				util.WriteError(status.Errorf(status.Unauthorized, "token invalid"), w)
 				return
 			}
@@ -126,6 +130,11 @@ func (m *AuthMiddleware) CheckPATFromRequest(w http.ResponseWriter, r *http.Requ
 		return fmt.Errorf("token expired")
 	}
 
+	resource := patResourceFromPath(r.URL.Path)
+	if !pat.HasScope(resource, isWriteMethod(r.Method)) {
+		return status.Errorf(status.PermissionDenied, "token has no scope for %s %s", r.Method, r.URL.Path)
+	}
+
 	err = m.markPATUsed(pat.ID)
 	if err != nil {
 		return err
@@ -136,6 +145,7 @@ func (m *AuthMiddleware) CheckPATFromRequest(w http.ResponseWriter, r *http.Requ
 	claimMaps[m.audience+jwtclaims.AccountIDSuffix] = account.Id
 	claimMaps[m.audience+jwtclaims.DomainIDSuffix] = account.Domain
 	claimMaps[m.audience+jwtclaims.DomainCategorySuffix] = account.DomainCategory
+	claimMaps[m.audience+jwtclaims.PATIDSuffix] = pat.ID
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claimMaps)
 	newRequest := r.WithContext(context.WithValue(r.Context(), jwtclaims.TokenUserProperty, jwtToken)) //nolint
 	// Update the current request with the new context information.
@@ -143,6 +153,27 @@ func (m *AuthMiddleware) CheckPATFromRequest(w http.ResponseWriter, r *http.Requ
 	return nil
 }
 
+// patResourceFromPath returns the API resource of the request path used to match the token scopes,
+// e.g. /api/users/{id}/tokens belongs to the users resource
+func patResourceFromPath(path string) string {
+	_, resourcePath, found := strings.Cut(path, "/api/")
+	if !found {
+		return ""
+	}
+	resource, _, _ := strings.Cut(resourcePath, "/")
+	return resource
+}
+
+// isWriteMethod returns true if the HTTP method modifies a resource
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
 // getTokenFromJWTRequest is a "TokenExtractor" that takes a give request and extracts
 // the JWT token from the Authorization header.
 func getTokenFromJWTRequest(r *http.Request) (string, error) {
@@ -19,7 +19,9 @@ const (
 	domain      = "domain"
 	userID      = "userID"
 	tokenID     = "tokenID"
+	scopedID    = "scopedID"
 	PAT         = "PAT"
+	scopedPAT   = "scopedPAT"
 	JWT         = "JWT"
 	wrongToken  = "wrongToken"
 )
@@ -40,6 +42,16 @@ var testAccount = &server.Account{
 					CreatedAt:      time.Now().UTC(),
 					LastUsed:       time.Now().UTC(),
 				},
+				scopedID: {
+					ID:             scopedID,
+					Name:           "Scoped token",
+					HashedToken:    "someOtherHash",
+					ExpirationDate: time.Now().UTC().AddDate(0, 0, 7),
+					Scopes:         []string{"peers:read", "routes:write", "webhooks:read"},
+					CreatedBy:      userID,
+					CreatedAt:      time.Now().UTC(),
+					LastUsed:       time.Now().UTC(),
+				},
 			},
 		},
 	},
@@ -49,6 +61,9 @@ func mockGetAccountFromPAT(token string) (*server.Account, *server.User, *server
 	if token == PAT {
 		return testAccount, testAccount.Users[userID], testAccount.Users[userID].PATs[tokenID], nil
 	}
+	if token == scopedPAT {
+		return testAccount, testAccount.Users[userID], testAccount.Users[userID].PATs[scopedID], nil
+	}
 	return nil, nil, nil, fmt.Errorf("PAT invalid")
 }
 
@@ -60,7 +75,7 @@ func mockValidateAndParseToken(token string) (*jwt.Token, error) {
 }
 
 func mockMarkPATUsed(token string) error {
-	if token == tokenID {
+	if token == tokenID || token == scopedID {
 		return nil
 	}
 	return fmt.Errorf("Should never get reached")
@@ -122,5 +137,46 @@ func TestAuthMiddleware_Handler(t *testing.T) {
 			}
 		})
 	}
+}
+
+func TestAuthMiddleware_PATScopes(t *testing.T) {
+	tt := []struct {
+		name               string
+		method             string
+		path               string
+		expectedStatusCode int
+	}{
+		{"read scope allows GET", http.MethodGet, "/api/peers", 200},
+		{"read scope denies PUT", http.MethodPut, "/api/peers/peer", 403},
+		{"write scope allows DELETE", http.MethodDelete, "/api/routes/route", 200},
+		{"write scope allows GET", http.MethodGet, "/api/routes", 200},
+		{"missing scope denies GET", http.MethodGet, "/api/events", 403},
+		{"webhooks read scope allows GET", http.MethodGet, "/api/webhooks/hook/dead-letters", 200},
+		{"webhooks read scope denies POST", http.MethodPost, "/api/webhooks", 403},
+		{"missing scope denies token creation", http.MethodPost, "/api/users/" + userID + "/tokens", 403},
+	}
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// do nothing
+	})
 
+	authMiddleware := NewAuthMiddleware(mockGetAccountFromPAT, mockValidateAndParseToken, mockMarkPATUsed, audience, userIDClaim)
+
+	handlerToTest := authMiddleware.Handler(nextHandler)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "http://testing"+tc.path, nil)
+			req.Header.Set("Authorization", "Token "+scopedPAT)
+			rec := httptest.NewRecorder()
+
+			handlerToTest.ServeHTTP(rec, req)
+
+			result := rec.Result()
+			defer result.Body.Close()
+			if result.StatusCode != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, result.StatusCode)
+			}
+		})
+	}
 }
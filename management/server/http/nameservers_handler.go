@@ -79,7 +79,12 @@ func (h *NameserversHandler) CreateNameserverGroup(w http.ResponseWriter, r *htt
 		return
 	}
 
-	nsGroup, err := h.accountManager.CreateNameServerGroup(account.Id, req.Name, req.Description, nsList, req.Groups, req.Primary, req.Domains, req.Enabled, user.Id)
+	var searchDomainsEnabled bool
+	if req.SearchDomainsEnabled != nil {
+		searchDomainsEnabled = *req.SearchDomainsEnabled
+	}
+
+	nsGroup, err := h.accountManager.CreateNameServerGroup(account.Id, req.Name, req.Description, nsList, req.Groups, req.Primary, req.Domains, req.Enabled, user.Id, searchDomainsEnabled)
 	if err != nil {
 		util.WriteError(err, w)
 		return
@@ -128,6 +133,9 @@ func (h *NameserversHandler) UpdateNameserverGroup(w http.ResponseWriter, r *htt
 		Groups:      req.Groups,
 		Enabled:     req.Enabled,
 	}
+	if req.SearchDomainsEnabled != nil {
+		updatedNSGroup.SearchDomainsEnabled = *req.SearchDomainsEnabled
+	}
 
 	err = h.accountManager.SaveNameServerGroup(account.Id, user.Id, updatedNSGroup)
 	if err != nil {
@@ -198,6 +206,9 @@ func toServerNSList(apiNSList []api.Nameserver) ([]nbdns.NameServer, error) {
 		if err != nil {
 			return nil, err
 		}
+		if apiNS.ServerName != nil {
+			parsed.ServerName = *apiNS.ServerName
+		}
 		nsList = append(nsList, parsed)
 	}
 
@@ -212,17 +223,24 @@ func toNameserverGroupResponse(serverNSGroup *nbdns.NameServerGroup) *api.Namese
 			NsType: api.NameserverNsType(ns.NSType.String()),
 			Port:   ns.Port,
 		}
+		if ns.ServerName != "" {
+			serverName := ns.ServerName
+			apiNS.ServerName = &serverName
+		}
 		nsList = append(nsList, apiNS)
 	}
 
+	searchDomainsEnabled := serverNSGroup.SearchDomainsEnabled
+
 	return &api.NameserverGroup{
-		Id:          serverNSGroup.ID,
-		Name:        serverNSGroup.Name,
-		Description: serverNSGroup.Description,
-		Primary:     serverNSGroup.Primary,
-		Domains:     serverNSGroup.Domains,
-		Groups:      serverNSGroup.Groups,
-		Nameservers: nsList,
-		Enabled:     serverNSGroup.Enabled,
+		Id:                   serverNSGroup.ID,
+		Name:                 serverNSGroup.Name,
+		Description:          serverNSGroup.Description,
+		Primary:              serverNSGroup.Primary,
+		Domains:              serverNSGroup.Domains,
+		Groups:               serverNSGroup.Groups,
+		Nameservers:          nsList,
+		Enabled:              serverNSGroup.Enabled,
+		SearchDomainsEnabled: &searchDomainsEnabled,
 	}
 }
@@ -67,16 +67,17 @@ func initNameserversTestData() *NameserversHandler {
 				}
 				return nil, status.Errorf(status.NotFound, "nameserver group with ID %s not found", nsGroupID)
 			},
-			CreateNameServerGroupFunc: func(accountID string, name, description string, nameServerList []nbdns.NameServer, groups []string, primary bool, domains []string, enabled bool, _ string) (*nbdns.NameServerGroup, error) {
+			CreateNameServerGroupFunc: func(accountID string, name, description string, nameServerList []nbdns.NameServer, groups []string, primary bool, domains []string, enabled bool, _ string, searchDomainsEnabled bool) (*nbdns.NameServerGroup, error) {
 				return &nbdns.NameServerGroup{
-					ID:          existingNSGroupID,
-					Name:        name,
-					Description: description,
-					NameServers: nameServerList,
-					Groups:      groups,
-					Enabled:     enabled,
-					Primary:     primary,
-					Domains:     domains,
+					ID:                   existingNSGroupID,
+					Name:                 name,
+					Description:          description,
+					NameServers:          nameServerList,
+					Groups:               groups,
+					Enabled:              enabled,
+					Primary:              primary,
+					Domains:              domains,
+					SearchDomainsEnabled: searchDomainsEnabled,
 				}, nil
 			},
 			DeleteNameServerGroupFunc: func(accountID, nsGroupID, _ string) error {
@@ -105,6 +106,8 @@ func initNameserversTestData() *NameserversHandler {
 }
 
 func TestNameserversHandlers(t *testing.T) {
+	str := func(s string) *string { return &s }
+	boolean := func(b bool) *bool { return &b }
 	tt := []struct {
 		name            string
 		expectedStatus  int
@@ -147,9 +150,66 @@ func TestNameserversHandlers(t *testing.T) {
 						Port:   53,
 					},
 				},
-				Groups:  []string{"group"},
-				Enabled: true,
-				Primary: true,
+				Groups:               []string{"group"},
+				Enabled:              true,
+				Primary:              true,
+				SearchDomainsEnabled: boolean(false),
+			},
+		},
+		{
+			name:        "POST Encrypted Nameserver OK",
+			requestType: http.MethodPost,
+			requestPath: "/api/dns/nameservers",
+			requestBody: bytes.NewBuffer(
+				[]byte("{\"name\":\"name\",\"Description\":\"Post\",\"nameservers\":[{\"ip\":\"8.8.8.8\",\"ns_type\":\"tls\",\"port\":853,\"server_name\":\"dns.google\"},{\"ip\":\"1.1.1.1\",\"ns_type\":\"https\",\"port\":443}],\"groups\":[\"group\"],\"enabled\":true,\"primary\":true}")),
+			expectedStatus: http.StatusOK,
+			expectedBody:   true,
+			expectedNSGroup: &api.NameserverGroup{
+				Id:          existingNSGroupID,
+				Name:        "name",
+				Description: "Post",
+				Nameservers: []api.Nameserver{
+					{
+						Ip:         "8.8.8.8",
+						NsType:     "tls",
+						Port:       853,
+						ServerName: str("dns.google"),
+					},
+					{
+						Ip:     "1.1.1.1",
+						NsType: "https",
+						Port:   443,
+					},
+				},
+				Groups:               []string{"group"},
+				Enabled:              true,
+				Primary:              true,
+				SearchDomainsEnabled: boolean(false),
+			},
+		},
+		{
+			name:        "POST Search Domains OK",
+			requestType: http.MethodPost,
+			requestPath: "/api/dns/nameservers",
+			requestBody: bytes.NewBuffer(
+				[]byte("{\"name\":\"name\",\"Description\":\"Post\",\"nameservers\":[{\"ip\":\"1.1.1.1\",\"ns_type\":\"udp\",\"port\":53}],\"groups\":[\"group\"],\"enabled\":true,\"primary\":false,\"domains\":[\"example.com\"],\"search_domains_enabled\":true}")),
+			expectedStatus: http.StatusOK,
+			expectedBody:   true,
+			expectedNSGroup: &api.NameserverGroup{
+				Id:          existingNSGroupID,
+				Name:        "name",
+				Description: "Post",
+				Nameservers: []api.Nameserver{
+					{
+						Ip:     "1.1.1.1",
+						NsType: "udp",
+						Port:   53,
+					},
+				},
+				Groups:               []string{"group"},
+				Enabled:              true,
+				Domains:              []string{"example.com"},
+				SearchDomainsEnabled: boolean(true),
 			},
 		},
 		{
@@ -180,9 +240,10 @@ func TestNameserversHandlers(t *testing.T) {
 						Port:   53,
 					},
 				},
-				Groups:  []string{"group"},
-				Enabled: true,
-				Primary: true,
+				Groups:               []string{"group"},
+				Enabled:              true,
+				Primary:              true,
+				SearchDomainsEnabled: boolean(false),
 			},
 		},
 		{
@@ -115,7 +115,21 @@ func (h *PATHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pat, err := h.accountManager.CreatePAT(account.Id, user.Id, targetUserID, req.Name, req.ExpiresIn)
+	var scopes []string
+	if req.Scopes != nil {
+		scopes = *req.Scopes
+	}
+
+	// a token can't create a token with more permissions than its own
+	if claims.PATID != "" {
+		callerPAT, ok := user.PATs[claims.PATID]
+		if !ok || !callerPAT.CoversScopes(scopes) {
+			util.WriteError(status.Errorf(status.PermissionDenied, "the token scopes must be a subset of the scopes of the token used for the request"), w)
+			return
+		}
+	}
+
+	pat, err := h.accountManager.CreatePAT(account.Id, user.Id, targetUserID, req.Name, req.ExpiresIn, scopes)
 	if err != nil {
 		util.WriteError(err, w)
 		return
@@ -160,6 +174,10 @@ func toPATResponse(pat *server.PersonalAccessToken) *api.PersonalAccessToken {
 	if !pat.LastUsed.IsZero() {
 		lastUsed = &pat.LastUsed
 	}
+	var scopes *[]string
+	if len(pat.Scopes) != 0 {
+		scopes = &pat.Scopes
+	}
 	return &api.PersonalAccessToken{
 		CreatedAt:      pat.CreatedAt,
 		CreatedBy:      pat.CreatedBy,
@@ -167,6 +185,7 @@ func toPATResponse(pat *server.PersonalAccessToken) *api.PersonalAccessToken {
 		ExpirationDate: pat.ExpirationDate,
 		Id:             pat.ID,
 		LastUsed:       lastUsed,
+		Scopes:         scopes,
 	}
 }
 
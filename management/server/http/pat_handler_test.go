@@ -63,7 +63,7 @@ var testAccount = &server.Account{
 func initPATTestData() *PATHandler {
 	return &PATHandler{
 		accountManager: &mock_server.MockAccountManager{
-			CreatePATFunc: func(accountID string, initiatorUserID string, targetUserID string, tokenName string, expiresIn int) (*server.PersonalAccessTokenGenerated, error) {
+			CreatePATFunc: func(accountID string, initiatorUserID string, targetUserID string, tokenName string, expiresIn int, scopes []string) (*server.PersonalAccessTokenGenerated, error) {
 				if accountID != existingAccountID {
 					return nil, status.Errorf(status.NotFound, "account with ID %s not found", accountID)
 				}
@@ -252,3 +252,66 @@ func toTokenResponse(serverToken server.PersonalAccessToken) api.PersonalAccessT
 		ExpirationDate: serverToken.ExpirationDate,
 	}
 }
+
+func TestCreateTokenWithScopedToken(t *testing.T) {
+	scopedTokenID := "scopedTokenID"
+	user := &server.User{
+		Id: existingUserID,
+		PATs: map[string]*server.PersonalAccessToken{
+			scopedTokenID: {
+				ID:     scopedTokenID,
+				Scopes: []string{"users:write", "peers:read"},
+			},
+		},
+	}
+
+	tt := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+	}{
+		{
+			name:           "subset of the scopes",
+			requestBody:    `{"name":"name","expires_in":7,"scopes":["users:read","peers:read"]}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "scope not granted to the token",
+			requestBody:    `{"name":"name","expires_in":7,"scopes":["peers:write"]}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "full access",
+			requestBody:    `{"name":"name","expires_in":7}`,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	p := initPATTestData()
+	p.accountManager.(*mock_server.MockAccountManager).GetAccountFromTokenFunc = func(_ jwtclaims.AuthorizationClaims) (*server.Account, *server.User, error) {
+		return testAccount, user, nil
+	}
+	p.claimsExtractor = jwtclaims.NewClaimsExtractor(
+		jwtclaims.WithFromRequestContext(func(r *http.Request) jwtclaims.AuthorizationClaims {
+			return jwtclaims.AuthorizationClaims{
+				UserId:    existingUserID,
+				Domain:    domain,
+				AccountId: existingAccountID,
+				PATID:     scopedTokenID,
+			}
+		}),
+	)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/"+existingUserID+"/tokens", bytes.NewBufferString(tc.requestBody))
+
+			router := mux.NewRouter()
+			router.HandleFunc("/api/users/{userId}/tokens", p.CreateToken).Methods("POST")
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedStatus, recorder.Code, recorder.Body.String())
+		})
+	}
+}
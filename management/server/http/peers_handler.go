@@ -3,6 +3,7 @@ package http
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -51,6 +52,14 @@ func (h *PeersHandler) updatePeer(account *server.Account, user *server.User, pe
 
 	update := &server.Peer{ID: peerID, SSHEnabled: req.SshEnabled, Name: req.Name,
 		LoginExpirationEnabled: req.LoginExpirationEnabled}
+
+	if req.Ip != nil && *req.Ip != "" {
+		update.IP = net.ParseIP(*req.Ip)
+		if update.IP == nil || update.IP.To4() == nil {
+			util.WriteError(status.Errorf(status.InvalidArgument, "invalid peer IP %s", *req.Ip), w)
+			return
+		}
+	}
 	peer, err := h.accountManager.UpdatePeer(account.Id, user.Id, update)
 	if err != nil {
 		util.WriteError(err, w)
@@ -99,6 +108,52 @@ func (h *PeersHandler) HandlePeer(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ApprovePeer approves a peer pending approval
+func (h *PeersHandler) ApprovePeer(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+	peerID := mux.Vars(r)["peerId"]
+	if len(peerID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid peer ID"), w)
+		return
+	}
+
+	peer, err := h.accountManager.ApprovePeer(account.Id, user.Id, peerID)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, toPeerResponse(peer, account, h.accountManager.GetDNSDomain()))
+}
+
+// RejectPeer rejects a peer pending approval and removes it from the account
+func (h *PeersHandler) RejectPeer(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+	peerID := mux.Vars(r)["peerId"]
+	if len(peerID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid peer ID"), w)
+		return
+	}
+
+	err = h.accountManager.RejectPeer(account.Id, user.Id, peerID)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, emptyObject{})
+}
+
 // GetAllPeers returns a list of all peers associated with a provided account
 func (h *PeersHandler) GetAllPeers(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
@@ -156,10 +211,26 @@ func toPeerResponse(peer *server.Peer, account *server.Account, dnsDomain string
 		fqdn = peer.DNSLabel
 	}
 
+	var failedPostureChecks *[]string
+	if failed := account.GetPeerFailedPostureChecks(peer.ID); len(failed) != 0 {
+		ids := make([]string, 0, len(failed))
+		for _, postureChecks := range failed {
+			ids = append(ids, postureChecks.ID)
+		}
+		failedPostureChecks = &ids
+	}
+
+	var ipv6 *string
+	if peer.IPv6 != nil {
+		address := peer.IPv6.String()
+		ipv6 = &address
+	}
+
 	return &api.Peer{
 		Id:                     peer.ID,
 		Name:                   peer.Name,
 		Ip:                     peer.IP.String(),
+		Ipv6:                   ipv6,
 		Connected:              peer.Status.Connected,
 		LastSeen:               peer.Status.LastSeen,
 		Os:                     fmt.Sprintf("%s %s", peer.Meta.OS, peer.Meta.Core),
@@ -173,5 +244,8 @@ func toPeerResponse(peer *server.Peer, account *server.Account, dnsDomain string
 		LoginExpirationEnabled: peer.LoginExpirationEnabled,
 		LastLogin:              peer.LastLogin,
 		LoginExpired:           peer.Status.LoginExpired,
+		ApprovalRequired:       peer.Status.RequiresApproval,
+		PostureChecksCompliant: failedPostureChecks == nil,
+		FailedPostureChecks:    failedPostureChecks,
 	}
 }
@@ -3,6 +3,7 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -32,6 +33,14 @@ func initTestMetaData(peers ...*server.Peer) *PeersHandler {
 				p.SSHEnabled = update.SSHEnabled
 				p.LoginExpirationEnabled = update.LoginExpirationEnabled
 				p.Name = update.Name
+				if update.IP != nil {
+					p.IP = update.IP
+				}
+				return p, nil
+			},
+			ApprovePeerFunc: func(accountID, userID, peerID string) (*server.Peer, error) {
+				p := peers[0].Copy()
+				p.Status.RequiresApproval = false
 				return p, nil
 			},
 			GetPeerFunc: func(accountID, peerID, userID string) (*server.Peer, error) {
@@ -79,7 +88,7 @@ func TestGetPeers(t *testing.T) {
 		Key:                    "key",
 		SetupKey:               "setupkey",
 		IP:                     net.ParseIP("100.64.0.1"),
-		Status:                 &server.PeerStatus{},
+		Status:                 &server.PeerStatus{RequiresApproval: true},
 		Name:                   "PeerName",
 		LoginExpirationEnabled: false,
 		Meta: server.PeerSystemMeta{
@@ -98,6 +107,12 @@ func TestGetPeers(t *testing.T) {
 	expectedUpdatedPeer.SSHEnabled = true
 	expectedUpdatedPeer.Name = "New Name"
 
+	expectedReaddressedPeer := expectedUpdatedPeer.Copy()
+	expectedReaddressedPeer.IP = net.ParseIP("100.64.0.20")
+
+	expectedApprovedPeer := peer.Copy()
+	expectedApprovedPeer.Status.RequiresApproval = false
+
 	tt := []struct {
 		name           string
 		expectedStatus int
@@ -132,6 +147,23 @@ func TestGetPeers(t *testing.T) {
 			requestBody:    bytes.NewBufferString("{\"login_expiration_enabled\":true,\"name\":\"New Name\",\"ssh_enabled\":true}"),
 			expectedPeer:   expectedUpdatedPeer,
 		},
+		{
+			name:           "PutPeerIP",
+			requestType:    http.MethodPut,
+			requestPath:    "/api/peers/" + testPeerID,
+			expectedStatus: http.StatusOK,
+			expectedArray:  false,
+			requestBody:    bytes.NewBufferString("{\"login_expiration_enabled\":true,\"name\":\"New Name\",\"ssh_enabled\":true,\"ip\":\"100.64.0.20\"}"),
+			expectedPeer:   expectedReaddressedPeer,
+		},
+		{
+			name:           "ApprovePeer",
+			requestType:    http.MethodPost,
+			requestPath:    "/api/peers/" + testPeerID + "/approve",
+			expectedStatus: http.StatusOK,
+			expectedArray:  false,
+			expectedPeer:   expectedApprovedPeer,
+		},
 	}
 
 	rr := httptest.NewRecorder()
@@ -148,6 +180,7 @@ func TestGetPeers(t *testing.T) {
 			router.HandleFunc("/api/peers/", p.GetAllPeers).Methods("GET")
 			router.HandleFunc("/api/peers/{peerId}", p.HandlePeer).Methods("GET")
 			router.HandleFunc("/api/peers/{peerId}", p.HandlePeer).Methods("PUT")
+			router.HandleFunc("/api/peers/{peerId}/approve", p.ApprovePeer).Methods("POST")
 			router.ServeHTTP(recorder, req)
 
 			res := recorder.Result()
@@ -186,6 +219,24 @@ func TestGetPeers(t *testing.T) {
 			assert.Equal(t, got.Os, "OS core")
 			assert.Equal(t, got.LoginExpirationEnabled, tc.expectedPeer.LoginExpirationEnabled)
 			assert.Equal(t, got.SshEnabled, tc.expectedPeer.SSHEnabled)
+			assert.Equal(t, got.ApprovalRequired, tc.expectedPeer.Status.RequiresApproval)
 		})
 	}
 }
+
+func TestUpdatePeerInvalidIP(t *testing.T) {
+	peer := &server.Peer{ID: testPeerID, IP: net.ParseIP("100.64.0.1"), Status: &server.PeerStatus{}}
+	p := initTestMetaData(peer)
+
+	for _, ip := range []string{"not-an-ip", "fd00::1"} {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/api/peers/"+testPeerID,
+			bytes.NewBufferString(fmt.Sprintf("{\"name\":\"name\",\"ssh_enabled\":false,\"login_expiration_enabled\":false,\"ip\":%q}", ip)))
+
+		router := mux.NewRouter()
+		router.HandleFunc("/api/peers/{peerId}", p.HandlePeer).Methods("PUT")
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code, ip)
+	}
+}
@@ -3,7 +3,6 @@ package http
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/rs/xid"
@@ -137,6 +136,9 @@ func (h *Policies) savePolicy(
 		Enabled:     req.Enabled,
 		Description: req.Description,
 	}
+	if req.SourcePostureChecks != nil {
+		policy.SourcePostureChecks = *req.SourcePostureChecks
+	}
 	for _, r := range req.Rules {
 		pr := server.PolicyRule{
 			ID:            policyID, //TODO: when policy can contain multiple rules, need refactor
@@ -177,8 +179,8 @@ func (h *Policies) savePolicy(
 
 		if r.Ports != nil && len(*r.Ports) != 0 {
 			for _, v := range *r.Ports {
-				if port, err := strconv.Atoi(v); err != nil || port < 1 || port > 65535 {
-					util.WriteError(status.Errorf(status.InvalidArgument, "valid port value is in 1..65535 range"), w)
+				if err := server.ValidatePolicyRulePort(v); err != nil {
+					util.WriteError(err, w)
 					return
 				}
 				pr.Ports = append(pr.Ports, v)
@@ -289,6 +291,11 @@ func toPolicyResponse(account *server.Account, policy *server.Policy) *api.Polic
 		Description: policy.Description,
 		Enabled:     policy.Enabled,
 	}
+	if len(policy.SourcePostureChecks) != 0 {
+		postureChecks := make([]string, len(policy.SourcePostureChecks))
+		copy(postureChecks, policy.SourcePostureChecks)
+		ap.SourcePostureChecks = &postureChecks
+	}
 	for _, r := range policy.Rules {
 		rule := api.PolicyRule{
 			Id:            &r.ID,
@@ -217,6 +217,59 @@ func TestPoliciesWritePolicy(t *testing.T) {
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   false,
 		},
+		{
+			name:        "WritePolicy POST Port Range OK",
+			requestType: http.MethodPost,
+			requestPath: "/api/policies",
+			requestBody: bytes.NewBuffer(
+				[]byte(`{
+                    "Name":"Default POSTed Policy",
+                    "Rules":[
+                        {
+                            "Name":"Default POSTed Policy",
+                            "Protocol": "tcp",
+                            "Action": "accept",
+                            "Bidirectional":true,
+                            "Ports":["22","8000-8100"]
+                        }
+                ]}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   true,
+			expectedPolicy: &api.Policy{
+				Id:   str("id-was-set"),
+				Name: "Default POSTed Policy",
+				Rules: []api.PolicyRule{
+					{
+						Id:            str("id-was-set"),
+						Name:          "Default POSTed Policy",
+						Description:   str(""),
+						Protocol:      "tcp",
+						Action:        "accept",
+						Bidirectional: true,
+						Ports:         &[]string{"22", "8000-8100"},
+					},
+				},
+			},
+		},
+		{
+			name:        "WritePolicy POST Invalid Port Range",
+			requestType: http.MethodPost,
+			requestPath: "/api/policies",
+			requestBody: bytes.NewBuffer(
+				[]byte(`{
+                    "Name":"Default POSTed Policy",
+                    "Rules":[
+                        {
+                            "Name":"Default POSTed Policy",
+                            "Protocol": "tcp",
+                            "Action": "accept",
+                            "Bidirectional":true,
+                            "Ports":["8100-8000"]
+                        }
+                ]}`)),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   false,
+		},
 		{
 			name:        "WritePolicy PUT OK",
 			requestType: http.MethodPut,
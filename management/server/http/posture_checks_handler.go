@@ -0,0 +1,239 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/xid"
+
+	"github.com/netbirdio/netbird/management/server"
+	"github.com/netbirdio/netbird/management/server/http/api"
+	"github.com/netbirdio/netbird/management/server/http/util"
+	"github.com/netbirdio/netbird/management/server/jwtclaims"
+	"github.com/netbirdio/netbird/management/server/status"
+)
+
+// PostureChecksHandler is a handler that returns posture checks of the account
+type PostureChecksHandler struct {
+	accountManager  server.AccountManager
+	claimsExtractor *jwtclaims.ClaimsExtractor
+}
+
+// NewPostureChecksHandler creates a new PostureChecks handler
+func NewPostureChecksHandler(accountManager server.AccountManager, authCfg AuthCfg) *PostureChecksHandler {
+	return &PostureChecksHandler{
+		accountManager: accountManager,
+		claimsExtractor: jwtclaims.NewClaimsExtractor(
+			jwtclaims.WithAudience(authCfg.Audience),
+			jwtclaims.WithUserIDClaim(authCfg.UserIDClaim),
+		),
+	}
+}
+
+// GetAllPostureChecks list for the account
+func (p *PostureChecksHandler) GetAllPostureChecks(w http.ResponseWriter, r *http.Request) {
+	claims := p.claimsExtractor.FromRequestContext(r)
+	account, user, err := p.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	accountPostureChecks, err := p.accountManager.ListPostureChecks(account.Id, user.Id)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	postureChecks := []*api.PostureCheck{}
+	for _, postureCheck := range accountPostureChecks {
+		postureChecks = append(postureChecks, toPostureChecksResponse(postureCheck))
+	}
+
+	util.WriteJSONObject(w, postureChecks)
+}
+
+// UpdatePostureCheck handles update to a posture check identified by a given ID
+func (p *PostureChecksHandler) UpdatePostureCheck(w http.ResponseWriter, r *http.Request) {
+	claims := p.claimsExtractor.FromRequestContext(r)
+	account, user, err := p.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	vars := mux.Vars(r)
+	postureChecksID := vars["postureCheckId"]
+	if len(postureChecksID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid posture checks ID"), w)
+		return
+	}
+
+	postureChecksIdx := -1
+	for i, postureCheck := range account.PostureChecks {
+		if postureCheck.ID == postureChecksID {
+			postureChecksIdx = i
+			break
+		}
+	}
+	if postureChecksIdx < 0 {
+		util.WriteError(status.Errorf(status.NotFound, "couldn't find posture checks id %s", postureChecksID), w)
+		return
+	}
+
+	p.savePostureChecks(w, r, account, user, postureChecksID)
+}
+
+// CreatePostureCheck handles posture check creation request
+func (p *PostureChecksHandler) CreatePostureCheck(w http.ResponseWriter, r *http.Request) {
+	claims := p.claimsExtractor.FromRequestContext(r)
+	account, user, err := p.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	p.savePostureChecks(w, r, account, user, "")
+}
+
+// GetPostureCheck handles a posture check Get request identified by ID
+func (p *PostureChecksHandler) GetPostureCheck(w http.ResponseWriter, r *http.Request) {
+	claims := p.claimsExtractor.FromRequestContext(r)
+	account, user, err := p.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	vars := mux.Vars(r)
+	postureChecksID := vars["postureCheckId"]
+	if len(postureChecksID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid posture checks ID"), w)
+		return
+	}
+
+	postureChecks, err := p.accountManager.GetPostureChecks(account.Id, postureChecksID, user.Id)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, toPostureChecksResponse(postureChecks))
+}
+
+// DeletePostureCheck handles posture check deletion request
+func (p *PostureChecksHandler) DeletePostureCheck(w http.ResponseWriter, r *http.Request) {
+	claims := p.claimsExtractor.FromRequestContext(r)
+	account, user, err := p.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	vars := mux.Vars(r)
+	postureChecksID := vars["postureCheckId"]
+	if len(postureChecksID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid posture checks ID"), w)
+		return
+	}
+
+	if err = p.accountManager.DeletePostureChecks(account.Id, postureChecksID, user.Id); err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, emptyObject{})
+}
+
+// savePostureChecks handles posture checks creation and update
+func (p *PostureChecksHandler) savePostureChecks(
+	w http.ResponseWriter,
+	r *http.Request,
+	account *server.Account,
+	user *server.User,
+	postureChecksID string,
+) {
+	var req api.PostureCheckUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.WriteErrorResponse("couldn't parse JSON request", http.StatusBadRequest, w)
+		return
+	}
+
+	if postureChecksID == "" {
+		postureChecksID = xid.New().String()
+	}
+
+	postureChecks := &server.PostureChecks{
+		ID:          postureChecksID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if nbVersionCheck := req.Checks.NbVersionCheck; nbVersionCheck != nil {
+		postureChecks.NBVersionCheck = &server.NBVersionCheck{
+			MinVersion: nbVersionCheck.MinVersion,
+		}
+	}
+
+	if osCheck := req.Checks.OsCheck; osCheck != nil {
+		postureChecks.OSCheck = &server.OSCheck{
+			AllowedOS: osCheck.AllowedOs,
+		}
+	}
+
+	if kernelVersionCheck := req.Checks.KernelVersionCheck; kernelVersionCheck != nil {
+		postureChecks.KernelVersionCheck = &server.KernelVersionCheck{}
+		if kernelVersionCheck.MinVersion != nil {
+			postureChecks.KernelVersionCheck.MinVersion = *kernelVersionCheck.MinVersion
+		}
+		if kernelVersionCheck.MaxVersion != nil {
+			postureChecks.KernelVersionCheck.MaxVersion = *kernelVersionCheck.MaxVersion
+		}
+	}
+
+	if err := p.accountManager.SavePostureChecks(account.Id, user.Id, postureChecks); err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, toPostureChecksResponse(postureChecks))
+}
+
+func toPostureChecksResponse(postureChecks *server.PostureChecks) *api.PostureCheck {
+	var checks api.Checks
+
+	if postureChecks.NBVersionCheck != nil {
+		checks.NbVersionCheck = &api.NBVersionCheck{
+			MinVersion: postureChecks.NBVersionCheck.MinVersion,
+		}
+	}
+
+	if postureChecks.OSCheck != nil {
+		allowedOS := make([]string, len(postureChecks.OSCheck.AllowedOS))
+		copy(allowedOS, postureChecks.OSCheck.AllowedOS)
+		checks.OsCheck = &api.OSCheck{
+			AllowedOs: allowedOS,
+		}
+	}
+
+	if postureChecks.KernelVersionCheck != nil {
+		kernelVersionCheck := &api.KernelVersionCheck{}
+		if postureChecks.KernelVersionCheck.MinVersion != "" {
+			minVersion := postureChecks.KernelVersionCheck.MinVersion
+			kernelVersionCheck.MinVersion = &minVersion
+		}
+		if postureChecks.KernelVersionCheck.MaxVersion != "" {
+			maxVersion := postureChecks.KernelVersionCheck.MaxVersion
+			kernelVersionCheck.MaxVersion = &maxVersion
+		}
+		checks.KernelVersionCheck = kernelVersionCheck
+	}
+
+	return &api.PostureCheck{
+		Id:          postureChecks.ID,
+		Name:        postureChecks.Name,
+		Description: postureChecks.Description,
+		Checks:      checks,
+	}
+}
@@ -0,0 +1,316 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/management/server"
+	"github.com/netbirdio/netbird/management/server/http/api"
+	"github.com/netbirdio/netbird/management/server/jwtclaims"
+	"github.com/netbirdio/netbird/management/server/mock_server"
+	"github.com/netbirdio/netbird/management/server/status"
+)
+
+func initPostureChecksTestData(postureChecks ...*server.PostureChecks) *PostureChecksHandler {
+	testPostureChecks := make(map[string]*server.PostureChecks, len(postureChecks))
+	for _, postureCheck := range postureChecks {
+		testPostureChecks[postureCheck.ID] = postureCheck
+	}
+
+	return &PostureChecksHandler{
+		accountManager: &mock_server.MockAccountManager{
+			GetPostureChecksFunc: func(_, postureChecksID, _ string) (*server.PostureChecks, error) {
+				p, ok := testPostureChecks[postureChecksID]
+				if !ok {
+					return nil, status.Errorf(status.NotFound, "posture checks not found")
+				}
+				return p, nil
+			},
+			SavePostureChecksFunc: func(_, _ string, postureChecks *server.PostureChecks) error {
+				if err := postureChecks.Validate(); err != nil {
+					return err
+				}
+				if !strings.HasPrefix(postureChecks.ID, "id-") {
+					postureChecks.ID = "id-was-set"
+				}
+				testPostureChecks[postureChecks.ID] = postureChecks
+				return nil
+			},
+			DeletePostureChecksFunc: func(_, postureChecksID, _ string) error {
+				if _, ok := testPostureChecks[postureChecksID]; !ok {
+					return status.Errorf(status.NotFound, "posture checks not found")
+				}
+				delete(testPostureChecks, postureChecksID)
+				return nil
+			},
+			ListPostureChecksFunc: func(_, _ string) ([]*server.PostureChecks, error) {
+				accountPostureChecks := make([]*server.PostureChecks, 0, len(testPostureChecks))
+				for _, p := range testPostureChecks {
+					accountPostureChecks = append(accountPostureChecks, p)
+				}
+				return accountPostureChecks, nil
+			},
+			GetAccountFromTokenFunc: func(claims jwtclaims.AuthorizationClaims) (*server.Account, *server.User, error) {
+				user := server.NewAdminUser("test_user")
+				return &server.Account{
+					Id:     claims.AccountId,
+					Domain: "hotmail.com",
+					PostureChecks: []*server.PostureChecks{
+						{ID: "id-existed"},
+					},
+					Users: map[string]*server.User{
+						"test_user": user,
+					},
+				}, user, nil
+			},
+		},
+		claimsExtractor: jwtclaims.NewClaimsExtractor(
+			jwtclaims.WithFromRequestContext(func(r *http.Request) jwtclaims.AuthorizationClaims {
+				return jwtclaims.AuthorizationClaims{
+					UserId:    "test_user",
+					Domain:    "hotmail.com",
+					AccountId: "test_id",
+				}
+			}),
+		),
+	}
+}
+
+func TestGetPostureCheck(t *testing.T) {
+	postureCheck := &server.PostureChecks{
+		ID:             "postureCheck",
+		Name:           "name",
+		NBVersionCheck: &server.NBVersionCheck{MinVersion: "0.23.0"},
+		OSCheck:        &server.OSCheck{AllowedOS: []string{"linux"}},
+	}
+
+	tt := []struct {
+		name           string
+		id             string
+		expectedStatus int
+		expectedBody   bool
+	}{
+		{
+			name:           "GetPostureCheck OK",
+			id:             postureCheck.ID,
+			expectedStatus: http.StatusOK,
+			expectedBody:   true,
+		},
+		{
+			name:           "GetPostureCheck not found",
+			id:             "not-exists",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	p := initPostureChecksTestData(postureCheck)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/posture-checks/"+tc.id, nil)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/api/posture-checks/{postureCheckId}", p.GetPostureCheck).Methods("GET")
+			router.ServeHTTP(recorder, req)
+
+			res := recorder.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if !tc.expectedBody {
+				return
+			}
+
+			content, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatalf("I don't know what I expected; %v", err)
+			}
+
+			var got api.PostureCheck
+			if err = json.Unmarshal(content, &got); err != nil {
+				t.Fatalf("Sent content is not in correct json format; %v", err)
+			}
+
+			assert.Equal(t, postureCheck.ID, got.Id)
+			assert.Equal(t, postureCheck.Name, got.Name)
+			if assert.NotNil(t, got.Checks.NbVersionCheck) {
+				assert.Equal(t, "0.23.0", got.Checks.NbVersionCheck.MinVersion)
+			}
+			if assert.NotNil(t, got.Checks.OsCheck) {
+				assert.Equal(t, []string{"linux"}, got.Checks.OsCheck.AllowedOs)
+			}
+			assert.Nil(t, got.Checks.KernelVersionCheck)
+		})
+	}
+}
+
+func TestPostureCheckUpdate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tt := []struct {
+		name                 string
+		requestType          string
+		requestPath          string
+		requestBody          io.Reader
+		expectedStatus       int
+		expectedPostureCheck *api.PostureCheck
+	}{
+		{
+			name:        "Create Posture Checks NB version",
+			requestType: http.MethodPost,
+			requestPath: "/api/posture-checks",
+			requestBody: bytes.NewBufferString(`{
+				"name": "default",
+				"description": "default",
+				"checks": {"nb_version_check": {"min_version": "1.2.3"}}
+			}`),
+			expectedStatus: http.StatusOK,
+			expectedPostureCheck: &api.PostureCheck{
+				Id:          "id-was-set",
+				Name:        "default",
+				Description: "default",
+				Checks: api.Checks{
+					NbVersionCheck: &api.NBVersionCheck{MinVersion: "1.2.3"},
+				},
+			},
+		},
+		{
+			name:        "Create Posture Checks kernel version range",
+			requestType: http.MethodPost,
+			requestPath: "/api/posture-checks",
+			requestBody: bytes.NewBufferString(`{
+				"name": "kernel",
+				"description": "",
+				"checks": {"kernel_version_check": {"min_version": "5.4", "max_version": "6.5.0"}}
+			}`),
+			expectedStatus: http.StatusOK,
+			expectedPostureCheck: &api.PostureCheck{
+				Id:   "id-was-set",
+				Name: "kernel",
+				Checks: api.Checks{
+					KernelVersionCheck: &api.KernelVersionCheck{MinVersion: str("5.4"), MaxVersion: str("6.5.0")},
+				},
+			},
+		},
+		{
+			name:        "Create Posture Checks invalid version",
+			requestType: http.MethodPost,
+			requestPath: "/api/posture-checks",
+			requestBody: bytes.NewBufferString(`{
+				"name": "default",
+				"description": "default",
+				"checks": {"nb_version_check": {"min_version": "not-a-version"}}
+			}`),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Create Posture Checks unknown OS",
+			requestType: http.MethodPost,
+			requestPath: "/api/posture-checks",
+			requestBody: bytes.NewBufferString(`{
+				"name": "default",
+				"description": "default",
+				"checks": {"os_check": {"allowed_os": ["plan9"]}}
+			}`),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Create Posture Checks without checks",
+			requestType: http.MethodPost,
+			requestPath: "/api/posture-checks",
+			requestBody: bytes.NewBufferString(`{
+				"name": "default",
+				"description": "default",
+				"checks": {}
+			}`),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Update Posture Checks",
+			requestType: http.MethodPut,
+			requestPath: "/api/posture-checks/id-existed",
+			requestBody: bytes.NewBufferString(`{
+				"name": "default",
+				"description": "default",
+				"checks": {"os_check": {"allowed_os": ["linux", "darwin"]}}
+			}`),
+			expectedStatus: http.StatusOK,
+			expectedPostureCheck: &api.PostureCheck{
+				Id:          "id-existed",
+				Name:        "default",
+				Description: "default",
+				Checks: api.Checks{
+					OsCheck: &api.OSCheck{AllowedOs: []string{"linux", "darwin"}},
+				},
+			},
+		},
+		{
+			name:        "Update Posture Checks not found",
+			requestType: http.MethodPut,
+			requestPath: "/api/posture-checks/id-not-existed",
+			requestBody: bytes.NewBufferString(`{
+				"name": "default",
+				"description": "default",
+				"checks": {"os_check": {"allowed_os": ["linux"]}}
+			}`),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	p := initPostureChecksTestData()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.requestType, tc.requestPath, tc.requestBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/api/posture-checks", p.CreatePostureCheck).Methods("POST")
+			router.HandleFunc("/api/posture-checks/{postureCheckId}", p.UpdatePostureCheck).Methods("PUT")
+			router.ServeHTTP(recorder, req)
+
+			res := recorder.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.expectedPostureCheck == nil {
+				return
+			}
+
+			content, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatalf("I don't know what I expected; %v", err)
+			}
+
+			var got api.PostureCheck
+			if err = json.Unmarshal(content, &got); err != nil {
+				t.Fatalf("Sent content is not in correct json format; %v", err)
+			}
+
+			assert.Equal(t, *tc.expectedPostureCheck, got)
+		})
+	}
+}
+
+func TestDeletePostureCheck(t *testing.T) {
+	p := initPostureChecksTestData(&server.PostureChecks{ID: "postureCheck"})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/posture-checks/{postureCheckId}", p.DeletePostureCheck).Methods("DELETE")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/api/posture-checks/postureCheck", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/api/posture-checks/postureCheck", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -97,12 +97,11 @@ func (h *RoutesHandler) CreateRoute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// do not allow non Linux peers
-	if peer := account.GetPeer(peerId); peer != nil {
-		if peer.Meta.GoOS != "linux" {
-			util.WriteError(status.Errorf(status.InvalidArgument, "non-linux peers are non supported as network routes"), w)
-			return
-		}
+	// do not allow peers that can't route traffic
+	if peer := account.GetPeer(peerId); peer != nil && !peer.SupportsRouting() {
+		util.WriteError(status.Errorf(status.InvalidArgument,
+			"peer %s doesn't support routing, upgrade the peer client to use it as a network route", peer.Name), w)
+		return
 	}
 
 	newRoute, err := h.accountManager.CreateRoute(
@@ -176,12 +175,11 @@ func (h *RoutesHandler) UpdateRoute(w http.ResponseWriter, r *http.Request) {
 		peerID = *req.Peer
 	}
 
-	// do not allow non Linux peers
-	if peer := account.GetPeer(peerID); peer != nil {
-		if peer.Meta.GoOS != "linux" {
-			util.WriteError(status.Errorf(status.InvalidArgument, "non-linux peers are non supported as network routes"), w)
-			return
-		}
+	// do not allow peers that can't route traffic
+	if peer := account.GetPeer(peerID); peer != nil && !peer.SupportsRouting() {
+		util.WriteError(status.Errorf(status.InvalidArgument,
+			"peer %s doesn't support routing, upgrade the peer client to use it as a network route", peer.Name), w)
+		return
 	}
 
 	newRoute := &route.Route{
@@ -28,6 +28,7 @@ const (
 	notFoundRouteID         = "notFoundRouteID"
 	existingPeerIP1         = "100.64.0.100"
 	existingPeerIP2         = "100.64.0.101"
+	existingPeerIP3         = "100.64.0.102"
 	notFoundPeerID          = "nonExistingPeer"
 	existingPeerKey         = "existingPeerKey"
 	nonLinuxExistingPeerKey = "darwinExistingPeerKey"
@@ -39,6 +40,7 @@ const (
 var emptyString = ""
 var existingPeerID = "peer-id"
 var nonLinuxExistingPeerID = "darwin-peer-id"
+var routingCapablePeerID = "windows-peer-id"
 
 var baseExistingRoute = &route.Route{
 	ID:          existingRouteID,
@@ -72,6 +74,15 @@ var testingAccount = &server.Account{
 				GoOS: "darwin",
 			},
 		},
+		routingCapablePeerID: {
+			Key: routingCapablePeerID,
+			IP:  netip.MustParseAddr(existingPeerIP3).AsSlice(),
+			ID:  routingCapablePeerID,
+			Meta: server.PeerSystemMeta{
+				GoOS:         "windows",
+				Capabilities: []string{route.RoutingPeerCapability},
+			},
+		},
 	},
 	Users: map[string]*server.User{
 		"test_user": server.NewAdminUser("test_user"),
@@ -217,6 +228,25 @@ func TestRoutesHandlers(t *testing.T) {
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   false,
 		},
+		{
+			name:           "POST Non Linux Peer With Routing Capability",
+			requestType:    http.MethodPost,
+			requestPath:    "/api/routes",
+			requestBody:    bytes.NewBufferString(fmt.Sprintf("{\"Description\":\"Post\",\"Network\":\"192.168.0.0/16\",\"network_id\":\"awesomeNet\",\"Peer\":\"%s\",\"groups\":[\"%s\"]}", routingCapablePeerID, existingGroupID)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   true,
+			expectedRoute: &api.Route{
+				Id:          existingRouteID,
+				Description: "Post",
+				NetworkId:   "awesomeNet",
+				Network:     "192.168.0.0/16",
+				Peer:        &routingCapablePeerID,
+				NetworkType: route.IPv4NetworkString,
+				Masquerade:  false,
+				Enabled:     false,
+				Groups:      []string{existingGroupID},
+			},
+		},
 		{
 			name:           "POST Not Found Peer",
 			requestType:    http.MethodPost,
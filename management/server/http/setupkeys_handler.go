@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 	"time"
 
@@ -75,8 +76,17 @@ func (h *SetupKeysHandler) CreateSetupKey(w http.ResponseWriter, r *http.Request
 	if req.Ephemeral != nil {
 		ephemeral = *req.Ephemeral
 	}
+	var reservedIP net.IP
+	if req.ReservedIp != nil && *req.ReservedIp != "" {
+		reservedIP = net.ParseIP(*req.ReservedIp)
+		if reservedIP == nil || reservedIP.To4() == nil {
+			util.WriteError(status.Errorf(status.InvalidArgument, "invalid reserved IP %s", *req.ReservedIp), w)
+			return
+		}
+	}
+
 	setupKey, err := h.accountManager.CreateSetupKey(account.Id, req.Name, server.SetupKeyType(req.Type), expiresIn,
-		req.AutoGroups, req.UsageLimit, user.Id, ephemeral)
+		req.AutoGroups, req.UsageLimit, user.Id, ephemeral, reservedIP)
 	if err != nil {
 		util.WriteError(err, w)
 		return
@@ -202,6 +212,12 @@ func toResponseBody(key *server.SetupKey) *api.SetupKey {
 		state = "valid"
 	}
 
+	var reservedIP *string
+	if key.ReservedIP != nil {
+		ip := key.ReservedIP.String()
+		reservedIP = &ip
+	}
+
 	return &api.SetupKey{
 		Id:         key.Id,
 		Key:        key.Key,
@@ -217,5 +233,6 @@ func toResponseBody(key *server.SetupKey) *api.SetupKey {
 		UpdatedAt:  key.UpdatedAt,
 		UsageLimit: key.UsageLimit,
 		Ephemeral:  key.Ephemeral,
+		ReservedIp: reservedIP,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -51,11 +52,12 @@ func initSetupKeysTestMetaData(defaultKey *server.SetupKey, newKey *server.Setup
 				}, user, nil
 			},
 			CreateSetupKeyFunc: func(_ string, keyName string, typ server.SetupKeyType, _ time.Duration, _ []string,
-				_ int, _ string, ephemeral bool,
+				_ int, _ string, ephemeral bool, reservedIP net.IP,
 			) (*server.SetupKey, error) {
 				if keyName == newKey.Name || typ != newKey.Type {
 					nk := newKey.Copy()
 					nk.Ephemeral = ephemeral
+					nk.ReservedIP = reservedIP
 					return nk, nil
 				}
 				return nil, fmt.Errorf("failed creating setup key")
@@ -102,6 +104,8 @@ func TestSetupKeysHandlers(t *testing.T) {
 
 	newSetupKey := server.GenerateSetupKey(newSetupKeyName, server.SetupKeyReusable, 0, []string{"group-1"},
 		server.SetupKeyUnlimitedUsage, true)
+	reservedSetupKey := newSetupKey.Copy()
+	reservedSetupKey.ReservedIP = net.ParseIP("100.64.0.20")
 	updatedDefaultSetupKey := defaultSetupKey.Copy()
 	updatedDefaultSetupKey.AutoGroups = []string{"group-1"}
 	updatedDefaultSetupKey.Name = updatedSetupKeyName
@@ -150,6 +154,27 @@ func TestSetupKeysHandlers(t *testing.T) {
 			expectedBody:     true,
 			expectedSetupKey: toResponseBody(newSetupKey),
 		},
+		{
+			name:        "Create Setup Key With Reserved IP",
+			requestType: http.MethodPost,
+			requestPath: "/api/setup-keys",
+			requestBody: bytes.NewBuffer(
+				[]byte(fmt.Sprintf("{\"name\":\"%s\",\"type\":\"%s\",\"expires_in\":86400, \"ephemeral\":true, \"reserved_ip\":\"100.64.0.20\"}",
+					newSetupKey.Name, newSetupKey.Type))),
+			expectedStatus:   http.StatusOK,
+			expectedBody:     true,
+			expectedSetupKey: toResponseBody(reservedSetupKey),
+		},
+		{
+			name:        "Create Setup Key With Invalid Reserved IP",
+			requestType: http.MethodPost,
+			requestPath: "/api/setup-keys",
+			requestBody: bytes.NewBuffer(
+				[]byte(fmt.Sprintf("{\"name\":\"%s\",\"type\":\"%s\",\"expires_in\":86400, \"reserved_ip\":\"100.64.0\"}",
+					newSetupKey.Name, newSetupKey.Type))),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   false,
+		},
 		{
 			name:        "Update Setup Key",
 			requestType: http.MethodPut,
@@ -232,4 +257,5 @@ func assertKeys(t *testing.T, got *api.SetupKey, expected *api.SetupKey) {
 	assert.Equal(t, got.Revoked, expected.Revoked)
 	assert.ElementsMatch(t, got.AutoGroups, expected.AutoGroups)
 	assert.Equal(t, got.Ephemeral, expected.Ephemeral)
+	assert.Equal(t, got.ReservedIp, expected.ReservedIp)
 }
@@ -0,0 +1,245 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/management/server"
+	"github.com/netbirdio/netbird/management/server/http/api"
+	"github.com/netbirdio/netbird/management/server/http/util"
+	"github.com/netbirdio/netbird/management/server/jwtclaims"
+	"github.com/netbirdio/netbird/management/server/status"
+	"github.com/netbirdio/netbird/management/server/webhook"
+)
+
+// WebhooksHandler is a handler that returns webhooks of the account
+type WebhooksHandler struct {
+	accountManager  server.AccountManager
+	claimsExtractor *jwtclaims.ClaimsExtractor
+}
+
+// NewWebhooksHandler creates a new Webhooks handler
+func NewWebhooksHandler(accountManager server.AccountManager, authCfg AuthCfg) *WebhooksHandler {
+	return &WebhooksHandler{
+		accountManager: accountManager,
+		claimsExtractor: jwtclaims.NewClaimsExtractor(
+			jwtclaims.WithAudience(authCfg.Audience),
+			jwtclaims.WithUserIDClaim(authCfg.UserIDClaim),
+		),
+	}
+}
+
+// GetAllWebhooks list for the account
+func (h *WebhooksHandler) GetAllWebhooks(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	accountWebhooks, err := h.accountManager.ListWebhooks(account.Id, user.Id)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	webhooks := []*api.Webhook{}
+	for _, hook := range accountWebhooks {
+		webhooks = append(webhooks, toWebhookResponse(hook))
+	}
+
+	util.WriteJSONObject(w, webhooks)
+}
+
+// UpdateWebhook handles update to a webhook identified by a given ID
+func (h *WebhooksHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	webhookID := mux.Vars(r)["webhookId"]
+	if len(webhookID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid webhook ID"), w)
+		return
+	}
+
+	found := false
+	for _, hook := range account.Webhooks {
+		if hook.ID == webhookID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		util.WriteError(status.Errorf(status.NotFound, "couldn't find webhook id %s", webhookID), w)
+		return
+	}
+
+	h.saveWebhook(w, r, account, user, webhookID)
+}
+
+// CreateWebhook handles webhook creation request
+func (h *WebhooksHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	h.saveWebhook(w, r, account, user, "")
+}
+
+// GetWebhook handles a webhook Get request identified by ID
+func (h *WebhooksHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	webhookID := mux.Vars(r)["webhookId"]
+	if len(webhookID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid webhook ID"), w)
+		return
+	}
+
+	hook, err := h.accountManager.GetWebhook(account.Id, webhookID, user.Id)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, toWebhookResponse(hook))
+}
+
+// DeleteWebhook handles webhook deletion request
+func (h *WebhooksHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	webhookID := mux.Vars(r)["webhookId"]
+	if len(webhookID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid webhook ID"), w)
+		return
+	}
+
+	if err = h.accountManager.DeleteWebhook(account.Id, webhookID, user.Id); err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, emptyObject{})
+}
+
+// GetWebhookDeadLetters handles the request of the deliveries to a webhook that failed all the attempts
+func (h *WebhooksHandler) GetWebhookDeadLetters(w http.ResponseWriter, r *http.Request) {
+	claims := h.claimsExtractor.FromRequestContext(r)
+	account, user, err := h.accountManager.GetAccountFromToken(claims)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	webhookID := mux.Vars(r)["webhookId"]
+	if len(webhookID) == 0 {
+		util.WriteError(status.Errorf(status.InvalidArgument, "invalid webhook ID"), w)
+		return
+	}
+
+	webhookDeadLetters, err := h.accountManager.ListWebhookDeadLetters(account.Id, webhookID, user.Id)
+	if err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	deadLetters := []*api.WebhookDeadLetter{}
+	for _, deadLetter := range webhookDeadLetters {
+		deadLetters = append(deadLetters, toWebhookDeadLetterResponse(deadLetter))
+	}
+
+	util.WriteJSONObject(w, deadLetters)
+}
+
+// saveWebhook handles webhook creation and update
+func (h *WebhooksHandler) saveWebhook(
+	w http.ResponseWriter,
+	r *http.Request,
+	account *server.Account,
+	user *server.User,
+	webhookID string,
+) {
+	var req api.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.WriteErrorResponse("couldn't parse JSON request", http.StatusBadRequest, w)
+		return
+	}
+
+	if webhookID == "" {
+		if req.Secret == nil || *req.Secret == "" {
+			util.WriteError(status.Errorf(status.InvalidArgument, "webhook secret is required"), w)
+			return
+		}
+		webhookID = xid.New().String()
+	}
+
+	hook := &server.Webhook{
+		ID:      webhookID,
+		Name:    req.Name,
+		URL:     req.Url,
+		Events:  req.Events,
+		Enabled: req.Enabled,
+	}
+	if req.Secret != nil {
+		hook.Secret = *req.Secret
+	}
+
+	if err := h.accountManager.SaveWebhook(account.Id, user.Id, hook); err != nil {
+		util.WriteError(err, w)
+		return
+	}
+
+	util.WriteJSONObject(w, toWebhookResponse(hook))
+}
+
+func toWebhookResponse(hook *server.Webhook) *api.Webhook {
+	events := make([]string, len(hook.Events))
+	copy(events, hook.Events)
+
+	return &api.Webhook{
+		Id:      hook.ID,
+		Name:    hook.Name,
+		Url:     hook.URL,
+		Events:  events,
+		Enabled: hook.Enabled,
+	}
+}
+
+func toWebhookDeadLetterResponse(deadLetter *webhook.DeadLetter) *api.WebhookDeadLetter {
+	payload := make(map[string]interface{})
+	if err := json.Unmarshal(deadLetter.Payload, &payload); err != nil {
+		log.Errorf("failed to decode the payload of dead letter %s: %v", deadLetter.ID, err)
+	}
+
+	return &api.WebhookDeadLetter{
+		Id:        deadLetter.ID,
+		EventType: deadLetter.EventType,
+		Payload:   payload,
+		Attempts:  deadLetter.Attempts,
+		LastError: deadLetter.LastError,
+		CreatedAt: deadLetter.CreatedAt,
+	}
+}
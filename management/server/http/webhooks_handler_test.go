@@ -0,0 +1,315 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/management/server"
+	"github.com/netbirdio/netbird/management/server/http/api"
+	"github.com/netbirdio/netbird/management/server/jwtclaims"
+	"github.com/netbirdio/netbird/management/server/mock_server"
+	"github.com/netbirdio/netbird/management/server/status"
+	"github.com/netbirdio/netbird/management/server/webhook"
+)
+
+func initWebhooksTestData(webhooks ...*server.Webhook) (*WebhooksHandler, map[string]*server.Webhook) {
+	testWebhooks := make(map[string]*server.Webhook, len(webhooks))
+	for _, hook := range webhooks {
+		testWebhooks[hook.ID] = hook
+	}
+
+	return &WebhooksHandler{
+		accountManager: &mock_server.MockAccountManager{
+			GetWebhookFunc: func(_, webhookID, _ string) (*server.Webhook, error) {
+				hook, ok := testWebhooks[webhookID]
+				if !ok {
+					return nil, status.Errorf(status.NotFound, "webhook not found")
+				}
+				return hook, nil
+			},
+			SaveWebhookFunc: func(_, _ string, hook *server.Webhook) error {
+				if existing, ok := testWebhooks[hook.ID]; ok && hook.Secret == "" {
+					hook.Secret = existing.Secret
+				}
+				if err := hook.Validate(); err != nil {
+					return err
+				}
+				if !strings.HasPrefix(hook.ID, "id-") {
+					hook.ID = "id-was-set"
+				}
+				testWebhooks[hook.ID] = hook
+				return nil
+			},
+			DeleteWebhookFunc: func(_, webhookID, _ string) error {
+				if _, ok := testWebhooks[webhookID]; !ok {
+					return status.Errorf(status.NotFound, "webhook not found")
+				}
+				delete(testWebhooks, webhookID)
+				return nil
+			},
+			ListWebhooksFunc: func(_, _ string) ([]*server.Webhook, error) {
+				accountWebhooks := make([]*server.Webhook, 0, len(testWebhooks))
+				for _, hook := range testWebhooks {
+					accountWebhooks = append(accountWebhooks, hook)
+				}
+				return accountWebhooks, nil
+			},
+			ListWebhookDeadLettersFunc: func(_, webhookID, _ string) ([]*webhook.DeadLetter, error) {
+				if _, ok := testWebhooks[webhookID]; !ok {
+					return nil, status.Errorf(status.NotFound, "webhook not found")
+				}
+				return []*webhook.DeadLetter{
+					{
+						ID:        "delivery",
+						WebhookID: webhookID,
+						EventType: "user.peer.add",
+						Payload:   []byte(`{"activity_code":"user.peer.add"}`),
+						Attempts:  5,
+						LastError: "webhook endpoint responded with status 500",
+						CreatedAt: time.Date(2023, 5, 5, 10, 4, 37, 0, time.UTC),
+					},
+				}, nil
+			},
+			GetAccountFromTokenFunc: func(claims jwtclaims.AuthorizationClaims) (*server.Account, *server.User, error) {
+				user := server.NewAdminUser("test_user")
+				return &server.Account{
+					Id:     claims.AccountId,
+					Domain: "hotmail.com",
+					Webhooks: []*server.Webhook{
+						{ID: "id-existed"},
+					},
+					Users: map[string]*server.User{
+						"test_user": user,
+					},
+				}, user, nil
+			},
+		},
+		claimsExtractor: jwtclaims.NewClaimsExtractor(
+			jwtclaims.WithFromRequestContext(func(r *http.Request) jwtclaims.AuthorizationClaims {
+				return jwtclaims.AuthorizationClaims{
+					UserId:    "test_user",
+					Domain:    "hotmail.com",
+					AccountId: "test_id",
+				}
+			}),
+		),
+	}, testWebhooks
+}
+
+func TestGetWebhook(t *testing.T) {
+	h, _ := initWebhooksTestData(&server.Webhook{
+		ID:      "webhook",
+		Name:    "chat-ops",
+		URL:     "https://hooks.example.com/netbird",
+		Secret:  "0123456789abcdef",
+		Events:  []string{"user.peer.add"},
+		Enabled: true,
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/webhooks/{webhookId}", h.GetWebhook).Methods("GET")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/webhooks/webhook", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	content, err := io.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "0123456789abcdef", "the secret should not be returned")
+
+	var got api.Webhook
+	require.NoError(t, json.Unmarshal(content, &got))
+	assert.Equal(t, api.Webhook{
+		Id:      "webhook",
+		Name:    "chat-ops",
+		Url:     "https://hooks.example.com/netbird",
+		Events:  []string{"user.peer.add"},
+		Enabled: true,
+	}, got)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/webhooks/not-exists", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	tt := []struct {
+		name            string
+		requestType     string
+		requestPath     string
+		requestBody     io.Reader
+		expectedStatus  int
+		expectedWebhook *api.Webhook
+		expectedSecret  string
+	}{
+		{
+			name:        "Create Webhook",
+			requestType: http.MethodPost,
+			requestPath: "/api/webhooks",
+			requestBody: bytes.NewBufferString(`{
+				"name": "chat-ops",
+				"url": "https://hooks.example.com/netbird",
+				"secret": "0123456789abcdef",
+				"events": ["user.peer.add", "policy.update"],
+				"enabled": true
+			}`),
+			expectedStatus: http.StatusOK,
+			expectedWebhook: &api.Webhook{
+				Id:      "id-was-set",
+				Name:    "chat-ops",
+				Url:     "https://hooks.example.com/netbird",
+				Events:  []string{"user.peer.add", "policy.update"},
+				Enabled: true,
+			},
+			expectedSecret: "0123456789abcdef",
+		},
+		{
+			name:        "Create Webhook without secret",
+			requestType: http.MethodPost,
+			requestPath: "/api/webhooks",
+			requestBody: bytes.NewBufferString(`{
+				"name": "chat-ops",
+				"url": "https://hooks.example.com/netbird",
+				"events": [],
+				"enabled": true
+			}`),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Create Webhook unknown event",
+			requestType: http.MethodPost,
+			requestPath: "/api/webhooks",
+			requestBody: bytes.NewBufferString(`{
+				"name": "chat-ops",
+				"url": "https://hooks.example.com/netbird",
+				"secret": "0123456789abcdef",
+				"events": ["peer.teleport"],
+				"enabled": true
+			}`),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Update Webhook keeping the secret",
+			requestType: http.MethodPut,
+			requestPath: "/api/webhooks/id-existed",
+			requestBody: bytes.NewBufferString(`{
+				"name": "tickets",
+				"url": "https://tickets.example.com/netbird",
+				"events": [],
+				"enabled": false
+			}`),
+			expectedStatus: http.StatusOK,
+			expectedWebhook: &api.Webhook{
+				Id:     "id-existed",
+				Name:   "tickets",
+				Url:    "https://tickets.example.com/netbird",
+				Events: []string{},
+			},
+			expectedSecret: "existing-secret-value",
+		},
+		{
+			name:        "Update Webhook not found",
+			requestType: http.MethodPut,
+			requestPath: "/api/webhooks/id-not-existed",
+			requestBody: bytes.NewBufferString(`{
+				"name": "tickets",
+				"url": "https://tickets.example.com/netbird",
+				"events": [],
+				"enabled": false
+			}`),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			h, testWebhooks := initWebhooksTestData(&server.Webhook{
+				ID:     "id-existed",
+				Name:   "existing",
+				URL:    "https://hooks.example.com/existing",
+				Secret: "existing-secret-value",
+			})
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.requestType, tc.requestPath, tc.requestBody)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/api/webhooks", h.CreateWebhook).Methods("POST")
+			router.HandleFunc("/api/webhooks/{webhookId}", h.UpdateWebhook).Methods("PUT")
+			router.ServeHTTP(recorder, req)
+
+			res := recorder.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+			if tc.expectedWebhook == nil {
+				return
+			}
+
+			content, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatalf("I don't know what I expected; %v", err)
+			}
+
+			var got api.Webhook
+			if err = json.Unmarshal(content, &got); err != nil {
+				t.Fatalf("Sent content is not in correct json format; %v", err)
+			}
+
+			assert.Equal(t, *tc.expectedWebhook, got)
+			assert.Equal(t, tc.expectedSecret, testWebhooks[got.Id].Secret)
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	h, _ := initWebhooksTestData(&server.Webhook{ID: "webhook"})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/webhooks/{webhookId}", h.DeleteWebhook).Methods("DELETE")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/api/webhooks/webhook", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/api/webhooks/webhook", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestGetWebhookDeadLetters(t *testing.T) {
+	h, _ := initWebhooksTestData(&server.Webhook{ID: "webhook"})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/webhooks/{webhookId}/dead-letters", h.GetWebhookDeadLetters).Methods("GET")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/webhooks/webhook/dead-letters", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []api.WebhookDeadLetter
+	require.NoError(t, json.NewDecoder(recorder.Result().Body).Decode(&got))
+	require.Len(t, got, 1)
+	assert.Equal(t, api.WebhookDeadLetter{
+		Id:        "delivery",
+		EventType: "user.peer.add",
+		Payload:   map[string]interface{}{"activity_code": "user.peer.add"},
+		Attempts:  5,
+		LastError: "webhook endpoint responded with status 500",
+		CreatedAt: time.Date(2023, 5, 5, 10, 4, 37, 0, time.UTC),
+	}, got[0])
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/webhooks/not-exists/dead-letters", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -13,6 +13,8 @@ type AuthorizationClaims struct {
 	Domain         string
 	DomainCategory string
 	LastLogin      time.Time
+	// PATID is the ID of the personal access token the request was authenticated with, empty for JWTs
+	PATID string
 
 	Raw jwt.MapClaims
 }
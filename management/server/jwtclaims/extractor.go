@@ -20,6 +20,8 @@ const (
 	UserIDClaim = "sub"
 	// LastLoginSuffix claim for the last login
 	LastLoginSuffix = "nb_last_login"
+	// PATIDSuffix claim for the ID of the personal access token the request was authenticated with
+	PATIDSuffix = "nb_pat_id"
 )
 
 // ExtractClaims Extract function type
@@ -100,6 +102,10 @@ func (c *ClaimsExtractor) FromToken(token *jwt.Token) AuthorizationClaims {
 	if ok {
 		jwtClaims.LastLogin = parseTime(LastLoginClaimString.(string))
 	}
+	patIDClaim, ok := claims[c.authAudience+PATIDSuffix]
+	if ok {
+		jwtClaims.PATID = patIDClaim.(string)
+	}
 	return jwtClaims
 }
 
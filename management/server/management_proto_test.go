@@ -269,6 +269,127 @@ func Test_SyncProtocol(t *testing.T) {
 	}
 }
 
+func Test_SyncProtocolNetworkMapDelta(t *testing.T) {
+	dir := t.TempDir()
+	err := util.CopyFileContents("testdata/store_with_expired_peers.json", filepath.Join(dir, "store.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mgmtServer, mgmtAddr, err := startManagement(t, &Config{
+		Stuns:      []*Host{{Proto: "udp", URI: "stun:stun.wiretrustee.com:3468"}},
+		TURNConfig: &TURNConfig{Secret: "whatever", Turns: []*Host{{Proto: "udp", URI: "turn:stun.wiretrustee.com:3468"}}},
+		Signal:     &Host{Proto: "http", URI: "signal.wiretrustee.com:10000"},
+		Datadir:    dir,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mgmtServer.GracefulStop()
+
+	client, clientConn, err := createRawClient(mgmtAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clientConn.Close()
+
+	peers, err := registerPeers(1, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverKey, err := getServerKey(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := *peers[0]
+	message, err := encryption.EncryptMessage(*serverKey, key, &mgmtProto.SyncRequest{NetworkMapDeltaSupported: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sync, err := client.Sync(ctx, &mgmtProto.EncryptedMessage{
+		WgPubKey: key.PublicKey().String(),
+		Body:     message,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receive := func() *mgmtProto.SyncResponse {
+		resp := &mgmtProto.EncryptedMessage{}
+		if err := sync.RecvMsg(resp); err != nil {
+			t.Fatal(err)
+		}
+		syncResp := &mgmtProto.SyncResponse{}
+		if err := encryption.DecryptMessage(*serverKey, key, resp.Body, syncResp); err != nil {
+			t.Fatal(err)
+		}
+		return syncResp
+	}
+
+	initial := receive()
+	if initial.GetNetworkMap() == nil || initial.GetNetworkMapDelta() != nil {
+		t.Fatal("expecting the first SyncResponse to have a full NetworkMap")
+	}
+	if initial.GetSequence() != 1 {
+		t.Fatalf("expecting the first SyncResponse to have sequence 1, got %d", initial.GetSequence())
+	}
+
+	newPeers, err := registerPeers(1, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a new peer changes all the network maps of the account
+	update := receive()
+	if update.GetNetworkMap() == nil || update.GetSequence() != 2 {
+		t.Fatalf("expecting the update to have a full NetworkMap with sequence 2, got sequence %d", update.GetSequence())
+	}
+
+	// a new SSH key changes the network maps of the peers connected to the peer only
+	newPeerKey := *newPeers[0]
+	loginMessage, err := encryption.EncryptMessage(*serverKey, newPeerKey, &mgmtProto.LoginRequest{
+		SetupKey: TestValidSetupKey,
+		Meta:     &mgmtProto.PeerSystemMeta{Hostname: newPeerKey.PublicKey().String(), GoOS: runtime.GOOS, OS: runtime.GOOS},
+		PeerKeys: &mgmtProto.PeerKeys{SshPubKey: []byte("new-ssh-key")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Login(context.TODO(), &mgmtProto.EncryptedMessage{
+		WgPubKey: newPeerKey.PublicKey().String(),
+		Body:     loginMessage,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update = receive()
+	if update.GetNetworkMap() != nil {
+		t.Fatal("expecting the update to have no full NetworkMap")
+	}
+	delta := update.GetNetworkMapDelta()
+	if delta == nil {
+		t.Fatal("expecting the update to have a NetworkMapDelta")
+	}
+	if delta.GetBaseSequence() != 2 || update.GetSequence() != 3 {
+		t.Fatalf("expecting the delta with sequence 3 to apply to sequence 2, got %d and %d",
+			update.GetSequence(), delta.GetBaseSequence())
+	}
+	if len(delta.GetUpsertedPeers()) != 1 || delta.GetUpsertedPeers()[0].GetWgPubKey() != newPeerKey.PublicKey().String() {
+		t.Fatalf("expecting the delta to update the peer with the new SSH key, got %v", delta.GetUpsertedPeers())
+	}
+	if string(delta.GetUpsertedPeers()[0].GetSshConfig().GetSshPubKey()) != "new-ssh-key" {
+		t.Fatalf("expecting the delta to have the new SSH key, got %v", delta.GetUpsertedPeers()[0].GetSshConfig())
+	}
+	if len(delta.GetRemovedPeers()) != 0 {
+		t.Fatalf("expecting the delta to remove no peers, got %v", delta.GetRemovedPeers())
+	}
+}
+
 func loginPeerWithValidSetupKey(key wgtypes.Key, client mgmtProto.ManagementServiceClient) (*mgmtProto.LoginResponse, error) {
 	serverKey, err := getServerKey(client)
 	if err != nil {
@@ -409,10 +530,10 @@ func startManagement(t *testing.T, config *Config) (*grpc.Server, string, error)
 	if err != nil {
 		return nil, "", err
 	}
-	peersUpdateManager := NewPeersUpdateManager()
+	peersUpdateManager := NewPeersUpdateManager(nil)
 	eventStore := &activity.InMemoryEventStore{}
 	accountManager, err := BuildManager(store, peersUpdateManager, nil, "", "",
-		eventStore, false)
+		eventStore, false, 0, nil)
 	if err != nil {
 		return nil, "", err
 	}
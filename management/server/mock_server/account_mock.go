@@ -1,6 +1,8 @@
 package mock_server
 
 import (
+	"net"
+	"net/netip"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -10,13 +12,14 @@ import (
 	"github.com/netbirdio/netbird/management/server"
 	"github.com/netbirdio/netbird/management/server/activity"
 	"github.com/netbirdio/netbird/management/server/jwtclaims"
+	"github.com/netbirdio/netbird/management/server/webhook"
 	"github.com/netbirdio/netbird/route"
 )
 
 type MockAccountManager struct {
 	GetOrCreateAccountByUserFunc func(userId, domain string) (*server.Account, error)
 	CreateSetupKeyFunc           func(accountId string, keyName string, keyType server.SetupKeyType,
-		expiresIn time.Duration, autoGroups []string, usageLimit int, userID string, ephemeral bool) (*server.SetupKey, error)
+		expiresIn time.Duration, autoGroups []string, usageLimit int, userID string, ephemeral bool, reservedIP net.IP) (*server.SetupKey, error)
 	GetSetupKeyFunc                 func(accountID, userID, keyID string) (*server.SetupKey, error)
 	GetAccountByUserOrAccountIdFunc func(userId, accountId, domain string) (*server.Account, error)
 	GetUserFunc                     func(claims jwtclaims.AuthorizationClaims) (*server.User, error)
@@ -40,12 +43,27 @@ type MockAccountManager struct {
 	SavePolicyFunc                  func(accountID, userID string, policy *server.Policy) error
 	DeletePolicyFunc                func(accountID, policyID, userID string) error
 	ListPoliciesFunc                func(accountID, userID string) ([]*server.Policy, error)
+	GetPostureChecksFunc            func(accountID, postureChecksID, userID string) (*server.PostureChecks, error)
+	SavePostureChecksFunc           func(accountID, userID string, postureChecks *server.PostureChecks) error
+	DeletePostureChecksFunc         func(accountID, postureChecksID, userID string) error
+	ListPostureChecksFunc           func(accountID, userID string) ([]*server.PostureChecks, error)
+	GetWebhookFunc                  func(accountID, webhookID, userID string) (*server.Webhook, error)
+	SaveWebhookFunc                 func(accountID, userID string, hook *server.Webhook) error
+	DeleteWebhookFunc               func(accountID, webhookID, userID string) error
+	ListWebhooksFunc                func(accountID, userID string) ([]*server.Webhook, error)
+	ListWebhookDeadLettersFunc      func(accountID, webhookID, userID string) ([]*webhook.DeadLetter, error)
+	GetDNSZoneFunc                  func(accountID, zoneID, userID string) (*server.DNSZone, error)
+	SaveDNSZoneFunc                 func(accountID, userID string, zone *server.DNSZone) error
+	DeleteDNSZoneFunc               func(accountID, zoneID, userID string) error
+	ListDNSZonesFunc                func(accountID, userID string) ([]*server.DNSZone, error)
 	GetUsersFromAccountFunc         func(accountID, userID string) ([]*server.UserInfo, error)
 	GetAccountFromPATFunc           func(pat string) (*server.Account, *server.User, *server.PersonalAccessToken, error)
 	MarkPATUsedFunc                 func(pat string) error
 	UpdatePeerMetaFunc              func(peerID string, meta server.PeerSystemMeta) error
 	UpdatePeerSSHKeyFunc            func(peerID string, sshKey string) error
 	UpdatePeerFunc                  func(accountID, userID string, peer *server.Peer) (*server.Peer, error)
+	ApprovePeerFunc                 func(accountID, userID, peerID string) (*server.Peer, error)
+	RejectPeerFunc                  func(accountID, userID, peerID string) error
 	CreateRouteFunc                 func(accountID, prefix, peer string, peerGroups []string, description, netID string, masquerade bool, metric int, groups []string, enabled bool, userID string) (*route.Route, error)
 	GetRouteFunc                    func(accountID, routeID, userID string) (*route.Route, error)
 	SaveRouteFunc                   func(accountID, userID string, route *route.Route) error
@@ -55,23 +73,24 @@ type MockAccountManager struct {
 	ListSetupKeysFunc               func(accountID, userID string) ([]*server.SetupKey, error)
 	SaveUserFunc                    func(accountID, userID string, user *server.User) (*server.UserInfo, error)
 	DeleteUserFunc                  func(accountID string, initiatorUserID string, targetUserID string) error
-	CreatePATFunc                   func(accountID string, initiatorUserID string, targetUserId string, tokenName string, expiresIn int) (*server.PersonalAccessTokenGenerated, error)
+	CreatePATFunc                   func(accountID string, initiatorUserID string, targetUserId string, tokenName string, expiresIn int, scopes []string) (*server.PersonalAccessTokenGenerated, error)
 	DeletePATFunc                   func(accountID string, initiatorUserID string, targetUserId string, tokenID string) error
 	GetPATFunc                      func(accountID string, initiatorUserID string, targetUserId string, tokenID string) (*server.PersonalAccessToken, error)
 	GetAllPATsFunc                  func(accountID string, initiatorUserID string, targetUserId string) ([]*server.PersonalAccessToken, error)
 	GetNameServerGroupFunc          func(accountID, nsGroupID string) (*nbdns.NameServerGroup, error)
-	CreateNameServerGroupFunc       func(accountID string, name, description string, nameServerList []nbdns.NameServer, groups []string, primary bool, domains []string, enabled bool, userID string) (*nbdns.NameServerGroup, error)
+	CreateNameServerGroupFunc       func(accountID string, name, description string, nameServerList []nbdns.NameServer, groups []string, primary bool, domains []string, enabled bool, userID string, searchDomainsEnabled bool) (*nbdns.NameServerGroup, error)
 	SaveNameServerGroupFunc         func(accountID, userID string, nsGroupToSave *nbdns.NameServerGroup) error
 	DeleteNameServerGroupFunc       func(accountID, nsGroupID, userID string) error
 	ListNameServerGroupsFunc        func(accountID string) ([]*nbdns.NameServerGroup, error)
 	CreateUserFunc                  func(accountID, userID string, key *server.UserInfo) (*server.UserInfo, error)
 	GetAccountFromTokenFunc         func(claims jwtclaims.AuthorizationClaims) (*server.Account, *server.User, error)
 	GetDNSDomainFunc                func() string
-	GetEventsFunc                   func(accountID, userID string) ([]*activity.Event, error)
+	GetEventsFunc                   func(accountID, userID string, filter activity.Filter) ([]*activity.Event, string, error)
 	GetDNSSettingsFunc              func(accountID, userID string) (*server.DNSSettings, error)
 	SaveDNSSettingsFunc             func(accountID, userID string, dnsSettingsToSave *server.DNSSettings) error
 	GetPeerFunc                     func(accountID, peerID, userID string) (*server.Peer, error)
 	UpdateAccountSettingsFunc       func(accountID, userID string, newSettings *server.Settings) (*server.Account, error)
+	UpdateAccountNetworkRangeFunc   func(accountID, userID string, networkRange netip.Prefix) (*server.Account, error)
 	LoginPeerFunc                   func(login server.PeerLogin) (*server.Peer, *server.NetworkMap, error)
 	SyncPeerFunc                    func(sync server.PeerSync) (*server.Peer, *server.NetworkMap, error)
 	InviteUserFunc                  func(accountID string, initiatorUserID string, targetUserEmail string) error
@@ -116,9 +135,10 @@ func (am *MockAccountManager) CreateSetupKey(
 	usageLimit int,
 	userID string,
 	ephemeral bool,
+	reservedIP net.IP,
 ) (*server.SetupKey, error) {
 	if am.CreateSetupKeyFunc != nil {
-		return am.CreateSetupKeyFunc(accountID, keyName, keyType, expiresIn, autoGroups, usageLimit, userID, ephemeral)
+		return am.CreateSetupKeyFunc(accountID, keyName, keyType, expiresIn, autoGroups, usageLimit, userID, ephemeral, reservedIP)
 	}
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSetupKey is not implemented")
 }
@@ -161,9 +181,9 @@ func (am *MockAccountManager) MarkPATUsed(pat string) error {
 }
 
 // CreatePAT mock implementation of GetPAT from server.AccountManager interface
-func (am *MockAccountManager) CreatePAT(accountID string, initiatorUserID string, targetUserID string, name string, expiresIn int) (*server.PersonalAccessTokenGenerated, error) {
+func (am *MockAccountManager) CreatePAT(accountID string, initiatorUserID string, targetUserID string, name string, expiresIn int, scopes []string) (*server.PersonalAccessTokenGenerated, error) {
 	if am.CreatePATFunc != nil {
-		return am.CreatePATFunc(accountID, initiatorUserID, targetUserID, name, expiresIn)
+		return am.CreatePATFunc(accountID, initiatorUserID, targetUserID, name, expiresIn, scopes)
 	}
 	return nil, status.Errorf(codes.Unimplemented, "method CreatePAT is not implemented")
 }
@@ -332,6 +352,110 @@ func (am *MockAccountManager) ListPolicies(accountID, userID string) ([]*server.
 	return nil, status.Errorf(codes.Unimplemented, "method ListPolicies is not implemented")
 }
 
+// GetPostureChecks mock implementation of GetPostureChecks from server.AccountManager interface
+func (am *MockAccountManager) GetPostureChecks(accountID, postureChecksID, userID string) (*server.PostureChecks, error) {
+	if am.GetPostureChecksFunc != nil {
+		return am.GetPostureChecksFunc(accountID, postureChecksID, userID)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostureChecks is not implemented")
+}
+
+// SavePostureChecks mock implementation of SavePostureChecks from server.AccountManager interface
+func (am *MockAccountManager) SavePostureChecks(accountID, userID string, postureChecks *server.PostureChecks) error {
+	if am.SavePostureChecksFunc != nil {
+		return am.SavePostureChecksFunc(accountID, userID, postureChecks)
+	}
+	return status.Errorf(codes.Unimplemented, "method SavePostureChecks is not implemented")
+}
+
+// DeletePostureChecks mock implementation of DeletePostureChecks from server.AccountManager interface
+func (am *MockAccountManager) DeletePostureChecks(accountID, postureChecksID, userID string) error {
+	if am.DeletePostureChecksFunc != nil {
+		return am.DeletePostureChecksFunc(accountID, postureChecksID, userID)
+	}
+	return status.Errorf(codes.Unimplemented, "method DeletePostureChecks is not implemented")
+}
+
+// ListPostureChecks mock implementation of ListPostureChecks from server.AccountManager interface
+func (am *MockAccountManager) ListPostureChecks(accountID, userID string) ([]*server.PostureChecks, error) {
+	if am.ListPostureChecksFunc != nil {
+		return am.ListPostureChecksFunc(accountID, userID)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "method ListPostureChecks is not implemented")
+}
+
+// GetWebhook mock implementation of GetWebhook from server.AccountManager interface
+func (am *MockAccountManager) GetWebhook(accountID, webhookID, userID string) (*server.Webhook, error) {
+	if am.GetWebhookFunc != nil {
+		return am.GetWebhookFunc(accountID, webhookID, userID)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhook is not implemented")
+}
+
+// SaveWebhook mock implementation of SaveWebhook from server.AccountManager interface
+func (am *MockAccountManager) SaveWebhook(accountID, userID string, hook *server.Webhook) error {
+	if am.SaveWebhookFunc != nil {
+		return am.SaveWebhookFunc(accountID, userID, hook)
+	}
+	return status.Errorf(codes.Unimplemented, "method SaveWebhook is not implemented")
+}
+
+// DeleteWebhook mock implementation of DeleteWebhook from server.AccountManager interface
+func (am *MockAccountManager) DeleteWebhook(accountID, webhookID, userID string) error {
+	if am.DeleteWebhookFunc != nil {
+		return am.DeleteWebhookFunc(accountID, webhookID, userID)
+	}
+	return status.Errorf(codes.Unimplemented, "method DeleteWebhook is not implemented")
+}
+
+// ListWebhooks mock implementation of ListWebhooks from server.AccountManager interface
+func (am *MockAccountManager) ListWebhooks(accountID, userID string) ([]*server.Webhook, error) {
+	if am.ListWebhooksFunc != nil {
+		return am.ListWebhooksFunc(accountID, userID)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks is not implemented")
+}
+
+// ListWebhookDeadLetters mock implementation of ListWebhookDeadLetters from server.AccountManager interface
+func (am *MockAccountManager) ListWebhookDeadLetters(accountID, webhookID, userID string) ([]*webhook.DeadLetter, error) {
+	if am.ListWebhookDeadLettersFunc != nil {
+		return am.ListWebhookDeadLettersFunc(accountID, webhookID, userID)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeadLetters is not implemented")
+}
+
+// GetDNSZone mock implementation of GetDNSZone from server.AccountManager interface
+func (am *MockAccountManager) GetDNSZone(accountID, zoneID, userID string) (*server.DNSZone, error) {
+	if am.GetDNSZoneFunc != nil {
+		return am.GetDNSZoneFunc(accountID, zoneID, userID)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "method GetDNSZone is not implemented")
+}
+
+// SaveDNSZone mock implementation of SaveDNSZone from server.AccountManager interface
+func (am *MockAccountManager) SaveDNSZone(accountID, userID string, zone *server.DNSZone) error {
+	if am.SaveDNSZoneFunc != nil {
+		return am.SaveDNSZoneFunc(accountID, userID, zone)
+	}
+	return status.Errorf(codes.Unimplemented, "method SaveDNSZone is not implemented")
+}
+
+// DeleteDNSZone mock implementation of DeleteDNSZone from server.AccountManager interface
+func (am *MockAccountManager) DeleteDNSZone(accountID, zoneID, userID string) error {
+	if am.DeleteDNSZoneFunc != nil {
+		return am.DeleteDNSZoneFunc(accountID, zoneID, userID)
+	}
+	return status.Errorf(codes.Unimplemented, "method DeleteDNSZone is not implemented")
+}
+
+// ListDNSZones mock implementation of ListDNSZones from server.AccountManager interface
+func (am *MockAccountManager) ListDNSZones(accountID, userID string) ([]*server.DNSZone, error) {
+	if am.ListDNSZonesFunc != nil {
+		return am.ListDNSZonesFunc(accountID, userID)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "method ListDNSZones is not implemented")
+}
+
 // UpdatePeerMeta mock implementation of UpdatePeerMeta from server.AccountManager interface
 func (am *MockAccountManager) UpdatePeerMeta(peerID string, meta server.PeerSystemMeta) error {
 	if am.UpdatePeerMetaFunc != nil {
@@ -364,6 +488,22 @@ func (am *MockAccountManager) UpdatePeer(accountID, userID string, peer *server.
 	return nil, status.Errorf(codes.Unimplemented, "method UpdatePeerFunc is is not implemented")
 }
 
+// ApprovePeer mocks ApprovePeerFunc function of the account manager
+func (am *MockAccountManager) ApprovePeer(accountID, userID, peerID string) (*server.Peer, error) {
+	if am.ApprovePeerFunc != nil {
+		return am.ApprovePeerFunc(accountID, userID, peerID)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "method ApprovePeer is not implemented")
+}
+
+// RejectPeer mocks RejectPeerFunc function of the account manager
+func (am *MockAccountManager) RejectPeer(accountID, userID, peerID string) error {
+	if am.RejectPeerFunc != nil {
+		return am.RejectPeerFunc(accountID, userID, peerID)
+	}
+	return status.Errorf(codes.Unimplemented, "method RejectPeer is not implemented")
+}
+
 // CreateRoute mock implementation of CreateRoute from server.AccountManager interface
 func (am *MockAccountManager) CreateRoute(accountID, network, peerID string, peerGroups []string, description, netID string, masquerade bool, metric int, groups []string, enabled bool, userID string) (*route.Route, error) {
 	if am.CreateRouteFunc != nil {
@@ -463,9 +603,9 @@ func (am *MockAccountManager) GetNameServerGroup(accountID, nsGroupID string) (*
 }
 
 // CreateNameServerGroup mocks CreateNameServerGroup of the AccountManager interface
-func (am *MockAccountManager) CreateNameServerGroup(accountID string, name, description string, nameServerList []nbdns.NameServer, groups []string, primary bool, domains []string, enabled bool, userID string) (*nbdns.NameServerGroup, error) {
+func (am *MockAccountManager) CreateNameServerGroup(accountID string, name, description string, nameServerList []nbdns.NameServer, groups []string, primary bool, domains []string, enabled bool, userID string, searchDomainsEnabled bool) (*nbdns.NameServerGroup, error) {
 	if am.CreateNameServerGroupFunc != nil {
-		return am.CreateNameServerGroupFunc(accountID, name, description, nameServerList, groups, primary, domains, enabled, userID)
+		return am.CreateNameServerGroupFunc(accountID, name, description, nameServerList, groups, primary, domains, enabled, userID, searchDomainsEnabled)
 	}
 	return nil, nil
 }
@@ -529,11 +669,11 @@ func (am *MockAccountManager) GetDNSDomain() string {
 }
 
 // GetEvents mocks GetEvents of the AccountManager interface
-func (am *MockAccountManager) GetEvents(accountID, userID string) ([]*activity.Event, error) {
+func (am *MockAccountManager) GetEvents(accountID, userID string, filter activity.Filter) ([]*activity.Event, string, error) {
 	if am.GetEventsFunc != nil {
-		return am.GetEventsFunc(accountID, userID)
+		return am.GetEventsFunc(accountID, userID, filter)
 	}
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllEvents is not implemented")
+	return nil, "", status.Errorf(codes.Unimplemented, "method GetAllEvents is not implemented")
 }
 
 // GetDNSSettings mocks GetDNSSettings of the AccountManager interface
@@ -568,6 +708,14 @@ func (am *MockAccountManager) UpdateAccountSettings(accountID, userID string, ne
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccountSettings is not implemented")
 }
 
+// UpdateAccountNetworkRange mocks UpdateAccountNetworkRange of the AccountManager interface
+func (am *MockAccountManager) UpdateAccountNetworkRange(accountID, userID string, networkRange netip.Prefix) (*server.Account, error) {
+	if am.UpdateAccountNetworkRangeFunc != nil {
+		return am.UpdateAccountNetworkRangeFunc(accountID, userID, networkRange)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccountNetworkRange is not implemented")
+}
+
 // LoginPeer mocks LoginPeer of the AccountManager interface
 func (am *MockAccountManager) LoginPeer(login server.PeerLogin) (*server.Peer, *server.NetworkMap, error) {
 	if am.LoginPeerFunc != nil {
@@ -35,7 +35,7 @@ func (am *DefaultAccountManager) GetNameServerGroup(accountID, nsGroupID string)
 }
 
 // CreateNameServerGroup creates and saves a new nameserver group
-func (am *DefaultAccountManager) CreateNameServerGroup(accountID string, name, description string, nameServerList []nbdns.NameServer, groups []string, primary bool, domains []string, enabled bool, userID string) (*nbdns.NameServerGroup, error) {
+func (am *DefaultAccountManager) CreateNameServerGroup(accountID string, name, description string, nameServerList []nbdns.NameServer, groups []string, primary bool, domains []string, enabled bool, userID string, searchDomainsEnabled bool) (*nbdns.NameServerGroup, error) {
 
 	unlock := am.Store.AcquireAccountLock(accountID)
 	defer unlock()
@@ -45,15 +45,25 @@ func (am *DefaultAccountManager) CreateNameServerGroup(accountID string, name, d
 		return nil, err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.CanManageNetwork() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to create nameserver groups")
+	}
+
 	newNSGroup := &nbdns.NameServerGroup{
-		ID:          xid.New().String(),
-		Name:        name,
-		Description: description,
-		NameServers: nameServerList,
-		Groups:      groups,
-		Enabled:     enabled,
-		Primary:     primary,
-		Domains:     domains,
+		ID:                   xid.New().String(),
+		Name:                 name,
+		Description:          description,
+		NameServers:          nameServerList,
+		Groups:               groups,
+		Enabled:              enabled,
+		Primary:              primary,
+		Domains:              domains,
+		SearchDomainsEnabled: searchDomainsEnabled,
 	}
 
 	err = validateNameServerGroup(false, newNSGroup, account)
@@ -73,7 +83,7 @@ func (am *DefaultAccountManager) CreateNameServerGroup(accountID string, name, d
 		return nil, err
 	}
 
-	am.updateAccountPeers(account)
+	am.updateAffectedPeersDNS(account, account.getPeersAffectedByNameServerGroup(newNSGroup))
 
 	am.storeEvent(userID, newNSGroup.ID, accountID, activity.NameserverGroupCreated, newNSGroup.EventMeta())
 
@@ -95,12 +105,23 @@ func (am *DefaultAccountManager) SaveNameServerGroup(accountID, userID string, n
 		return err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to update nameserver groups")
+	}
+
 	err = validateNameServerGroup(true, nsGroupToSave, account)
 	if err != nil {
 		return err
 	}
 
+	affectedPeers := account.getPeersAffectedByNameServerGroup(account.NameServerGroups[nsGroupToSave.ID])
 	account.NameServerGroups[nsGroupToSave.ID] = nsGroupToSave
+	affectedPeers.add(account.getPeersAffectedByNameServerGroup(nsGroupToSave))
 
 	account.Network.IncSerial()
 	err = am.Store.SaveAccount(account)
@@ -108,7 +129,7 @@ func (am *DefaultAccountManager) SaveNameServerGroup(accountID, userID string, n
 		return err
 	}
 
-	am.updateAccountPeers(account)
+	am.updateAffectedPeersDNS(account, affectedPeers)
 
 	am.storeEvent(userID, nsGroupToSave.ID, accountID, activity.NameserverGroupUpdated, nsGroupToSave.EventMeta())
 
@@ -126,6 +147,15 @@ func (am *DefaultAccountManager) DeleteNameServerGroup(accountID, nsGroupID, use
 		return err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to delete nameserver groups")
+	}
+
 	nsGroup := account.NameServerGroups[nsGroupID]
 	if nsGroup == nil {
 		return status.Errorf(status.NotFound, "nameserver group %s wasn't found", nsGroupID)
@@ -138,7 +168,7 @@ func (am *DefaultAccountManager) DeleteNameServerGroup(accountID, nsGroupID, use
 		return err
 	}
 
-	am.updateAccountPeers(account)
+	am.updateAffectedPeersDNS(account, account.getPeersAffectedByNameServerGroup(nsGroup))
 
 	am.storeEvent(userID, nsGroup.ID, accountID, activity.NameserverGroupDeleted, nsGroup.EventMeta())
 
@@ -174,7 +204,7 @@ func validateNameServerGroup(existingGroup bool, nameserverGroup *nbdns.NameServ
 		}
 	}
 
-	err := validateDomainInput(nameserverGroup.Primary, nameserverGroup.Domains)
+	err := validateDomainInput(nameserverGroup.Primary, nameserverGroup.Domains, nameserverGroup.SearchDomainsEnabled)
 	if err != nil {
 		return err
 	}
@@ -197,7 +227,7 @@ func validateNameServerGroup(existingGroup bool, nameserverGroup *nbdns.NameServ
 	return nil
 }
 
-func validateDomainInput(primary bool, domains []string) error {
+func validateDomainInput(primary bool, domains []string, searchDomainsEnabled bool) error {
 	if !primary && len(domains) == 0 {
 		return status.Errorf(status.InvalidArgument, "nameserver group primary status is false and domains are empty,"+
 			" it should be primary or have at least one domain")
@@ -206,6 +236,10 @@ func validateDomainInput(primary bool, domains []string) error {
 		return status.Errorf(status.InvalidArgument, "nameserver group primary status is true and domains are not empty,"+
 			" you should set either primary or domain")
 	}
+	if primary && searchDomainsEnabled {
+		return status.Errorf(status.InvalidArgument, "nameserver group primary status is true and search domains are enabled,"+
+			" only the domains of a non-primary group can be added as search domains")
+	}
 	for _, domain := range domains {
 		if err := validateDomain(domain); err != nil {
 			return status.Errorf(status.InvalidArgument, "nameserver group got an invalid domain: %s %q", domain, err)
@@ -233,6 +267,19 @@ func validateNSList(list []nbdns.NameServer) error {
 	if nsListLenght == 0 || nsListLenght > 2 {
 		return status.Errorf(status.InvalidArgument, "the list of nameservers should be 1 or 2, got %d", len(list))
 	}
+
+	for _, ns := range list {
+		if ns.ServerName == "" {
+			continue
+		}
+		if !ns.IsEncrypted() {
+			return status.Errorf(status.InvalidArgument, "nameserver %s has a server name but its type %s is not encrypted",
+				ns.IP, ns.NSType)
+		}
+		if err := validateDomain(ns.ServerName); err != nil {
+			return status.Errorf(status.InvalidArgument, "nameserver %s got an invalid server name: %s %q", ns.IP, ns.ServerName, err)
+		}
+	}
 	return nil
 }
 
@@ -23,13 +23,14 @@ const (
 
 func TestCreateNameServerGroup(t *testing.T) {
 	type input struct {
-		name        string
-		description string
-		enabled     bool
-		groups      []string
-		nameServers []nbdns.NameServer
-		primary     bool
-		domains     []string
+		name                 string
+		description          string
+		enabled              bool
+		groups               []string
+		nameServers          []nbdns.NameServer
+		primary              bool
+		domains              []string
+		searchDomainsEnabled bool
 	}
 
 	testCases := []struct {
@@ -127,6 +128,61 @@ func TestCreateNameServerGroup(t *testing.T) {
 				Enabled: true,
 			},
 		},
+		{
+			name: "Create A NS Group With Search Domains",
+			inputArgs: input{
+				name:        "super",
+				description: "super",
+				groups:      []string{group1ID},
+				domains:     []string{validDomain},
+				nameServers: []nbdns.NameServer{
+					{
+						IP:     netip.MustParseAddr("1.1.1.1"),
+						NSType: nbdns.UDPNameServerType,
+						Port:   nbdns.DefaultDNSPort,
+					},
+				},
+				enabled:              true,
+				searchDomainsEnabled: true,
+			},
+			errFunc:      require.NoError,
+			shouldCreate: true,
+			expectedNSGroup: &nbdns.NameServerGroup{
+				Name:        "super",
+				Description: "super",
+				Domains:     []string{"example.com"},
+				Groups:      []string{group1ID},
+				NameServers: []nbdns.NameServer{
+					{
+						IP:     netip.MustParseAddr("1.1.1.1"),
+						NSType: nbdns.UDPNameServerType,
+						Port:   nbdns.DefaultDNSPort,
+					},
+				},
+				Enabled:              true,
+				SearchDomainsEnabled: true,
+			},
+		},
+		{
+			name: "Should Not Create A Primary NS Group With Search Domains",
+			inputArgs: input{
+				name:        "super",
+				description: "super",
+				groups:      []string{group1ID},
+				primary:     true,
+				nameServers: []nbdns.NameServer{
+					{
+						IP:     netip.MustParseAddr("1.1.1.1"),
+						NSType: nbdns.UDPNameServerType,
+						Port:   nbdns.DefaultDNSPort,
+					},
+				},
+				enabled:              true,
+				searchDomainsEnabled: true,
+			},
+			errFunc:      require.Error,
+			shouldCreate: false,
+		},
 		{
 			name: "Should Not Create If Name Exist",
 			inputArgs: input{
@@ -360,6 +416,91 @@ func TestCreateNameServerGroup(t *testing.T) {
 			errFunc:      require.Error,
 			shouldCreate: false,
 		},
+		{
+			name: "Create A NS Group With Encrypted Nameservers",
+			inputArgs: input{
+				name:        "super",
+				description: "super",
+				groups:      []string{group1ID},
+				primary:     true,
+				nameServers: []nbdns.NameServer{
+					{
+						IP:         netip.MustParseAddr("1.1.1.1"),
+						NSType:     nbdns.TLSNameServerType,
+						Port:       nbdns.DefaultDoTPort,
+						ServerName: "cloudflare-dns.com",
+					},
+					{
+						IP:     netip.MustParseAddr("1.0.0.1"),
+						NSType: nbdns.HTTPSNameServerType,
+						Port:   nbdns.DefaultDoHPort,
+					},
+				},
+				enabled: true,
+			},
+			errFunc:      require.NoError,
+			shouldCreate: true,
+			expectedNSGroup: &nbdns.NameServerGroup{
+				Name:        "super",
+				Description: "super",
+				Primary:     true,
+				Groups:      []string{group1ID},
+				NameServers: []nbdns.NameServer{
+					{
+						IP:         netip.MustParseAddr("1.1.1.1"),
+						NSType:     nbdns.TLSNameServerType,
+						Port:       nbdns.DefaultDoTPort,
+						ServerName: "cloudflare-dns.com",
+					},
+					{
+						IP:     netip.MustParseAddr("1.0.0.1"),
+						NSType: nbdns.HTTPSNameServerType,
+						Port:   nbdns.DefaultDoHPort,
+					},
+				},
+				Enabled: true,
+			},
+		},
+		{
+			name: "Should Not Create If UDP Nameserver Has Server Name",
+			inputArgs: input{
+				name:        "super",
+				description: "super",
+				groups:      []string{group1ID},
+				primary:     true,
+				nameServers: []nbdns.NameServer{
+					{
+						IP:         netip.MustParseAddr("1.1.1.1"),
+						NSType:     nbdns.UDPNameServerType,
+						Port:       nbdns.DefaultDNSPort,
+						ServerName: "cloudflare-dns.com",
+					},
+				},
+				enabled: true,
+			},
+			errFunc:      require.Error,
+			shouldCreate: false,
+		},
+		{
+			name: "Should Not Create If Server Name Is Invalid",
+			inputArgs: input{
+				name:        "super",
+				description: "super",
+				groups:      []string{group1ID},
+				primary:     true,
+				nameServers: []nbdns.NameServer{
+					{
+						IP:         netip.MustParseAddr("1.1.1.1"),
+						NSType:     nbdns.TLSNameServerType,
+						Port:       nbdns.DefaultDoTPort,
+						ServerName: invalidDomain,
+					},
+				},
+				enabled: true,
+			},
+			errFunc:      require.Error,
+			shouldCreate: false,
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -383,6 +524,7 @@ func TestCreateNameServerGroup(t *testing.T) {
 				testCase.inputArgs.domains,
 				testCase.inputArgs.enabled,
 				userID,
+				testCase.inputArgs.searchDomainsEnabled,
 			)
 
 			testCase.errFunc(t, err)
@@ -744,7 +886,7 @@ func createNSManager(t *testing.T) (*DefaultAccountManager, error) {
 		return nil, err
 	}
 	eventStore := &activity.InMemoryEventStore{}
-	return BuildManager(store, NewPeersUpdateManager(), nil, "", "", eventStore, false)
+	return BuildManager(store, NewPeersUpdateManager(nil), nil, "", "", eventStore, false, 0, nil)
 }
 
 func createNSStore(t *testing.T) (Store, error) {
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/binary"
 	"math/rand"
 	"net"
 	"sync"
@@ -20,8 +21,17 @@ const (
 	// NetSize is a global network size 100.64.0.0/10
 	NetSize = 10
 
+	// MinNetworkRangeSize is the shortest prefix length of a network range the account can be configured with
+	MinNetworkRangeSize = 16
+	// MaxNetworkRangeSize is the longest prefix length of a network range the account can be configured with
+	MaxNetworkRangeSize = 28
+
 	// AllowedIPsFormat generates Wireguard AllowedIPs format (e.g. 100.64.30.1/32)
 	AllowedIPsFormat = "%s/32"
+	// AllowedIPsV6Format generates Wireguard AllowedIPs format for the IPv6 overlay address (e.g. fd5e:1c2a:9b00::1/128)
+	AllowedIPsV6Format = "%s/128"
+	// SubnetV6Size is a size of the IPv6 overlay network of an account, e.g. fd5e:1c2a:9b00::/64
+	SubnetV6Size = 64
 )
 
 type NetworkMap struct {
@@ -36,7 +46,9 @@ type NetworkMap struct {
 type Network struct {
 	Id  string
 	Net net.IPNet
-	Dns string
+	// NetV6 is the unique local (ULA) IPv6 network of the account the peers get their IPv6 overlay addresses from
+	NetV6 net.IPNet
+	Dns   string
 	// Serial is an ID that increments by 1 when any change to the network happened (e.g. new peer has been added).
 	// Used to synchronize state to the client apps.
 	Serial uint64
@@ -58,10 +70,25 @@ func NewNetwork() *Network {
 	return &Network{
 		Id:     xid.New().String(),
 		Net:    sub[intn].IPNet,
+		NetV6:  newULANetwork(r),
 		Dns:    "",
 		Serial: 0}
 }
 
+// newULANetwork generates a random unique local IPv6 /64 network as defined in RFC 4193:
+// the fd00::/8 prefix followed by a random 40-bit global ID and a zero subnet ID
+func newULANetwork(r *rand.Rand) net.IPNet {
+	ip := make(net.IP, net.IPv6len)
+	ip[0] = 0xfd
+	_, _ = r.Read(ip[1:6])
+	return net.IPNet{IP: ip, Mask: net.CIDRMask(SubnetV6Size, 128)}
+}
+
+// HasIPv6 returns true if the network has an IPv6 overlay network assigned
+func (n *Network) HasIPv6() bool {
+	return n.NetV6.IP != nil
+}
+
 // IncSerial increments Serial by 1 reflecting that the network state has been changed
 func (n *Network) IncSerial() {
 	n.mu.Lock()
@@ -80,6 +107,7 @@ func (n *Network) Copy() *Network {
 	return &Network{
 		Id:     n.Id,
 		Net:    n.Net,
+		NetV6:  n.NetV6,
 		Dns:    n.Dns,
 		Serial: n.Serial,
 	}
@@ -109,6 +137,140 @@ func AllocatePeerIP(ipNet net.IPNet, takenIps []net.IP) (net.IP, error) {
 	return ips[intn], nil
 }
 
+// remapPeerIPs moves the IPs of the peers to a new empty net.IPNet keeping their host part, e.g. 100.64.0.10 of
+// 100.64.0.0/16 becomes 10.20.0.10 of 10.20.0.0/24. It is used to re-address all the peers of an account at once
+// when its network range changes. An IP whose host part doesn't fit in the new network or isn't available in it
+// gets a random available IP instead
+func remapPeerIPs(oldNet, newNet net.IPNet, ips []net.IP) ([]net.IP, error) {
+	available, _ := generateIPs(&newNet, map[string]struct{}{newNet.IP.String(): {}})
+	if len(available) < len(ips) {
+		return nil, status.Errorf(status.PreconditionFailed, "network %s has %d available IPs, %d are required",
+			newNet.String(), len(available), len(ips))
+	}
+
+	free := make(map[string]struct{}, len(available))
+	for _, ip := range available {
+		free[ip.String()] = struct{}{}
+	}
+
+	oldOnes, _ := oldNet.Mask.Size()
+	newOnes, _ := newNet.Mask.Size()
+	oldHostMask := ^(^uint32(0) << (32 - oldOnes))
+	newHostMask := ^(^uint32(0) << (32 - newOnes))
+	newPrefix := binary.BigEndian.Uint32(newNet.IP.To4()) &^ newHostMask
+
+	remapped := make([]net.IP, len(ips))
+	var pending []int
+	for i, ip := range ips {
+		ip4 := ip.To4()
+		if ip4 == nil || !oldNet.Contains(ip4) {
+			pending = append(pending, i)
+			continue
+		}
+
+		host := binary.BigEndian.Uint32(ip4) & oldHostMask
+		if host&^newHostMask != 0 {
+			pending = append(pending, i)
+			continue
+		}
+
+		newIP := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(newIP, newPrefix|host)
+		if _, ok := free[newIP.String()]; !ok {
+			pending = append(pending, i)
+			continue
+		}
+		delete(free, newIP.String())
+		remapped[i] = newIP
+	}
+
+	if len(pending) == 0 {
+		return remapped, nil
+	}
+
+	remaining := make([]net.IP, 0, len(free))
+	for _, ip := range available {
+		if _, ok := free[ip.String()]; ok {
+			remaining = append(remaining, ip)
+		}
+	}
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+	r.Shuffle(len(remaining), func(i, j int) {
+		remaining[i], remaining[j] = remaining[j], remaining[i]
+	})
+
+	for n, i := range pending {
+		remapped[i] = remaining[n]
+	}
+
+	return remapped, nil
+}
+
+// AllocatePeerIPv6 picks a random available IPv6 address from the IPv6 overlay network.
+// The interface ID is random, so the addresses don't reveal the number or the order of the peers
+func AllocatePeerIPv6(ipNet net.IPNet, takenIps []net.IP) (net.IP, error) {
+	if ipNet.IP.To4() != nil || len(ipNet.IP) != net.IPv6len {
+		return nil, status.Errorf(status.PreconditionFailed, "failed allocating new IPv6 for the ipNet %s - not an IPv6 network", ipNet.String())
+	}
+
+	takenIPMap := make(map[string]struct{}, len(takenIps))
+	for _, ip := range takenIps {
+		takenIPMap[ip.String()] = struct{}{}
+	}
+
+	s := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(s)
+	// the space is large enough to find a free address in a few attempts, the limit only guards against a full network
+	for i := 0; i < 1000; i++ {
+		ip := make(net.IP, net.IPv6len)
+		_, _ = r.Read(ip)
+		for b := 0; b < net.IPv6len; b++ {
+			ip[b] = ipNet.IP[b]&ipNet.Mask[b] | ip[b]&^ipNet.Mask[b]
+		}
+
+		// skip the subnet-router anycast address
+		if ip.Equal(ipNet.IP.Mask(ipNet.Mask)) {
+			continue
+		}
+
+		if _, taken := takenIPMap[ip.String()]; !taken {
+			return ip, nil
+		}
+	}
+
+	return nil, status.Errorf(status.PreconditionFailed, "failed allocating new IPv6 for the ipNet %s - network is out of IPs", ipNet.String())
+}
+
+// validatePeerIP returns an error if the IP doesn't belong to the network, is one of the addresses AllocatePeerIP never
+// picks (the first address, the addresses ending with .0 and the last two addresses) or is taken
+func validatePeerIP(ipNet net.IPNet, ip net.IP, takenIps []net.IP) error {
+	ip4 := ip.To4()
+	if ip4 == nil || !ipNet.Contains(ip4) {
+		return status.Errorf(status.InvalidArgument, "IP %s doesn't belong to the network %s", ip, ipNet.String())
+	}
+
+	mask := ipNet.Mask
+	if len(mask) == net.IPv6len {
+		mask = mask[12:]
+	}
+	first := binary.BigEndian.Uint32(ipNet.IP.To4()) & binary.BigEndian.Uint32(mask)
+	last := first | ^binary.BigEndian.Uint32(mask)
+	value := binary.BigEndian.Uint32(ip4)
+	if ip4[3] == 0 || value <= first+1 || value >= last-1 {
+		return status.Errorf(status.InvalidArgument, "IP %s is reserved in the network %s", ip, ipNet.String())
+	}
+
+	for _, taken := range takenIps {
+		if taken.Equal(ip4) {
+			return status.Errorf(status.AlreadyExists, "IP %s is already taken", ip)
+		}
+	}
+
+	return nil
+}
+
 // generateIPs generates a list of all possible IPs of the given network excluding IPs specified in the exclusion list
 func generateIPs(ipNet *net.IPNet, exclusions map[string]struct{}) ([]net.IP, int) {
 
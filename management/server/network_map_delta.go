@@ -0,0 +1,227 @@
+package server
+
+import (
+	"sort"
+
+	"github.com/netbirdio/netbird/management/proto"
+)
+
+// firewallRuleWildcardIPs are the peer IPs of the firewall rules that apply to all the peers
+var firewallRuleWildcardIPs = []string{"0.0.0.0", "::"}
+
+// networkMapChange is the change set of an account change, the network map deltas are computed from it instead of
+// comparing the network maps
+type networkMapChange struct {
+	// peers maps the ID of a peer the change may have modified, or modified the visibility of, to true if the peer
+	// itself changed, e.g. its SSH key or its login expiration, and to false if only its visibility to the other peers
+	// may have changed, e.g. with a policy change. The network maps of the peers that changed are sent in full
+	peers map[string]bool
+	// dns is true when the change may have modified the DNS configuration of the peers
+	dns bool
+}
+
+// merge returns the change set of both changes, nil if any of them isn't known
+func (c *networkMapChange) merge(other *networkMapChange) *networkMapChange {
+	if c == nil || other == nil {
+		return nil
+	}
+
+	merged := &networkMapChange{
+		peers: make(map[string]bool, len(c.peers)+len(other.peers)),
+		dns:   c.dns || other.dns,
+	}
+	for peerID, changed := range c.peers {
+		merged.peers[peerID] = changed
+	}
+	for peerID, changed := range other.peers {
+		merged.peers[peerID] = merged.peers[peerID] || changed
+	}
+	return merged
+}
+
+// networkMapUpdate is the network map of a peers update with the change set it results from
+type networkMapUpdate struct {
+	peer       *Peer
+	networkMap *NetworkMap
+	dnsName    string
+	// change is nil when it isn't known, the network map is then sent in full
+	change *networkMapChange
+}
+
+// streamPeer is a remote peer of the network map of a Sync stream client
+type streamPeer struct {
+	key string
+	// ips are the IPs the firewall rules of the peer are generated for
+	ips []string
+}
+
+func newStreamPeer(peer *Peer) streamPeer {
+	ips := []string{peer.IP.String()}
+	if peer.IPv6 != nil {
+		ips = append(ips, peer.IPv6.String())
+	}
+	return streamPeer{key: peer.Key, ips: ips}
+}
+
+// networkMapStream keeps what the client of a Sync stream knows of its network map, the deltas apply to it.
+// Only the clients supporting NetworkMapDelta have one
+type networkMapStream struct {
+	// sequence of the last network map or delta sent on the stream
+	sequence uint64
+	// known is false when the stream doesn't know the network map of the client, e.g. after the update of a deleted
+	// peer, the next network map is sent in full
+	known bool
+	// peers and offlinePeers are the remote peers of the client indexed by peer ID
+	peers        map[string]streamPeer
+	offlinePeers map[string]streamPeer
+}
+
+// toResponse returns the response to send on the stream for the update: the network map of the update is replaced
+// with a delta when possible. The network maps and deltas are numbered, so the client applies a delta only to the
+// network map it was computed for
+func (s *networkMapStream) toResponse(update *UpdateMessage) *proto.SyncResponse {
+	resp := update.Update
+	if resp.GetNetworkMap() == nil {
+		return resp
+	}
+
+	baseSequence := s.sequence
+	s.sequence++
+
+	if update.networkMapUpdate == nil {
+		s.known = false
+		resp.Sequence = s.sequence
+		return resp
+	}
+
+	delta := s.delta(update.networkMapUpdate)
+	if delta == nil {
+		s.reset(update.networkMapUpdate.networkMap)
+		resp.Sequence = s.sequence
+		return resp
+	}
+
+	delta.BaseSequence = baseSequence
+	return &proto.SyncResponse{
+		WiretrusteeConfig: resp.GetWiretrusteeConfig(),
+		NetworkMapDelta:   delta,
+		Sequence:          s.sequence,
+	}
+}
+
+// reset sets the network map sent in full as the network map of the client
+func (s *networkMapStream) reset(networkMap *NetworkMap) {
+	s.known = true
+	s.peers = make(map[string]streamPeer, len(networkMap.Peers))
+	for _, peer := range networkMap.Peers {
+		s.peers[peer.ID] = newStreamPeer(peer)
+	}
+	s.offlinePeers = make(map[string]streamPeer, len(networkMap.OfflinePeers))
+	for _, peer := range networkMap.OfflinePeers {
+		s.offlinePeers[peer.ID] = newStreamPeer(peer)
+	}
+}
+
+// delta returns the delta of the update relative to the network map of the client and applies it to the stream.
+// Only the peers of the change set are looked at. It returns nil when the network map has to be sent in full: the
+// change set or the network map of the client isn't known, the peer itself changed or the delta isn't smaller than
+// the network map
+func (s *networkMapStream) delta(update *networkMapUpdate) *proto.NetworkMapDelta {
+	change := update.change
+	if !s.known || change == nil || change.peers[update.peer.ID] {
+		return nil
+	}
+	networkMap := update.networkMap
+
+	var peers, offlinePeers []*Peer
+	for _, peer := range networkMap.Peers {
+		if _, ok := change.peers[peer.ID]; ok {
+			peers = append(peers, peer)
+		}
+	}
+	for _, peer := range networkMap.OfflinePeers {
+		if _, ok := change.peers[peer.ID]; ok {
+			offlinePeers = append(offlinePeers, peer)
+		}
+	}
+
+	if len(peers)+len(offlinePeers) >= len(networkMap.Peers)+len(networkMap.OfflinePeers) {
+		return nil
+	}
+
+	ipv6 := update.peer.SupportsIPv6()
+	delta := &proto.NetworkMapDelta{
+		Serial:               networkMap.Network.CurrentSerial(),
+		PeerConfig:           toPeerConfig(update.peer, networkMap.Network, update.dnsName),
+		UpsertedPeers:        toRemotePeerConfig(peers, update.dnsName, ipv6),
+		UpsertedOfflinePeers: toRemotePeerConfig(offlinePeers, update.dnsName, ipv6),
+		Routes:               toProtocolRoutes(networkMap.Routes),
+	}
+	if change.dns {
+		delta.DNSConfig = toProtocolDNSConfig(networkMap.DNSConfig)
+	}
+
+	// the firewall rules of the changed peers are replaced, the ones of the peers that aren't part of the network map
+	// anymore are looked up in the peers the client has
+	ruleIPs := make(map[string]struct{})
+	for _, ip := range firewallRuleWildcardIPs {
+		ruleIPs[ip] = struct{}{}
+	}
+	addRuleIPs := func(peer streamPeer) {
+		for _, ip := range peer.ips {
+			ruleIPs[ip] = struct{}{}
+		}
+	}
+
+	for peerID := range change.peers {
+		if peer, ok := s.peers[peerID]; ok {
+			delete(s.peers, peerID)
+			addRuleIPs(peer)
+			delta.RemovedPeers = append(delta.RemovedPeers, peer.key)
+		}
+		if peer, ok := s.offlinePeers[peerID]; ok {
+			delete(s.offlinePeers, peerID)
+			addRuleIPs(peer)
+			delta.RemovedOfflinePeers = append(delta.RemovedOfflinePeers, peer.key)
+		}
+	}
+	for _, peer := range peers {
+		s.peers[peer.ID] = newStreamPeer(peer)
+		addRuleIPs(s.peers[peer.ID])
+	}
+	for _, peer := range offlinePeers {
+		s.offlinePeers[peer.ID] = newStreamPeer(peer)
+		addRuleIPs(s.offlinePeers[peer.ID])
+	}
+	// an upserted peer replaces the peer with the same key, it doesn't have to be removed first
+	delta.RemovedPeers = withoutPeers(delta.RemovedPeers, peers)
+	delta.RemovedOfflinePeers = withoutPeers(delta.RemovedOfflinePeers, offlinePeers)
+
+	var rules []*FirewallRule
+	for _, rule := range networkMap.FirewallRules {
+		if _, ok := ruleIPs[rule.PeerIP]; ok {
+			rules = append(rules, rule)
+		}
+	}
+	delta.FirewallRules = toProtocolFirewallRules(rules)
+	for ip := range ruleIPs {
+		delta.FirewallRulesPeerIPs = append(delta.FirewallRulesPeerIPs, ip)
+	}
+	sort.Strings(delta.FirewallRulesPeerIPs)
+
+	return delta
+}
+
+func withoutPeers(keys []string, peers []*Peer) []string {
+	excluded := make(map[string]struct{}, len(peers))
+	for _, peer := range peers {
+		excluded[peer.Key] = struct{}{}
+	}
+	result := keys[:0]
+	for _, key := range keys {
+		if _, ok := excluded[key]; !ok {
+			result = append(result, key)
+		}
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/management/proto"
+)
+
+func testNetworkMapUpdate(serial uint64, peers int) *networkMapUpdate {
+	networkMap := &NetworkMap{
+		Network: &Network{
+			Net:    net.IPNet{IP: net.ParseIP("100.64.0.0"), Mask: net.CIDRMask(16, 32)},
+			Serial: serial,
+		},
+	}
+	for i := 0; i < peers; i++ {
+		peer := &Peer{
+			ID:       fmt.Sprintf("peer%d", i),
+			Key:      fmt.Sprintf("key%d", i),
+			IP:       net.ParseIP(fmt.Sprintf("100.64.0.%d", i+2)),
+			DNSLabel: fmt.Sprintf("peer%d", i),
+		}
+		networkMap.Peers = append(networkMap.Peers, peer)
+		networkMap.FirewallRules = append(networkMap.FirewallRules, &FirewallRule{
+			PeerIP:   peer.IP.String(),
+			Action:   string(PolicyTrafficActionAccept),
+			Protocol: string(PolicyRuleProtocolALL),
+		})
+	}
+	return &networkMapUpdate{
+		peer:       &Peer{ID: "local", Key: "local", IP: net.ParseIP("100.64.0.1")},
+		networkMap: networkMap,
+		dnsName:    "netbird.cloud",
+	}
+}
+
+func toUpdateMessage(update *networkMapUpdate) *UpdateMessage {
+	return &UpdateMessage{
+		Update:           toSyncResponse(nil, update.peer, nil, update.networkMap, update.dnsName),
+		networkMapUpdate: update,
+	}
+}
+
+func TestNetworkMapStream_Delta(t *testing.T) {
+	stream := &networkMapStream{}
+
+	resp := stream.toResponse(toUpdateMessage(testNetworkMapUpdate(1, 10)))
+	require.NotNil(t, resp.NetworkMap, "the first network map should be sent in full")
+	assert.Equal(t, uint64(1), resp.Sequence)
+
+	update := testNetworkMapUpdate(2, 10)
+	// peer1 has a new SSH key, peer2 is offline and peer3 isn't part of the network map anymore
+	update.networkMap.Peers[1].SSHKey = "new-ssh-key"
+	update.networkMap.OfflinePeers = []*Peer{update.networkMap.Peers[2]}
+	update.networkMap.Peers = append(update.networkMap.Peers[:2:2], update.networkMap.Peers[4:]...)
+	update.networkMap.FirewallRules = append(update.networkMap.FirewallRules[:3:3], update.networkMap.FirewallRules[4:]...)
+	update.change = &networkMapChange{peers: map[string]bool{"peer1": true, "peer2": true, "peer3": true}}
+
+	resp = stream.toResponse(toUpdateMessage(update))
+	assert.Nil(t, resp.NetworkMap)
+	delta := resp.NetworkMapDelta
+	require.NotNil(t, delta)
+	assert.Equal(t, uint64(2), resp.Sequence)
+	assert.Equal(t, uint64(1), delta.BaseSequence)
+	assert.Equal(t, uint64(2), delta.Serial)
+	assert.NotNil(t, delta.PeerConfig)
+	assert.Nil(t, delta.DNSConfig, "the DNS config should be sent only for the changes of the DNS config")
+
+	require.Len(t, delta.UpsertedPeers, 1)
+	assert.Equal(t, "key1", delta.UpsertedPeers[0].WgPubKey)
+	assert.Equal(t, []byte("new-ssh-key"), delta.UpsertedPeers[0].SshConfig.SshPubKey)
+	require.Len(t, delta.UpsertedOfflinePeers, 1)
+	assert.Equal(t, "key2", delta.UpsertedOfflinePeers[0].WgPubKey)
+	assert.ElementsMatch(t, []string{"key2", "key3"}, delta.RemovedPeers)
+	assert.Empty(t, delta.RemovedOfflinePeers)
+
+	assert.Equal(t, []string{"0.0.0.0", "100.64.0.3", "100.64.0.4", "100.64.0.5", "::"}, delta.FirewallRulesPeerIPs)
+	require.Len(t, delta.FirewallRules, 2)
+	assert.Equal(t, "100.64.0.3", delta.FirewallRules[0].PeerIP)
+	assert.Equal(t, "100.64.0.4", delta.FirewallRules[1].PeerIP)
+
+	// the delta applies to the network map of the previous delta
+	update = testNetworkMapUpdate(3, 10)
+	update.change = &networkMapChange{peers: map[string]bool{"peer2": false}, dns: true}
+	resp = stream.toResponse(toUpdateMessage(update))
+	require.NotNil(t, resp.NetworkMapDelta)
+	assert.Equal(t, uint64(2), resp.NetworkMapDelta.BaseSequence)
+	assert.Equal(t, []string{"key2"}, resp.NetworkMapDelta.RemovedOfflinePeers)
+	assert.Empty(t, resp.NetworkMapDelta.RemovedPeers)
+	require.Len(t, resp.NetworkMapDelta.UpsertedPeers, 1)
+	assert.NotNil(t, resp.NetworkMapDelta.DNSConfig)
+}
+
+func TestNetworkMapStream_FullNetworkMap(t *testing.T) {
+	stream := &networkMapStream{}
+	stream.toResponse(toUpdateMessage(testNetworkMapUpdate(1, 10)))
+
+	turnUpdate := &UpdateMessage{Update: &proto.SyncResponse{WiretrusteeConfig: &proto.WiretrusteeConfig{}}}
+	resp := stream.toResponse(turnUpdate)
+	assert.Same(t, turnUpdate.Update, resp, "the updates without a network map should be sent as is")
+	assert.Zero(t, resp.Sequence)
+
+	update := testNetworkMapUpdate(2, 10)
+	resp = stream.toResponse(toUpdateMessage(update))
+	assert.NotNil(t, resp.NetworkMap, "the network map should be sent in full when the change set isn't known")
+	assert.Equal(t, uint64(2), resp.Sequence)
+
+	update = testNetworkMapUpdate(3, 10)
+	update.change = &networkMapChange{peers: map[string]bool{"local": true, "peer1": false}}
+	resp = stream.toResponse(toUpdateMessage(update))
+	assert.NotNil(t, resp.NetworkMap, "the network map should be sent in full when the peer itself changed")
+
+	update = testNetworkMapUpdate(4, 2)
+	update.change = &networkMapChange{peers: map[string]bool{"peer0": false, "peer1": false}}
+	resp = stream.toResponse(toUpdateMessage(update))
+	assert.NotNil(t, resp.NetworkMap, "the network map should be sent in full when the delta isn't smaller")
+
+	deletedPeerUpdate := &UpdateMessage{Update: &proto.SyncResponse{NetworkMap: &proto.NetworkMap{Serial: 5, RemotePeersIsEmpty: true}}}
+	resp = stream.toResponse(deletedPeerUpdate)
+	assert.Same(t, deletedPeerUpdate.Update, resp, "the update of a deleted peer should be sent in full")
+	assert.Equal(t, uint64(5), resp.Sequence)
+
+	update = testNetworkMapUpdate(6, 10)
+	update.change = &networkMapChange{peers: map[string]bool{"peer1": false}}
+	resp = stream.toResponse(toUpdateMessage(update))
+	assert.NotNil(t, resp.NetworkMap, "the network map should be sent in full when the one of the client isn't known")
+}
+
+func TestNetworkMapChange_Merge(t *testing.T) {
+	change := &networkMapChange{peers: map[string]bool{"peer1": false, "peer2": true}}
+	merged := change.merge(&networkMapChange{peers: map[string]bool{"peer1": true, "peer3": false}, dns: true})
+	assert.Equal(t, map[string]bool{"peer1": true, "peer2": true, "peer3": false}, merged.peers)
+	assert.True(t, merged.dns)
+
+	assert.Nil(t, change.merge(nil), "the merge with an unknown change set should be unknown")
+	var unknown *networkMapChange
+	assert.Nil(t, unknown.merge(change))
+}
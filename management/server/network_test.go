@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewNetwork(t *testing.T) {
@@ -13,6 +14,39 @@ func TestNewNetwork(t *testing.T) {
 	// generated net should be a subnet of a larger 100.64.0.0/10 net
 	ipNet := net.IPNet{IP: net.ParseIP("100.64.0.0"), Mask: net.IPMask{255, 192, 0, 0}}
 	assert.Equal(t, ipNet.Contains(network.Net.IP), true)
+
+	// generated IPv6 net should be a /64 of the unique local fd00::/8 space
+	ula := net.IPNet{IP: net.ParseIP("fd00::"), Mask: net.CIDRMask(8, 128)}
+	assert.True(t, network.HasIPv6())
+	assert.True(t, ula.Contains(network.NetV6.IP))
+	ones, bits := network.NetV6.Mask.Size()
+	assert.Equal(t, SubnetV6Size, ones)
+	assert.Equal(t, 128, bits)
+}
+
+func TestAllocatePeerIPv6(t *testing.T) {
+	_, ipNet, _ := net.ParseCIDR("fd5e:1c2a:9b00::/64")
+	var ips []net.IP
+	for i := 0; i < 500; i++ {
+		ip, err := AllocatePeerIPv6(*ipNet, ips)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, ipNet.Contains(ip), "allocated IP %s is outside of %s", ip, ipNet)
+		assert.False(t, ip.Equal(ipNet.IP), "subnet-router anycast address should not be allocated")
+		ips = append(ips, ip)
+	}
+
+	uniq := make(map[string]struct{})
+	for _, ip := range ips {
+		if _, ok := uniq[ip.String()]; ok {
+			t.Errorf("found duplicate IP %s", ip.String())
+		}
+		uniq[ip.String()] = struct{}{}
+	}
+
+	_, err := AllocatePeerIPv6(net.IPNet{IP: net.ParseIP("100.64.0.0").To4(), Mask: net.CIDRMask(16, 32)}, nil)
+	assert.Error(t, err, "IPv6 allocation from an IPv4 network should fail")
 }
 
 func TestAllocatePeerIP(t *testing.T) {
@@ -38,6 +72,47 @@ func TestAllocatePeerIP(t *testing.T) {
 	}
 }
 
+func TestRemapPeerIPs(t *testing.T) {
+	oldNet := net.IPNet{IP: net.IP{100, 64, 0, 0}, Mask: net.CIDRMask(16, 32)}
+	newNet := net.IPNet{IP: net.IP{10, 20, 0, 0}, Mask: net.CIDRMask(24, 32)}
+
+	ips, err := remapPeerIPs(oldNet, newNet, []net.IP{
+		{100, 64, 0, 10},
+		{100, 64, 1, 10},
+		{100, 64, 0, 20},
+		{100, 65, 0, 30},
+	})
+	require.NoError(t, err)
+	require.Len(t, ips, 4)
+	assert.Equal(t, "10.20.0.10", ips[0].String(), "the host part should be kept when it fits")
+	assert.NotEqual(t, "10.20.0.10", ips[1].String(), "a host part that doesn't fit should get a free IP")
+	assert.Equal(t, "10.20.0.20", ips[2].String())
+	assert.NotEqual(t, "10.20.0.30", ips[3].String(), "an IP outside of the old network should get a random IP")
+
+	uniq := make(map[string]struct{})
+	for _, ip := range ips {
+		assert.NoError(t, validatePeerIP(newNet, ip, nil), "the remapped IPs should be valid peer IPs")
+		uniq[ip.String()] = struct{}{}
+	}
+	assert.Len(t, uniq, 4, "the remapped IPs should be distinct")
+
+	many := make([]net.IP, 252)
+	for i := range many {
+		many[i] = net.IP{100, 64, byte(i / 200), byte(1 + i%200)}
+	}
+	ips, err = remapPeerIPs(oldNet, newNet, many)
+	require.NoError(t, err)
+	uniq = make(map[string]struct{})
+	for _, ip := range ips {
+		assert.NoError(t, validatePeerIP(newNet, ip, nil), "the remapped IPs should be valid peer IPs")
+		uniq[ip.String()] = struct{}{}
+	}
+	assert.Len(t, uniq, 252, "the colliding IPs should get distinct free IPs")
+
+	_, err = remapPeerIPs(oldNet, newNet, append(many, net.IP{100, 64, 2, 1}))
+	assert.Error(t, err, "the network should not have enough IPs")
+}
+
 func TestGenerateIPs(t *testing.T) {
 	ipNet := net.IPNet{IP: net.ParseIP("100.64.0.0"), Mask: net.IPMask{255, 255, 255, 0}}
 	ips, ipsLen := generateIPs(&ipNet, map[string]struct{}{"100.64.0.0": {}})
@@ -49,3 +124,36 @@ func TestGenerateIPs(t *testing.T) {
 		t.Errorf("expected last ip to be: 100.64.0.253, got %s", ips[len(ips)-1].String())
 	}
 }
+
+func TestValidatePeerIP(t *testing.T) {
+	ipNet := net.IPNet{IP: net.ParseIP("100.64.0.0").To4(), Mask: net.IPMask{255, 255, 0, 0}}
+	taken := []net.IP{net.ParseIP("100.64.0.10")}
+
+	tt := []struct {
+		name          string
+		ip            string
+		expectedError bool
+	}{
+		{name: "Free IP", ip: "100.64.0.11"},
+		{name: "Free IP In Another /24", ip: "100.64.12.1"},
+		{name: "Taken IP", ip: "100.64.0.10", expectedError: true},
+		{name: "Outside Of The Network", ip: "100.65.0.10", expectedError: true},
+		{name: "IPv6", ip: "fd00::10", expectedError: true},
+		{name: "Network Address", ip: "100.64.0.0", expectedError: true},
+		{name: "First Address", ip: "100.64.0.1", expectedError: true},
+		{name: "Address Ending With Zero", ip: "100.64.3.0", expectedError: true},
+		{name: "Next To Last Address", ip: "100.64.255.254", expectedError: true},
+		{name: "Broadcast Address", ip: "100.64.255.255", expectedError: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePeerIP(ipNet, net.ParseIP(tc.ip), taken)
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
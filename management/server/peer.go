@@ -14,8 +14,13 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/netbirdio/netbird/management/proto"
+	"github.com/netbirdio/netbird/route"
 )
 
+// IPv6OverlayCapability is reported in the peer system meta by clients able to configure the IPv6 overlay address.
+// It must match the capability reported by the client system package
+const IPv6OverlayCapability = "ipv6_overlay"
+
 // PeerSystemMeta is a metadata of a Peer machine system
 type PeerSystemMeta struct {
 	Hostname  string
@@ -26,6 +31,8 @@ type PeerSystemMeta struct {
 	OS        string
 	WtVersion string
 	UIVersion string
+	// Capabilities lists the optional features supported by the peer, e.g. route.RoutingPeerCapability
+	Capabilities []string
 }
 
 func (p PeerSystemMeta) isEqual(other PeerSystemMeta) bool {
@@ -36,7 +43,30 @@ func (p PeerSystemMeta) isEqual(other PeerSystemMeta) bool {
 		p.Platform == other.Platform &&
 		p.OS == other.OS &&
 		p.WtVersion == other.WtVersion &&
-		p.UIVersion == other.UIVersion
+		p.UIVersion == other.UIVersion &&
+		isEqualCapabilities(p.Capabilities, other.Capabilities)
+}
+
+func isEqualCapabilities(capabilities, other []string) bool {
+	if len(capabilities) != len(other) {
+		return false
+	}
+	for i := range capabilities {
+		if capabilities[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// HasCapability returns true if the peer reported the given capability
+func (p PeerSystemMeta) HasCapability(capability string) bool {
+	for _, c := range p.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
 }
 
 type PeerStatus struct {
@@ -46,6 +76,8 @@ type PeerStatus struct {
 	Connected bool
 	// LoginExpired
 	LoginExpired bool
+	// RequiresApproval indicates that the peer is pending an admin approval and is not connected to other peers
+	RequiresApproval bool
 }
 
 // PeerSync used as a data object between the gRPC API and AccountManager on Sync request.
@@ -79,6 +111,8 @@ type Peer struct {
 	SetupKey string
 	// IP address of the Peer
 	IP net.IP
+	// IPv6 is the overlay address of the Peer in the account's unique local IPv6 network, nil if not assigned
+	IPv6 net.IP
 	// Meta is a Peer system meta data
 	Meta PeerSystemMeta
 	// Name is peer's name (machine name)
@@ -108,6 +142,11 @@ func (p *Peer) AddedWithSSOLogin() bool {
 	return p.UserID != ""
 }
 
+// PendingApproval indicates whether the peer is waiting for an admin approval
+func (p *Peer) PendingApproval() bool {
+	return p.Status != nil && p.Status.RequiresApproval
+}
+
 // Copy copies Peer object
 func (p *Peer) Copy() *Peer {
 	peerStatus := p.Status
@@ -119,6 +158,7 @@ func (p *Peer) Copy() *Peer {
 		Key:                    p.Key,
 		SetupKey:               p.SetupKey,
 		IP:                     p.IP,
+		IPv6:                   p.IPv6,
 		Meta:                   p.Meta,
 		Name:                   p.Name,
 		DNSLabel:               p.DNSLabel,
@@ -132,6 +172,18 @@ func (p *Peer) Copy() *Peer {
 	}
 }
 
+// SupportsRouting returns true if the peer can act as a routing peer of a network route.
+// Linux peers always could, peers running on other systems have to report the routing capability.
+func (p *Peer) SupportsRouting() bool {
+	return p.Meta.GoOS == "linux" || p.Meta.HasCapability(route.RoutingPeerCapability)
+}
+
+// SupportsIPv6 returns true if the peer has an IPv6 overlay address and is able to configure it on its interface.
+// Peers running older clients get neither their IPv6 address nor the IPv6 addresses of the other peers.
+func (p *Peer) SupportsIPv6() bool {
+	return p.IPv6 != nil && p.Meta.HasCapability(IPv6OverlayCapability)
+}
+
 // UpdateMetaIfNew updates peer's system metadata if new information is provided
 // returns true if meta was updated, false otherwise
 func (p *Peer) UpdateMetaIfNew(meta PeerSystemMeta) bool {
@@ -189,14 +241,15 @@ func (p *Peer) EventMeta(dnsDomain string) map[string]any {
 // Copy PeerStatus
 func (p *PeerStatus) Copy() *PeerStatus {
 	return &PeerStatus{
-		LastSeen:     p.LastSeen,
-		Connected:    p.Connected,
-		LoginExpired: p.LoginExpired,
+		LastSeen:         p.LastSeen,
+		Connected:        p.Connected,
+		LoginExpired:     p.LoginExpired,
+		RequiresApproval: p.RequiresApproval,
 	}
 }
 
 // GetPeers returns a list of peers under the given account filtering out peers that do not belong to a user if
-// the current user is not an admin.
+// the current user is a regular user.
 func (am *DefaultAccountManager) GetPeers(accountID, userID string) ([]*Peer, error) {
 	account, err := am.Store.GetAccount(accountID)
 	if err != nil {
@@ -211,8 +264,8 @@ func (am *DefaultAccountManager) GetPeers(accountID, userID string) ([]*Peer, er
 	peers := make([]*Peer, 0)
 	peersMap := make(map[string]*Peer)
 	for _, peer := range account.Peers {
-		if !user.IsAdmin() && user.Id != peer.UserID {
-			// only display peers that belong to the current user if the current user is not an admin
+		if !user.HasReadAllAccess() && user.Id != peer.UserID {
+			// only display peers that belong to the current user if the current user is a regular user
 			continue
 		}
 		p := peer.Copy()
@@ -280,7 +333,7 @@ func (am *DefaultAccountManager) MarkPeerConnected(peerPubKey string, connected
 	if oldStatus.LoginExpired {
 		// we need to update other peers because when peer login expires all other peers are notified to disconnect from
 		// the expired one. Here we notify them that connection is now allowed again.
-		am.updateAccountPeers(account)
+		am.updatePeersAffectedByPeers(account, peer.ID)
 	}
 
 	return nil
@@ -296,6 +349,15 @@ func (am *DefaultAccountManager) UpdatePeer(accountID, userID string, update *Pe
 		return nil, err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAdmin() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins are allowed to update peers")
+	}
+
 	peer := account.GetPeer(update.ID)
 	if peer == nil {
 		return nil, status.Errorf(status.NotFound, "peer %s not found", update.ID)
@@ -310,8 +372,10 @@ func (am *DefaultAccountManager) UpdatePeer(accountID, userID string, update *Pe
 		am.storeEvent(userID, peer.IP.String(), accountID, event, peer.EventMeta(am.GetDNSDomain()))
 	}
 
+	renamed := false
 	if peer.Name != update.Name {
 		peer.Name = update.Name
+		renamed = true
 
 		existingLabels := account.getPeerDNSLabels()
 
@@ -344,14 +408,43 @@ func (am *DefaultAccountManager) UpdatePeer(accountID, userID string, update *Pe
 		}
 	}
 
+	readdressed := false
+	if update.IP != nil && !update.IP.Equal(peer.IP) {
+		var takenIps []net.IP
+		for _, ip := range account.getTakenIPs() {
+			if !ip.Equal(peer.IP) {
+				takenIps = append(takenIps, ip)
+			}
+		}
+
+		err = validatePeerIP(account.Network.Net, update.IP, takenIps)
+		if err != nil {
+			return nil, err
+		}
+
+		oldIP := peer.IP
+		peer.IP = update.IP.To4()
+		readdressed = true
+		account.Network.IncSerial()
+
+		meta := peer.EventMeta(am.GetDNSDomain())
+		meta["old_ip"] = oldIP.String()
+		am.storeEvent(userID, peer.ID, accountID, activity.PeerIPUpdated, meta)
+	}
+
 	account.UpdatePeer(peer)
 
-	err = am.Store.SaveAccount(account)
+	err = am.Store.SaveAccountPeer(account, peer.ID, nil, "")
 	if err != nil {
 		return nil, err
 	}
 
-	am.updateAccountPeers(account)
+	if renamed || readdressed {
+		// the DNS records of the peer are part of the network maps of all the peers
+		am.updateAccountPeers(account)
+	} else {
+		am.updatePeersAffectedByPeers(account, peer.ID)
+	}
 
 	return peer, nil
 }
@@ -374,29 +467,37 @@ func (am *DefaultAccountManager) deletePeers(account *Account, peerIDs []string,
 	// the 2nd loop performs the actual modification
 	for _, peer := range peers {
 		account.DeletePeer(peer.ID)
-		am.peersUpdateManager.SendUpdate(peer.ID,
-			&UpdateMessage{
-				Update: &proto.SyncResponse{
-					// fill those field for backward compatibility
-					RemotePeers:        []*proto.RemotePeerConfig{},
-					RemotePeersIsEmpty: true,
-					// new field
-					NetworkMap: &proto.NetworkMap{
-						Serial:               account.Network.CurrentSerial(),
-						RemotePeers:          []*proto.RemotePeerConfig{},
-						RemotePeersIsEmpty:   true,
-						FirewallRules:        []*proto.FirewallRule{},
-						FirewallRulesIsEmpty: true,
-					},
-				},
-			})
-		am.peersUpdateManager.CloseChannel(peer.ID)
+		am.disconnectDeletedPeer(peer.ID, account.Network.CurrentSerial())
 		am.storeEvent(userID, peer.ID, account.Id, activity.PeerRemovedByUser, peer.EventMeta(am.GetDNSDomain()))
 	}
 
+	// the deleted peers might be connected to other replicas
+	am.publishAccountChange(account.Id, peerIDs)
+
 	return nil
 }
 
+// disconnectDeletedPeer sends an empty network map to a deleted peer and closes its updates channel
+func (am *DefaultAccountManager) disconnectDeletedPeer(peerID string, serial uint64) {
+	am.peersUpdateManager.SendUpdate(peerID,
+		&UpdateMessage{
+			Update: &proto.SyncResponse{
+				// fill those field for backward compatibility
+				RemotePeers:        []*proto.RemotePeerConfig{},
+				RemotePeersIsEmpty: true,
+				// new field
+				NetworkMap: &proto.NetworkMap{
+					Serial:               serial,
+					RemotePeers:          []*proto.RemotePeerConfig{},
+					RemotePeersIsEmpty:   true,
+					FirewallRules:        []*proto.FirewallRule{},
+					FirewallRulesIsEmpty: true,
+				},
+			},
+		})
+	am.peersUpdateManager.CloseChannel(peerID)
+}
+
 // DeletePeer removes peer from the account by its IP
 func (am *DefaultAccountManager) DeletePeer(accountID, peerID, userID string) error {
 	unlock := am.Store.AcquireAccountLock(accountID)
@@ -407,6 +508,106 @@ func (am *DefaultAccountManager) DeletePeer(accountID, peerID, userID string) er
 		return err
 	}
 
+	// the expired ephemeral peers are deleted by the system
+	if userID != activity.SystemInitiator {
+		user, err := account.FindUser(userID)
+		if err != nil {
+			return err
+		}
+
+		if !user.IsAdmin() {
+			return status.Errorf(status.PermissionDenied, "only admins are allowed to delete peers")
+		}
+	}
+
+	err = am.deletePeers(account, []string{peerID}, userID)
+	if err != nil {
+		return err
+	}
+
+	err = am.Store.SaveAccount(account)
+	if err != nil {
+		return err
+	}
+
+	am.updateAccountPeers(account)
+
+	return nil
+}
+
+// ApprovePeer approves a peer pending approval and connects it to the rest of the network
+func (am *DefaultAccountManager) ApprovePeer(accountID, userID, peerID string) (*Peer, error) {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAdmin() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins are allowed to approve peers")
+	}
+
+	peer := account.GetPeer(peerID)
+	if peer == nil {
+		return nil, status.Errorf(status.NotFound, "peer %s not found", peerID)
+	}
+
+	if !peer.PendingApproval() {
+		return nil, status.Errorf(status.PreconditionFailed, "peer %s is not pending approval", peerID)
+	}
+
+	peer.Status.RequiresApproval = false
+	account.UpdatePeer(peer)
+	account.Network.IncSerial()
+	err = am.Store.SaveAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	am.storeEvent(userID, peer.ID, accountID, activity.PeerApproved, peer.EventMeta(am.GetDNSDomain()))
+
+	am.updateAccountPeers(account)
+
+	return peer, nil
+}
+
+// RejectPeer rejects a peer pending approval and removes it from the account
+func (am *DefaultAccountManager) RejectPeer(accountID, userID, peerID string) error {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsAdmin() {
+		return status.Errorf(status.PermissionDenied, "only admins are allowed to reject peers")
+	}
+
+	peer := account.GetPeer(peerID)
+	if peer == nil {
+		return status.Errorf(status.NotFound, "peer %s not found", peerID)
+	}
+
+	if !peer.PendingApproval() {
+		return status.Errorf(status.PreconditionFailed, "peer %s is not pending approval", peerID)
+	}
+
+	am.storeEvent(userID, peer.ID, accountID, activity.PeerRejected, peer.EventMeta(am.GetDNSDomain()))
+
 	err = am.deletePeers(account, []string{peerID}, userID)
 	if err != nil {
 		return err
@@ -498,6 +699,7 @@ func (am *DefaultAccountManager) AddPeer(setupKey, userID string, peer *Peer) (*
 	}
 
 	var ephemeral bool
+	var reservedIP net.IP
 	if !addedByUser {
 		// validate the setup key if adding with a key
 		sk, err := account.FindSetupKey(upperKey)
@@ -513,6 +715,7 @@ func (am *DefaultAccountManager) AddPeer(setupKey, userID string, peer *Peer) (*
 		opEvent.InitiatorID = sk.Id
 		opEvent.Activity = activity.PeerAddedWithSetupKey
 		ephemeral = sk.Ephemeral
+		reservedIP = sk.ReservedIP
 	} else {
 		opEvent.InitiatorID = userID
 		opEvent.Activity = activity.PeerAddedByUser
@@ -528,9 +731,27 @@ func (am *DefaultAccountManager) AddPeer(setupKey, userID string, peer *Peer) (*
 
 	peer.DNSLabel = newLabel
 	network := account.Network
-	nextIp, err := AllocatePeerIP(network.Net, takenIps)
-	if err != nil {
-		return nil, nil, err
+	var nextIp net.IP
+	if reservedIP != nil {
+		// the reservation of the key is released by its usage, so the IP is taken only if another resource holds it
+		err = validatePeerIP(network.Net, reservedIP, takenIps)
+		if err != nil {
+			return nil, nil, status.Errorf(status.PreconditionFailed, "couldn't add peer with the IP reserved by the setup key: %v", err)
+		}
+		nextIp = reservedIP
+	} else {
+		nextIp, err = AllocatePeerIP(network.Net, takenIps)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var nextIPv6 net.IP
+	if network.HasIPv6() {
+		nextIPv6, err = AllocatePeerIPv6(network.NetV6, account.getTakenIPv6s())
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	newPeer := &Peer{
@@ -538,11 +759,12 @@ func (am *DefaultAccountManager) AddPeer(setupKey, userID string, peer *Peer) (*
 		Key:                    peer.Key,
 		SetupKey:               upperKey,
 		IP:                     nextIp,
+		IPv6:                   nextIPv6,
 		Meta:                   peer.Meta,
 		Name:                   peer.Meta.Hostname,
 		DNSLabel:               newLabel,
 		UserID:                 userID,
-		Status:                 &PeerStatus{Connected: false, LastSeen: time.Now().UTC()},
+		Status:                 &PeerStatus{Connected: false, LastSeen: time.Now().UTC(), RequiresApproval: account.Settings.PeerApprovalEnabled},
 		SSHEnabled:             false,
 		SSHKey:                 peer.SSHKey,
 		LastLogin:              time.Now().UTC(),
@@ -556,6 +778,7 @@ func (am *DefaultAccountManager) AddPeer(setupKey, userID string, peer *Peer) (*
 		return nil, nil, err
 	}
 	group.Peers = append(group.Peers, newPeer.ID)
+	changedGroups := []string{group.ID}
 
 	var groupsToAdd []string
 	if addedByUser {
@@ -574,13 +797,19 @@ func (am *DefaultAccountManager) AddPeer(setupKey, userID string, peer *Peer) (*
 		for _, s := range groupsToAdd {
 			if g, ok := account.Groups[s]; ok && g.Name != "All" {
 				g.Peers = append(g.Peers, newPeer.ID)
+				changedGroups = append(changedGroups, g.ID)
 			}
 		}
 	}
 
+	usedSetupKey := ""
+	if !addedByUser {
+		usedSetupKey = upperKey
+	}
+
 	account.Peers[newPeer.ID] = newPeer
 	account.Network.IncSerial()
-	err = am.Store.SaveAccount(account)
+	err = am.Store.SaveAccountPeer(account, newPeer.ID, changedGroups, usedSetupKey)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -689,6 +918,10 @@ func (am *DefaultAccountManager) LoginPeer(login PeerLogin) (*Peer, *NetworkMap,
 	peer, updated := updatePeerMeta(peer, login.Meta, account)
 	if updated {
 		shouldStoreAccount = true
+		// a new client version or OS update can change the peer's posture checks compliance
+		if len(account.PostureChecks) > 0 {
+			updateRemotePeers = true
+		}
 	}
 
 	peer, err = am.checkAndUpdatePeerSSHKey(peer, account, login.SSHKey)
@@ -697,14 +930,14 @@ func (am *DefaultAccountManager) LoginPeer(login PeerLogin) (*Peer, *NetworkMap,
 	}
 
 	if shouldStoreAccount {
-		err = am.Store.SaveAccount(account)
+		err = am.Store.SaveAccountPeer(account, peer.ID, nil, "")
 		if err != nil {
 			return nil, nil, err
 		}
 	}
 
 	if updateRemotePeers {
-		am.updateAccountPeers(account)
+		am.updatePeersAffectedByPeers(account, peer.ID)
 	}
 	return peer, account.GetPeerNetworkMap(peer.ID, am.dnsDomain), nil
 }
@@ -772,13 +1005,13 @@ func (am *DefaultAccountManager) checkAndUpdatePeerSSHKey(peer *Peer, account *A
 	peer.SSHKey = newSSHKey
 	account.UpdatePeer(peer)
 
-	err := am.Store.SaveAccount(account)
+	err := am.Store.SaveAccountPeer(account, peer.ID, nil, "")
 	if err != nil {
 		return nil, err
 	}
 
 	// trigger network map update
-	am.updateAccountPeers(account)
+	am.updatePeersAffectedByPeers(account, peer.ID)
 
 	return peer, nil
 }
@@ -817,13 +1050,13 @@ func (am *DefaultAccountManager) UpdatePeerSSHKey(peerID string, sshKey string)
 	peer.SSHKey = sshKey
 	account.UpdatePeer(peer)
 
-	err = am.Store.SaveAccount(account)
+	err = am.Store.SaveAccountPeer(account, peer.ID, nil, "")
 	if err != nil {
 		return err
 	}
 
 	// trigger network map update
-	am.updateAccountPeers(account)
+	am.updatePeersAffectedByPeers(account, peer.ID)
 
 	return nil
 }
@@ -848,8 +1081,8 @@ func (am *DefaultAccountManager) GetPeer(accountID, peerID, userID string) (*Pee
 		return nil, status.Errorf(status.NotFound, "peer with %s not found under account %s", peerID, accountID)
 	}
 
-	// if admin or user owns this peer, return peer
-	if user.IsAdmin() || peer.UserID == userID {
+	// if user can view all peers or owns this peer, return peer
+	if user.HasReadAllAccess() || peer.UserID == userID {
 		return peer, nil
 	}
 
@@ -879,15 +1112,3 @@ func updatePeerMeta(peer *Peer, meta PeerSystemMeta, account *Account) (*Peer, b
 	}
 	return peer, false
 }
-
-// updateAccountPeers updates all peers that belong to an account.
-// Should be called when changes have to be synced to peers.
-func (am *DefaultAccountManager) updateAccountPeers(account *Account) {
-	peers := account.GetPeers()
-
-	for _, peer := range peers {
-		remotePeerNetworkMap := account.GetPeerNetworkMap(peer.ID, am.dnsDomain)
-		update := toSyncResponse(nil, peer, nil, remotePeerNetworkMap, am.GetDNSDomain())
-		am.peersUpdateManager.SendUpdate(peer.ID, &UpdateMessage{Update: update})
-	}
-}
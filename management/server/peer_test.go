@@ -1,13 +1,17 @@
 package server
 
 import (
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/rs/xid"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/netbirdio/netbird/management/server/activity"
 )
 
 func TestPeer_LoginExpired(t *testing.T) {
@@ -78,7 +82,7 @@ func TestAccountManager_GetNetworkMap(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userId, false)
+	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userId, false, nil)
 	if err != nil {
 		t.Fatal("error creating setup key")
 		return
@@ -327,7 +331,7 @@ func TestAccountManager_GetPeerNetwork(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userId, false)
+	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userId, false, nil)
 	if err != nil {
 		t.Fatal("error creating setup key")
 		return
@@ -397,7 +401,7 @@ func TestDefaultAccountManager_GetPeer(t *testing.T) {
 	}
 
 	// two peers one added by a regular user and one with a setup key
-	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, adminUser, false)
+	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, adminUser, false, nil)
 	if err != nil {
 		t.Fatal("error creating setup key")
 		return
@@ -478,3 +482,154 @@ func TestDefaultAccountManager_GetPeer(t *testing.T) {
 	}
 	assert.NotNil(t, peer)
 }
+
+func TestDefaultAccountManager_PeerApproval(t *testing.T) {
+	manager, err := createManager(t)
+	require.NoError(t, err)
+
+	userID := "account_creator"
+	account, err := createAccount(manager, "test_account", userID, "")
+	require.NoError(t, err)
+
+	setupKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userID, false, nil)
+	require.NoError(t, err)
+
+	addPeer := func(hostname string) *Peer {
+		key, err := wgtypes.GeneratePrivateKey()
+		require.NoError(t, err)
+		peer, _, err := manager.AddPeer(setupKey.Key, "", &Peer{
+			Key:  key.PublicKey().String(),
+			Meta: PeerSystemMeta{Hostname: hostname},
+		})
+		require.NoError(t, err)
+		return peer
+	}
+
+	approvedPeer := addPeer("approved-peer")
+	assert.False(t, approvedPeer.PendingApproval(), "peers added without the approval setting should not be pending")
+
+	_, err = manager.UpdateAccountSettings(account.Id, userID, &Settings{
+		PeerLoginExpiration: time.Hour,
+		PeerApprovalEnabled: true,
+	})
+	require.NoError(t, err)
+	getEvent(t, account.Id, userID, manager, activity.AccountPeerApprovalEnabled)
+
+	pendingPeer := addPeer("pending-peer")
+	assert.True(t, pendingPeer.PendingApproval(), "new peers should be pending approval")
+
+	networkMap, err := manager.GetNetworkMap(pendingPeer.ID)
+	require.NoError(t, err)
+	assert.Empty(t, networkMap.Peers, "the pending peer should receive an empty network map")
+	assert.Empty(t, networkMap.FirewallRules)
+
+	networkMap, err = manager.GetNetworkMap(approvedPeer.ID)
+	require.NoError(t, err)
+	assert.Empty(t, networkMap.Peers, "the pending peer should be excluded from the network maps of other peers")
+
+	stored, err := manager.Store.GetAccount(account.Id)
+	require.NoError(t, err)
+	zone := getPeersCustomZone(stored, "netbird.cloud")
+	require.NotEmpty(t, zone.Records)
+	for _, record := range zone.Records {
+		assert.NotEqual(t, pendingPeer.IP.String(), record.RData, "the pending peer should not have DNS records")
+	}
+
+	approved, err := manager.ApprovePeer(account.Id, userID, pendingPeer.ID)
+	require.NoError(t, err)
+	assert.False(t, approved.PendingApproval())
+	getEvent(t, account.Id, userID, manager, activity.PeerApproved)
+
+	networkMap, err = manager.GetNetworkMap(approvedPeer.ID)
+	require.NoError(t, err)
+	require.Len(t, networkMap.Peers, 1)
+	assert.Equal(t, pendingPeer.ID, networkMap.Peers[0].ID)
+
+	_, err = manager.ApprovePeer(account.Id, userID, pendingPeer.ID)
+	assert.Error(t, err, "approving a peer which is not pending should fail")
+
+	rejectedPeer := addPeer("rejected-peer")
+	require.NoError(t, manager.RejectPeer(account.Id, userID, rejectedPeer.ID))
+	getEvent(t, account.Id, userID, manager, activity.PeerRejected)
+
+	stored, err = manager.Store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.Nil(t, stored.GetPeer(rejectedPeer.ID), "the rejected peer should be removed")
+}
+
+func TestDefaultAccountManager_ReservedPeerIP(t *testing.T) {
+	manager, err := createManager(t)
+	require.NoError(t, err)
+
+	userID := "account_creator"
+	account, err := createAccount(manager, "test_account", userID, "")
+	require.NoError(t, err)
+
+	reservedIP := reservedTestIP(account.Network.Net, 20)
+	setupKey, err := manager.CreateSetupKey(account.Id, "reserved-key", SetupKeyOneOff, time.Hour, nil, 0, userID, false, reservedIP)
+	require.NoError(t, err)
+	assert.True(t, reservedIP.Equal(setupKey.ReservedIP))
+
+	_, err = manager.CreateSetupKey(account.Id, "duplicate-key", SetupKeyOneOff, time.Hour, nil, 0, userID, false, reservedIP)
+	assert.Error(t, err, "the IP reserved by a valid setup key should be taken")
+
+	_, err = manager.CreateSetupKey(account.Id, "reusable-key", SetupKeyReusable, time.Hour, nil, 0, userID, false,
+		reservedTestIP(account.Network.Net, 21))
+	assert.Error(t, err, "a reusable key without the usage limit should not reserve an IP")
+
+	reusableKey, err := manager.CreateSetupKey(account.Id, "test-key", SetupKeyReusable, time.Hour, nil, 999, userID, false, nil)
+	require.NoError(t, err)
+
+	addPeer := func(key, hostname string) (*Peer, error) {
+		wgKey, err := wgtypes.GeneratePrivateKey()
+		require.NoError(t, err)
+		peer, _, err := manager.AddPeer(key, "", &Peer{
+			Key:  wgKey.PublicKey().String(),
+			Meta: PeerSystemMeta{Hostname: hostname},
+		})
+		return peer, err
+	}
+
+	peer1, err := addPeer(setupKey.Key, "peer-1")
+	require.NoError(t, err)
+	assert.True(t, reservedIP.Equal(peer1.IP), "the peer should get the IP reserved by the setup key")
+
+	peer2, err := addPeer(reusableKey.Key, "peer-2")
+	require.NoError(t, err)
+
+	updMsg := manager.peersUpdateManager.CreateChannel(peer2.ID)
+	defer manager.peersUpdateManager.CloseChannel(peer2.ID)
+
+	_, err = manager.UpdatePeer(account.Id, userID, &Peer{ID: peer2.ID, Name: peer2.Name, IP: peer1.IP})
+	assert.Error(t, err, "the IP of another peer should be rejected")
+
+	newIP := reservedTestIP(account.Network.Net, 30)
+	updated, err := manager.UpdatePeer(account.Id, userID, &Peer{ID: peer2.ID, Name: peer2.Name, IP: newIP})
+	require.NoError(t, err)
+	assert.True(t, newIP.Equal(updated.IP))
+	getEvent(t, account.Id, userID, manager, activity.PeerIPUpdated)
+
+	select {
+	case message := <-updMsg:
+		assert.Equal(t, newIP.String()+"/16", message.Update.GetNetworkMap().GetPeerConfig().GetAddress(),
+			"the new address should be pushed to the peer")
+	case <-time.After(time.Second):
+		t.Fatal("the peer should receive an update")
+	}
+
+	networkMap, err := manager.GetNetworkMap(peer1.ID)
+	require.NoError(t, err)
+	require.Len(t, networkMap.Peers, 1)
+	assert.True(t, newIP.Equal(networkMap.Peers[0].IP), "the neighbors should get the new address")
+
+	// the same IP doesn't change the peer
+	_, err = manager.UpdatePeer(account.Id, userID, &Peer{ID: peer2.ID, Name: peer2.Name, IP: newIP})
+	require.NoError(t, err)
+}
+
+// reservedTestIP returns the IP with the given last byte in the first /24 of the network
+func reservedTestIP(ipNet net.IPNet, last byte) net.IP {
+	ip := copyIP(ipNet.IP.To4())
+	ip[3] = last
+	return ip
+}
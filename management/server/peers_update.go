@@ -0,0 +1,242 @@
+package server
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// pendingPeersUpdate is a network map fan-out of an account waiting for the end of the update window
+type pendingPeersUpdate struct {
+	// all is true when all the peers of the account have to be updated
+	all bool
+	// peers are the IDs of the peers to update when all is false
+	peers lookupMap
+	// change is the change set of the coalesced changes, nil if it isn't known
+	change *networkMapChange
+	// changes is the number of account changes coalesced in the update
+	changes int
+	// since is the time of the first coalesced change
+	since time.Time
+}
+
+// updateAccountPeers updates all peers that belong to an account.
+// Should be called when changes have to be synced to peers.
+func (am *DefaultAccountManager) updateAccountPeers(account *Account) {
+	am.publishAccountChange(account.Id, nil)
+	am.schedulePeersUpdate(account, nil, nil)
+}
+
+// updateAffectedPeers updates only the given peers of an account.
+// Should be called instead of updateAccountPeers when a change affects a known subset of the peers,
+// see the getPeersAffectedBy* functions of the Account. The change must not modify the peers themselves nor their
+// DNS configuration, the network map deltas hold the given peers only.
+func (am *DefaultAccountManager) updateAffectedPeers(account *Account, peerIDs lookupMap) {
+	am.updatePeers(account, peerIDs, newNetworkMapChange(peerIDs, false, false))
+}
+
+// updateAffectedPeersDNS is updateAffectedPeers for the changes that may modify the DNS configuration of the peers,
+// e.g. a change of their groups
+func (am *DefaultAccountManager) updateAffectedPeersDNS(account *Account, peerIDs lookupMap) {
+	am.updatePeers(account, peerIDs, newNetworkMapChange(peerIDs, false, true))
+}
+
+// updatePeersAffectedByPeers updates the peers affected by a change of the given peers that doesn't modify the DNS
+// records of the account, see getPeersAffectedByPeer. The network map deltas hold the changed peers only.
+func (am *DefaultAccountManager) updatePeersAffectedByPeers(account *Account, peerIDs ...string) {
+	affectedPeers := make(lookupMap)
+	changedPeers := make(lookupMap, len(peerIDs))
+	for _, peerID := range peerIDs {
+		affectedPeers.add(account.getPeersAffectedByPeer(peerID))
+		changedPeers[peerID] = struct{}{}
+	}
+	am.updatePeers(account, affectedPeers, newNetworkMapChange(changedPeers, true, false))
+}
+
+// newNetworkMapChange returns the change set of the given peers, changed is true if the peers themselves changed
+func newNetworkMapChange(peerIDs lookupMap, changed bool, dns bool) *networkMapChange {
+	change := &networkMapChange{
+		peers: make(map[string]bool, len(peerIDs)),
+		dns:   dns,
+	}
+	for peerID := range peerIDs {
+		change.peers[peerID] = changed
+	}
+	return change
+}
+
+func (am *DefaultAccountManager) updatePeers(account *Account, peerIDs lookupMap, change *networkMapChange) {
+	if len(peerIDs) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(peerIDs))
+	for peerID := range peerIDs {
+		ids = append(ids, peerID)
+	}
+	am.publishAccountChange(account.Id, ids)
+	am.schedulePeersUpdate(account, peerIDs, change)
+}
+
+// publishAccountChange notifies the other management replicas, if any, that the given peers of the account have to be
+// updated. A nil peerIDs means all the peers of the account
+func (am *DefaultAccountManager) publishAccountChange(accountID string, peerIDs []string) {
+	if am.accountChanges == nil {
+		return
+	}
+	if err := am.accountChanges.PublishAccountChange(accountID, peerIDs); err != nil {
+		log.Errorf("failed to publish the change of account %s to the other replicas: %v", accountID, err)
+	}
+}
+
+// handleAccountChange updates the peers connected to this replica after a change of the account by another replica.
+// A nil peerIDs means all the peers of the account
+func (am *DefaultAccountManager) handleAccountChange(accountID string, peerIDs []string) {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		log.Errorf("failed to get account %s changed by another replica: %v", accountID, err)
+		return
+	}
+
+	// the change set isn't known beyond the updated peers
+	var change *networkMapChange
+	if peerIDs == nil {
+		for peerID := range account.Peers {
+			peerIDs = append(peerIDs, peerID)
+		}
+	} else {
+		change = &networkMapChange{peers: make(map[string]bool, len(peerIDs)), dns: true}
+		for _, peerID := range peerIDs {
+			change.peers[peerID] = false
+		}
+	}
+
+	connectedPeers := am.peersUpdateManager.GetAllConnectedPeers()
+	localPeers := make(lookupMap)
+	for _, peerID := range peerIDs {
+		if _, ok := connectedPeers[peerID]; !ok {
+			continue
+		}
+
+		peer := account.GetPeer(peerID)
+		switch {
+		case peer == nil:
+			am.disconnectDeletedPeer(peerID, account.Network.CurrentSerial())
+		case peer.Status.LoginExpired:
+			// the peer has to log in again
+			am.peersUpdateManager.CloseChannel(peerID)
+		default:
+			localPeers[peerID] = struct{}{}
+		}
+	}
+
+	if len(localPeers) > 0 {
+		am.schedulePeersUpdate(account, localPeers, change)
+	}
+}
+
+// schedulePeersUpdate sends the network maps to the peers right away when there is no update window.
+// Otherwise, the peers are added to the pending update of the account, which is sent at the end of the window
+// with the state of the account at that time. A nil peerIDs means all the peers of the account.
+// The change is the change set of the network map deltas, nil if it isn't known.
+// Should be called with the account lock held.
+func (am *DefaultAccountManager) schedulePeersUpdate(account *Account, peerIDs lookupMap, change *networkMapChange) {
+	if am.peersUpdateWindow <= 0 {
+		am.sendPeersUpdate(account, peerIDs, change)
+		return
+	}
+
+	am.pendingUpdatesMux.Lock()
+	defer am.pendingUpdatesMux.Unlock()
+
+	pending, ok := am.pendingUpdates[account.Id]
+	if !ok {
+		pending = &pendingPeersUpdate{peers: make(lookupMap), change: change, since: time.Now()}
+		am.pendingUpdates[account.Id] = pending
+		accountID := account.Id
+		time.AfterFunc(am.peersUpdateWindow, func() {
+			am.flushPeersUpdate(accountID)
+		})
+	}
+
+	if pending.changes > 0 {
+		pending.change = pending.change.merge(change)
+	}
+	pending.changes++
+	if peerIDs == nil {
+		pending.all = true
+		return
+	}
+	pending.peers.add(peerIDs)
+}
+
+// flushPeersUpdate sends the pending update of the account
+func (am *DefaultAccountManager) flushPeersUpdate(accountID string) {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	am.pendingUpdatesMux.Lock()
+	pending := am.pendingUpdates[accountID]
+	delete(am.pendingUpdates, accountID)
+	am.pendingUpdatesMux.Unlock()
+
+	if pending == nil {
+		return
+	}
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		log.Errorf("failed to get account %s to send the network map updates: %v", accountID, err)
+		return
+	}
+
+	peerIDs := pending.peers
+	if pending.all {
+		peerIDs = nil
+	}
+	updated := am.sendPeersUpdate(account, peerIDs, pending.change)
+
+	log.Debugf("sent %d network map updates of account %s for %d changes", updated, accountID, pending.changes)
+	if metrics := am.peersUpdateManager.updateChannelMetrics(); metrics != nil {
+		metrics.CountPeersUpdateBatch(pending.changes, updated, time.Since(pending.since))
+	}
+}
+
+// sendPeersUpdate computes and sends the network maps of the given peers of the account with the change set they result
+// from. A nil peerIDs means all the peers of the account. It returns the number of updated peers
+func (am *DefaultAccountManager) sendPeersUpdate(account *Account, peerIDs lookupMap, change *networkMapChange) int {
+	if peerIDs == nil {
+		for _, peer := range account.GetPeers() {
+			am.sendPeerUpdate(account, peer, nil)
+		}
+		return len(account.Peers)
+	}
+
+	updated := 0
+	for peerID := range peerIDs {
+		peer := account.GetPeer(peerID)
+		if peer == nil {
+			continue
+		}
+		am.sendPeerUpdate(account, peer, change)
+		updated++
+	}
+	return updated
+}
+
+func (am *DefaultAccountManager) sendPeerUpdate(account *Account, peer *Peer, change *networkMapChange) {
+	remotePeerNetworkMap := account.GetPeerNetworkMap(peer.ID, am.dnsDomain)
+	update := toSyncResponse(nil, peer, nil, remotePeerNetworkMap, am.GetDNSDomain())
+	am.peersUpdateManager.SendUpdate(peer.ID, &UpdateMessage{
+		Update: update,
+		networkMapUpdate: &networkMapUpdate{
+			peer:       peer,
+			networkMap: remotePeerNetworkMap,
+			dnsName:    am.GetDNSDomain(),
+			change:     change,
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultAccountManager_PeersUpdateWindow(t *testing.T) {
+	manager, err := createManager(t)
+	require.NoError(t, err)
+	manager.peersUpdateWindow = 100 * time.Millisecond
+
+	// 4 groups of 2 peers: group0 <-> group1 <-> group2 <-> group3 <-> group0
+	account := newSyntheticAccount(8, 2)
+	require.NoError(t, manager.Store.SaveAccount(account))
+
+	updates := make(map[string]chan *UpdateMessage)
+	for peerID := range account.Peers {
+		updates[peerID] = manager.peersUpdateManager.CreateChannel(peerID)
+	}
+
+	for _, peerID := range []string{"peer0", "peer1", "peer4", "peer5"} {
+		require.NoError(t, manager.GroupAddPeer(account.Id, "group2", peerID))
+	}
+
+	for peerID, ch := range updates {
+		assert.Empty(t, ch, "peer %s should not be updated before the end of the window", peerID)
+	}
+
+	account, err = manager.Store.GetAccount(account.Id)
+	require.NoError(t, err)
+	expectedSerial := account.Network.CurrentSerial()
+
+	time.Sleep(3 * manager.peersUpdateWindow)
+
+	for peerID, ch := range updates {
+		require.Len(t, ch, 1, "peer %s should receive a single update", peerID)
+		update := <-ch
+		assert.Equal(t, expectedSerial, update.Update.NetworkMap.Serial,
+			"peer %s should receive the network map of the last change", peerID)
+	}
+
+	manager.pendingUpdatesMux.Lock()
+	assert.Empty(t, manager.pendingUpdates, "the pending update should be sent")
+	manager.pendingUpdatesMux.Unlock()
+}
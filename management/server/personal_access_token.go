@@ -5,12 +5,14 @@ import (
 	b64 "encoding/base64"
 	"fmt"
 	"hash/crc32"
+	"strings"
 	"time"
 
 	b "github.com/hashicorp/go-secure-stdlib/base62"
 	"github.com/rs/xid"
 
 	"github.com/netbirdio/netbird/base62"
+	"github.com/netbirdio/netbird/management/server/status"
 )
 
 const (
@@ -22,32 +24,109 @@ const (
 	PATChecksumLength = 6
 	// PATLength total number of characters used for the token
 	PATLength = 40
+
+	// PATScopeRead is the scope action allowing to view a resource, e.g. peers:read
+	PATScopeRead = "read"
+	// PATScopeWrite is the scope action allowing to view and modify a resource, e.g. routes:write
+	PATScopeWrite = "write"
 )
 
+// PATScopeResources lists the API resources a personal access token can be scoped to
+var PATScopeResources = []string{
+	"accounts", "users", "peers", "setup-keys", "groups", "policies", "rules", "posture-checks", "routes", "dns", "events", "webhooks",
+}
+
 // PersonalAccessToken holds all information about a PAT including a hashed version of it for verification
 type PersonalAccessToken struct {
 	ID             string
 	Name           string
 	HashedToken    string
 	ExpirationDate time.Time
-	// scope could be added in future
+	// Scopes restrict the token to the listed resources and actions, e.g. peers:read.
+	// A token without scopes has all the permissions of its user
+	Scopes    []string
 	CreatedBy string
 	CreatedAt time.Time
 	LastUsed  time.Time
 }
 
 func (t *PersonalAccessToken) Copy() *PersonalAccessToken {
+	var scopes []string
+	if t.Scopes != nil {
+		scopes = make([]string, len(t.Scopes))
+		copy(scopes, t.Scopes)
+	}
 	return &PersonalAccessToken{
 		ID:             t.ID,
 		Name:           t.Name,
 		HashedToken:    t.HashedToken,
 		ExpirationDate: t.ExpirationDate,
+		Scopes:         scopes,
 		CreatedBy:      t.CreatedBy,
 		CreatedAt:      t.CreatedAt,
 		LastUsed:       t.LastUsed,
 	}
 }
 
+// HasScope returns true if the token is allowed to access the resource. The write scope implies the read scope
+func (t *PersonalAccessToken) HasScope(resource string, write bool) bool {
+	if len(t.Scopes) == 0 {
+		return true
+	}
+
+	for _, scope := range t.Scopes {
+		scopeResource, action, _ := strings.Cut(scope, ":")
+		if scopeResource != resource {
+			continue
+		}
+		if action == PATScopeWrite || !write {
+			return true
+		}
+	}
+	return false
+}
+
+// CoversScopes returns true if the token grants all the permissions of the scopes, so a token created with it
+// can't get more permissions than it has. Empty scopes stand for all the permissions of the user
+func (t *PersonalAccessToken) CoversScopes(scopes []string) bool {
+	if len(t.Scopes) == 0 {
+		return true
+	}
+	if len(scopes) == 0 {
+		return false
+	}
+
+	for _, scope := range scopes {
+		resource, action, _ := strings.Cut(scope, ":")
+		if !t.HasScope(resource, action == PATScopeWrite) {
+			return false
+		}
+	}
+	return true
+}
+
+// validatePATScopes checks that the scopes are in the resource:action form with a known resource and action
+func validatePATScopes(scopes []string) error {
+	for _, scope := range scopes {
+		resource, action, found := strings.Cut(scope, ":")
+		if !found || (action != PATScopeRead && action != PATScopeWrite) {
+			return status.Errorf(status.InvalidArgument, "invalid scope %q, expected resource:read or resource:write", scope)
+		}
+
+		known := false
+		for _, r := range PATScopeResources {
+			if r == resource {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return status.Errorf(status.InvalidArgument, "invalid scope %q, unknown resource %s", scope, resource)
+		}
+	}
+	return nil
+}
+
 // PersonalAccessTokenGenerated holds the new PersonalAccessToken and the plain text version of it
 type PersonalAccessTokenGenerated struct {
 	PlainToken string
@@ -56,7 +135,7 @@ type PersonalAccessTokenGenerated struct {
 
 // CreateNewPAT will generate a new PersonalAccessToken that can be assigned to a User.
 // Additionally, it will return the token in plain text once, to give to the user and only save a hashed version
-func CreateNewPAT(name string, expirationInDays int, createdBy string) (*PersonalAccessTokenGenerated, error) {
+func CreateNewPAT(name string, expirationInDays int, scopes []string, createdBy string) (*PersonalAccessTokenGenerated, error) {
 	hashedToken, plainToken, err := generateNewToken()
 	if err != nil {
 		return nil, err
@@ -68,6 +147,7 @@ func CreateNewPAT(name string, expirationInDays int, createdBy string) (*Persona
 			Name:           name,
 			HashedToken:    hashedToken,
 			ExpirationDate: currentTime.AddDate(0, 0, expirationInDays),
+			Scopes:         scopes,
 			CreatedBy:      createdBy,
 			CreatedAt:      currentTime,
 			LastUsed:       time.Time{},
@@ -44,3 +44,36 @@ func TestPAT_GenerateToken_Checksum(t *testing.T) {
 	}
 	assert.Equal(t, expectedChecksum, actualChecksum)
 }
+
+func TestPAT_HasScope(t *testing.T) {
+	unscoped := &PersonalAccessToken{}
+	assert.True(t, unscoped.HasScope("users", true), "a token without scopes should have full access")
+
+	pat := &PersonalAccessToken{Scopes: []string{"peers:read", "routes:write"}}
+	assert.True(t, pat.HasScope("peers", false))
+	assert.False(t, pat.HasScope("peers", true))
+	assert.True(t, pat.HasScope("routes", false), "the write scope should imply the read scope")
+	assert.True(t, pat.HasScope("routes", true))
+	assert.False(t, pat.HasScope("events", false))
+}
+
+func TestPAT_ValidateScopes(t *testing.T) {
+	assert.NoError(t, validatePATScopes(nil))
+	assert.NoError(t, validatePATScopes([]string{"peers:read", "setup-keys:write", "events:read", "webhooks:write"}))
+	assert.Error(t, validatePATScopes([]string{"peers"}))
+	assert.Error(t, validatePATScopes([]string{"peers:delete"}))
+	assert.Error(t, validatePATScopes([]string{"unknown:read"}))
+}
+
+func TestPAT_CoversScopes(t *testing.T) {
+	unscoped := &PersonalAccessToken{}
+	assert.True(t, unscoped.CoversScopes(nil), "a token without scopes should create tokens with full access")
+	assert.True(t, unscoped.CoversScopes([]string{"users:write"}))
+
+	pat := &PersonalAccessToken{Scopes: []string{"peers:read", "routes:write"}}
+	assert.False(t, pat.CoversScopes(nil), "a scoped token should not create a token with full access")
+	assert.True(t, pat.CoversScopes([]string{"peers:read"}))
+	assert.True(t, pat.CoversScopes([]string{"routes:read", "routes:write"}))
+	assert.False(t, pat.CoversScopes([]string{"peers:write"}))
+	assert.False(t, pat.CoversScopes([]string{"peers:read", "users:read"}))
+}
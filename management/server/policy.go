@@ -142,6 +142,9 @@ type Policy struct {
 
 	// Rules of the policy
 	Rules []*PolicyRule
+
+	// SourcePostureChecks are the IDs of the posture checks the source peers of the policy have to satisfy
+	SourcePostureChecks []string
 }
 
 // Copy returns a copy of the policy.
@@ -156,6 +159,10 @@ func (p *Policy) Copy() *Policy {
 	for i, r := range p.Rules {
 		c.Rules[i] = r.Copy()
 	}
+	if p.SourcePostureChecks != nil {
+		c.SourcePostureChecks = make([]string, len(p.SourcePostureChecks))
+		copy(c.SourcePostureChecks, p.SourcePostureChecks)
+	}
 	return c
 }
 
@@ -200,7 +207,8 @@ type FirewallRule struct {
 //
 // This function returns the list of peers and firewall rules that are applicable to a given peer.
 func (a *Account) getPeerConnectionResources(peerID string) ([]*Peer, []*FirewallRule) {
-	generateResources, getAccumulatedResources := a.connResourcesGenerator()
+	peer := a.GetPeer(peerID)
+	generateResources, getAccumulatedResources := a.connResourcesGenerator(peer != nil && peer.SupportsIPv6())
 
 	for _, policy := range a.Policies {
 		if !policy.Enabled {
@@ -215,6 +223,14 @@ func (a *Account) getPeerConnectionResources(peerID string) ([]*Peer, []*Firewal
 			sourcePeers, peerInSources := getAllPeersFromGroups(a, rule.Sources, peerID)
 			destinationPeers, peerInDestinations := getAllPeersFromGroups(a, rule.Destinations, peerID)
 
+			// non-compliant source peers are neither reachable through the policy nor able to reach its destinations
+			if len(policy.SourcePostureChecks) > 0 {
+				sourcePeers = a.filterPostureCompliantPeers(sourcePeers, policy.SourcePostureChecks)
+				if peerInSources && !a.isPeerPostureCompliant(a.GetPeer(peerID), policy.SourcePostureChecks) {
+					peerInSources = false
+				}
+			}
+
 			if rule.Bidirectional {
 				if peerInSources {
 					generateResources(rule, destinationPeers, firewallRuleDirectionIN)
@@ -242,7 +258,8 @@ func (a *Account) getPeerConnectionResources(peerID string) ([]*Peer, []*Firewal
 // The generator function is used to generate the list of peers and firewall rules that are applicable to a given peer.
 // It safe to call the generator function multiple times for same peer and different rules no duplicates will be
 // generated. The accumulator function returns the result of all the generator calls.
-func (a *Account) connResourcesGenerator() (func(*PolicyRule, []*Peer, int), func() ([]*Peer, []*FirewallRule)) {
+// Rules for the IPv6 overlay addresses are generated only if ipv6 is true.
+func (a *Account) connResourcesGenerator(ipv6 bool) (func(*PolicyRule, []*Peer, int), func() ([]*Peer, []*FirewallRule)) {
 	rulesExists := make(map[string]struct{})
 	peersExists := make(map[string]struct{})
 	rules := make([]*FirewallRule, 0)
@@ -265,33 +282,43 @@ func (a *Account) connResourcesGenerator() (func(*PolicyRule, []*Peer, int), fun
 					peersExists[peer.ID] = struct{}{}
 				}
 
-				fr := FirewallRule{
-					PeerIP:    peer.IP.String(),
-					Direction: direction,
-					Action:    string(rule.Action),
-					Protocol:  string(rule.Protocol),
-				}
-
+				peerIPs := []string{peer.IP.String()}
 				if isAll {
-					fr.PeerIP = "0.0.0.0"
+					peerIPs[0] = "0.0.0.0"
 				}
-
-				ruleID := (rule.ID + fr.PeerIP + strconv.Itoa(direction) +
-					fr.Protocol + fr.Action + strings.Join(rule.Ports, ","))
-				if _, ok := rulesExists[ruleID]; ok {
-					continue
-				}
-				rulesExists[ruleID] = struct{}{}
-
-				if len(rule.Ports) == 0 {
-					rules = append(rules, &fr)
-					continue
+				if ipv6 && peer.SupportsIPv6() {
+					if isAll {
+						peerIPs = append(peerIPs, "::")
+					} else {
+						peerIPs = append(peerIPs, peer.IPv6.String())
+					}
 				}
 
-				for _, port := range rule.Ports {
-					pr := fr // clone rule and add set new port
-					pr.Port = port
-					rules = append(rules, &pr)
+				for _, peerIP := range peerIPs {
+					fr := FirewallRule{
+						PeerIP:    peerIP,
+						Direction: direction,
+						Action:    string(rule.Action),
+						Protocol:  string(rule.Protocol),
+					}
+
+					ruleID := (rule.ID + fr.PeerIP + strconv.Itoa(direction) +
+						fr.Protocol + fr.Action + strings.Join(rule.Ports, ","))
+					if _, ok := rulesExists[ruleID]; ok {
+						continue
+					}
+					rulesExists[ruleID] = struct{}{}
+
+					if len(rule.Ports) == 0 {
+						rules = append(rules, &fr)
+						continue
+					}
+
+					for _, port := range rule.Ports {
+						pr := fr // clone rule and add set new port
+						pr.Port = port
+						rules = append(rules, &pr)
+					}
 				}
 			}
 		}, func() ([]*Peer, []*FirewallRule) {
@@ -314,8 +341,8 @@ func (am *DefaultAccountManager) GetPolicy(accountID, policyID, userID string) (
 		return nil, err
 	}
 
-	if !user.IsAdmin() {
-		return nil, status.Errorf(status.PermissionDenied, "only admins are allowed to view policies")
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view policies")
 	}
 
 	for _, policy := range account.Policies {
@@ -337,7 +364,32 @@ func (am *DefaultAccountManager) SavePolicy(accountID, userID string, policy *Po
 		return err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to update policies")
+	}
+
+	for _, postureChecksID := range policy.SourcePostureChecks {
+		if account.getPostureChecks(postureChecksID) == nil {
+			return status.Errorf(status.InvalidArgument, "posture checks with ID %s don't exist", postureChecksID)
+		}
+	}
+
+	for _, rule := range policy.Rules {
+		for _, port := range rule.Ports {
+			if err := ValidatePolicyRulePort(port); err != nil {
+				return err
+			}
+		}
+	}
+
+	affectedPeers := account.getPeersAffectedByPolicy(account.getPolicy(policy.ID))
 	exists := am.savePolicy(account, policy)
+	affectedPeers.add(account.getPeersAffectedByPolicy(policy))
 
 	account.Network.IncSerial()
 	if err = am.Store.SaveAccount(account); err != nil {
@@ -350,7 +402,7 @@ func (am *DefaultAccountManager) SavePolicy(accountID, userID string, policy *Po
 	}
 	am.storeEvent(userID, policy.ID, accountID, action, policy.EventMeta())
 
-	am.updateAccountPeers(account)
+	am.updateAffectedPeers(account, affectedPeers)
 
 	return nil
 }
@@ -365,6 +417,15 @@ func (am *DefaultAccountManager) DeletePolicy(accountID, policyID, userID string
 		return err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to delete policies")
+	}
+
 	policy, err := am.deletePolicy(account, policyID)
 	if err != nil {
 		return err
@@ -377,7 +438,7 @@ func (am *DefaultAccountManager) DeletePolicy(accountID, policyID, userID string
 
 	am.storeEvent(userID, policy.ID, accountID, activity.PolicyRemoved, policy.EventMeta())
 
-	am.updateAccountPeers(account)
+	am.updateAffectedPeers(account, account.getPeersAffectedByPolicy(policy))
 
 	return nil
 }
@@ -397,13 +458,23 @@ func (am *DefaultAccountManager) ListPolicies(accountID, userID string) ([]*Poli
 		return nil, err
 	}
 
-	if !user.IsAdmin() {
-		return nil, status.Errorf(status.PermissionDenied, "Only Administrators can view policies")
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view policies")
 	}
 
 	return account.Policies[:], nil
 }
 
+// getPolicy returns the policy with the given ID or nil if it doesn't exist
+func (a *Account) getPolicy(policyID string) *Policy {
+	for _, policy := range a.Policies {
+		if policy.ID == policyID {
+			return policy
+		}
+	}
+	return nil
+}
+
 func (am *DefaultAccountManager) deletePolicy(account *Account, policyID string) (*Policy, error) {
 	policyIdx := -1
 	for i, policy := range account.Policies {
@@ -435,6 +506,28 @@ func (am *DefaultAccountManager) savePolicy(account *Account, policy *Policy) (e
 	return
 }
 
+// ValidatePolicyRulePort checks that the port of a policy rule is a single port, e.g. "80",
+// or an inclusive range of ports, e.g. "8000-8100", in the 1..65535 range
+func ValidatePolicyRulePort(port string) error {
+	start, end, isRange := strings.Cut(port, "-")
+	if !isRange {
+		end = start
+	}
+
+	startPort, err := strconv.Atoi(start)
+	if err != nil || startPort < 1 || startPort > 65535 {
+		return status.Errorf(status.InvalidArgument, "invalid port %q, valid port value is in 1..65535 range", port)
+	}
+	endPort, err := strconv.Atoi(end)
+	if err != nil || endPort < 1 || endPort > 65535 {
+		return status.Errorf(status.InvalidArgument, "invalid port %q, valid port value is in 1..65535 range", port)
+	}
+	if startPort > endPort {
+		return status.Errorf(status.InvalidArgument, "invalid port range %q, the start port is greater than the end port", port)
+	}
+	return nil
+}
+
 func toProtocolFirewallRules(update []*FirewallRule) []*proto.FirewallRule {
 	result := make([]*proto.FirewallRule, len(update))
 	for i := range update {
@@ -489,6 +582,11 @@ func getAllPeersFromGroups(account *Account, groups []string, peerID string) ([]
 				continue
 			}
 
+			// peers pending approval are not reachable by other peers
+			if ok && peer != nil && peer.PendingApproval() {
+				continue
+			}
+
 			filteredPeers = append(filteredPeers, peer)
 		}
 	}
@@ -435,3 +435,75 @@ func sortFunc() func(a *FirewallRule, b *FirewallRule) bool {
 		return a.PeerIP+fmt.Sprintf("%d", a.Direction) < b.PeerIP+fmt.Sprintf("%d", b.Direction)
 	}
 }
+
+func TestAccount_getPeersByPolicyIPv6(t *testing.T) {
+	ipv6Meta := PeerSystemMeta{GoOS: "linux", Capabilities: []string{IPv6OverlayCapability}}
+	account := &Account{
+		Peers: map[string]*Peer{
+			"peerA": {ID: "peerA", IP: net.ParseIP("100.65.14.88"), IPv6: net.ParseIP("fd5e:1c2a:9b00::a"), Meta: ipv6Meta},
+			"peerB": {ID: "peerB", IP: net.ParseIP("100.65.80.39"), IPv6: net.ParseIP("fd5e:1c2a:9b00::b"), Meta: ipv6Meta},
+			// peerC runs a client without IPv6 overlay support
+			"peerC": {ID: "peerC", IP: net.ParseIP("100.65.254.139"), IPv6: net.ParseIP("fd5e:1c2a:9b00::c")},
+			"peerD": {ID: "peerD", IP: net.ParseIP("100.65.62.5"), IPv6: net.ParseIP("fd5e:1c2a:9b00::d"), Meta: ipv6Meta},
+		},
+		Groups: map[string]*Group{
+			"GroupAll": {ID: "GroupAll", Name: "All", Peers: []string{"peerA", "peerB", "peerC", "peerD"}},
+			"GroupSrc": {ID: "GroupSrc", Name: "Sources", Peers: []string{"peerA"}},
+			"GroupDst": {ID: "GroupDst", Name: "Destinations", Peers: []string{"peerB", "peerC"}},
+		},
+		Policies: []*Policy{
+			{
+				ID:      "policy",
+				Enabled: true,
+				Rules: []*PolicyRule{{
+					ID:           "rule",
+					Enabled:      true,
+					Action:       PolicyTrafficActionAccept,
+					Sources:      []string{"GroupSrc"},
+					Destinations: []string{"GroupDst"},
+					Protocol:     PolicyRuleProtocolTCP,
+					Ports:        []string{"22"},
+				}},
+			},
+		},
+	}
+
+	peerIPs := func(rules []*FirewallRule) []string {
+		ips := make([]string, 0, len(rules))
+		for _, r := range rules {
+			ips = append(ips, r.PeerIP)
+		}
+		return ips
+	}
+
+	t.Run("IPv6 rules are generated for the peers supporting IPv6", func(t *testing.T) {
+		_, firewallRules := account.getPeerConnectionResources("peerA")
+		assert.ElementsMatch(t, []string{"100.65.80.39", "fd5e:1c2a:9b00::b", "100.65.254.139"}, peerIPs(firewallRules))
+	})
+
+	t.Run("peer without IPv6 support gets IPv4 rules only", func(t *testing.T) {
+		_, firewallRules := account.getPeerConnectionResources("peerC")
+		assert.ElementsMatch(t, []string{"100.65.14.88"}, peerIPs(firewallRules))
+	})
+
+	t.Run("remote peers get the IPv6 allowed IP only when both sides support IPv6", func(t *testing.T) {
+		remotePeers := toRemotePeerConfig([]*Peer{account.Peers["peerB"], account.Peers["peerC"]}, "netbird.io", true)
+		assert.ElementsMatch(t, []string{"100.65.80.39/32", "fd5e:1c2a:9b00::b/128"}, remotePeers[0].AllowedIps)
+		assert.ElementsMatch(t, []string{"100.65.254.139/32"}, remotePeers[1].AllowedIps)
+
+		remotePeers = toRemotePeerConfig([]*Peer{account.Peers["peerB"]}, "netbird.io", false)
+		assert.ElementsMatch(t, []string{"100.65.80.39/32"}, remotePeers[0].AllowedIps)
+	})
+}
+
+func TestValidatePolicyRulePort(t *testing.T) {
+	validPorts := []string{"1", "22", "65535", "8000-8100", "443-443"}
+	for _, port := range validPorts {
+		assert.NoError(t, ValidatePolicyRulePort(port), "port %q should be valid", port)
+	}
+
+	invalidPorts := []string{"", "0", "65536", "http", "8000-", "-8100", "8100-8000", "1-65536", "80,443", "8000-8100-8200"}
+	for _, port := range invalidPorts {
+		assert.Error(t, ValidatePolicyRulePort(port), "port %q should be invalid", port)
+	}
+}
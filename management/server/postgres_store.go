@@ -0,0 +1,51 @@
+package server
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/lib/pq"
+
+	"github.com/netbirdio/netbird/management/server/telemetry"
+)
+
+// postgresDSNEnv is the environment variable holding the connection string of the Postgres store
+const postgresDSNEnv = "NETBIRD_STORE_ENGINE_POSTGRES_DSN"
+
+var postgresDialect = &sqlDialect{
+	engine:         PostgresStoreEngine,
+	numberedParams: true,
+	schemaTypes:    strings.NewReplacer(" DATETIME", " TIMESTAMPTZ", " INTEGER", " BIGINT"),
+	lockSchema:     `SELECT pg_advisory_xact_lock(hashtextextended('netbird/schema', 0))`,
+	addColumn:      addPostgresColumn,
+}
+
+// NewPostgresStore connects to the Postgres database of the connection string and returns the store backed by it.
+// Several management replicas can share the database, see HAStore
+func NewPostgresStore(dsn string, metrics telemetry.AppMetrics) (*SqlStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to the Postgres store: %v", err)
+	}
+
+	store, err := newSqlStore(&sqlDB{DB: db, dialect: postgresDialect}, metrics)
+	if err != nil {
+		return nil, err
+	}
+	store.dsn = dsn
+
+	return store, nil
+}
+
+// addPostgresColumn adds the column to its table unless the table already has it
+func addPostgresColumn(tx *sqlTx, column sqlColumn) error {
+	_, err := tx.Exec(`ALTER TABLE ` + column.table + ` ADD COLUMN IF NOT EXISTS ` + column.name + ` ` + column.definition)
+	return err
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"database/sql"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqlDialect_Rebind(t *testing.T) {
+	query := `UPDATE peers SET status_connected = ? WHERE account_id = ? AND id = ?`
+	assert.Equal(t, query, sqliteDialect.rebind(query))
+	assert.Equal(t, `UPDATE peers SET status_connected = $1 WHERE account_id = $2 AND id = $3`, postgresDialect.rebind(query))
+	assert.Equal(t, `SELECT id FROM accounts`, postgresDialect.rebind(`SELECT id FROM accounts`))
+}
+
+func TestPostgres_SaveAccount(t *testing.T) {
+	store := newTestPostgresStore(t)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	setupKey := GenerateDefaultSetupKey()
+	account.SetupKeys[setupKey.Key] = setupKey
+	account.Peers["testpeer"] = &Peer{
+		Key:      "peerkey",
+		ID:       "testpeer",
+		SetupKey: "peerkeysetupkey",
+		IP:       net.IP{127, 0, 0, 1},
+		Meta:     PeerSystemMeta{},
+		Name:     "peer name",
+		Status:   &PeerStatus{Connected: true, LastSeen: time.Now().UTC()},
+	}
+	require.NoError(t, store.SaveAccount(account))
+	// the rows of the account are replaced
+	require.NoError(t, store.SaveAccount(account))
+
+	a, err := store.GetAccountByPeerPubKey("peerkey")
+	require.NoError(t, err)
+	assert.Equal(t, account.Id, a.Id)
+	assert.Equal(t, "peer name", a.Peers["testpeer"].Name)
+
+	a, err = store.GetAccountBySetupKey(setupKey.Key)
+	require.NoError(t, err)
+	assert.Equal(t, account.Id, a.Id)
+
+	a.Peers["testpeer"].Name = "renamed"
+	require.NoError(t, store.SaveAccountPeer(a, "testpeer", nil, setupKey.Key))
+	require.NoError(t, store.SavePeerStatus(a.Id, "testpeer", PeerStatus{LastSeen: time.Now().UTC()}))
+
+	a, err = store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", a.Peers["testpeer"].Name)
+	assert.False(t, a.Peers["testpeer"].Status.Connected)
+	assert.Len(t, store.GetAllAccounts(), 1)
+}
+
+// newTestPostgresStore returns a store backed by a new schema of the test Postgres database, see newTestPostgresDSN
+func newTestPostgresStore(t *testing.T) *SqlStore {
+	t.Helper()
+
+	store, err := NewPostgresStore(newTestPostgresDSN(t), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store
+}
+
+// newTestPostgresDSN creates a schema in the Postgres database of the NETBIRD_STORE_ENGINE_POSTGRES_DSN environment
+// variable and returns the connection string using it. The test is skipped when the variable isn't set
+func newTestPostgresDSN(t *testing.T) string {
+	t.Helper()
+
+	dsn, ok := os.LookupEnv(postgresDSNEnv)
+	if !ok {
+		t.Skipf("set %s to run the tests against Postgres", postgresDSNEnv)
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	schema := "test_" + xid.New().String()
+	_, err = db.Exec(`CREATE SCHEMA ` + schema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = db.Exec(`DROP SCHEMA ` + schema + ` CASCADE`)
+		_ = db.Close()
+	})
+
+	if !strings.HasPrefix(dsn, "postgres://") && !strings.HasPrefix(dsn, "postgresql://") {
+		return dsn + " search_path=" + schema
+	}
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,563 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/management/server/activity"
+	"github.com/netbirdio/netbird/management/server/status"
+)
+
+const (
+	// NBVersionCheckName is the name of the NetBird client version posture check
+	NBVersionCheckName = "NBVersionCheck"
+	// OSCheckName is the name of the operating system posture check
+	OSCheckName = "OSCheck"
+	// KernelVersionCheckName is the name of the kernel version posture check
+	KernelVersionCheckName = "KernelVersionCheck"
+)
+
+// supportedPostureCheckOS is the list of GOOS values peers report in their system meta
+var supportedPostureCheckOS = []string{"linux", "darwin", "windows", "freebsd", "android", "ios"}
+
+// PostureCheck is a single requirement evaluated against the system information reported by the peer
+type PostureCheck interface {
+	// Name of the check
+	Name() string
+	// Check returns true if the peer satisfies the check
+	Check(peer *Peer) (bool, error)
+	// Validate returns an error if the check is not properly configured
+	Validate() error
+}
+
+// NBVersionCheck requires peers to run a NetBird client of at least MinVersion
+type NBVersionCheck struct {
+	MinVersion string
+
+	// minVersion is MinVersion parsed when the check is validated or loaded
+	minVersion *version.Version
+}
+
+// Name of the check
+func (c *NBVersionCheck) Name() string {
+	return NBVersionCheckName
+}
+
+// Check returns true if the peer runs a NetBird client of at least MinVersion
+func (c *NBVersionCheck) Check(peer *Peer) (bool, error) {
+	minVersion, err := c.parsedMinVersion()
+	if err != nil {
+		return false, err
+	}
+
+	peerVersion, err := version.NewVersion(peer.Meta.WtVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed parsing peer version %q: %v", peer.Meta.WtVersion, err)
+	}
+
+	return peerVersion.Core().GreaterThanOrEqual(minVersion.Core()), nil
+}
+
+// Validate returns an error if MinVersion is not a valid version
+func (c *NBVersionCheck) Validate() error {
+	minVersion, err := c.parsedMinVersion()
+	if err != nil {
+		return err
+	}
+	c.minVersion = minVersion
+	return nil
+}
+
+// UnmarshalJSON decodes the check and parses its version once instead of on every evaluation.
+// An invalid version is reported by Check
+func (c *NBVersionCheck) UnmarshalJSON(data []byte) error {
+	type nbVersionCheck NBVersionCheck
+	if err := json.Unmarshal(data, (*nbVersionCheck)(c)); err != nil {
+		return err
+	}
+	c.minVersion, _ = c.parsedMinVersion()
+	return nil
+}
+
+// parsedMinVersion returns the parsed MinVersion, parsing it if it hasn't been parsed yet
+func (c *NBVersionCheck) parsedMinVersion() (*version.Version, error) {
+	if c.minVersion != nil {
+		return c.minVersion, nil
+	}
+	minVersion, err := version.NewVersion(c.MinVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minimum NetBird version %q", c.MinVersion)
+	}
+	return minVersion, nil
+}
+
+// OSCheck requires peers to run one of the AllowedOS operating systems. Values are matched against the peer GOOS
+type OSCheck struct {
+	AllowedOS []string
+}
+
+// Name of the check
+func (c *OSCheck) Name() string {
+	return OSCheckName
+}
+
+// Check returns true if the peer operating system is in the allowed list
+func (c *OSCheck) Check(peer *Peer) (bool, error) {
+	for _, os := range c.AllowedOS {
+		if strings.EqualFold(os, peer.Meta.GoOS) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Validate returns an error if the allowed list is empty or contains an unknown operating system
+func (c *OSCheck) Validate() error {
+	if len(c.AllowedOS) == 0 {
+		return fmt.Errorf("allowed operating systems list shouldn't be empty")
+	}
+	for _, os := range c.AllowedOS {
+		if !isSupportedPostureCheckOS(os) {
+			return fmt.Errorf("unsupported operating system %q, supported values are %s",
+				os, strings.Join(supportedPostureCheckOS, ", "))
+		}
+	}
+	return nil
+}
+
+// KernelVersionCheck requires the peer kernel version to be within the MinVersion and MaxVersion range.
+// Any of the boundaries can be left empty. The kernel version is taken from the Core field of the peer system meta
+type KernelVersionCheck struct {
+	MinVersion string
+	MaxVersion string
+
+	// minVersion and maxVersion are the boundaries parsed when the check is validated or loaded, nil when empty
+	minVersion *version.Version
+	maxVersion *version.Version
+}
+
+// Name of the check
+func (c *KernelVersionCheck) Name() string {
+	return KernelVersionCheckName
+}
+
+// Check returns true if the peer kernel version is within the configured range
+func (c *KernelVersionCheck) Check(peer *Peer) (bool, error) {
+	minVersion, maxVersion, err := c.parsedBoundaries()
+	if err != nil {
+		return false, err
+	}
+
+	kernelVersion, err := version.NewVersion(peer.Meta.Core)
+	if err != nil {
+		return false, fmt.Errorf("failed parsing peer kernel version %q: %v", peer.Meta.Core, err)
+	}
+	// drop distribution suffixes like -76-generic
+	kernelVersion = kernelVersion.Core()
+
+	if minVersion != nil && kernelVersion.LessThan(minVersion.Core()) {
+		return false, nil
+	}
+
+	if maxVersion != nil && kernelVersion.GreaterThan(maxVersion.Core()) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// Validate returns an error if the range is empty or any of its boundaries is not a valid version
+func (c *KernelVersionCheck) Validate() error {
+	if c.MinVersion == "" && c.MaxVersion == "" {
+		return fmt.Errorf("kernel version check requires a minimum or a maximum version")
+	}
+
+	minVersion, maxVersion, err := c.parsedBoundaries()
+	if err != nil {
+		return err
+	}
+	if minVersion != nil && maxVersion != nil && minVersion.GreaterThan(maxVersion) {
+		return fmt.Errorf("minimum kernel version %s is greater than the maximum %s", c.MinVersion, c.MaxVersion)
+	}
+
+	c.minVersion, c.maxVersion = minVersion, maxVersion
+	return nil
+}
+
+// UnmarshalJSON decodes the check and parses its boundaries once instead of on every evaluation.
+// Invalid boundaries are reported by Check
+func (c *KernelVersionCheck) UnmarshalJSON(data []byte) error {
+	type kernelVersionCheck KernelVersionCheck
+	if err := json.Unmarshal(data, (*kernelVersionCheck)(c)); err != nil {
+		return err
+	}
+	minVersion, maxVersion, err := c.parsedBoundaries()
+	if err == nil {
+		c.minVersion, c.maxVersion = minVersion, maxVersion
+	}
+	return nil
+}
+
+// parsedBoundaries returns the parsed boundaries, parsing them if they haven't been parsed yet
+func (c *KernelVersionCheck) parsedBoundaries() (minVersion, maxVersion *version.Version, err error) {
+	minVersion, maxVersion = c.minVersion, c.maxVersion
+	if minVersion == nil && c.MinVersion != "" {
+		minVersion, err = version.NewVersion(c.MinVersion)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid minimum kernel version %q", c.MinVersion)
+		}
+	}
+	if maxVersion == nil && c.MaxVersion != "" {
+		maxVersion, err = version.NewVersion(c.MaxVersion)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid maximum kernel version %q", c.MaxVersion)
+		}
+	}
+	return minVersion, maxVersion, nil
+}
+
+// PostureChecks is a named set of requirements that peers have to meet.
+// Policies reference posture checks to restrict their source peers to the compliant ones.
+type PostureChecks struct {
+	// ID of the posture checks
+	ID string
+
+	// Name of the posture checks visible in the UI
+	Name string
+
+	// Description of the posture checks visible in the UI
+	Description string
+
+	// NBVersionCheck requires a minimum NetBird client version
+	NBVersionCheck *NBVersionCheck `json:",omitempty"`
+
+	// OSCheck requires one of the allowed operating systems
+	OSCheck *OSCheck `json:",omitempty"`
+
+	// KernelVersionCheck requires a kernel version within a range
+	KernelVersionCheck *KernelVersionCheck `json:",omitempty"`
+}
+
+// Copy returns a copy of the posture checks
+func (pc *PostureChecks) Copy() *PostureChecks {
+	c := &PostureChecks{
+		ID:          pc.ID,
+		Name:        pc.Name,
+		Description: pc.Description,
+	}
+	if pc.NBVersionCheck != nil {
+		check := *pc.NBVersionCheck
+		c.NBVersionCheck = &check
+	}
+	if pc.OSCheck != nil {
+		c.OSCheck = &OSCheck{AllowedOS: make([]string, len(pc.OSCheck.AllowedOS))}
+		copy(c.OSCheck.AllowedOS, pc.OSCheck.AllowedOS)
+	}
+	if pc.KernelVersionCheck != nil {
+		check := *pc.KernelVersionCheck
+		c.KernelVersionCheck = &check
+	}
+	return c
+}
+
+// EventMeta returns activity event meta related to the posture checks
+func (pc *PostureChecks) EventMeta() map[string]any {
+	return map[string]any{"name": pc.Name}
+}
+
+// GetChecks returns the configured checks
+func (pc *PostureChecks) GetChecks() []PostureCheck {
+	var checks []PostureCheck
+	if pc.NBVersionCheck != nil {
+		checks = append(checks, pc.NBVersionCheck)
+	}
+	if pc.OSCheck != nil {
+		checks = append(checks, pc.OSCheck)
+	}
+	if pc.KernelVersionCheck != nil {
+		checks = append(checks, pc.KernelVersionCheck)
+	}
+	return checks
+}
+
+// Validate returns an error if the posture checks have no name, no checks or any misconfigured check
+func (pc *PostureChecks) Validate() error {
+	if pc.Name == "" {
+		return status.Errorf(status.InvalidArgument, "posture checks name shouldn't be empty")
+	}
+
+	checks := pc.GetChecks()
+	if len(checks) == 0 {
+		return status.Errorf(status.InvalidArgument, "posture checks should contain at least one check")
+	}
+
+	for _, check := range checks {
+		if err := check.Validate(); err != nil {
+			return status.Errorf(status.InvalidArgument, "%s: %v", check.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// IsCompliant returns true if the peer satisfies all the checks.
+// A check that can't be evaluated, e.g. because the peer didn't report its version, is considered failed.
+func (pc *PostureChecks) IsCompliant(peer *Peer) bool {
+	for _, check := range pc.GetChecks() {
+		ok, err := check.Check(peer)
+		if err != nil {
+			log.Debugf("posture check %s of %s failed for peer %s: %v", check.Name(), pc.ID, peer.ID, err)
+			return false
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func isSupportedPostureCheckOS(os string) bool {
+	for _, supported := range supportedPostureCheckOS {
+		if strings.EqualFold(os, supported) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPostureChecks returns the posture checks with the given ID or nil if it doesn't exist
+func (a *Account) getPostureChecks(postureChecksID string) *PostureChecks {
+	for _, postureChecks := range a.PostureChecks {
+		if postureChecks.ID == postureChecksID {
+			return postureChecks
+		}
+	}
+	return nil
+}
+
+// isPeerPostureCompliant returns true if the peer satisfies all the referenced posture checks.
+// References to posture checks that don't exist are ignored.
+func (a *Account) isPeerPostureCompliant(peer *Peer, postureChecksIDs []string) bool {
+	for _, postureChecksID := range postureChecksIDs {
+		postureChecks := a.getPostureChecks(postureChecksID)
+		if postureChecks == nil {
+			continue
+		}
+		if !postureChecks.IsCompliant(peer) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterPostureCompliantPeers returns the peers that satisfy all the referenced posture checks
+func (a *Account) filterPostureCompliantPeers(peers []*Peer, postureChecksIDs []string) []*Peer {
+	filtered := make([]*Peer, 0, len(peers))
+	for _, peer := range peers {
+		if peer == nil || !a.isPeerPostureCompliant(peer, postureChecksIDs) {
+			continue
+		}
+		filtered = append(filtered, peer)
+	}
+	return filtered
+}
+
+// GetPeerFailedPostureChecks returns the posture checks that the peer has to satisfy and doesn't.
+// A peer has to satisfy the posture checks of every enabled policy that has the peer in its sources.
+func (a *Account) GetPeerFailedPostureChecks(peerID string) []*PostureChecks {
+	peer := a.GetPeer(peerID)
+	if peer == nil {
+		return nil
+	}
+
+	var failed []*PostureChecks
+	seen := make(map[string]struct{})
+	for _, policy := range a.Policies {
+		if !policy.Enabled || len(policy.SourcePostureChecks) == 0 {
+			continue
+		}
+
+		peerInSources := false
+		for _, rule := range policy.Rules {
+			if !rule.Enabled {
+				continue
+			}
+			if _, ok := getAllPeersFromGroups(a, rule.Sources, peerID); ok {
+				peerInSources = true
+				break
+			}
+		}
+		if !peerInSources {
+			continue
+		}
+
+		for _, postureChecksID := range policy.SourcePostureChecks {
+			if _, ok := seen[postureChecksID]; ok {
+				continue
+			}
+			seen[postureChecksID] = struct{}{}
+
+			postureChecks := a.getPostureChecks(postureChecksID)
+			if postureChecks != nil && !postureChecks.IsCompliant(peer) {
+				failed = append(failed, postureChecks)
+			}
+		}
+	}
+
+	return failed
+}
+
+// GetPostureChecks returns the posture checks with the given ID
+func (am *DefaultAccountManager) GetPostureChecks(accountID, postureChecksID, userID string) (*PostureChecks, error) {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view posture checks")
+	}
+
+	postureChecks := account.getPostureChecks(postureChecksID)
+	if postureChecks == nil {
+		return nil, status.Errorf(status.NotFound, "posture checks with ID %s not found", postureChecksID)
+	}
+
+	return postureChecks, nil
+}
+
+// SavePostureChecks creates or updates the posture checks of the account
+func (am *DefaultAccountManager) SavePostureChecks(accountID, userID string, postureChecks *PostureChecks) error {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to update posture checks")
+	}
+
+	if err = postureChecks.Validate(); err != nil {
+		return err
+	}
+
+	exists := false
+	for i, pc := range account.PostureChecks {
+		if pc.ID == postureChecks.ID {
+			account.PostureChecks[i] = postureChecks
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		account.PostureChecks = append(account.PostureChecks, postureChecks)
+	}
+
+	account.Network.IncSerial()
+	if err = am.Store.SaveAccount(account); err != nil {
+		return err
+	}
+
+	action := activity.PostureCheckCreated
+	if exists {
+		action = activity.PostureCheckUpdated
+	}
+	am.storeEvent(userID, postureChecks.ID, accountID, action, postureChecks.EventMeta())
+
+	am.updateAffectedPeers(account, account.getPeersAffectedByPostureChecks(postureChecks.ID))
+
+	return nil
+}
+
+// DeletePostureChecks removes the posture checks from the account. Posture checks referenced by a policy can't be removed
+func (am *DefaultAccountManager) DeletePostureChecks(accountID, postureChecksID, userID string) error {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to delete posture checks")
+	}
+
+	for _, policy := range account.Policies {
+		for _, id := range policy.SourcePostureChecks {
+			if id == postureChecksID {
+				return status.Errorf(status.PreconditionFailed, "posture checks have been linked to policy: %s", policy.Name)
+			}
+		}
+	}
+
+	idx := -1
+	for i, pc := range account.PostureChecks {
+		if pc.ID == postureChecksID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return status.Errorf(status.NotFound, "posture checks with ID %s doesn't exist", postureChecksID)
+	}
+
+	postureChecks := account.PostureChecks[idx]
+	account.PostureChecks = append(account.PostureChecks[:idx], account.PostureChecks[idx+1:]...)
+
+	account.Network.IncSerial()
+	if err = am.Store.SaveAccount(account); err != nil {
+		return err
+	}
+
+	am.storeEvent(userID, postureChecks.ID, accountID, activity.PostureCheckDeleted, postureChecks.EventMeta())
+
+	return nil
+}
+
+// ListPostureChecks returns all the posture checks of the account
+func (am *DefaultAccountManager) ListPostureChecks(accountID, userID string) ([]*PostureChecks, error) {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view posture checks")
+	}
+
+	return account.PostureChecks, nil
+}
@@ -0,0 +1,277 @@
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostureChecks_Check(t *testing.T) {
+	peer := func(goOS, core, wtVersion string) *Peer {
+		return &Peer{ID: "peer", Meta: PeerSystemMeta{GoOS: goOS, Core: core, WtVersion: wtVersion}}
+	}
+
+	tt := []struct {
+		name     string
+		check    PostureCheck
+		peer     *Peer
+		expected bool
+		err      bool
+	}{
+		{
+			name:     "NetBird version equal to minimum",
+			check:    &NBVersionCheck{MinVersion: "0.23.0"},
+			peer:     peer("linux", "", "0.23.0"),
+			expected: true,
+		},
+		{
+			name:     "NetBird version below minimum",
+			check:    &NBVersionCheck{MinVersion: "0.23.0"},
+			peer:     peer("linux", "", "0.22.7"),
+			expected: false,
+		},
+		{
+			name:     "NetBird development version",
+			check:    &NBVersionCheck{MinVersion: "0.23.0"},
+			peer:     peer("linux", "", "development"),
+			expected: false,
+			err:      true,
+		},
+		{
+			name:     "OS allowed",
+			check:    &OSCheck{AllowedOS: []string{"darwin", "linux"}},
+			peer:     peer("linux", "", ""),
+			expected: true,
+		},
+		{
+			name:     "OS not allowed",
+			check:    &OSCheck{AllowedOS: []string{"darwin", "linux"}},
+			peer:     peer("windows", "", ""),
+			expected: false,
+		},
+		{
+			name:     "Kernel version within range",
+			check:    &KernelVersionCheck{MinVersion: "5.4", MaxVersion: "6.2"},
+			peer:     peer("linux", "5.15.0-76-generic", ""),
+			expected: true,
+		},
+		{
+			name:     "Kernel version equal to maximum with suffix",
+			check:    &KernelVersionCheck{MaxVersion: "5.15.0"},
+			peer:     peer("linux", "5.15.0-76-generic", ""),
+			expected: true,
+		},
+		{
+			name:     "Kernel version below minimum",
+			check:    &KernelVersionCheck{MinVersion: "5.4"},
+			peer:     peer("linux", "4.19.0", ""),
+			expected: false,
+		},
+		{
+			name:     "Kernel version above maximum",
+			check:    &KernelVersionCheck{MaxVersion: "6.2"},
+			peer:     peer("linux", "6.5.0-1-amd64", ""),
+			expected: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := tc.check.Check(tc.peer)
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+}
+
+func TestPostureChecks_Validate(t *testing.T) {
+	tt := []struct {
+		name          string
+		postureChecks *PostureChecks
+		valid         bool
+	}{
+		{
+			name:          "valid",
+			postureChecks: &PostureChecks{Name: "default", NBVersionCheck: &NBVersionCheck{MinVersion: "0.23.0"}},
+			valid:         true,
+		},
+		{
+			name:          "empty name",
+			postureChecks: &PostureChecks{NBVersionCheck: &NBVersionCheck{MinVersion: "0.23.0"}},
+		},
+		{
+			name:          "no checks",
+			postureChecks: &PostureChecks{Name: "default"},
+		},
+		{
+			name:          "invalid NetBird version",
+			postureChecks: &PostureChecks{Name: "default", NBVersionCheck: &NBVersionCheck{MinVersion: "latest"}},
+		},
+		{
+			name:          "empty OS list",
+			postureChecks: &PostureChecks{Name: "default", OSCheck: &OSCheck{}},
+		},
+		{
+			name:          "unknown OS",
+			postureChecks: &PostureChecks{Name: "default", OSCheck: &OSCheck{AllowedOS: []string{"plan9"}}},
+		},
+		{
+			name:          "empty kernel range",
+			postureChecks: &PostureChecks{Name: "default", KernelVersionCheck: &KernelVersionCheck{}},
+		},
+		{
+			name: "inverted kernel range",
+			postureChecks: &PostureChecks{Name: "default",
+				KernelVersionCheck: &KernelVersionCheck{MinVersion: "6.0", MaxVersion: "5.0"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.postureChecks.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestPostureChecks_ParsedOnLoad(t *testing.T) {
+	stored, err := json.Marshal(&PostureChecks{
+		Name:               "default",
+		NBVersionCheck:     &NBVersionCheck{MinVersion: "0.23.0"},
+		KernelVersionCheck: &KernelVersionCheck{MinVersion: "5.4", MaxVersion: "6.5.0"},
+	})
+	require.NoError(t, err)
+
+	postureChecks := &PostureChecks{}
+	require.NoError(t, json.Unmarshal(stored, postureChecks))
+	assert.NotNil(t, postureChecks.NBVersionCheck.minVersion, "the NetBird version should be parsed on load")
+	assert.NotNil(t, postureChecks.KernelVersionCheck.minVersion, "the kernel version range should be parsed on load")
+	assert.NotNil(t, postureChecks.KernelVersionCheck.maxVersion, "the kernel version range should be parsed on load")
+
+	c := postureChecks.Copy()
+	assert.Equal(t, postureChecks.KernelVersionCheck.minVersion, c.KernelVersionCheck.minVersion)
+	assert.True(t, c.IsCompliant(&Peer{Meta: PeerSystemMeta{Core: "5.15.0-76-generic", WtVersion: "0.24.0"}}))
+}
+
+func TestAccount_GetPeerNetworkMapWithPostureChecks(t *testing.T) {
+	account := &Account{
+		Peers: map[string]*Peer{
+			"peerA": {ID: "peerA", IP: net.ParseIP("100.65.14.88"), Status: &PeerStatus{},
+				Meta: PeerSystemMeta{GoOS: "linux", WtVersion: "0.23.0"}},
+			"peerB": {ID: "peerB", IP: net.ParseIP("100.65.80.39"), Status: &PeerStatus{},
+				Meta: PeerSystemMeta{GoOS: "linux", WtVersion: "0.21.0"}},
+			"peerC": {ID: "peerC", IP: net.ParseIP("100.65.254.139"), Status: &PeerStatus{},
+				Meta: PeerSystemMeta{GoOS: "linux", WtVersion: "0.23.1"}},
+		},
+		Groups: map[string]*Group{
+			"GroupAll": {ID: "GroupAll", Name: "All", Peers: []string{"peerA", "peerB", "peerC"}},
+			"GroupSrc": {ID: "GroupSrc", Name: "Sources", Peers: []string{"peerA", "peerB"}},
+			"GroupDst": {ID: "GroupDst", Name: "Destinations", Peers: []string{"peerC"}},
+		},
+		PostureChecks: []*PostureChecks{
+			{ID: "minVersion", Name: "min version", NBVersionCheck: &NBVersionCheck{MinVersion: "0.22.0"}},
+		},
+		Policies: []*Policy{
+			{
+				ID:                  "policy",
+				Enabled:             true,
+				SourcePostureChecks: []string{"minVersion"},
+				Rules: []*PolicyRule{{
+					ID:            "rule",
+					Enabled:       true,
+					Action:        PolicyTrafficActionAccept,
+					Sources:       []string{"GroupSrc"},
+					Destinations:  []string{"GroupDst"},
+					Bidirectional: true,
+					Protocol:      PolicyRuleProtocolALL,
+				}},
+			},
+		},
+		Settings: &Settings{},
+		Network:  &Network{},
+	}
+
+	peerIDs := func(peers []*Peer) []string {
+		ids := make([]string, 0, len(peers))
+		for _, p := range peers {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+
+	t.Run("non-compliant source peer is excluded from the destination network map", func(t *testing.T) {
+		networkMap := account.GetPeerNetworkMap("peerC", "netbird.io")
+		assert.ElementsMatch(t, []string{"peerA"}, peerIDs(networkMap.Peers))
+	})
+
+	t.Run("non-compliant source peer gets no peers", func(t *testing.T) {
+		networkMap := account.GetPeerNetworkMap("peerB", "netbird.io")
+		assert.Empty(t, networkMap.Peers)
+		assert.Empty(t, networkMap.FirewallRules)
+	})
+
+	t.Run("compliant source peer reaches the destinations", func(t *testing.T) {
+		networkMap := account.GetPeerNetworkMap("peerA", "netbird.io")
+		assert.ElementsMatch(t, []string{"peerC"}, peerIDs(networkMap.Peers))
+	})
+
+	t.Run("failed posture checks are reported for the source peers only", func(t *testing.T) {
+		failed := account.GetPeerFailedPostureChecks("peerB")
+		require.Len(t, failed, 1)
+		assert.Equal(t, "minVersion", failed[0].ID)
+		assert.Empty(t, account.GetPeerFailedPostureChecks("peerA"))
+		assert.Empty(t, account.GetPeerFailedPostureChecks("peerC"))
+	})
+}
+
+func TestDefaultAccountManager_PostureChecks(t *testing.T) {
+	am, err := createManager(t)
+	require.NoError(t, err)
+
+	account, err := createAccount(am, "account", "admin", "")
+	require.NoError(t, err)
+
+	postureChecks := &PostureChecks{
+		ID:             "postureChecks",
+		Name:           "min version",
+		NBVersionCheck: &NBVersionCheck{MinVersion: "0.23.0"},
+	}
+	require.NoError(t, am.SavePostureChecks(account.Id, "admin", postureChecks))
+	require.Error(t, am.SavePostureChecks(account.Id, "admin", &PostureChecks{ID: "invalid", Name: "invalid"}),
+		"posture checks without checks should be rejected")
+
+	saved, err := am.GetPostureChecks(account.Id, postureChecks.ID, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, postureChecks.NBVersionCheck.MinVersion, saved.NBVersionCheck.MinVersion)
+
+	policy := &Policy{
+		ID:                  "policy",
+		Name:                "policy",
+		Enabled:             true,
+		SourcePostureChecks: []string{"missing"},
+	}
+	require.Error(t, am.SavePolicy(account.Id, "admin", policy), "unknown posture checks should be rejected")
+
+	policy.SourcePostureChecks = []string{postureChecks.ID}
+	require.NoError(t, am.SavePolicy(account.Id, "admin", policy))
+	require.Error(t, am.DeletePostureChecks(account.Id, postureChecks.ID, "admin"),
+		"posture checks linked to a policy should not be deleted")
+
+	require.NoError(t, am.DeletePolicy(account.Id, policy.ID, "admin"))
+	require.NoError(t, am.DeletePostureChecks(account.Id, postureChecks.ID, "admin"))
+
+	all, err := am.ListPostureChecks(account.Id, "admin")
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
@@ -26,8 +26,8 @@ func (am *DefaultAccountManager) GetRoute(accountID, routeID, userID string) (*r
 		return nil, err
 	}
 
-	if !user.IsAdmin() {
-		return nil, status.Errorf(status.PermissionDenied, "Only administrators can view Network Routes")
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view network routes")
 	}
 
 	wantedRoute, found := account.Routes[routeID]
@@ -122,6 +122,15 @@ func (am *DefaultAccountManager) CreateRoute(accountID, network, peerID string,
 		return nil, err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.CanManageNetwork() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to create routes")
+	}
+
 	if peerID != "" && len(peerGroupIDs) != 0 {
 		return nil, status.Errorf(
 			status.InvalidArgument,
@@ -184,7 +193,7 @@ func (am *DefaultAccountManager) CreateRoute(accountID, network, peerID string,
 		return nil, err
 	}
 
-	am.updateAccountPeers(account)
+	am.updateAffectedPeers(account, account.getPeersAffectedByRoute(&newRoute))
 
 	am.storeEvent(userID, newRoute.ID, accountID, activity.RouteCreated, newRoute.EventMeta())
 
@@ -217,6 +226,15 @@ func (am *DefaultAccountManager) SaveRoute(accountID, userID string, routeToSave
 		return err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to update routes")
+	}
+
 	if routeToSave.Peer != "" && len(routeToSave.PeerGroups) != 0 {
 		return status.Errorf(status.InvalidArgument, "peer with ID and peer groups should not be provided at the same time")
 	}
@@ -238,14 +256,16 @@ func (am *DefaultAccountManager) SaveRoute(accountID, userID string, routeToSave
 		return err
 	}
 
+	affectedPeers := account.getPeersAffectedByRoute(account.Routes[routeToSave.ID])
 	account.Routes[routeToSave.ID] = routeToSave
+	affectedPeers.add(account.getPeersAffectedByRoute(routeToSave))
 
 	account.Network.IncSerial()
 	if err = am.Store.SaveAccount(account); err != nil {
 		return err
 	}
 
-	am.updateAccountPeers(account)
+	am.updateAffectedPeers(account, affectedPeers)
 
 	am.storeEvent(userID, routeToSave.ID, accountID, activity.RouteUpdated, routeToSave.EventMeta())
 
@@ -262,6 +282,15 @@ func (am *DefaultAccountManager) DeleteRoute(accountID, routeID, userID string)
 		return err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanManageNetwork() {
+		return status.Errorf(status.PermissionDenied, "only admins and network admins are allowed to delete routes")
+	}
+
 	routy := account.Routes[routeID]
 	if routy == nil {
 		return status.Errorf(status.NotFound, "route with ID %s doesn't exist", routeID)
@@ -275,7 +304,7 @@ func (am *DefaultAccountManager) DeleteRoute(accountID, routeID, userID string)
 
 	am.storeEvent(userID, routy.ID, accountID, activity.RouteRemoved, routy.EventMeta())
 
-	am.updateAccountPeers(account)
+	am.updateAffectedPeers(account, account.getPeersAffectedByRoute(routy))
 
 	return nil
 }
@@ -295,8 +324,8 @@ func (am *DefaultAccountManager) ListRoutes(accountID, userID string) ([]*route.
 		return nil, err
 	}
 
-	if !user.IsAdmin() {
-		return nil, status.Errorf(status.PermissionDenied, "Only administrators can view Network Routes")
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view network routes")
 	}
 
 	routes := make([]*route.Route, 0, len(account.Routes))
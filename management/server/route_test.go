@@ -106,6 +106,32 @@ func TestCreateRoute(t *testing.T) {
 				Groups:      []string{routeGroup1, routeGroup2},
 			},
 		},
+		{
+			name: "Happy Path Default Route Peer Groups",
+			inputArgs: input{
+				network:      "0.0.0.0/0",
+				netID:        "exit",
+				peerGroupIDs: []string{routeGroupHA1, routeGroupHA2},
+				description:  "exit node",
+				masquerade:   true,
+				metric:       9999,
+				enabled:      true,
+				groups:       []string{routeGroup1},
+			},
+			errFunc:      require.NoError,
+			shouldCreate: true,
+			expectedRoute: &route.Route{
+				Network:     netip.MustParsePrefix("0.0.0.0/0"),
+				NetworkType: route.IPv4Network,
+				NetID:       "exit",
+				PeerGroups:  []string{routeGroupHA1, routeGroupHA2},
+				Description: "exit node",
+				Masquerade:  true,
+				Metric:      9999,
+				Enabled:     true,
+				Groups:      []string{routeGroup1},
+			},
+		},
 		{
 			name: "Both peer and peer_groups Provided Should Fail",
 			inputArgs: input{
@@ -972,13 +998,64 @@ func TestGetNetworkMap_RouteSync(t *testing.T) {
 	require.Len(t, peer1DeletedRoute.Routes, 0, "we should receive one route for peer1")
 }
 
+func TestGetNetworkMap_RoutingPeerCapability(t *testing.T) {
+	newPeer := func(id, ip, goOS string, capabilities ...string) *Peer {
+		return &Peer{ID: id, Key: id + "-key", IP: netip.MustParseAddr(ip).AsSlice(), Status: &PeerStatus{},
+			Meta: PeerSystemMeta{GoOS: goOS, Capabilities: capabilities}}
+	}
+	newRoute := func(id, network, peerID string) *route.Route {
+		return &route.Route{ID: id, Network: netip.MustParsePrefix(network), NetID: id, NetworkType: route.IPv4Network,
+			Peer: peerID, Metric: 9999, Enabled: true, Groups: []string{"groupAll"}}
+	}
+
+	account := &Account{
+		Peers: map[string]*Peer{
+			"client":  newPeer("client", "100.64.0.1", "linux"),
+			"linux":   newPeer("linux", "100.64.0.2", "linux"),
+			"darwin":  newPeer("darwin", "100.64.0.3", "darwin", route.RoutingPeerCapability),
+			"windows": newPeer("windows", "100.64.0.4", "windows"),
+		},
+		Groups: map[string]*Group{
+			"groupAll": {ID: "groupAll", Name: "All", Peers: []string{"client", "linux", "darwin", "windows"}},
+		},
+		Routes: map[string]*route.Route{
+			"linuxRoute":   newRoute("linuxRoute", "10.0.1.0/24", "linux"),
+			"darwinRoute":  newRoute("darwinRoute", "10.0.2.0/24", "darwin"),
+			"windowsRoute": newRoute("windowsRoute", "10.0.3.0/24", "windows"),
+		},
+		Policies: []*Policy{{
+			ID:      "policy",
+			Enabled: true,
+			Rules: []*PolicyRule{{
+				ID:            "rule",
+				Enabled:       true,
+				Action:        PolicyTrafficActionAccept,
+				Sources:       []string{"groupAll"},
+				Destinations:  []string{"groupAll"},
+				Bidirectional: true,
+				Protocol:      PolicyRuleProtocolALL,
+			}},
+		}},
+		Settings: &Settings{},
+		Network:  &Network{},
+	}
+
+	networkMap := account.GetPeerNetworkMap("client", "netbird.io")
+	routingPeers := make([]string, 0, len(networkMap.Routes))
+	for _, r := range networkMap.Routes {
+		routingPeers = append(routingPeers, r.Peer)
+	}
+	require.ElementsMatch(t, []string{"linux-key", "darwin-key"}, routingPeers,
+		"only routes of peers supporting routing should be distributed")
+}
+
 func createRouterManager(t *testing.T) (*DefaultAccountManager, error) {
 	store, err := createRouterStore(t)
 	if err != nil {
 		return nil, err
 	}
 	eventStore := &activity.InMemoryEventStore{}
-	return BuildManager(store, NewPeersUpdateManager(), nil, "", "", eventStore, false)
+	return BuildManager(store, NewPeersUpdateManager(nil), nil, "", "", eventStore, false, 0, nil)
 }
 
 func createRouterStore(t *testing.T) (Store, error) {
@@ -2,6 +2,7 @@ package server
 
 import (
 	"hash/fnv"
+	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -88,6 +89,8 @@ type SetupKey struct {
 	UsageLimit int
 	// Ephemeral indicate if the peers will be ephemeral or not
 	Ephemeral bool
+	// ReservedIP is the IP assigned to the peer registered with this key. It is taken while the key is valid
+	ReservedIP net.IP
 }
 
 // Copy copies SetupKey to a new object
@@ -111,9 +114,17 @@ func (key *SetupKey) Copy() *SetupKey {
 		AutoGroups: autoGroups,
 		UsageLimit: key.UsageLimit,
 		Ephemeral:  key.Ephemeral,
+		ReservedIP: copyReservedIP(key.ReservedIP),
 	}
 }
 
+func copyReservedIP(ip net.IP) net.IP {
+	if ip == nil {
+		return nil
+	}
+	return copyIP(ip)
+}
+
 // EventMeta returns activity event meta related to the setup key
 func (key *SetupKey) EventMeta() map[string]any {
 	return map[string]any{"name": key.Name, "type": key.Type, "key": key.HiddenCopy(1).Key}
@@ -204,8 +215,9 @@ func Hash(s string) uint32 {
 
 // CreateSetupKey generates a new setup key with a given name, type, list of groups IDs to auto-assign to peers registered with this key,
 // and adds it to the specified account. A list of autoGroups IDs can be empty.
+// The peer registered with a key that has a reservedIP gets this IP. Only single use keys can reserve an IP.
 func (am *DefaultAccountManager) CreateSetupKey(accountID string, keyName string, keyType SetupKeyType,
-	expiresIn time.Duration, autoGroups []string, usageLimit int, userID string, ephemeral bool) (*SetupKey, error) {
+	expiresIn time.Duration, autoGroups []string, usageLimit int, userID string, ephemeral bool, reservedIP net.IP) (*SetupKey, error) {
 	unlock := am.Store.AcquireAccountLock(accountID)
 	defer unlock()
 
@@ -219,6 +231,15 @@ func (am *DefaultAccountManager) CreateSetupKey(accountID string, keyName string
 		return nil, err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAdmin() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins are allowed to create setup keys")
+	}
+
 	for _, group := range autoGroups {
 		if _, ok := account.Groups[group]; !ok {
 			return nil, status.Errorf(status.NotFound, "group %s doesn't exist", group)
@@ -226,6 +247,17 @@ func (am *DefaultAccountManager) CreateSetupKey(accountID string, keyName string
 	}
 
 	setupKey := GenerateSetupKey(keyName, keyType, keyDuration, autoGroups, usageLimit, ephemeral)
+
+	if reservedIP != nil {
+		if setupKey.UsageLimit != 1 {
+			return nil, status.Errorf(status.InvalidArgument, "only one-off setup keys or keys with the usage limit of 1 can reserve an IP")
+		}
+		if err = validatePeerIP(account.Network.Net, reservedIP, account.getTakenIPs()); err != nil {
+			return nil, err
+		}
+		setupKey.ReservedIP = reservedIP.To4()
+	}
+
 	account.SetupKeys[setupKey.Key] = setupKey
 	err = am.Store.SaveAccount(account)
 	if err != nil {
@@ -264,6 +296,15 @@ func (am *DefaultAccountManager) SaveSetupKey(accountID string, keyToSave *Setup
 		return nil, err
 	}
 
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAdmin() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins are allowed to update setup keys")
+	}
+
 	var oldKey *SetupKey
 	for _, key := range account.SetupKeys {
 		if key.Id == keyToSave.Id {
@@ -317,8 +358,6 @@ func (am *DefaultAccountManager) SaveSetupKey(accountID string, keyToSave *Setup
 		}
 	}()
 
-	am.updateAccountPeers(account)
-
 	return newKey, nil
 }
 
@@ -2,6 +2,7 @@ package server
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 	"testing"
 	"time"
@@ -37,7 +38,7 @@ func TestDefaultAccountManager_SaveSetupKey(t *testing.T) {
 	keyName := "my-test-key"
 
 	key, err := manager.CreateSetupKey(account.Id, keyName, SetupKeyReusable, expiresIn, []string{},
-		SetupKeyUnlimitedUsage, userID, false)
+		SetupKeyUnlimitedUsage, userID, false, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -59,7 +60,7 @@ func TestDefaultAccountManager_SaveSetupKey(t *testing.T) {
 		key.Id, time.Now().UTC(), autoGroups)
 
 	// check the corresponding events that should have been generated
-	ev := getEvent(t, account.Id, manager, activity.SetupKeyRevoked)
+	ev := getEvent(t, account.Id, userID, manager, activity.SetupKeyRevoked)
 
 	assert.NotNil(t, ev)
 	assert.Equal(t, account.Id, ev.AccountID)
@@ -136,7 +137,7 @@ func TestDefaultAccountManager_CreateSetupKey(t *testing.T) {
 	for _, tCase := range []testCase{testCase1, testCase2} {
 		t.Run(tCase.name, func(t *testing.T) {
 			key, err := manager.CreateSetupKey(account.Id, tCase.expectedKeyName, SetupKeyReusable, expiresIn,
-				tCase.expectedGroups, SetupKeyUnlimitedUsage, userID, false)
+				tCase.expectedGroups, SetupKeyUnlimitedUsage, userID, false, nil)
 
 			if tCase.expectedFailure {
 				if err == nil {
@@ -154,7 +155,7 @@ func TestDefaultAccountManager_CreateSetupKey(t *testing.T) {
 				tCase.expectedUpdatedAt, tCase.expectedGroups)
 
 			// check the corresponding events that should have been generated
-			ev := getEvent(t, account.Id, manager, activity.SetupKeyCreated)
+			ev := getEvent(t, account.Id, userID, manager, activity.SetupKeyCreated)
 
 			assert.NotNil(t, ev)
 			assert.Equal(t, account.Id, ev.AccountID)
@@ -283,9 +284,13 @@ func assertKey(t *testing.T, key *SetupKey, expectedName string, expectedRevoke
 func TestSetupKey_Copy(t *testing.T) {
 
 	key := GenerateSetupKey("key name", SetupKeyOneOff, time.Hour, []string{}, SetupKeyUnlimitedUsage, false)
+	key.ReservedIP = net.ParseIP("100.64.0.20")
 	keyCopy := key.Copy()
 
 	assertKey(t, keyCopy, key.Name, key.Revoked, string(key.Type), key.UsedTimes, key.CreatedAt, key.ExpiresAt, key.Id,
 		key.UpdatedAt, key.AutoGroups)
+	assert.Equal(t, key.ReservedIP, keyCopy.ReservedIP)
+	keyCopy.ReservedIP[15] = 21
+	assert.Equal(t, "100.64.0.20", key.ReservedIP.String(), "the copy should not share the reserved IP")
 
 }
@@ -0,0 +1,1478 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net"
+	"net/netip"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	log "github.com/sirupsen/logrus"
+
+	nbdns "github.com/netbirdio/netbird/dns"
+	"github.com/netbirdio/netbird/management/server/status"
+	"github.com/netbirdio/netbird/management/server/telemetry"
+	"github.com/netbirdio/netbird/route"
+)
+
+// storeSqliteFileName is the name of the SQLite database file. Stored in the datadir
+const storeSqliteFileName = "store.db"
+
+// sqlSchema holds the statements creating the normalized account tables.
+// Lists that belong to a single row (e.g. group peers or route groups) are stored as JSON encoded text.
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS installations (id INTEGER PRIMARY KEY, installation_id TEXT NOT NULL);`,
+	`CREATE TABLE IF NOT EXISTS accounts (
+		id TEXT PRIMARY KEY,
+		created_by TEXT,
+		domain TEXT,
+		domain_category TEXT,
+		is_domain_primary_account BOOLEAN,
+		network_id TEXT,
+		network_net TEXT,
+		network_dns TEXT,
+		network_serial INTEGER,
+		dns_settings TEXT,
+		settings TEXT);`,
+	`CREATE INDEX IF NOT EXISTS idx_accounts_domain ON accounts (domain);`,
+	`CREATE TABLE IF NOT EXISTS setup_keys (
+		id TEXT NOT NULL,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		key TEXT NOT NULL,
+		name TEXT,
+		type TEXT,
+		created_at DATETIME,
+		expires_at DATETIME,
+		updated_at DATETIME,
+		revoked BOOLEAN,
+		used_times INTEGER,
+		last_used DATETIME,
+		auto_groups TEXT,
+		usage_limit INTEGER,
+		ephemeral BOOLEAN,
+		PRIMARY KEY (account_id, key));`,
+	`CREATE INDEX IF NOT EXISTS idx_setup_keys_key ON setup_keys (key);`,
+	`CREATE TABLE IF NOT EXISTS peers (
+		id TEXT PRIMARY KEY,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		key TEXT NOT NULL,
+		setup_key TEXT,
+		ip TEXT,
+		meta TEXT,
+		name TEXT,
+		dns_label TEXT,
+		status_last_seen DATETIME,
+		status_connected BOOLEAN,
+		status_login_expired BOOLEAN,
+		user_id TEXT,
+		ssh_key TEXT,
+		ssh_enabled BOOLEAN,
+		login_expiration_enabled BOOLEAN,
+		last_login DATETIME,
+		ephemeral BOOLEAN);`,
+	`CREATE INDEX IF NOT EXISTS idx_peers_account_id ON peers (account_id);`,
+	`CREATE INDEX IF NOT EXISTS idx_peers_key ON peers (key);`,
+	`CREATE TABLE IF NOT EXISTS users (
+		id TEXT PRIMARY KEY,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		role TEXT,
+		is_service_user BOOLEAN,
+		service_user_name TEXT,
+		auto_groups TEXT,
+		blocked BOOLEAN,
+		last_login DATETIME);`,
+	`CREATE INDEX IF NOT EXISTS idx_users_account_id ON users (account_id);`,
+	`CREATE TABLE IF NOT EXISTS personal_access_tokens (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		name TEXT,
+		hashed_token TEXT NOT NULL,
+		expiration_date DATETIME,
+		created_by TEXT,
+		created_at DATETIME,
+		last_used DATETIME);`,
+	`CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_hashed_token ON personal_access_tokens (hashed_token);`,
+	`CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_account_id ON personal_access_tokens (account_id);`,
+	`CREATE TABLE IF NOT EXISTS "groups" (
+		id TEXT NOT NULL,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		name TEXT,
+		issued TEXT,
+		peers TEXT,
+		PRIMARY KEY (account_id, id));`,
+	`CREATE TABLE IF NOT EXISTS policies (
+		id TEXT NOT NULL,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		position INTEGER,
+		name TEXT,
+		description TEXT,
+		enabled BOOLEAN,
+		PRIMARY KEY (account_id, id));`,
+	`CREATE TABLE IF NOT EXISTS policy_rules (
+		id TEXT NOT NULL,
+		policy_id TEXT NOT NULL,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		position INTEGER,
+		name TEXT,
+		description TEXT,
+		enabled BOOLEAN,
+		action TEXT,
+		destinations TEXT,
+		sources TEXT,
+		bidirectional BOOLEAN,
+		protocol TEXT,
+		ports TEXT,
+		PRIMARY KEY (account_id, policy_id, id));`,
+	`CREATE TABLE IF NOT EXISTS routes (
+		id TEXT NOT NULL,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		network TEXT,
+		net_id TEXT,
+		description TEXT,
+		peer TEXT,
+		peer_groups TEXT,
+		network_type INTEGER,
+		masquerade BOOLEAN,
+		metric INTEGER,
+		enabled BOOLEAN,
+		groups TEXT,
+		PRIMARY KEY (account_id, id));`,
+	`CREATE TABLE IF NOT EXISTS name_server_groups (
+		id TEXT NOT NULL,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		name TEXT,
+		description TEXT,
+		name_servers TEXT,
+		groups TEXT,
+		is_primary BOOLEAN,
+		domains TEXT,
+		enabled BOOLEAN,
+		PRIMARY KEY (account_id, id));`,
+	`CREATE TABLE IF NOT EXISTS posture_checks (
+		id TEXT NOT NULL,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		position INTEGER,
+		name TEXT,
+		description TEXT,
+		checks TEXT,
+		PRIMARY KEY (account_id, id));`,
+	`CREATE TABLE IF NOT EXISTS webhooks (
+		id TEXT NOT NULL,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		position INTEGER,
+		name TEXT,
+		url TEXT,
+		secret TEXT,
+		events TEXT,
+		enabled BOOLEAN,
+		PRIMARY KEY (account_id, id));`,
+	`CREATE TABLE IF NOT EXISTS dns_zones (
+		id TEXT NOT NULL,
+		account_id TEXT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+		name TEXT,
+		description TEXT,
+		records TEXT,
+		distribution_groups TEXT,
+		enabled BOOLEAN,
+		PRIMARY KEY (account_id, id));`,
+}
+
+// sqlColumn is a column added to a table after the table has been released
+type sqlColumn struct {
+	table      string
+	name       string
+	definition string
+}
+
+// sqlColumns lists the columns added to the schema over time. Missing columns are added when the store is opened
+var sqlColumns = []sqlColumn{
+	{table: "policies", name: "source_posture_checks", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "accounts", name: "network_net_v6", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "peers", name: "ipv6", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "personal_access_tokens", name: "scopes", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "peers", name: "status_requires_approval", definition: "BOOLEAN NOT NULL DEFAULT FALSE"},
+	{table: "setup_keys", name: "reserved_ip", definition: "TEXT NOT NULL DEFAULT ''"},
+	{table: "name_server_groups", name: "search_domains_enabled", definition: "BOOLEAN NOT NULL DEFAULT FALSE"},
+	{table: "dns_zones", name: "search_domain_enabled", definition: "BOOLEAN NOT NULL DEFAULT FALSE"},
+}
+
+// accountChildTables lists the tables that hold account resources. They are rewritten on every SaveAccount
+var accountChildTables = []string{
+	"setup_keys", "peers", "users", "personal_access_tokens", `"groups"`, "policies", "policy_rules", "routes", "name_server_groups",
+	"posture_checks", "webhooks", "dns_zones",
+}
+
+// SqlStore represents an account storage backed by a SQL database: a SQLite database persisted to the datadir or a
+// Postgres database
+type SqlStore struct {
+	db *sqlDB
+
+	// sync.Mutex indexed by accountID
+	accountLocks      sync.Map
+	globalAccountLock sync.Mutex
+
+	// fence is checked in the transaction of every write when it is set, see writeFence
+	fence writeFence
+	// dsn is the connection string of the Postgres database, empty for the other databases
+	dsn string
+
+	metrics telemetry.AppMetrics
+}
+
+// writeFence checks in the transaction of a write to the account that the write is still allowed, e.g. that the
+// management replica still holds the lock of the account. An empty accountID is a write that doesn't belong to an
+// account
+type writeFence func(tx *sqlTx, accountID string) error
+
+// sqlDialect holds what differs between the databases backing a SqlStore. The queries are written for SQLite and
+// rewritten for the other databases
+type sqlDialect struct {
+	engine StoreEngine
+	// numberedParams is true when the query parameters are written $1, $2... instead of ?
+	numberedParams bool
+	// schemaTypes replaces the column types of the schema with the types of the database
+	schemaTypes *strings.Replacer
+	// lockSchema is run in the transaction creating the schema, it keeps the stores opened concurrently from
+	// creating the schema at the same time
+	lockSchema string
+	// addColumn adds the column to its table unless the table already has it
+	addColumn func(tx *sqlTx, column sqlColumn) error
+}
+
+var sqliteDialect = &sqlDialect{
+	engine:      SqliteStoreEngine,
+	schemaTypes: strings.NewReplacer(),
+	addColumn:   addSqliteColumn,
+}
+
+// rebind rewrites the parameters of the query for the database
+func (d *sqlDialect) rebind(query string) string {
+	if !d.numberedParams || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	param := 0
+	for _, c := range query {
+		if c != '?' {
+			b.WriteRune(c)
+			continue
+		}
+		param++
+		b.WriteString("$" + strconv.Itoa(param))
+	}
+	return b.String()
+}
+
+// sqlDB is the database of a SqlStore, the queries are rewritten for its dialect
+type sqlDB struct {
+	*sql.DB
+	dialect *sqlDialect
+}
+
+func (db *sqlDB) Exec(query string, args ...any) (sql.Result, error) {
+	return db.DB.Exec(db.dialect.rebind(query), args...)
+}
+
+func (db *sqlDB) Query(query string, args ...any) (*sql.Rows, error) {
+	return db.DB.Query(db.dialect.rebind(query), args...)
+}
+
+func (db *sqlDB) QueryRow(query string, args ...any) *sql.Row {
+	return db.DB.QueryRow(db.dialect.rebind(query), args...)
+}
+
+func (db *sqlDB) Begin() (*sqlTx, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &sqlTx{Tx: tx, dialect: db.dialect}, nil
+}
+
+// sqlTx is a transaction of a SqlStore, the queries are rewritten for its dialect
+type sqlTx struct {
+	*sql.Tx
+	dialect *sqlDialect
+}
+
+func (tx *sqlTx) Exec(query string, args ...any) (sql.Result, error) {
+	return tx.Tx.Exec(tx.dialect.rebind(query), args...)
+}
+
+func (tx *sqlTx) Query(query string, args ...any) (*sql.Rows, error) {
+	return tx.Tx.Query(tx.dialect.rebind(query), args...)
+}
+
+func (tx *sqlTx) QueryRow(query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRow(tx.dialect.rebind(query), args...)
+}
+
+// NewSqliteStore opens (or creates) the SQLite store located in the datadir
+func NewSqliteStore(dataDir string, metrics telemetry.AppMetrics) (*SqlStore, error) {
+	dbFile := filepath.Join(dataDir, storeSqliteFileName)
+	db, err := sql.Open("sqlite3", dbFile+"?_journal_mode=WAL&_busy_timeout=10000&_foreign_keys=on")
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer at a time, serialize access on the pool level
+	db.SetMaxOpenConns(1)
+
+	return newSqlStore(&sqlDB{DB: db, dialect: sqliteDialect}, metrics)
+}
+
+// newSqlStore creates the schema of the store in the database, or adds the missing columns to it, and returns the store
+func newSqlStore(db *sqlDB, metrics telemetry.AppMetrics) (*SqlStore, error) {
+	err := createSchema(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &SqlStore{db: db, metrics: metrics}, nil
+}
+
+func createSchema(db *sqlDB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if db.dialect.lockSchema != "" {
+		if _, err = tx.Exec(db.dialect.lockSchema); err != nil {
+			return err
+		}
+	}
+
+	for _, stmt := range sqlSchema {
+		if _, err = tx.Exec(db.dialect.schemaTypes.Replace(stmt)); err != nil {
+			return err
+		}
+	}
+
+	for _, column := range sqlColumns {
+		if err = db.dialect.addColumn(tx, column); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// addSqliteColumn adds the column to its table unless the table already has it
+func addSqliteColumn(tx *sqlTx, column sqlColumn) error {
+	rows, err := tx.Query(`SELECT name FROM pragma_table_info(?)`, column.table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column.name {
+			return nil
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	_ = rows.Close()
+
+	log.Infof("adding column %s to the %s table of the SQLite store", column.name, column.table)
+	_, err = tx.Exec(`ALTER TABLE ` + column.table + ` ADD COLUMN ` + column.name + ` ` + column.definition)
+	return err
+}
+
+// NewSqliteStoreFromFileStore creates a SQLite store in the datadir and copies all the data of the FileStore into it
+func NewSqliteStoreFromFileStore(fileStore *FileStore, dataDir string, metrics telemetry.AppMetrics) (*SqlStore, error) {
+	store, err := NewSqliteStore(dataDir, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.SaveInstallationID(fileStore.InstallationID)
+	if err != nil {
+		_ = store.Close()
+		return nil, err
+	}
+
+	for _, account := range fileStore.GetAllAccounts() {
+		err = store.SaveAccount(account)
+		if err != nil {
+			_ = store.Close()
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+// AcquireGlobalLock acquires global lock across all the accounts and returns a function that releases the lock
+func (s *SqlStore) AcquireGlobalLock() (unlock func()) {
+	log.Debugf("acquiring global lock")
+	start := time.Now()
+	s.globalAccountLock.Lock()
+
+	unlock = func() {
+		s.globalAccountLock.Unlock()
+		log.Debugf("released global lock in %v", time.Since(start))
+	}
+
+	took := time.Since(start)
+	log.Debugf("took %v to acquire global lock", took)
+	if s.metrics != nil {
+		s.metrics.StoreMetrics().CountGlobalLockAcquisitionDuration(took)
+	}
+
+	return unlock
+}
+
+// AcquireAccountLock acquires account lock and returns a function that releases the lock
+func (s *SqlStore) AcquireAccountLock(accountID string) (unlock func()) {
+	log.Debugf("acquiring lock for account %s", accountID)
+	start := time.Now()
+	value, _ := s.accountLocks.LoadOrStore(accountID, &sync.Mutex{})
+	mtx := value.(*sync.Mutex)
+	mtx.Lock()
+
+	unlock = func() {
+		mtx.Unlock()
+		log.Debugf("released lock for account %s in %v", accountID, time.Since(start))
+	}
+
+	return unlock
+}
+
+// SaveAccount replaces all the stored rows of the account in a single transaction
+func (s *SqlStore) SaveAccount(account *Account) error {
+	if account.Id == "" {
+		return status.Errorf(status.InvalidArgument, "account id should not be empty")
+	}
+
+	start := time.Now()
+
+	err := s.write(account.Id, func(tx *sqlTx) error {
+		return saveAccount(tx, account)
+	})
+	if err != nil {
+		return err
+	}
+
+	took := time.Since(start)
+	if s.metrics != nil {
+		s.metrics.StoreMetrics().CountPersistenceDuration(took)
+	}
+	log.Debugf("took %d ms to persist an account to the %s store", took.Milliseconds(), s.db.dialect.engine)
+
+	return nil
+}
+
+// write runs the write to the account in a single transaction, checked by the write fence when the store has one
+func (s *SqlStore) write(accountID string, write func(tx *sqlTx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if s.fence != nil {
+		err = s.fence(tx, accountID)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	err = write(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func saveAccount(tx *sqlTx, account *Account) error {
+	network := account.Network
+	if network == nil {
+		network = &Network{}
+	}
+
+	dnsSettings, err := marshalColumn(account.DNSSettings)
+	if err != nil {
+		return err
+	}
+	settings, err := marshalColumn(account.Settings)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT INTO accounts (id, created_by, domain, domain_category, is_domain_primary_account,
+		network_id, network_net, network_net_v6, network_dns, network_serial, dns_settings, settings)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET created_by = excluded.created_by, domain = excluded.domain,
+		domain_category = excluded.domain_category, is_domain_primary_account = excluded.is_domain_primary_account,
+		network_id = excluded.network_id, network_net = excluded.network_net, network_net_v6 = excluded.network_net_v6,
+		network_dns = excluded.network_dns, network_serial = excluded.network_serial, dns_settings = excluded.dns_settings,
+		settings = excluded.settings`,
+		account.Id, account.CreatedBy, account.Domain, account.DomainCategory, account.IsDomainPrimaryAccount,
+		network.Id, network.Net.String(), ipNetToColumn(network.NetV6), network.Dns, network.CurrentSerial(), dnsSettings,
+		settings)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range accountChildTables {
+		_, err = tx.Exec("DELETE FROM "+table+" WHERE account_id = ?", account.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range account.SetupKeys {
+		err = saveSetupKey(tx, account.Id, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, peer := range account.Peers {
+		err = savePeer(tx, account.Id, peer)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, user := range account.Users {
+		autoGroups, err := marshalColumn(user.AutoGroups)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO users (id, account_id, role, is_service_user, service_user_name, auto_groups, blocked, last_login)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			user.Id, account.Id, string(user.Role), user.IsServiceUser, user.ServiceUserName, autoGroups, user.Blocked, user.LastLogin)
+		if err != nil {
+			return err
+		}
+
+		for _, pat := range user.PATs {
+			scopes, err := marshalColumn(pat.Scopes)
+			if err != nil {
+				return err
+			}
+			_, err = tx.Exec(`INSERT INTO personal_access_tokens (id, user_id, account_id, name, hashed_token, expiration_date,
+				created_by, created_at, last_used, scopes) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				pat.ID, user.Id, account.Id, pat.Name, pat.HashedToken, pat.ExpirationDate, pat.CreatedBy, pat.CreatedAt, pat.LastUsed,
+				scopes)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, group := range account.Groups {
+		err = saveGroup(tx, account.Id, group)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, policy := range account.Policies {
+		postureChecks, err := marshalColumn(policy.SourcePostureChecks)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO policies (id, account_id, position, name, description, enabled, source_posture_checks)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			policy.ID, account.Id, i, policy.Name, policy.Description, policy.Enabled, postureChecks)
+		if err != nil {
+			return err
+		}
+
+		for j, rule := range policy.Rules {
+			err = savePolicyRule(tx, account.Id, policy.ID, j, rule)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, r := range account.Routes {
+		peerGroups, err := marshalColumn(r.PeerGroups)
+		if err != nil {
+			return err
+		}
+		groups, err := marshalColumn(r.Groups)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO routes (id, account_id, network, net_id, description, peer, peer_groups, network_type,
+			masquerade, metric, enabled, groups) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			r.ID, account.Id, r.Network.String(), r.NetID, r.Description, r.Peer, peerGroups, int(r.NetworkType),
+			r.Masquerade, r.Metric, r.Enabled, groups)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, nsGroup := range account.NameServerGroups {
+		nameServers, err := marshalColumn(nsGroup.NameServers)
+		if err != nil {
+			return err
+		}
+		groups, err := marshalColumn(nsGroup.Groups)
+		if err != nil {
+			return err
+		}
+		domains, err := marshalColumn(nsGroup.Domains)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO name_server_groups (id, account_id, name, description, name_servers, groups, is_primary,
+			domains, enabled, search_domains_enabled) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			nsGroup.ID, account.Id, nsGroup.Name, nsGroup.Description, nameServers, groups, nsGroup.Primary, domains, nsGroup.Enabled,
+			nsGroup.SearchDomainsEnabled)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, postureChecks := range account.PostureChecks {
+		checks, err := marshalColumn(postureChecks)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO posture_checks (id, account_id, position, name, description, checks) VALUES (?, ?, ?, ?, ?, ?)`,
+			postureChecks.ID, account.Id, i, postureChecks.Name, postureChecks.Description, checks)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, hook := range account.Webhooks {
+		events, err := marshalColumn(hook.Events)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO webhooks (id, account_id, position, name, url, secret, events, enabled)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			hook.ID, account.Id, i, hook.Name, hook.URL, hook.Secret, events, hook.Enabled)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, zone := range account.DNSZones {
+		records, err := marshalColumn(zone.Records)
+		if err != nil {
+			return err
+		}
+		groups, err := marshalColumn(zone.DistributionGroups)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO dns_zones (id, account_id, name, description, records, distribution_groups, enabled,
+			search_domain_enabled) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			zone.ID, account.Id, zone.Name, zone.Description, records, groups, zone.Enabled, zone.SearchDomainEnabled)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveSetupKey inserts the setup key row or replaces the existing one
+func saveSetupKey(tx *sqlTx, accountID string, key *SetupKey) error {
+	autoGroups, err := marshalColumn(key.AutoGroups)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO setup_keys (id, account_id, key, name, type, created_at, expires_at, updated_at,
+		revoked, used_times, last_used, auto_groups, usage_limit, ephemeral, reserved_ip)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (account_id, key) DO UPDATE SET id = excluded.id, name = excluded.name, type = excluded.type,
+		created_at = excluded.created_at, expires_at = excluded.expires_at, updated_at = excluded.updated_at,
+		revoked = excluded.revoked, used_times = excluded.used_times, last_used = excluded.last_used,
+		auto_groups = excluded.auto_groups, usage_limit = excluded.usage_limit, ephemeral = excluded.ephemeral,
+		reserved_ip = excluded.reserved_ip`,
+		key.Id, accountID, key.Key, key.Name, string(key.Type), key.CreatedAt, key.ExpiresAt, key.UpdatedAt,
+		key.Revoked, key.UsedTimes, key.LastUsed, autoGroups, key.UsageLimit, key.Ephemeral, ipToColumn(key.ReservedIP))
+	return err
+}
+
+// savePeer inserts the peer row or replaces the existing one
+func savePeer(tx *sqlTx, accountID string, peer *Peer) error {
+	meta, err := marshalColumn(peer.Meta)
+	if err != nil {
+		return err
+	}
+	peerStatus := peer.Status
+	if peerStatus == nil {
+		peerStatus = &PeerStatus{}
+	}
+	_, err = tx.Exec(`INSERT INTO peers (id, account_id, key, setup_key, ip, ipv6, meta, name, dns_label,
+		status_last_seen, status_connected, status_login_expired, status_requires_approval, user_id, ssh_key, ssh_enabled,
+		login_expiration_enabled, last_login, ephemeral)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET account_id = excluded.account_id, key = excluded.key, setup_key = excluded.setup_key,
+		ip = excluded.ip, ipv6 = excluded.ipv6, meta = excluded.meta, name = excluded.name, dns_label = excluded.dns_label,
+		status_last_seen = excluded.status_last_seen, status_connected = excluded.status_connected,
+		status_login_expired = excluded.status_login_expired, status_requires_approval = excluded.status_requires_approval,
+		user_id = excluded.user_id, ssh_key = excluded.ssh_key, ssh_enabled = excluded.ssh_enabled,
+		login_expiration_enabled = excluded.login_expiration_enabled, last_login = excluded.last_login,
+		ephemeral = excluded.ephemeral`,
+		peer.ID, accountID, peer.Key, peer.SetupKey, ipToColumn(peer.IP), ipToColumn(peer.IPv6), meta, peer.Name, peer.DNSLabel,
+		peerStatus.LastSeen, peerStatus.Connected, peerStatus.LoginExpired, peerStatus.RequiresApproval, peer.UserID, peer.SSHKey,
+		peer.SSHEnabled, peer.LoginExpirationEnabled, peer.LastLogin, peer.Ephemeral)
+	return err
+}
+
+// saveGroup inserts the group row or replaces the existing one
+func saveGroup(tx *sqlTx, accountID string, group *Group) error {
+	peers, err := marshalColumn(group.Peers)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO "groups" (id, account_id, name, issued, peers) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (account_id, id) DO UPDATE SET name = excluded.name, issued = excluded.issued, peers = excluded.peers`,
+		group.ID, accountID, group.Name, group.Issued, peers)
+	return err
+}
+
+func savePolicyRule(tx *sqlTx, accountID, policyID string, position int, rule *PolicyRule) error {
+	destinations, err := marshalColumn(rule.Destinations)
+	if err != nil {
+		return err
+	}
+	sources, err := marshalColumn(rule.Sources)
+	if err != nil {
+		return err
+	}
+	ports, err := marshalColumn(rule.Ports)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO policy_rules (id, policy_id, account_id, position, name, description, enabled, action,
+		destinations, sources, bidirectional, protocol, ports) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rule.ID, policyID, accountID, position, rule.Name, rule.Description, rule.Enabled, string(rule.Action),
+		destinations, sources, rule.Bidirectional, string(rule.Protocol), ports)
+	return err
+}
+
+// DeleteHashedPAT2TokenIDIndex is a no-op for the SqlStore as tokens are removed together with the user record
+func (s *SqlStore) DeleteHashedPAT2TokenIDIndex(hashedToken string) error {
+	return nil
+}
+
+// DeleteTokenID2UserIDIndex is a no-op for the SqlStore as tokens are removed together with the user record
+func (s *SqlStore) DeleteTokenID2UserIDIndex(tokenID string) error {
+	return nil
+}
+
+// GetAccountByPrivateDomain returns account by private domain
+func (s *SqlStore) GetAccountByPrivateDomain(domain string) (*Account, error) {
+	var accountID string
+	err := s.db.QueryRow(`SELECT id FROM accounts WHERE LOWER(domain) = ? AND domain_category = ? AND is_domain_primary_account = ?`,
+		strings.ToLower(domain), PrivateCategory, true).Scan(&accountID)
+	if err != nil {
+		return nil, notFoundOrError(err, "account not found: provided domain is not registered or is not private")
+	}
+
+	return s.GetAccount(accountID)
+}
+
+// GetAccountBySetupKey returns account by setup key id
+func (s *SqlStore) GetAccountBySetupKey(setupKey string) (*Account, error) {
+	var accountID string
+	err := s.db.QueryRow(`SELECT account_id FROM setup_keys WHERE UPPER(key) = ?`, strings.ToUpper(setupKey)).Scan(&accountID)
+	if err != nil {
+		return nil, notFoundOrError(err, "account not found: provided setup key doesn't exists")
+	}
+
+	return s.GetAccount(accountID)
+}
+
+// GetTokenIDByHashedToken returns the id of a personal access token by its hashed secret
+func (s *SqlStore) GetTokenIDByHashedToken(hashedToken string) (string, error) {
+	var tokenID string
+	err := s.db.QueryRow(`SELECT id FROM personal_access_tokens WHERE hashed_token = ?`, hashedToken).Scan(&tokenID)
+	if err != nil {
+		return "", notFoundOrError(err, "tokenID not found: provided token doesn't exists")
+	}
+
+	return tokenID, nil
+}
+
+// GetUserByTokenID returns a User object a tokenID belongs to
+func (s *SqlStore) GetUserByTokenID(tokenID string) (*User, error) {
+	var userID, accountID string
+	err := s.db.QueryRow(`SELECT user_id, account_id FROM personal_access_tokens WHERE id = ?`, tokenID).Scan(&userID, &accountID)
+	if err != nil {
+		return nil, notFoundOrError(err, "user not found: provided tokenID doesn't exists")
+	}
+
+	account, err := s.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, ok := account.Users[userID]
+	if !ok {
+		return nil, status.Errorf(status.NotFound, "accountID not found: provided userID doesn't exists")
+	}
+
+	return user, nil
+}
+
+// GetAllAccounts returns all accounts
+func (s *SqlStore) GetAllAccounts() (all []*Account) {
+	rows, err := s.db.Query(`SELECT id FROM accounts`)
+	if err != nil {
+		log.Errorf("failed to list accounts from the %s store: %v", s.db.dialect.engine, err)
+		return nil
+	}
+
+	var accountIDs []string
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			log.Errorf("failed to read account id from the %s store: %v", s.db.dialect.engine, err)
+			continue
+		}
+		accountIDs = append(accountIDs, id)
+	}
+	_ = rows.Close()
+
+	for _, id := range accountIDs {
+		account, err := s.GetAccount(id)
+		if err != nil {
+			log.Errorf("failed to load account %s from the %s store: %v", id, s.db.dialect.engine, err)
+			continue
+		}
+		all = append(all, account)
+	}
+
+	return all
+}
+
+// GetAccount returns an account for ID
+func (s *SqlStore) GetAccount(accountID string) (*Account, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	return loadAccount(tx, accountID)
+}
+
+// GetAccountByUser returns a user account
+func (s *SqlStore) GetAccountByUser(userID string) (*Account, error) {
+	var accountID string
+	err := s.db.QueryRow(`SELECT account_id FROM users WHERE id = ?`, userID).Scan(&accountID)
+	if err != nil {
+		return nil, notFoundOrError(err, "account not found")
+	}
+
+	return s.GetAccount(accountID)
+}
+
+// GetAccountByPeerID returns an account for a given peer ID
+func (s *SqlStore) GetAccountByPeerID(peerID string) (*Account, error) {
+	var accountID string
+	err := s.db.QueryRow(`SELECT account_id FROM peers WHERE id = ?`, peerID).Scan(&accountID)
+	if err != nil {
+		return nil, notFoundOrError(err, "provided peer ID doesn't exists "+peerID)
+	}
+
+	return s.GetAccount(accountID)
+}
+
+// GetAccountByPeerPubKey returns an account for a given peer WireGuard public key
+func (s *SqlStore) GetAccountByPeerPubKey(peerKey string) (*Account, error) {
+	var accountID string
+	err := s.db.QueryRow(`SELECT account_id FROM peers WHERE key = ?`, peerKey).Scan(&accountID)
+	if err != nil {
+		return nil, notFoundOrError(err, "provided peer key doesn't exists "+peerKey)
+	}
+
+	return s.GetAccount(accountID)
+}
+
+// GetInstallationID returns the installation ID from the store
+func (s *SqlStore) GetInstallationID() string {
+	var installationID string
+	err := s.db.QueryRow(`SELECT installation_id FROM installations WHERE id = 1`).Scan(&installationID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Errorf("failed to read installation ID from the %s store: %v", s.db.dialect.engine, err)
+	}
+
+	return installationID
+}
+
+// SaveInstallationID saves the installation ID
+func (s *SqlStore) SaveInstallationID(ID string) error {
+	return s.write("", func(tx *sqlTx) error {
+		_, err := tx.Exec(`INSERT INTO installations (id, installation_id) VALUES (1, ?)
+			ON CONFLICT (id) DO UPDATE SET installation_id = excluded.installation_id`, ID)
+		return err
+	})
+}
+
+// SavePeerStatus updates the status columns of a single peer without rewriting the account
+func (s *SqlStore) SavePeerStatus(accountID, peerID string, peerStatus PeerStatus) error {
+	return s.write(accountID, func(tx *sqlTx) error {
+		result, err := tx.Exec(`UPDATE peers SET status_last_seen = ?, status_connected = ?, status_login_expired = ?,
+			status_requires_approval = ? WHERE account_id = ? AND id = ?`,
+			peerStatus.LastSeen, peerStatus.Connected, peerStatus.LoginExpired, peerStatus.RequiresApproval, accountID, peerID)
+		if err != nil {
+			return err
+		}
+
+		return notFoundIfNoRowsAffected(result, "peer %s not found", peerID)
+	})
+}
+
+// SaveAccountPeer writes a single peer of the account, the network serial and the listed groups and setup key changed
+// with the peer in a single transaction without rewriting the other rows of the account
+func (s *SqlStore) SaveAccountPeer(account *Account, peerID string, groupIDs []string, setupKey string) error {
+	peer := account.Peers[peerID]
+	if peer == nil {
+		return status.Errorf(status.NotFound, "peer %s not found", peerID)
+	}
+
+	start := time.Now()
+
+	err := s.write(account.Id, func(tx *sqlTx) error {
+		return saveAccountPeer(tx, account, peer, groupIDs, setupKey)
+	})
+	if err != nil {
+		return err
+	}
+
+	took := time.Since(start)
+	if s.metrics != nil {
+		s.metrics.StoreMetrics().CountPersistenceDuration(took)
+	}
+	log.Debugf("took %d ms to persist a peer to the %s store", took.Milliseconds(), s.db.dialect.engine)
+
+	return nil
+}
+
+func saveAccountPeer(tx *sqlTx, account *Account, peer *Peer, groupIDs []string, setupKey string) error {
+	result, err := tx.Exec(`UPDATE accounts SET network_serial = ? WHERE id = ?`, account.Network.CurrentSerial(), account.Id)
+	if err != nil {
+		return err
+	}
+	err = notFoundIfNoRowsAffected(result, "account %s not found", account.Id)
+	if err != nil {
+		return err
+	}
+
+	err = savePeer(tx, account.Id, peer)
+	if err != nil {
+		return err
+	}
+
+	for _, groupID := range groupIDs {
+		group := account.Groups[groupID]
+		if group == nil {
+			return status.Errorf(status.NotFound, "group %s not found", groupID)
+		}
+		err = saveGroup(tx, account.Id, group)
+		if err != nil {
+			return err
+		}
+	}
+
+	if setupKey != "" {
+		key := account.SetupKeys[setupKey]
+		if key == nil {
+			return status.Errorf(status.NotFound, "setup key not found")
+		}
+		err = saveSetupKey(tx, account.Id, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SaveUserLastLogin updates the last login time of a single user without rewriting the account
+func (s *SqlStore) SaveUserLastLogin(accountID, userID string, lastLogin time.Time) error {
+	return s.write(accountID, func(tx *sqlTx) error {
+		result, err := tx.Exec(`UPDATE users SET last_login = ? WHERE account_id = ? AND id = ?`, lastLogin, accountID, userID)
+		if err != nil {
+			return err
+		}
+
+		return notFoundIfNoRowsAffected(result, "user %s not found", userID)
+	})
+}
+
+// Close the SqlStore closing the underlying database
+func (s *SqlStore) Close() error {
+	log.Infof("closing the %s store", s.db.dialect.engine)
+	return s.db.Close()
+}
+
+// GetStoreEngine returns the engine of the database backing the store
+func (s *SqlStore) GetStoreEngine() StoreEngine {
+	return s.db.dialect.engine
+}
+
+// loadAccount reads all the rows of an account and assembles them into an Account object
+func loadAccount(tx *sqlTx, accountID string) (*Account, error) {
+	account := &Account{
+		Id:               accountID,
+		SetupKeys:        make(map[string]*SetupKey),
+		Network:          &Network{},
+		Peers:            make(map[string]*Peer),
+		Users:            make(map[string]*User),
+		Groups:           make(map[string]*Group),
+		Rules:            make(map[string]*Rule),
+		Policies:         make([]*Policy, 0),
+		Routes:           make(map[string]*route.Route),
+		NameServerGroups: make(map[string]*nbdns.NameServerGroup),
+		DNSZones:         make(map[string]*DNSZone),
+	}
+
+	var networkNet, networkNetV6, dnsSettings, settings string
+	err := tx.QueryRow(`SELECT created_by, domain, domain_category, is_domain_primary_account, network_id, network_net,
+		network_net_v6, network_dns, network_serial, dns_settings, settings FROM accounts WHERE id = ?`, accountID).Scan(
+		&account.CreatedBy, &account.Domain, &account.DomainCategory, &account.IsDomainPrimaryAccount, &account.Network.Id,
+		&networkNet, &networkNetV6, &account.Network.Dns, &account.Network.Serial, &dnsSettings, &settings)
+	if err != nil {
+		return nil, notFoundOrError(err, "account not found")
+	}
+
+	if _, ipNet, err := net.ParseCIDR(networkNet); err == nil {
+		account.Network.Net = *ipNet
+	}
+	if _, ipNet, err := net.ParseCIDR(networkNetV6); err == nil {
+		account.Network.NetV6 = *ipNet
+	}
+	if err = unmarshalColumn(dnsSettings, &account.DNSSettings); err != nil {
+		return nil, err
+	}
+	if err = unmarshalColumn(settings, &account.Settings); err != nil {
+		return nil, err
+	}
+
+	loaders := []func(*sqlTx, *Account) error{
+		loadSetupKeys, loadPeers, loadUsers, loadPATs, loadGroups, loadPolicies, loadRoutes, loadNameServerGroups,
+		loadPostureChecks, loadWebhooks, loadDNSZones,
+	}
+	for _, load := range loaders {
+		err = load(tx, account)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, policy := range account.Policies {
+		for _, rule := range policy.Rules {
+			account.Rules[rule.ID] = rule.ToRule()
+		}
+	}
+
+	return account, nil
+}
+
+func loadSetupKeys(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT id, key, name, type, created_at, expires_at, updated_at, revoked, used_times, last_used,
+		auto_groups, usage_limit, ephemeral, reserved_ip FROM setup_keys WHERE account_id = ?`, account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		key := &SetupKey{}
+		var keyType, autoGroups, reservedIP string
+		err = rows.Scan(&key.Id, &key.Key, &key.Name, &keyType, &key.CreatedAt, &key.ExpiresAt, &key.UpdatedAt, &key.Revoked,
+			&key.UsedTimes, &key.LastUsed, &autoGroups, &key.UsageLimit, &key.Ephemeral, &reservedIP)
+		if err != nil {
+			return err
+		}
+		key.Type = SetupKeyType(keyType)
+		key.ReservedIP = net.ParseIP(reservedIP)
+		if err = unmarshalColumn(autoGroups, &key.AutoGroups); err != nil {
+			return err
+		}
+		account.SetupKeys[key.Key] = key
+	}
+
+	return rows.Err()
+}
+
+func loadPeers(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT id, key, setup_key, ip, ipv6, meta, name, dns_label, status_last_seen, status_connected,
+		status_login_expired, status_requires_approval, user_id, ssh_key, ssh_enabled, login_expiration_enabled, last_login, ephemeral
+		FROM peers WHERE account_id = ?`, account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		peer := &Peer{Status: &PeerStatus{}}
+		var ip, ipv6, meta string
+		err = rows.Scan(&peer.ID, &peer.Key, &peer.SetupKey, &ip, &ipv6, &meta, &peer.Name, &peer.DNSLabel, &peer.Status.LastSeen,
+			&peer.Status.Connected, &peer.Status.LoginExpired, &peer.Status.RequiresApproval, &peer.UserID, &peer.SSHKey, &peer.SSHEnabled,
+			&peer.LoginExpirationEnabled, &peer.LastLogin, &peer.Ephemeral)
+		if err != nil {
+			return err
+		}
+		peer.IP = net.ParseIP(ip)
+		peer.IPv6 = net.ParseIP(ipv6)
+		if err = unmarshalColumn(meta, &peer.Meta); err != nil {
+			return err
+		}
+		account.Peers[peer.ID] = peer
+	}
+
+	return rows.Err()
+}
+
+func loadUsers(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT id, role, is_service_user, service_user_name, auto_groups, blocked, last_login
+		FROM users WHERE account_id = ?`, account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := &User{PATs: make(map[string]*PersonalAccessToken)}
+		var role, autoGroups string
+		err = rows.Scan(&user.Id, &role, &user.IsServiceUser, &user.ServiceUserName, &autoGroups, &user.Blocked, &user.LastLogin)
+		if err != nil {
+			return err
+		}
+		user.Role = UserRole(role)
+		if err = unmarshalColumn(autoGroups, &user.AutoGroups); err != nil {
+			return err
+		}
+		account.Users[user.Id] = user
+	}
+
+	return rows.Err()
+}
+
+func loadPATs(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT id, user_id, name, hashed_token, expiration_date, created_by, created_at, last_used, scopes
+		FROM personal_access_tokens WHERE account_id = ?`, account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		pat := &PersonalAccessToken{}
+		var userID, scopes string
+		err = rows.Scan(&pat.ID, &userID, &pat.Name, &pat.HashedToken, &pat.ExpirationDate, &pat.CreatedBy, &pat.CreatedAt, &pat.LastUsed,
+			&scopes)
+		if err == nil {
+			err = unmarshalColumn(scopes, &pat.Scopes)
+		}
+		if err != nil {
+			return err
+		}
+		user, ok := account.Users[userID]
+		if !ok {
+			log.Warnf("personal access token %s references user %s that doesn't exist under account %s", pat.ID, userID, account.Id)
+			continue
+		}
+		user.PATs[pat.ID] = pat
+	}
+
+	return rows.Err()
+}
+
+func loadGroups(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT id, name, issued, peers FROM "groups" WHERE account_id = ?`, account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		group := &Group{}
+		var peers string
+		err = rows.Scan(&group.ID, &group.Name, &group.Issued, &peers)
+		if err != nil {
+			return err
+		}
+		if err = unmarshalColumn(peers, &group.Peers); err != nil {
+			return err
+		}
+		account.Groups[group.ID] = group
+	}
+
+	return rows.Err()
+}
+
+func loadPolicies(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT id, name, description, enabled, source_posture_checks FROM policies WHERE account_id = ?
+		ORDER BY position`, account.Id)
+	if err != nil {
+		return err
+	}
+
+	policies := make(map[string]*Policy)
+	for rows.Next() {
+		policy := &Policy{Rules: make([]*PolicyRule, 0)}
+		var postureChecks string
+		err = rows.Scan(&policy.ID, &policy.Name, &policy.Description, &policy.Enabled, &postureChecks)
+		if err == nil {
+			err = unmarshalColumn(postureChecks, &policy.SourcePostureChecks)
+		}
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		policies[policy.ID] = policy
+		account.Policies = append(account.Policies, policy)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = tx.Query(`SELECT id, policy_id, name, description, enabled, action, destinations, sources, bidirectional,
+		protocol, ports FROM policy_rules WHERE account_id = ? ORDER BY policy_id, position`, account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rule := &PolicyRule{}
+		var policyID, action, destinations, sources, protocol, ports string
+		err = rows.Scan(&rule.ID, &policyID, &rule.Name, &rule.Description, &rule.Enabled, &action, &destinations, &sources,
+			&rule.Bidirectional, &protocol, &ports)
+		if err != nil {
+			return err
+		}
+		rule.Action = PolicyTrafficActionType(action)
+		rule.Protocol = PolicyRuleProtocolType(protocol)
+		if err = unmarshalColumn(destinations, &rule.Destinations); err != nil {
+			return err
+		}
+		if err = unmarshalColumn(sources, &rule.Sources); err != nil {
+			return err
+		}
+		if err = unmarshalColumn(ports, &rule.Ports); err != nil {
+			return err
+		}
+
+		policy, ok := policies[policyID]
+		if !ok {
+			log.Warnf("policy rule %s references policy %s that doesn't exist under account %s", rule.ID, policyID, account.Id)
+			continue
+		}
+		policy.Rules = append(policy.Rules, rule)
+	}
+
+	return rows.Err()
+}
+
+func loadRoutes(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT id, network, net_id, description, peer, peer_groups, network_type, masquerade, metric,
+		enabled, groups FROM routes WHERE account_id = ?`, account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r := &route.Route{}
+		var network, peerGroups, groups string
+		var networkType int
+		err = rows.Scan(&r.ID, &network, &r.NetID, &r.Description, &r.Peer, &peerGroups, &networkType, &r.Masquerade,
+			&r.Metric, &r.Enabled, &groups)
+		if err != nil {
+			return err
+		}
+		r.NetworkType = route.NetworkType(networkType)
+		if prefix, err := netip.ParsePrefix(network); err == nil {
+			r.Network = prefix
+		}
+		if err = unmarshalColumn(peerGroups, &r.PeerGroups); err != nil {
+			return err
+		}
+		if err = unmarshalColumn(groups, &r.Groups); err != nil {
+			return err
+		}
+		account.Routes[r.ID] = r
+	}
+
+	return rows.Err()
+}
+
+func loadNameServerGroups(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT id, name, description, name_servers, groups, is_primary, domains, enabled,
+		search_domains_enabled FROM name_server_groups WHERE account_id = ?`, account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		nsGroup := &nbdns.NameServerGroup{}
+		var nameServers, groups, domains string
+		err = rows.Scan(&nsGroup.ID, &nsGroup.Name, &nsGroup.Description, &nameServers, &groups, &nsGroup.Primary, &domains,
+			&nsGroup.Enabled, &nsGroup.SearchDomainsEnabled)
+		if err != nil {
+			return err
+		}
+		if err = unmarshalColumn(nameServers, &nsGroup.NameServers); err != nil {
+			return err
+		}
+		if err = unmarshalColumn(groups, &nsGroup.Groups); err != nil {
+			return err
+		}
+		if err = unmarshalColumn(domains, &nsGroup.Domains); err != nil {
+			return err
+		}
+		account.NameServerGroups[nsGroup.ID] = nsGroup
+	}
+
+	return rows.Err()
+}
+
+func loadPostureChecks(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT checks FROM posture_checks WHERE account_id = ? ORDER BY position`, account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var checks string
+		err = rows.Scan(&checks)
+		if err != nil {
+			return err
+		}
+		postureChecks := &PostureChecks{}
+		if err = unmarshalColumn(checks, postureChecks); err != nil {
+			return err
+		}
+		account.PostureChecks = append(account.PostureChecks, postureChecks)
+	}
+
+	return rows.Err()
+}
+
+func loadWebhooks(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT id, name, url, secret, events, enabled FROM webhooks WHERE account_id = ? ORDER BY position`,
+		account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var events string
+		hook := &Webhook{}
+		err = rows.Scan(&hook.ID, &hook.Name, &hook.URL, &hook.Secret, &events, &hook.Enabled)
+		if err != nil {
+			return err
+		}
+		if err = unmarshalColumn(events, &hook.Events); err != nil {
+			return err
+		}
+		account.Webhooks = append(account.Webhooks, hook)
+	}
+
+	return rows.Err()
+}
+
+func loadDNSZones(tx *sqlTx, account *Account) error {
+	rows, err := tx.Query(`SELECT id, name, description, records, distribution_groups, enabled, search_domain_enabled
+		FROM dns_zones WHERE account_id = ?`, account.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var records, groups string
+		zone := &DNSZone{}
+		err = rows.Scan(&zone.ID, &zone.Name, &zone.Description, &records, &groups, &zone.Enabled, &zone.SearchDomainEnabled)
+		if err != nil {
+			return err
+		}
+		if err = unmarshalColumn(records, &zone.Records); err != nil {
+			return err
+		}
+		if err = unmarshalColumn(groups, &zone.DistributionGroups); err != nil {
+			return err
+		}
+		account.DNSZones[zone.ID] = zone
+	}
+
+	return rows.Err()
+}
+
+// marshalColumn encodes a value into a JSON text column
+func marshalColumn(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// unmarshalColumn decodes a JSON text column. Empty columns leave the target untouched
+func unmarshalColumn(column string, v any) error {
+	if column == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(column), v)
+}
+
+func ipToColumn(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+func ipNetToColumn(ipNet net.IPNet) string {
+	if ipNet.IP == nil {
+		return ""
+	}
+	return ipNet.String()
+}
+
+func notFoundOrError(err error, message string) error {
+	if err == sql.ErrNoRows {
+		return status.Errorf(status.NotFound, message)
+	}
+	return err
+}
+
+func notFoundIfNoRowsAffected(result sql.Result, format string, a ...any) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return status.Errorf(status.NotFound, format, a...)
+	}
+	return nil
+}
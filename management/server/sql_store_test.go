@@ -0,0 +1,398 @@
+package server
+
+import (
+	"net"
+	"net/netip"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nbdns "github.com/netbirdio/netbird/dns"
+	"github.com/netbirdio/netbird/util"
+)
+
+func TestSqlite_NewStore(t *testing.T) {
+	store := newSqliteStore(t)
+
+	if len(store.GetAllAccounts()) != 0 {
+		t.Errorf("expected to create a new empty Accounts map when creating a new SqlStore")
+	}
+}
+
+func TestSqlite_SaveAccount(t *testing.T) {
+	store := newSqliteStore(t)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	setupKey := GenerateDefaultSetupKey()
+	account.SetupKeys[setupKey.Key] = setupKey
+	account.Peers["testpeer"] = &Peer{
+		Key:      "peerkey",
+		ID:       "testpeer",
+		SetupKey: "peerkeysetupkey",
+		IP:       net.IP{127, 0, 0, 1},
+		Meta:     PeerSystemMeta{},
+		Name:     "peer name",
+		Status:   &PeerStatus{Connected: true, LastSeen: time.Now().UTC()},
+	}
+
+	err := store.SaveAccount(account)
+	require.NoError(t, err)
+
+	account2 := newAccountWithId("account_id2", "testuser2", "")
+	setupKey = GenerateDefaultSetupKey()
+	account2.SetupKeys[setupKey.Key] = setupKey
+	account2.Peers["testpeer2"] = &Peer{
+		Key:      "peerkey2",
+		ID:       "testpeer2",
+		SetupKey: "peerkeysetupkey2",
+		IP:       net.IP{127, 0, 0, 2},
+		Meta:     PeerSystemMeta{},
+		Name:     "peer name 2",
+		Status:   &PeerStatus{Connected: true, LastSeen: time.Now().UTC()},
+	}
+
+	err = store.SaveAccount(account2)
+	require.NoError(t, err)
+
+	if len(store.GetAllAccounts()) != 2 {
+		t.Errorf("expecting 2 Accounts to be stored after SaveAccount()")
+	}
+
+	a, err := store.GetAccountByPeerPubKey("peerkey")
+	require.NoError(t, err, "expecting account to be found by peer key")
+	assert.Equal(t, account.Id, a.Id)
+
+	a, err = store.GetAccountByUser("testuser")
+	require.NoError(t, err, "expecting account to be found by user")
+	assert.Equal(t, account.Id, a.Id)
+
+	a, err = store.GetAccountByPeerID("testpeer")
+	require.NoError(t, err, "expecting account to be found by peer ID")
+	assert.Equal(t, account.Id, a.Id)
+
+	a, err = store.GetAccountBySetupKey(setupKey.Key)
+	require.NoError(t, err, "expecting account to be found by setup key")
+	assert.Equal(t, account2.Id, a.Id)
+}
+
+func TestSqlite_SaveAccountReplacesDeletedResources(t *testing.T) {
+	store := newSqliteStore(t)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	account.Peers["testpeer"] = &Peer{
+		Key:    "peerkey",
+		ID:     "testpeer",
+		IP:     net.IP{127, 0, 0, 1},
+		Status: &PeerStatus{},
+	}
+
+	err := store.SaveAccount(account)
+	require.NoError(t, err)
+
+	account.DeletePeer("testpeer")
+	err = store.SaveAccount(account)
+	require.NoError(t, err)
+
+	_, err = store.GetAccountByPeerID("testpeer")
+	require.Error(t, err, "expecting to get an error when the peer was removed")
+
+	_, err = store.GetAccountByPeerPubKey("peerkey")
+	require.Error(t, err, "expecting to get an error when the peer was removed")
+}
+
+func TestSqlite_RestoreFromFileStore(t *testing.T) {
+	storeDir := t.TempDir()
+
+	err := util.CopyFileContents("testdata/store.json", filepath.Join(storeDir, "store.json"))
+	require.NoError(t, err)
+
+	fileStore, err := NewFileStore(storeDir, nil)
+	require.NoError(t, err)
+
+	store, err := NewSqliteStoreFromFileStore(fileStore, storeDir, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	expected, err := fileStore.GetAccount("bf1c8084-ba50-4ce7-9439-34653001fc3b")
+	require.NoError(t, err)
+
+	account, err := store.GetAccount(expected.Id)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected.IsDomainPrimaryAccount, account.IsDomainPrimaryAccount)
+	assert.Equal(t, expected.DomainCategory, account.DomainCategory)
+	assert.Equal(t, expected.Domain, account.Domain)
+	assert.Equal(t, expected.CreatedBy, account.CreatedBy)
+	assert.Equal(t, expected.Network.Id, account.Network.Id)
+	assert.Equal(t, expected.Network.Net.String(), account.Network.Net.String())
+	assert.Len(t, account.Peers, len(expected.Peers))
+	assert.Len(t, account.Users, len(expected.Users))
+	assert.Len(t, account.SetupKeys, len(expected.SetupKeys))
+	assert.Len(t, account.Groups, len(expected.Groups))
+	assert.Len(t, account.Policies, len(expected.Policies))
+	assert.Len(t, account.Rules, len(expected.Rules))
+	assert.Len(t, account.Routes, len(expected.Routes))
+	assert.Len(t, account.NameServerGroups, len(expected.NameServerGroups))
+	assert.Equal(t, fileStore.GetInstallationID(), store.GetInstallationID())
+
+	a, err := store.GetAccountByPrivateDomain("TEST.COM")
+	require.NoError(t, err)
+	assert.Equal(t, expected.Id, a.Id)
+
+	tokenID, err := store.GetTokenIDByHashedToken("SoMeHaShEdToKeN")
+	require.NoError(t, err)
+	assert.Equal(t, "9dj38s35-63fb-11ec-90d6-0242ac120003", tokenID)
+
+	user, err := store.GetUserByTokenID(tokenID)
+	require.NoError(t, err)
+	assert.Equal(t, "f4f6d672-63fb-11ec-90d6-0242ac120003", user.Id)
+}
+
+func TestSqlite_SavePeerStatus(t *testing.T) {
+	store := newSqliteStore(t)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	err := store.SaveAccount(account)
+	require.NoError(t, err)
+
+	// save status of non-existing peer
+	newStatus := PeerStatus{Connected: true, LastSeen: time.Now().UTC()}
+	err = store.SavePeerStatus(account.Id, "non-existing-peer", newStatus)
+	assert.Error(t, err)
+
+	// save new status of existing peer
+	account.Peers["testpeer"] = &Peer{
+		Key:      "peerkey",
+		ID:       "testpeer",
+		SetupKey: "peerkeysetupkey",
+		IP:       net.IP{127, 0, 0, 1},
+		Meta:     PeerSystemMeta{},
+		Name:     "peer name",
+		Status:   &PeerStatus{Connected: false, LastSeen: time.Now().UTC()},
+	}
+
+	err = store.SaveAccount(account)
+	require.NoError(t, err)
+
+	err = store.SavePeerStatus(account.Id, "testpeer", newStatus)
+	require.NoError(t, err)
+
+	account, err = store.GetAccount(account.Id)
+	require.NoError(t, err)
+
+	actual := account.Peers["testpeer"].Status
+	assert.Equal(t, newStatus.Connected, actual.Connected)
+	assert.True(t, newStatus.LastSeen.Equal(actual.LastSeen))
+}
+
+func TestSqlite_SaveAccountPeer(t *testing.T) {
+	store := newSqliteStore(t)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	setupKey := GenerateDefaultSetupKey()
+	account.SetupKeys[setupKey.Key] = setupKey
+	account.Groups["group"] = &Group{ID: "group", Name: "group"}
+	err := store.SaveAccount(account)
+	require.NoError(t, err)
+
+	err = store.SaveAccountPeer(account, "non-existing-peer", nil, "")
+	assert.Error(t, err)
+
+	account.Peers["testpeer"] = &Peer{
+		Key:      "peerkey",
+		ID:       "testpeer",
+		SetupKey: setupKey.Key,
+		IP:       net.IP{100, 64, 0, 1},
+		Meta:     PeerSystemMeta{Hostname: "host"},
+		Name:     "peer name",
+		Status:   &PeerStatus{Connected: false, LastSeen: time.Now().UTC()},
+	}
+	account.Groups["group"].Peers = []string{"testpeer"}
+	account.SetupKeys[setupKey.Key] = setupKey.IncrementUsage()
+	account.Network.IncSerial()
+	// a change outside of the peer write shouldn't be persisted
+	account.Users["testuser"].Blocked = true
+
+	err = store.SaveAccountPeer(account, "testpeer", []string{"group"}, setupKey.Key)
+	require.NoError(t, err)
+
+	stored, err := store.GetAccount(account.Id)
+	require.NoError(t, err)
+	require.Contains(t, stored.Peers, "testpeer")
+	assert.Equal(t, "host", stored.Peers["testpeer"].Meta.Hostname)
+	assert.Equal(t, []string{"testpeer"}, stored.Groups["group"].Peers)
+	assert.Equal(t, 1, stored.SetupKeys[setupKey.Key].UsedTimes)
+	assert.Equal(t, account.Network.CurrentSerial(), stored.Network.CurrentSerial())
+	assert.False(t, stored.Users["testuser"].Blocked, "only the peer, its groups and setup key should be written")
+
+	account.Peers["testpeer"].Name = "renamed"
+	err = store.SaveAccountPeer(account, "testpeer", nil, "")
+	require.NoError(t, err)
+
+	stored, err = store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", stored.Peers["testpeer"].Name, "the existing peer should be updated")
+	assert.Len(t, stored.Peers, 1)
+
+	err = store.SaveAccountPeer(account, "testpeer", []string{"unknown"}, "")
+	assert.Error(t, err, "an unknown group should fail the write")
+}
+
+func TestSqlite_SavePostureChecks(t *testing.T) {
+	dataDir := t.TempDir()
+	store, err := NewSqliteStore(dataDir, nil)
+	require.NoError(t, err)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	account.PostureChecks = []*PostureChecks{
+		{ID: "version", Name: "version", NBVersionCheck: &NBVersionCheck{MinVersion: "0.23.0"}},
+		{ID: "kernel", Name: "kernel", KernelVersionCheck: &KernelVersionCheck{MinVersion: "5.4"},
+			OSCheck: &OSCheck{AllowedOS: []string{"linux"}}},
+	}
+	account.Policies[0].SourcePostureChecks = []string{"version", "kernel"}
+	for _, postureChecks := range account.PostureChecks {
+		// the checks are validated when saved through the account manager
+		require.NoError(t, postureChecks.Validate())
+	}
+	require.NoError(t, store.SaveAccount(account))
+	require.NoError(t, store.Close())
+
+	// reopening the store runs the column migrations against an up-to-date schema
+	store, err = NewSqliteStore(dataDir, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	stored, err := store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.Equal(t, account.PostureChecks, stored.PostureChecks)
+	assert.Equal(t, []string{"version", "kernel"}, stored.Policies[0].SourcePostureChecks)
+}
+
+func TestSqlite_SaveWebhooks(t *testing.T) {
+	store := newSqliteStore(t)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	account.Webhooks = []*Webhook{
+		{ID: "all", Name: "all", URL: "https://hooks.example.com/all", Secret: "0123456789abcdef", Enabled: true},
+		{ID: "peers", Name: "peers", URL: "https://hooks.example.com/peers", Secret: "fedcba9876543210",
+			Events: []string{"user.peer.add", "setupkey.peer.add"}},
+	}
+	require.NoError(t, store.SaveAccount(account))
+
+	stored, err := store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.Equal(t, account.Webhooks, stored.Webhooks)
+}
+
+func TestSqlite_SaveDNSZones(t *testing.T) {
+	store := newSqliteStore(t)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	account.DNSZones["zone"] = &DNSZone{
+		ID:          "zone",
+		Name:        "corp.example.com",
+		Description: "internal services",
+		Records: []*DNSRecord{
+			{Name: "db.corp.example.com", Type: DNSRecordTypeA, TTL: 300, Content: "10.0.0.10"},
+			{Name: "_sip._tcp.corp.example.com", Type: DNSRecordTypeSRV, TTL: 60, Content: "10 5 5060 sip.corp.example.com"},
+		},
+		DistributionGroups:  []string{"group"},
+		Enabled:             true,
+		SearchDomainEnabled: true,
+	}
+	require.NoError(t, store.SaveAccount(account))
+
+	stored, err := store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.Equal(t, account.DNSZones, stored.DNSZones)
+}
+
+func TestSqlite_SavePATScopes(t *testing.T) {
+	store := newSqliteStore(t)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	account.Users["testuser"].PATs = map[string]*PersonalAccessToken{
+		"scoped": {
+			ID:             "scoped",
+			Name:           "scoped",
+			HashedToken:    "scopedHash",
+			ExpirationDate: time.Now().UTC().AddDate(0, 0, 7).Truncate(time.Second),
+			Scopes:         []string{"peers:read", "events:read"},
+			CreatedBy:      "testuser",
+		},
+		"unscoped": {
+			ID:             "unscoped",
+			Name:           "unscoped",
+			HashedToken:    "unscopedHash",
+			ExpirationDate: time.Now().UTC().AddDate(0, 0, 7).Truncate(time.Second),
+			CreatedBy:      "testuser",
+		},
+	}
+	require.NoError(t, store.SaveAccount(account))
+
+	stored, err := store.GetAccount(account.Id)
+	require.NoError(t, err)
+	pats := stored.Users["testuser"].PATs
+	assert.Equal(t, []string{"peers:read", "events:read"}, pats["scoped"].Scopes)
+	assert.Empty(t, pats["unscoped"].Scopes)
+}
+
+func TestSqlite_SaveSetupKeyReservedIP(t *testing.T) {
+	store := newSqliteStore(t)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	key := GenerateSetupKey("reserved", SetupKeyOneOff, time.Hour, []string{}, SetupKeyUnlimitedUsage, false)
+	key.ReservedIP = net.ParseIP("100.64.0.20")
+	account.SetupKeys[key.Key] = key
+	require.NoError(t, store.SaveAccount(account))
+
+	stored, err := store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.True(t, key.ReservedIP.Equal(stored.SetupKeys[key.Key].ReservedIP))
+	for _, k := range stored.SetupKeys {
+		if k.Key != key.Key {
+			assert.Nil(t, k.ReservedIP, "keys without a reservation should not get an IP")
+		}
+	}
+}
+
+func TestSqlite_SaveSearchDomains(t *testing.T) {
+	store := newSqliteStore(t)
+
+	account := newAccountWithId("account_id", "testuser", "")
+	account.NameServerGroups["search"] = &nbdns.NameServerGroup{
+		ID:                   "search",
+		Name:                 "search",
+		NameServers:          []nbdns.NameServer{{IP: netip.MustParseAddr("1.1.1.1"), NSType: nbdns.UDPNameServerType, Port: 53}},
+		Groups:               []string{"group"},
+		Domains:              []string{"example.com"},
+		Enabled:              true,
+		SearchDomainsEnabled: true,
+	}
+	account.DNSSettings = &DNSSettings{DisabledManagementGroups: []string{}, PeerZoneSearchDomainEnabled: false}
+	require.NoError(t, store.SaveAccount(account))
+
+	stored, err := store.GetAccount(account.Id)
+	require.NoError(t, err)
+	assert.True(t, stored.NameServerGroups["search"].SearchDomainsEnabled)
+	assert.False(t, stored.DNSSettings.PeerZoneSearchDomainEnabled)
+}
+
+func newSqliteStore(t *testing.T) *SqlStore {
+	t.Helper()
+
+	store, err := NewSqliteStore(t.TempDir(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store
+}
@@ -1,6 +1,16 @@
 package server
 
-import "time"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/management/server/telemetry"
+)
 
 type Store interface {
 	GetAllAccounts() []*Account
@@ -23,6 +33,63 @@ type Store interface {
 	AcquireGlobalLock() func()
 	SavePeerStatus(accountID, peerID string, status PeerStatus) error
 	SaveUserLastLogin(accountID, userID string, lastLogin time.Time) error
+	// SaveAccountPeer should persist a single peer of the account along with the network serial and the groups and the
+	// setup key changed with the peer. Stores that can't write a single peer persist the whole account
+	SaveAccountPeer(account *Account, peerID string, groupIDs []string, setupKey string) error
 	// Close should close the store persisting all unsaved data.
 	Close() error
+	// GetStoreEngine should return StoreEngine of the current store implementation.
+	GetStoreEngine() StoreEngine
+}
+
+// StoreEngine identifies a Store implementation
+type StoreEngine string
+
+const (
+	FileStoreEngine   StoreEngine = "jsonfile"
+	SqliteStoreEngine StoreEngine = "sqlite"
+	// PostgresStoreEngine keeps the data in a Postgres database, the connection string is read from the
+	// NETBIRD_STORE_ENGINE_POSTGRES_DSN environment variable
+	PostgresStoreEngine StoreEngine = "postgres"
+)
+
+// StoreFile returns the path of the file holding the data of the store engine in the datadir
+func StoreFile(engine StoreEngine, dataDir string) (string, error) {
+	switch StoreEngine(strings.ToLower(string(engine))) {
+	case "", FileStoreEngine:
+		return filepath.Join(dataDir, storeFileName), nil
+	case SqliteStoreEngine:
+		return filepath.Join(dataDir, storeSqliteFileName), nil
+	case PostgresStoreEngine:
+		return "", fmt.Errorf("the %s store engine doesn't keep its data in the datadir", engine)
+	default:
+		return "", fmt.Errorf("unsupported store engine %s", engine)
+	}
+}
+
+// NewStore creates a new store based on the provided engine type. Falls back to the FileStore when engine is empty
+func NewStore(engine StoreEngine, dataDir string, metrics telemetry.AppMetrics) (Store, error) {
+	switch StoreEngine(strings.ToLower(string(engine))) {
+	case "", FileStoreEngine:
+		if _, err := os.Stat(filepath.Join(dataDir, storeSqliteFileName)); err == nil {
+			log.Warnf("a SQLite store was found in %s but the %s store engine is configured", dataDir, FileStoreEngine)
+		}
+		return NewFileStore(dataDir, metrics)
+	case SqliteStoreEngine:
+		if _, err := os.Stat(filepath.Join(dataDir, storeSqliteFileName)); os.IsNotExist(err) {
+			if _, err := os.Stat(filepath.Join(dataDir, storeFileName)); err == nil {
+				log.Warnf("the %s store engine is configured but only a %s file was found in %s, "+
+					"run the sqlite-migration command to move the existing data", SqliteStoreEngine, storeFileName, dataDir)
+			}
+		}
+		return NewSqliteStore(dataDir, metrics)
+	case PostgresStoreEngine:
+		dsn, ok := os.LookupEnv(postgresDSNEnv)
+		if !ok {
+			return nil, fmt.Errorf("the %s store engine requires the %s environment variable", PostgresStoreEngine, postgresDSNEnv)
+		}
+		return NewPostgresStore(dsn, metrics)
+	default:
+		return nil, fmt.Errorf("unsupported store engine %s", engine)
+	}
 }
@@ -0,0 +1,36 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+// ActivityMetrics represents all metrics related to the activity events streamed to the external sinks
+type ActivityMetrics struct {
+	droppedEventsCounter syncint64.Counter
+	ctx                  context.Context
+}
+
+// NewActivityMetrics creates an instance of ActivityMetrics
+func NewActivityMetrics(ctx context.Context, meter metric.Meter) (*ActivityMetrics, error) {
+	droppedEventsCounter, err := meter.SyncInt64().Counter("management.activity.sink.dropped.counter",
+		instrument.WithDescription("Number of activity events dropped because the queue of the sink stayed full"),
+		instrument.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ActivityMetrics{
+		droppedEventsCounter: droppedEventsCounter,
+		ctx:                  ctx,
+	}, nil
+}
+
+// CountDroppedEvent counts the activity events dropped by a sink
+func (metrics *ActivityMetrics) CountDroppedEvent(sink string) {
+	metrics.droppedEventsCounter.Add(metrics.ctx, 1, attribute.String("sink", sink))
+}
@@ -20,13 +20,15 @@ const defaultEndpoint = "/metrics"
 
 // MockAppMetrics mocks the AppMetrics interface
 type MockAppMetrics struct {
-	GetMeterFunc       func() metric2.Meter
-	CloseFunc          func() error
-	ExposeFunc         func(port int, endpoint string) error
-	IDPMetricsFunc     func() *IDPMetrics
-	HTTPMiddlewareFunc func() *HTTPMiddleware
-	GRPCMetricsFunc    func() *GRPCMetrics
-	StoreMetricsFunc   func() *StoreMetrics
+	GetMeterFunc             func() metric2.Meter
+	CloseFunc                func() error
+	ExposeFunc               func(port int, endpoint string) error
+	IDPMetricsFunc           func() *IDPMetrics
+	HTTPMiddlewareFunc       func() *HTTPMiddleware
+	GRPCMetricsFunc          func() *GRPCMetrics
+	StoreMetricsFunc         func() *StoreMetrics
+	UpdateChannelMetricsFunc func() *UpdateChannelMetrics
+	ActivityMetricsFunc      func() *ActivityMetrics
 }
 
 // GetMeter mocks the GetMeter function of the AppMetrics interface
@@ -85,6 +87,22 @@ func (mock *MockAppMetrics) StoreMetrics() *StoreMetrics {
 	return nil
 }
 
+// UpdateChannelMetrics mocks the MockAppMetrics function of the UpdateChannelMetrics interface
+func (mock *MockAppMetrics) UpdateChannelMetrics() *UpdateChannelMetrics {
+	if mock.UpdateChannelMetricsFunc != nil {
+		return mock.UpdateChannelMetricsFunc()
+	}
+	return nil
+}
+
+// ActivityMetrics mocks the MockAppMetrics function of the ActivityMetrics interface
+func (mock *MockAppMetrics) ActivityMetrics() *ActivityMetrics {
+	if mock.ActivityMetricsFunc != nil {
+		return mock.ActivityMetricsFunc()
+	}
+	return nil
+}
+
 // AppMetrics is metrics interface
 type AppMetrics interface {
 	GetMeter() metric2.Meter
@@ -94,18 +112,22 @@ type AppMetrics interface {
 	HTTPMiddleware() *HTTPMiddleware
 	GRPCMetrics() *GRPCMetrics
 	StoreMetrics() *StoreMetrics
+	UpdateChannelMetrics() *UpdateChannelMetrics
+	ActivityMetrics() *ActivityMetrics
 }
 
 // defaultAppMetrics are core application metrics based on OpenTelemetry https://opentelemetry.io/
 type defaultAppMetrics struct {
 	// Meter can be used by different application parts to create counters and measure things
-	Meter          metric2.Meter
-	listener       net.Listener
-	ctx            context.Context
-	idpMetrics     *IDPMetrics
-	httpMiddleware *HTTPMiddleware
-	grpcMetrics    *GRPCMetrics
-	storeMetrics   *StoreMetrics
+	Meter                metric2.Meter
+	listener             net.Listener
+	ctx                  context.Context
+	idpMetrics           *IDPMetrics
+	httpMiddleware       *HTTPMiddleware
+	grpcMetrics          *GRPCMetrics
+	storeMetrics         *StoreMetrics
+	updateChannelMetrics *UpdateChannelMetrics
+	activityMetrics      *ActivityMetrics
 }
 
 // IDPMetrics returns metrics for the idp package
@@ -128,6 +150,16 @@ func (appMetrics *defaultAppMetrics) StoreMetrics() *StoreMetrics {
 	return appMetrics.storeMetrics
 }
 
+// UpdateChannelMetrics returns metrics for the peers update channels
+func (appMetrics *defaultAppMetrics) UpdateChannelMetrics() *UpdateChannelMetrics {
+	return appMetrics.updateChannelMetrics
+}
+
+// ActivityMetrics returns metrics for the activity events sinks
+func (appMetrics *defaultAppMetrics) ActivityMetrics() *ActivityMetrics {
+	return appMetrics.activityMetrics
+}
+
 // Close stop application metrics HTTP handler and closes listener.
 func (appMetrics *defaultAppMetrics) Close() error {
 	if appMetrics.listener == nil {
@@ -199,6 +231,17 @@ func NewDefaultAppMetrics(ctx context.Context) (AppMetrics, error) {
 		return nil, err
 	}
 
+	updateChannelMetrics, err := NewUpdateChannelMetrics(ctx, meter)
+	if err != nil {
+		return nil, err
+	}
+
+	activityMetrics, err := NewActivityMetrics(ctx, meter)
+	if err != nil {
+		return nil, err
+	}
+
 	return &defaultAppMetrics{Meter: meter, ctx: ctx, idpMetrics: idpMetrics, httpMiddleware: middleware,
-		grpcMetrics: grpcMetrics, storeMetrics: storeMetrics}, nil
+		grpcMetrics: grpcMetrics, storeMetrics: storeMetrics, updateChannelMetrics: updateChannelMetrics,
+		activityMetrics: activityMetrics}, nil
 }
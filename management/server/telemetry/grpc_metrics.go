@@ -55,7 +55,7 @@ func NewGRPCMetrics(ctx context.Context, meter metric.Meter) (*GRPCMetrics, erro
 
 	// We use histogram here as we have multiple channel at the same time and we want to see a slice at any given time
 	// Then we should be able to extract min, manx, mean and the percentiles.
-	// TODO(yury): This needs custom bucketing as we are interested in the values from 0 to server.channelBufferSize (100)
+	// TODO(yury): This needs custom bucketing as we are interested in the values from 0 to server.channelBufferSize
 	channelQueue, err := meter.SyncInt64().Histogram(
 		"management.grpc.updatechannel.queue",
 		instrument.WithDescription("Number of update messages in the channel queue"),
@@ -0,0 +1,109 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/asyncint64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+// UpdateChannelMetrics represents all metrics related to the peers update channels and the batched network map fan-out
+type UpdateChannelMetrics struct {
+	meter                  metric.Meter
+	sentUpdatesCounter     syncint64.Counter
+	replacedUpdatesCounter syncint64.Counter
+	batchChanges           syncint64.Histogram
+	batchPeers             syncint64.Histogram
+	batchDelayMs           syncint64.Histogram
+	updateWindowGauge      asyncint64.Gauge
+	ctx                    context.Context
+}
+
+// NewUpdateChannelMetrics creates an instance of UpdateChannelMetrics
+func NewUpdateChannelMetrics(ctx context.Context, meter metric.Meter) (*UpdateChannelMetrics, error) {
+	sentUpdatesCounter, err := meter.SyncInt64().Counter("management.updatechannel.sent.counter",
+		instrument.WithDescription("Number of update messages sent to the peers update channels"),
+		instrument.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+
+	replacedUpdatesCounter, err := meter.SyncInt64().Counter("management.updatechannel.replaced.counter",
+		instrument.WithDescription("Number of pending update messages replaced by a newer one before being consumed"),
+		instrument.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+
+	batchChanges, err := meter.SyncInt64().Histogram("management.account.peers.update.batch.changes",
+		instrument.WithDescription("Number of account changes coalesced in a single network map fan-out"),
+		instrument.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+
+	batchPeers, err := meter.SyncInt64().Histogram("management.account.peers.update.batch.peers",
+		instrument.WithDescription("Number of peers updated by a single network map fan-out"),
+		instrument.WithUnit("1"))
+	if err != nil {
+		return nil, err
+	}
+
+	batchDelayMs, err := meter.SyncInt64().Histogram("management.account.peers.update.batch.delay.ms",
+		instrument.WithDescription("Delay between the first coalesced account change and the network map fan-out"),
+		instrument.WithUnit("milliseconds"))
+	if err != nil {
+		return nil, err
+	}
+
+	updateWindowGauge, err := meter.AsyncInt64().Gauge("management.account.peers.update.window.ms",
+		instrument.WithDescription("Window over which the account changes are coalesced before the network map fan-out"),
+		instrument.WithUnit("milliseconds"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateChannelMetrics{
+		meter:                  meter,
+		sentUpdatesCounter:     sentUpdatesCounter,
+		replacedUpdatesCounter: replacedUpdatesCounter,
+		batchChanges:           batchChanges,
+		batchPeers:             batchPeers,
+		batchDelayMs:           batchDelayMs,
+		updateWindowGauge:      updateWindowGauge,
+		ctx:                    ctx,
+	}, nil
+}
+
+// CountSentUpdate counts the update messages sent to the peers update channels
+func (metrics *UpdateChannelMetrics) CountSentUpdate() {
+	metrics.sentUpdatesCounter.Add(metrics.ctx, 1)
+}
+
+// CountReplacedUpdate counts the pending update messages replaced by a newer one
+func (metrics *UpdateChannelMetrics) CountReplacedUpdate() {
+	metrics.replacedUpdatesCounter.Add(metrics.ctx, 1)
+}
+
+// CountPeersUpdateBatch records the number of coalesced changes, the number of updated peers and the delay of a
+// network map fan-out
+func (metrics *UpdateChannelMetrics) CountPeersUpdateBatch(changes, peers int, delay time.Duration) {
+	metrics.batchChanges.Record(metrics.ctx, int64(changes))
+	metrics.batchPeers.Record(metrics.ctx, int64(peers))
+	metrics.batchDelayMs.Record(metrics.ctx, delay.Milliseconds())
+}
+
+// RegisterPeersUpdateWindow feeds the configured window of the network map fan-out to the metrics gauge
+func (metrics *UpdateChannelMetrics) RegisterPeersUpdateWindow(window time.Duration) error {
+	return metrics.meter.RegisterCallback(
+		[]instrument.Asynchronous{
+			metrics.updateWindowGauge,
+		},
+		func(ctx context.Context) {
+			metrics.updateWindowGauge.Observe(ctx, window.Milliseconds())
+		},
+	)
+}
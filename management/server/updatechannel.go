@@ -6,42 +6,121 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/netbirdio/netbird/management/proto"
+	"github.com/netbirdio/netbird/management/server/telemetry"
 )
 
-const channelBufferSize = 100
+// channelBufferSize is the size of the peers update channels. A channel only holds the newest update,
+// a pending update is replaced when a newer one is sent
+const channelBufferSize = 1
 
 type UpdateMessage struct {
 	Update *proto.SyncResponse
+	// networkMapUpdate is set for the network map updates of the account changes, the Sync streams of the clients
+	// supporting it send a delta of the network map
+	networkMapUpdate *networkMapUpdate
 }
 
 type PeersUpdateManager struct {
 	// peerChannels is an update channel indexed by Peer.ID
 	peerChannels map[string]chan *UpdateMessage
 	channelsMux  *sync.Mutex
+	// metrics may be nil
+	metrics telemetry.AppMetrics
 }
 
 // NewPeersUpdateManager returns a new instance of PeersUpdateManager
-func NewPeersUpdateManager() *PeersUpdateManager {
+func NewPeersUpdateManager(metrics telemetry.AppMetrics) *PeersUpdateManager {
 	return &PeersUpdateManager{
 		peerChannels: make(map[string]chan *UpdateMessage),
 		channelsMux:  &sync.Mutex{},
+		metrics:      metrics,
 	}
 }
 
-// SendUpdate sends update message to the peer's channel
+// SendUpdate sends update message to the peer's channel.
+// An update that is still pending in the channel is replaced by the new one, merged with the parts of the pending
+// update that the new one doesn't carry
 func (p *PeersUpdateManager) SendUpdate(peerID string, update *UpdateMessage) {
 	p.channelsMux.Lock()
 	defer p.channelsMux.Unlock()
-	if channel, ok := p.peerChannels[peerID]; ok {
-		select {
-		case channel <- update:
-			log.Debugf("update was sent to channel for peer %s", peerID)
-		default:
-			log.Warnf("channel for peer %s is %d full", peerID, len(channel))
-		}
-	} else {
+	channel, ok := p.peerChannels[peerID]
+	if !ok {
 		log.Debugf("peer %s has no channel", peerID)
+		return
+	}
+
+	select {
+	case pending := <-channel:
+		update = mergeUpdates(pending, update)
+		if metrics := p.updateChannelMetrics(); metrics != nil {
+			metrics.CountReplacedUpdate()
+		}
+		log.Debugf("replaced the pending update in the channel for peer %s", peerID)
+	default:
+	}
+
+	// the channel can't be full: all senders hold channelsMux and the pending update has been taken out
+	select {
+	case channel <- update:
+		if metrics := p.updateChannelMetrics(); metrics != nil {
+			metrics.CountSentUpdate()
+		}
+		log.Debugf("update was sent to channel for peer %s", peerID)
+	default:
+		log.Warnf("channel for peer %s is %d full", peerID, len(channel))
+	}
+}
+
+func (p *PeersUpdateManager) updateChannelMetrics() *telemetry.UpdateChannelMetrics {
+	if p.metrics == nil {
+		return nil
+	}
+	return p.metrics.UpdateChannelMetrics()
+}
+
+// mergeUpdates returns the update that replaces the pending update of a channel: the newest network map and
+// configuration are kept, e.g. a TURN credentials update doesn't drop a pending network map
+func mergeUpdates(pending, update *UpdateMessage) *UpdateMessage {
+	if pending.Update == nil {
+		return update
+	}
+	if update.Update == nil {
+		return pending
+	}
+
+	merged := &proto.SyncResponse{
+		WiretrusteeConfig:  update.Update.GetWiretrusteeConfig(),
+		PeerConfig:         update.Update.GetPeerConfig(),
+		RemotePeers:        update.Update.GetRemotePeers(),
+		RemotePeersIsEmpty: update.Update.GetRemotePeersIsEmpty(),
+		NetworkMap:         update.Update.GetNetworkMap(),
+	}
+	if merged.WiretrusteeConfig == nil {
+		merged.WiretrusteeConfig = pending.Update.GetWiretrusteeConfig()
+	}
+	if merged.NetworkMap == nil {
+		merged.PeerConfig = pending.Update.GetPeerConfig()
+		merged.RemotePeers = pending.Update.GetRemotePeers()
+		merged.RemotePeersIsEmpty = pending.Update.GetRemotePeersIsEmpty()
+		merged.NetworkMap = pending.Update.GetNetworkMap()
+		return &UpdateMessage{Update: merged, networkMapUpdate: pending.networkMapUpdate}
+	}
+
+	// the delta of the newest network map has to hold the changes of the replaced one too
+	mapUpdate := update.networkMapUpdate
+	if mapUpdate != nil && pending.Update.GetNetworkMap() != nil {
+		var pendingChange *networkMapChange
+		if pending.networkMapUpdate != nil {
+			pendingChange = pending.networkMapUpdate.change
+		}
+		mapUpdate = &networkMapUpdate{
+			peer:       mapUpdate.peer,
+			networkMap: mapUpdate.networkMap,
+			dnsName:    mapUpdate.dnsName,
+			change:     pendingChange.merge(mapUpdate.change),
+		}
 	}
+	return &UpdateMessage{Update: merged, networkMapUpdate: mapUpdate}
 }
 
 // CreateChannel creates a go channel for a given peer used to deliver updates relevant to the peer.
@@ -53,7 +132,6 @@ func (p *PeersUpdateManager) CreateChannel(peerID string) chan *UpdateMessage {
 		delete(p.peerChannels, peerID)
 		close(channel)
 	}
-	// mbragin: todo shouldn't it be more? or configurable?
 	channel := make(chan *UpdateMessage, channelBufferSize)
 	p.peerChannels[peerID] = channel
 
@@ -10,7 +10,7 @@ import (
 
 func TestCreateChannel(t *testing.T) {
 	peer := "test-create"
-	peersUpdater := NewPeersUpdateManager()
+	peersUpdater := NewPeersUpdateManager(nil)
 	defer peersUpdater.CloseChannel(peer)
 
 	_ = peersUpdater.CreateChannel(peer)
@@ -21,7 +21,7 @@ func TestCreateChannel(t *testing.T) {
 
 func TestSendUpdate(t *testing.T) {
 	peer := "test-sendupdate"
-	peersUpdater := NewPeersUpdateManager()
+	peersUpdater := NewPeersUpdateManager(nil)
 	update1 := &UpdateMessage{Update: &proto.SyncResponse{
 		NetworkMap: &proto.NetworkMap{
 			Serial: 0,
@@ -38,34 +38,75 @@ func TestSendUpdate(t *testing.T) {
 		t.Error("Update wasn't send")
 	}
 
-	for range [channelBufferSize]int{} {
-		peersUpdater.SendUpdate(peer, update1)
+	for serial := uint64(1); serial <= 10; serial++ {
+		peersUpdater.SendUpdate(peer, &UpdateMessage{Update: &proto.SyncResponse{
+			NetworkMap: &proto.NetworkMap{
+				Serial: serial,
+			},
+		}})
 	}
 
-	update2 := &UpdateMessage{Update: &proto.SyncResponse{
-		NetworkMap: &proto.NetworkMap{
-			Serial: 10,
-		},
+	if len(peersUpdater.peerChannels[peer]) != 1 {
+		t.Fatalf("expected the channel to hold only the newest update, got %d updates", len(peersUpdater.peerChannels[peer]))
+	}
+
+	turnUpdate := &UpdateMessage{Update: &proto.SyncResponse{
+		WiretrusteeConfig: &proto.WiretrusteeConfig{},
 	}}
+	peersUpdater.SendUpdate(peer, turnUpdate)
 
-	peersUpdater.SendUpdate(peer, update2)
 	timeout := time.After(5 * time.Second)
-	for range [channelBufferSize]int{} {
-		select {
-		case <-timeout:
-			t.Error("timed out reading previously sent updates")
-		case updateReader := <-peersUpdater.peerChannels[peer]:
-			if updateReader.Update.NetworkMap.Serial == update2.Update.NetworkMap.Serial {
-				t.Error("got the update that shouldn't have been sent")
-			}
+	select {
+	case <-timeout:
+		t.Error("timed out reading the pending update")
+	case updateReader := <-peersUpdater.peerChannels[peer]:
+		if updateReader.Update.NetworkMap.GetSerial() != 10 {
+			t.Errorf("expected the newest network map with serial 10, got %d", updateReader.Update.NetworkMap.GetSerial())
+		}
+		if updateReader.Update.WiretrusteeConfig == nil {
+			t.Error("the configuration update should be merged with the pending network map")
+		}
+	}
+}
+
+func TestSendUpdate_MergesChangeSets(t *testing.T) {
+	peer := "test-mergechanges"
+	peersUpdater := NewPeersUpdateManager(nil)
+	_ = peersUpdater.CreateChannel(peer)
+	defer peersUpdater.CloseChannel(peer)
+
+	networkMapUpdate := func(peerID string) *UpdateMessage {
+		return &UpdateMessage{
+			Update: &proto.SyncResponse{NetworkMap: &proto.NetworkMap{}},
+			networkMapUpdate: &networkMapUpdate{
+				change: &networkMapChange{peers: map[string]bool{peerID: false}},
+			},
 		}
 	}
 
+	peersUpdater.SendUpdate(peer, networkMapUpdate("peer1"))
+	peersUpdater.SendUpdate(peer, networkMapUpdate("peer2"))
+	peersUpdater.SendUpdate(peer, &UpdateMessage{Update: &proto.SyncResponse{WiretrusteeConfig: &proto.WiretrusteeConfig{}}})
+
+	update := <-peersUpdater.peerChannels[peer]
+	if update.networkMapUpdate == nil || len(update.networkMapUpdate.change.peers) != 2 {
+		t.Fatalf("expected the delta of the newest network map to hold the changes of the replaced one, got %v",
+			update.networkMapUpdate)
+	}
+
+	peersUpdater.SendUpdate(peer, networkMapUpdate("peer1"))
+	peersUpdater.SendUpdate(peer, &UpdateMessage{Update: &proto.SyncResponse{NetworkMap: &proto.NetworkMap{}}})
+	peersUpdater.SendUpdate(peer, networkMapUpdate("peer2"))
+
+	update = <-peersUpdater.peerChannels[peer]
+	if update.networkMapUpdate == nil || update.networkMapUpdate.change != nil {
+		t.Fatal("expected the change set to be unknown when a replaced network map has no change set")
+	}
 }
 
 func TestCloseChannel(t *testing.T) {
 	peer := "test-close"
-	peersUpdater := NewPeersUpdateManager()
+	peersUpdater := NewPeersUpdateManager(nil)
 	_ = peersUpdater.CreateChannel(peer)
 	if _, ok := peersUpdater.peerChannels[peer]; !ok {
 		t.Error("Error creating the channel")
@@ -15,9 +15,13 @@ import (
 )
 
 const (
-	UserRoleAdmin   UserRole = "admin"
-	UserRoleUser    UserRole = "user"
-	UserRoleUnknown UserRole = "unknown"
+	UserRoleAdmin UserRole = "admin"
+	UserRoleUser  UserRole = "user"
+	// UserRoleAuditor has read-only access to all the resources of the account and to the events
+	UserRoleAuditor UserRole = "auditor"
+	// UserRoleNetworkAdmin can manage groups, policies, routes and DNS, but not users, tokens or setup keys
+	UserRoleNetworkAdmin UserRole = "network_admin"
+	UserRoleUnknown      UserRole = "unknown"
 
 	UserStatusActive   UserStatus = "active"
 	UserStatusDisabled UserStatus = "disabled"
@@ -31,6 +35,10 @@ func StrRoleToUserRole(strRole string) UserRole {
 		return UserRoleAdmin
 	case "user":
 		return UserRoleUser
+	case "auditor":
+		return UserRoleAuditor
+	case "network_admin":
+		return UserRoleNetworkAdmin
 	default:
 		return UserRoleUnknown
 	}
@@ -72,6 +80,22 @@ func (u *User) IsAdmin() bool {
 	return u.Role == UserRoleAdmin
 }
 
+// HasReadAllAccess returns true if the user can view all the resources of the account, false if the user
+// can only view the resources it owns
+func (u *User) HasReadAllAccess() bool {
+	switch u.Role {
+	case UserRoleAdmin, UserRoleNetworkAdmin, UserRoleAuditor:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanManageNetwork returns true if the user can modify groups, policies, posture checks, routes and DNS
+func (u *User) CanManageNetwork() bool {
+	return u.Role == UserRoleAdmin || u.Role == UserRoleNetworkAdmin
+}
+
 // ToUserInfo converts a User object to a UserInfo object.
 func (u *User) ToUserInfo(userData *idp.UserData) (*UserInfo, error) {
 	autoGroups := u.AutoGroups
@@ -224,6 +248,14 @@ func (am *DefaultAccountManager) inviteNewUser(accountID, userID string, invite
 		return nil, status.Errorf(status.NotFound, "account %s doesn't exist", accountID)
 	}
 
+	executingUser, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !executingUser.IsAdmin() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins can invite users")
+	}
+
 	// initiator is the one who is inviting the new user
 	initiatorUser, err := am.lookupUserInCache(userID, account)
 	if err != nil {
@@ -410,6 +442,14 @@ func (am *DefaultAccountManager) InviteUser(accountID string, initiatorUserID st
 		return status.Errorf(status.NotFound, "account %s doesn't exist", accountID)
 	}
 
+	executingUser, err := account.FindUser(initiatorUserID)
+	if err != nil {
+		return err
+	}
+	if !executingUser.IsAdmin() {
+		return status.Errorf(status.PermissionDenied, "only admins can invite users")
+	}
+
 	// check if the user is already registered with this ID
 	user, err := am.lookupUserInCache(targetUserID, account)
 	if err != nil {
@@ -437,7 +477,7 @@ func (am *DefaultAccountManager) InviteUser(accountID string, initiatorUserID st
 }
 
 // CreatePAT creates a new PAT for the given user
-func (am *DefaultAccountManager) CreatePAT(accountID string, initiatorUserID string, targetUserID string, tokenName string, expiresIn int) (*PersonalAccessTokenGenerated, error) {
+func (am *DefaultAccountManager) CreatePAT(accountID string, initiatorUserID string, targetUserID string, tokenName string, expiresIn int, scopes []string) (*PersonalAccessTokenGenerated, error) {
 	unlock := am.Store.AcquireAccountLock(accountID)
 	defer unlock()
 
@@ -449,6 +489,10 @@ func (am *DefaultAccountManager) CreatePAT(accountID string, initiatorUserID str
 		return nil, status.Errorf(status.InvalidArgument, "expiration has to be between 1 and 365")
 	}
 
+	if err := validatePATScopes(scopes); err != nil {
+		return nil, err
+	}
+
 	account, err := am.Store.GetAccount(accountID)
 	if err != nil {
 		return nil, err
@@ -468,7 +512,7 @@ func (am *DefaultAccountManager) CreatePAT(accountID string, initiatorUserID str
 		return nil, status.Errorf(status.PermissionDenied, "no permission to create PAT for this user")
 	}
 
-	pat, err := CreateNewPAT(tokenName, expiresIn, executingUser.Id)
+	pat, err := CreateNewPAT(tokenName, expiresIn, scopes, executingUser.Id)
 	if err != nil {
 		return nil, status.Errorf(status.Internal, "failed to create PAT: %v", err)
 	}
@@ -481,6 +525,9 @@ func (am *DefaultAccountManager) CreatePAT(accountID string, initiatorUserID str
 	}
 
 	meta := map[string]any{"name": pat.Name, "is_service_user": targetUser.IsServiceUser, "user_name": targetUser.ServiceUserName}
+	if len(pat.Scopes) != 0 {
+		meta["scopes"] = pat.Scopes
+	}
 	am.storeEvent(initiatorUserID, targetUserID, accountID, activity.PersonalAccessTokenCreated, meta)
 
 	return pat, nil
@@ -676,7 +723,8 @@ func (am *DefaultAccountManager) SaveUser(accountID, initiatorUserID string, upd
 			return nil, err
 		}
 
-		am.updateAccountPeers(account)
+		changedGroups := append(removedGroups, update.AutoGroups...)
+		am.updateAffectedPeersDNS(account, account.getPeersAffectedByUserGroups(oldUser.Id, changedGroups...))
 	} else {
 		if err = am.Store.SaveAccount(account); err != nil {
 			return nil, err
@@ -802,8 +850,8 @@ func (am *DefaultAccountManager) GetUsersFromAccount(accountID, userID string) (
 	// in case of self-hosted, or IDP doesn't return anything, we will return the locally stored userInfo
 	if len(queriedUsers) == 0 {
 		for _, accountUser := range account.Users {
-			if !user.IsAdmin() && user.Id != accountUser.Id {
-				// if user is not an admin then show only current user and do not show other users
+			if !user.HasReadAllAccess() && user.Id != accountUser.Id {
+				// if user is a regular user then show only current user and do not show other users
 				continue
 			}
 			info, err := accountUser.ToUserInfo(nil)
@@ -816,8 +864,8 @@ func (am *DefaultAccountManager) GetUsersFromAccount(accountID, userID string) (
 	}
 
 	for _, localUser := range account.Users {
-		if !user.IsAdmin() && user.Id != localUser.Id {
-			// if user is not an admin then show only current user and do not show other users
+		if !user.HasReadAllAccess() && user.Id != localUser.Id {
+			// if user is a regular user then show only current user and do not show other users
 			continue
 		}
 
@@ -870,7 +918,7 @@ func (am *DefaultAccountManager) expireAndUpdatePeers(account *Account, peers []
 	if len(peerIDs) != 0 {
 		// this will trigger peer disconnect from the management service
 		am.peersUpdateManager.CloseChannels(peerIDs)
-		am.updateAccountPeers(account)
+		am.updatePeersAffectedByPeers(account, peerIDs...)
 	}
 	return nil
 }
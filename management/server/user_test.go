@@ -12,6 +12,7 @@ import (
 
 	"github.com/netbirdio/netbird/management/server/activity"
 	"github.com/netbirdio/netbird/management/server/jwtclaims"
+	"github.com/netbirdio/netbird/management/server/status"
 )
 
 const (
@@ -45,7 +46,7 @@ func TestUser_CreatePAT_ForSameUser(t *testing.T) {
 		eventStore: &activity.InMemoryEventStore{},
 	}
 
-	pat, err := am.CreatePAT(mockAccountID, mockUserID, mockUserID, mockTokenName, mockExpiresIn)
+	pat, err := am.CreatePAT(mockAccountID, mockUserID, mockUserID, mockTokenName, mockExpiresIn, nil)
 	if err != nil {
 		t.Fatalf("Error when adding PAT to user: %s", err)
 	}
@@ -85,7 +86,7 @@ func TestUser_CreatePAT_ForDifferentUser(t *testing.T) {
 		eventStore: &activity.InMemoryEventStore{},
 	}
 
-	_, err = am.CreatePAT(mockAccountID, mockUserID, mockTargetUserId, mockTokenName, mockExpiresIn)
+	_, err = am.CreatePAT(mockAccountID, mockUserID, mockTargetUserId, mockTokenName, mockExpiresIn, nil)
 	assert.Errorf(t, err, "Creating PAT for different user should thorw error")
 }
 
@@ -106,7 +107,7 @@ func TestUser_CreatePAT_ForServiceUser(t *testing.T) {
 		eventStore: &activity.InMemoryEventStore{},
 	}
 
-	pat, err := am.CreatePAT(mockAccountID, mockUserID, mockTargetUserId, mockTokenName, mockExpiresIn)
+	pat, err := am.CreatePAT(mockAccountID, mockUserID, mockTargetUserId, mockTokenName, mockExpiresIn, nil)
 	if err != nil {
 		t.Fatalf("Error when adding PAT to user: %s", err)
 	}
@@ -128,7 +129,7 @@ func TestUser_CreatePAT_WithWrongExpiration(t *testing.T) {
 		eventStore: &activity.InMemoryEventStore{},
 	}
 
-	_, err = am.CreatePAT(mockAccountID, mockUserID, mockUserID, mockTokenName, mockWrongExpiresIn)
+	_, err = am.CreatePAT(mockAccountID, mockUserID, mockUserID, mockTokenName, mockWrongExpiresIn, nil)
 	assert.Errorf(t, err, "Wrong expiration should thorw error")
 }
 
@@ -146,10 +147,34 @@ func TestUser_CreatePAT_WithEmptyName(t *testing.T) {
 		eventStore: &activity.InMemoryEventStore{},
 	}
 
-	_, err = am.CreatePAT(mockAccountID, mockUserID, mockUserID, mockEmptyTokenName, mockExpiresIn)
+	_, err = am.CreatePAT(mockAccountID, mockUserID, mockUserID, mockEmptyTokenName, mockExpiresIn, nil)
 	assert.Errorf(t, err, "Wrong expiration should thorw error")
 }
 
+func TestUser_CreatePAT_WithScopes(t *testing.T) {
+	store := newStore(t)
+	account := newAccountWithId(mockAccountID, mockUserID, "")
+
+	err := store.SaveAccount(account)
+	if err != nil {
+		t.Fatalf("Error when saving account: %s", err)
+	}
+
+	am := DefaultAccountManager{
+		Store:      store,
+		eventStore: &activity.InMemoryEventStore{},
+	}
+
+	pat, err := am.CreatePAT(mockAccountID, mockUserID, mockUserID, mockTokenName, mockExpiresIn, []string{"peers:read", "routes:write"})
+	if err != nil {
+		t.Fatalf("Error when adding PAT to user: %s", err)
+	}
+	assert.Equal(t, []string{"peers:read", "routes:write"}, pat.Scopes)
+
+	_, err = am.CreatePAT(mockAccountID, mockUserID, mockUserID, mockTokenName, mockExpiresIn, []string{"peers:delete"})
+	assert.Errorf(t, err, "Invalid scope should throw error")
+}
+
 func TestUser_DeletePAT(t *testing.T) {
 	store := newStore(t)
 	account := newAccountWithId(mockAccountID, mockUserID, "")
@@ -507,6 +532,109 @@ func TestUser_IsAdmin(t *testing.T) {
 	assert.False(t, user.IsAdmin())
 }
 
+func TestUser_Roles(t *testing.T) {
+	testCases := []struct {
+		role             string
+		expectedRole     UserRole
+		readAllAccess    bool
+		canManageNetwork bool
+	}{
+		{role: "admin", expectedRole: UserRoleAdmin, readAllAccess: true, canManageNetwork: true},
+		{role: "network_admin", expectedRole: UserRoleNetworkAdmin, readAllAccess: true, canManageNetwork: true},
+		{role: "auditor", expectedRole: UserRoleAuditor, readAllAccess: true},
+		{role: "user", expectedRole: UserRoleUser},
+		{role: "owner", expectedRole: UserRoleUnknown},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.role, func(t *testing.T) {
+			user := NewUser(mockUserID, StrRoleToUserRole(tc.role), false, "", nil)
+			assert.Equal(t, tc.expectedRole, user.Role)
+			assert.Equal(t, tc.readAllAccess, user.HasReadAllAccess())
+			assert.Equal(t, tc.canManageNetwork, user.CanManageNetwork())
+		})
+	}
+}
+
+func TestDefaultAccountManager_RolePermissions(t *testing.T) {
+	const (
+		auditorUserID      = "auditorUser"
+		networkAdminUserID = "networkAdminUser"
+		regularUserID      = "regularUser"
+	)
+
+	manager, err := createManager(t)
+	require.NoError(t, err)
+
+	account, err := createAccount(manager, mockAccountID, mockUserID, "")
+	require.NoError(t, err)
+	account.Users[auditorUserID] = NewUser(auditorUserID, UserRoleAuditor, false, "", nil)
+	account.Users[networkAdminUserID] = NewUser(networkAdminUserID, UserRoleNetworkAdmin, false, "", nil)
+	account.Users[regularUserID] = NewRegularUser(regularUserID)
+	require.NoError(t, manager.Store.SaveAccount(account))
+
+	requirePermissionDenied := func(t *testing.T, err error) {
+		t.Helper()
+		sErr, ok := status.FromError(err)
+		require.True(t, ok, "expected a status error, got %v", err)
+		assert.Equal(t, status.PermissionDenied, sErr.Type())
+	}
+
+	t.Run("network admin manages the network configuration", func(t *testing.T) {
+		group := &Group{ID: "group", Name: "group"}
+		require.NoError(t, manager.SaveGroup(mockAccountID, networkAdminUserID, group))
+
+		policy := &Policy{ID: "policy", Name: "policy", Enabled: true, Rules: []*PolicyRule{{
+			ID: "rule", Enabled: true, Action: PolicyTrafficActionAccept, Protocol: PolicyRuleProtocolALL,
+			Bidirectional: true, Sources: []string{group.ID}, Destinations: []string{group.ID},
+		}}}
+		require.NoError(t, manager.SavePolicy(mockAccountID, networkAdminUserID, policy))
+		require.NoError(t, manager.SaveDNSSettings(mockAccountID, networkAdminUserID, &DNSSettings{}))
+
+		users, err := manager.GetUsersFromAccount(mockAccountID, networkAdminUserID)
+		require.NoError(t, err)
+		assert.Len(t, users, 4)
+	})
+
+	t.Run("network admin can't manage users and setup keys", func(t *testing.T) {
+		_, err := manager.CreateSetupKey(mockAccountID, "key", SetupKeyReusable, time.Hour, nil, 0, networkAdminUserID, false, nil)
+		requirePermissionDenied(t, err)
+
+		_, err = manager.SaveUser(mockAccountID, networkAdminUserID, NewUser(regularUserID, UserRoleAdmin, false, "", nil))
+		requirePermissionDenied(t, err)
+
+		_, err = manager.CreatePAT(mockAccountID, networkAdminUserID, regularUserID, mockTokenName, mockExpiresIn, nil)
+		requirePermissionDenied(t, err)
+	})
+
+	t.Run("auditor has read-only access", func(t *testing.T) {
+		policies, err := manager.ListPolicies(mockAccountID, auditorUserID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, policies)
+
+		_, _, err = manager.GetEvents(mockAccountID, auditorUserID, activity.Filter{})
+		require.NoError(t, err)
+
+		users, err := manager.GetUsersFromAccount(mockAccountID, auditorUserID)
+		require.NoError(t, err)
+		assert.Len(t, users, 4)
+
+		requirePermissionDenied(t, manager.SaveGroup(mockAccountID, auditorUserID, &Group{ID: "other", Name: "other"}))
+		requirePermissionDenied(t, manager.DeletePolicy(mockAccountID, "policy", auditorUserID))
+		requirePermissionDenied(t, manager.SaveDNSSettings(mockAccountID, auditorUserID, &DNSSettings{}))
+	})
+
+	t.Run("regular user can't view the account resources", func(t *testing.T) {
+		_, err := manager.ListPolicies(mockAccountID, regularUserID)
+		requirePermissionDenied(t, err)
+
+		_, _, err = manager.GetEvents(mockAccountID, regularUserID, activity.Filter{})
+		requirePermissionDenied(t, err)
+
+		requirePermissionDenied(t, manager.SaveGroup(mockAccountID, regularUserID, &Group{ID: "other", Name: "other"}))
+	})
+}
+
 func TestUser_GetUsersFromAccount_ForAdmin(t *testing.T) {
 	store := newStore(t)
 	account := newAccountWithId(mockAccountID, mockUserID, "")
@@ -0,0 +1,317 @@
+package server
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/management/server/activity"
+	"github.com/netbirdio/netbird/management/server/status"
+	"github.com/netbirdio/netbird/management/server/webhook"
+)
+
+// minWebhookSecretLength is the minimum length of the secret the webhook deliveries are signed with
+const minWebhookSecretLength = 16
+
+// Webhook is an HTTP endpoint the activity events of the account are delivered to
+type Webhook struct {
+	// ID of the webhook
+	ID string
+
+	// Name of the webhook visible in the UI
+	Name string
+
+	// URL of the endpoint the events are posted to
+	URL string
+
+	// Secret the deliveries are signed with, see webhook.Sign
+	Secret string
+
+	// Events are the activity codes delivered to the endpoint. All the events are delivered when empty
+	Events []string
+
+	// Enabled status of the webhook
+	Enabled bool
+}
+
+// Copy returns a copy of the webhook
+func (w *Webhook) Copy() *Webhook {
+	c := *w
+	c.Events = make([]string, len(w.Events))
+	copy(c.Events, w.Events)
+	return &c
+}
+
+// EventMeta returns activity event meta related to the webhook
+func (w *Webhook) EventMeta() map[string]any {
+	return map[string]any{"name": w.Name, "url": w.URL}
+}
+
+// Validate returns an error if the webhook has no name, an invalid URL or a URL of an internal address, a short
+// secret or an unknown event type
+func (w *Webhook) Validate() error {
+	return w.validate(false)
+}
+
+// validate checks the webhook as Validate does, internal destinations are accepted when allowPrivateDestinations is
+// true, see webhook.Config
+func (w *Webhook) validate(allowPrivateDestinations bool) error {
+	if w.Name == "" {
+		return status.Errorf(status.InvalidArgument, "webhook name shouldn't be empty")
+	}
+
+	endpoint, err := url.Parse(w.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return status.Errorf(status.InvalidArgument, "invalid webhook URL %q, an http or https URL is expected", w.URL)
+	}
+
+	if !allowPrivateDestinations {
+		if err := webhook.ValidateDestination(endpoint.Hostname()); err != nil {
+			return status.Errorf(status.InvalidArgument, "invalid webhook URL %q: %v", w.URL, err)
+		}
+	}
+
+	if len(w.Secret) < minWebhookSecretLength {
+		return status.Errorf(status.InvalidArgument, "webhook secret should be at least %d characters long",
+			minWebhookSecretLength)
+	}
+
+	for _, event := range w.Events {
+		if !activity.IsKnownCode(event) {
+			return status.Errorf(status.InvalidArgument, "unknown event type %q", event)
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if the events of the given activity code have to be delivered to the webhook
+func (w *Webhook) Matches(activityCode string) bool {
+	if !w.Enabled {
+		return false
+	}
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, event := range w.Events {
+		if event == activityCode {
+			return true
+		}
+	}
+	return false
+}
+
+// getWebhook returns the webhook with the given ID or nil if it doesn't exist
+func (a *Account) getWebhook(webhookID string) *Webhook {
+	for _, hook := range a.Webhooks {
+		if hook.ID == webhookID {
+			return hook
+		}
+	}
+	return nil
+}
+
+// GetWebhook returns the webhook with the given ID
+func (am *DefaultAccountManager) GetWebhook(accountID, webhookID, userID string) (*Webhook, error) {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view webhooks")
+	}
+
+	hook := account.getWebhook(webhookID)
+	if hook == nil {
+		return nil, status.Errorf(status.NotFound, "webhook with ID %s not found", webhookID)
+	}
+
+	return hook, nil
+}
+
+// SaveWebhook creates or updates the webhook of the account. The secret of an existing webhook is kept when the
+// given secret is empty
+func (am *DefaultAccountManager) SaveWebhook(accountID, userID string, hook *Webhook) error {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsAdmin() {
+		return status.Errorf(status.PermissionDenied, "only admins are allowed to update webhooks")
+	}
+
+	existing := account.getWebhook(hook.ID)
+	if existing != nil && hook.Secret == "" {
+		hook.Secret = existing.Secret
+	}
+
+	if err = hook.validate(am.webhooks != nil && am.webhooks.AllowsPrivateDestinations()); err != nil {
+		return err
+	}
+
+	if existing != nil {
+		for i, h := range account.Webhooks {
+			if h.ID == hook.ID {
+				account.Webhooks[i] = hook
+				break
+			}
+		}
+	} else {
+		account.Webhooks = append(account.Webhooks, hook)
+	}
+
+	if err = am.Store.SaveAccount(account); err != nil {
+		return err
+	}
+
+	action := activity.WebhookCreated
+	if existing != nil {
+		action = activity.WebhookUpdated
+	}
+	am.storeEvent(userID, hook.ID, accountID, action, hook.EventMeta())
+
+	return nil
+}
+
+// DeleteWebhook removes the webhook and its dead letters from the account
+func (am *DefaultAccountManager) DeleteWebhook(accountID, webhookID, userID string) error {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsAdmin() {
+		return status.Errorf(status.PermissionDenied, "only admins are allowed to delete webhooks")
+	}
+
+	idx := -1
+	for i, hook := range account.Webhooks {
+		if hook.ID == webhookID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return status.Errorf(status.NotFound, "webhook with ID %s doesn't exist", webhookID)
+	}
+
+	hook := account.Webhooks[idx]
+	account.Webhooks = append(account.Webhooks[:idx], account.Webhooks[idx+1:]...)
+
+	if err = am.Store.SaveAccount(account); err != nil {
+		return err
+	}
+
+	if am.webhooks != nil {
+		if err = am.webhooks.DeadLetters().Delete(accountID, webhookID); err != nil {
+			log.Errorf("failed to delete the dead letters of webhook %s: %v", webhookID, err)
+		}
+	}
+
+	am.storeEvent(userID, hook.ID, accountID, activity.WebhookDeleted, hook.EventMeta())
+
+	return nil
+}
+
+// ListWebhooks returns all the webhooks of the account
+func (am *DefaultAccountManager) ListWebhooks(accountID, userID string) ([]*Webhook, error) {
+	unlock := am.Store.AcquireAccountLock(accountID)
+	defer unlock()
+
+	account, err := am.Store.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := account.FindUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.HasReadAllAccess() {
+		return nil, status.Errorf(status.PermissionDenied, "only admins, network admins and auditors are allowed to view webhooks")
+	}
+
+	return account.Webhooks, nil
+}
+
+// ListWebhookDeadLetters returns the deliveries to the webhook that failed all the attempts, newest first
+func (am *DefaultAccountManager) ListWebhookDeadLetters(accountID, webhookID, userID string) ([]*webhook.DeadLetter, error) {
+	hook, err := am.GetWebhook(accountID, webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if am.webhooks == nil {
+		return []*webhook.DeadLetter{}, nil
+	}
+
+	return am.webhooks.DeadLetters().Get(accountID, hook.ID)
+}
+
+// dispatchWebhooks delivers the activity event to the enabled webhooks of the account that match its type
+func (am *DefaultAccountManager) dispatchWebhooks(event *activity.Event) {
+	if am.webhooks == nil {
+		return
+	}
+
+	account, err := am.Store.GetAccount(event.AccountID)
+	if err != nil {
+		log.Debugf("skipping the webhooks of event %d, failed to get account %s: %v", event.ID, event.AccountID, err)
+		return
+	}
+
+	activityCode := event.Activity.StringCode()
+	var payload []byte
+	for _, hook := range account.Webhooks {
+		if !hook.Matches(activityCode) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(activity.NewRecord(event))
+			if err != nil {
+				log.Errorf("failed to encode event %d for the webhooks: %v", event.ID, err)
+				return
+			}
+		}
+
+		am.webhooks.Dispatch(&webhook.Delivery{
+			ID:        xid.New().String(),
+			AccountID: event.AccountID,
+			WebhookID: hook.ID,
+			URL:       hook.URL,
+			Secret:    hook.Secret,
+			EventType: activityCode,
+			Payload:   payload,
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// DeadLetter is a delivery that failed after all the attempts
+type DeadLetter struct {
+	// ID of the delivery
+	ID string
+	// AccountID is the ID of the account the webhook belongs to
+	AccountID string
+	// WebhookID is the ID of the webhook the delivery was sent to
+	WebhookID string
+	// EventType is the activity code of the delivered event
+	EventType string
+	// Payload is the JSON body of the delivery
+	Payload []byte
+	// Attempts is the number of delivery attempts made
+	Attempts int
+	// LastError is the error of the last attempt
+	LastError string
+	// CreatedAt is the time the delivery was given up
+	CreatedAt time.Time
+}
+
+// DeadLetterStore keeps the deliveries that failed after all the attempts
+type DeadLetterStore interface {
+	// Save a dead letter in the store
+	Save(deadLetter *DeadLetter) error
+	// Get returns the dead letters of a webhook of the account ordered descending by creation time
+	Get(accountID, webhookID string) ([]*DeadLetter, error)
+	// Delete removes all the dead letters of a webhook of the account
+	Delete(accountID, webhookID string) error
+	// Close the store
+	Close() error
+}
+
+// InMemoryDeadLetterStore implements the DeadLetterStore interface storing data in-memory
+type InMemoryDeadLetterStore struct {
+	mu          sync.Mutex
+	deadLetters []*DeadLetter
+}
+
+// Save a dead letter in memory
+func (store *InMemoryDeadLetterStore) Save(deadLetter *DeadLetter) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.deadLetters = append(store.deadLetters, deadLetter)
+	return nil
+}
+
+// Get returns the dead letters of a webhook of the account ordered descending by creation time
+func (store *InMemoryDeadLetterStore) Get(accountID, webhookID string) ([]*DeadLetter, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	deadLetters := make([]*DeadLetter, 0)
+	for i := len(store.deadLetters) - 1; i >= 0; i-- {
+		deadLetter := store.deadLetters[i]
+		if deadLetter.AccountID == accountID && deadLetter.WebhookID == webhookID {
+			deadLetters = append(deadLetters, deadLetter)
+		}
+	}
+	return deadLetters, nil
+}
+
+// Delete removes all the dead letters of a webhook of the account
+func (store *InMemoryDeadLetterStore) Delete(accountID, webhookID string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	kept := store.deadLetters[:0]
+	for _, deadLetter := range store.deadLetters {
+		if deadLetter.AccountID != accountID || deadLetter.WebhookID != webhookID {
+			kept = append(kept, deadLetter)
+		}
+	}
+	store.deadLetters = kept
+	return nil
+}
+
+// Close cleans up the dead letter list
+func (store *InMemoryDeadLetterStore) Close() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.deadLetters = nil
+	return nil
+}
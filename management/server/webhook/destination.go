@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// destinationLookupTimeout is the timeout of resolving the host of a webhook URL when it is validated
+const destinationLookupTimeout = 5 * time.Second
+
+// sharedAddressSpace is the RFC 6598 range used for the NetBird overlay network
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsForbiddenDestination returns true if the deliveries must not be sent to the address: loopback, private, link-local
+// (including the cloud metadata endpoint 169.254.169.254), shared, unspecified and multicast addresses are internal
+func IsForbiddenDestination(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// ValidateDestination returns an error if the host of a webhook URL is or resolves to a forbidden address,
+// see IsForbiddenDestination. The addresses are checked again when the deliveries are sent, because the DNS records
+// of the host can change after the validation
+func ValidateDestination(host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if IsForbiddenDestination(ip) {
+			return fmt.Errorf("%s is an internal address", host)
+		}
+		return nil
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%s is an internal host", host)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), destinationLookupTimeout)
+	defer cancel()
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		// the host might not be resolvable yet, the deliveries to internal addresses are rejected when dialing
+		return nil
+	}
+	for _, address := range addresses {
+		if IsForbiddenDestination(address.IP) {
+			return fmt.Errorf("%s resolves to the internal address %s", host, address.IP)
+		}
+	}
+	return nil
+}
+
+// dialControl rejects the connections to forbidden destinations. It runs after the host has been resolved,
+// so it also covers DNS records changed after the webhook was saved and redirects
+func dialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("unexpected dial address %s", address)
+	}
+	if IsForbiddenDestination(ip) {
+		return fmt.Errorf("deliveries to the internal address %s are not allowed", ip)
+	}
+	return nil
+}
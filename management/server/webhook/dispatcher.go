@@ -0,0 +1,294 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// SignatureHeader holds the HMAC-SHA256 signature of the timestamp and the body of a delivery, see Sign
+	SignatureHeader = "X-NetBird-Signature"
+	// TimestampHeader holds the unix time the delivery attempt was signed at
+	TimestampHeader = "X-NetBird-Timestamp"
+	// EventHeader holds the activity code of the delivered event
+	EventHeader = "X-NetBird-Event"
+	// DeliveryHeader holds the ID of the delivery. It doesn't change between the attempts
+	DeliveryHeader = "X-NetBird-Delivery"
+
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+	defaultTimeout        = 10 * time.Second
+	defaultQueueSize      = 1000
+	defaultWorkers        = 4
+	// maxResponseBodySize is the size of the endpoint response read before the connection is released
+	maxResponseBodySize = 64 << 10
+)
+
+// Config of the webhook deliveries. Zero values are replaced with the defaults
+type Config struct {
+	// MaxAttempts is the number of attempts made before a delivery is stored as a dead letter
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt. The delay doubles on every attempt
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between two attempts
+	MaxBackoff time.Duration
+	// Timeout of a single attempt
+	Timeout time.Duration
+	// QueueSize is the number of deliveries waiting for a worker. Deliveries exceeding the queue are stored as dead
+	// letters right away
+	QueueSize int
+	// Workers is the number of deliveries sent concurrently
+	Workers int
+	// AllowPrivateDestinations allows the deliveries to internal addresses, see IsForbiddenDestination.
+	// Only meant for self-hosted setups with the webhook endpoints in the same private network
+	AllowPrivateDestinations bool
+}
+
+// Delivery is an event to send to a webhook endpoint
+type Delivery struct {
+	// ID of the delivery
+	ID string
+	// AccountID is the ID of the account the webhook belongs to
+	AccountID string
+	// WebhookID is the ID of the webhook
+	WebhookID string
+	// URL of the webhook endpoint
+	URL string
+	// Secret the delivery is signed with
+	Secret string
+	// EventType is the activity code of the event
+	EventType string
+	// Payload is the JSON body of the delivery
+	Payload []byte
+}
+
+// Dispatcher sends the deliveries to the webhook endpoints in the background. Failed attempts are retried with an
+// exponential backoff and the deliveries that failed all the attempts are stored in the DeadLetterStore
+type Dispatcher struct {
+	config      Config
+	client      *http.Client
+	deadLetters DeadLetterStore
+	queue       chan *Delivery
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher creates a Dispatcher and starts its workers
+func NewDispatcher(deadLetters DeadLetterStore, config Config) *Dispatcher {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaultInitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaultQueueSize
+	}
+	if config.Workers <= 0 {
+		config.Workers = defaultWorkers
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		config:      config,
+		client:      newHTTPClient(config),
+		deadLetters: deadLetters,
+		queue:       make(chan *Delivery, config.QueueSize),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+
+	return d
+}
+
+// newHTTPClient returns the client sending the deliveries. Unless the config allows it, the connections to internal
+// addresses are rejected and no proxy is used, so the check applies to the endpoint itself
+func newHTTPClient(config Config) *http.Client {
+	if config.AllowPrivateDestinations {
+		return &http.Client{Timeout: config.Timeout}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   dialControl,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: config.Timeout, Transport: transport}
+}
+
+// AllowsPrivateDestinations returns true if the deliveries to internal addresses are allowed
+func (d *Dispatcher) AllowsPrivateDestinations() bool {
+	return d.config.AllowPrivateDestinations
+}
+
+// DeadLetters returns the store of the deliveries that failed all the attempts
+func (d *Dispatcher) DeadLetters() DeadLetterStore {
+	return d.deadLetters
+}
+
+// Dispatch queues the delivery. It doesn't block: the delivery is stored as a dead letter when the queue is full
+// or the dispatcher is closed
+func (d *Dispatcher) Dispatch(delivery *Delivery) {
+	if d.ctx.Err() != nil {
+		d.saveDeadLetter(delivery, 0, fmt.Errorf("dispatcher is closed"))
+		return
+	}
+
+	select {
+	case d.queue <- delivery:
+	default:
+		d.saveDeadLetter(delivery, 0, fmt.Errorf("delivery queue is full"))
+	}
+}
+
+// Close stops the workers. The deliveries in progress or waiting in the queue are stored as dead letters
+func (d *Dispatcher) Close() {
+	d.cancel()
+	d.wg.Wait()
+
+	for {
+		select {
+		case delivery := <-d.queue:
+			d.saveDeadLetter(delivery, 0, fmt.Errorf("dispatcher is closed"))
+		default:
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case delivery := <-d.queue:
+			d.deliver(delivery)
+		}
+	}
+}
+
+// deliver sends the delivery until it succeeds, fails permanently or runs out of attempts
+func (d *Dispatcher) deliver(delivery *Delivery) {
+	b := &backoff.ExponentialBackOff{
+		InitialInterval:     d.config.InitialBackoff,
+		RandomizationFactor: 0.1,
+		Multiplier:          2,
+		MaxInterval:         d.config.MaxBackoff,
+		Stop:                backoff.Stop,
+		Clock:               backoff.SystemClock,
+	}
+
+	attempts := 0
+	var lastErr error
+	operation := func() error {
+		attempts++
+		lastErr = d.send(delivery)
+		return lastErr
+	}
+
+	err := backoff.Retry(operation, backoff.WithContext(backoff.WithMaxRetries(b, uint64(d.config.MaxAttempts-1)), d.ctx))
+	if err == nil {
+		log.Debugf("delivered event %s to webhook %s of account %s in %d attempts",
+			delivery.EventType, delivery.WebhookID, delivery.AccountID, attempts)
+		return
+	}
+
+	if lastErr == nil {
+		lastErr = err
+	}
+	d.saveDeadLetter(delivery, attempts, lastErr)
+}
+
+// send makes a single delivery attempt. Errors that won't be fixed by retrying are permanent
+func (d *Dispatcher) send(delivery *Delivery) error {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "NetBird-Webhooks")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err = fmt.Errorf("webhook endpoint responded with status %d", resp.StatusCode)
+	if resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= http.StatusInternalServerError {
+		return err
+	}
+	return backoff.Permanent(err)
+}
+
+func (d *Dispatcher) saveDeadLetter(delivery *Delivery, attempts int, deliveryErr error) {
+	log.Warnf("giving up delivering event %s to webhook %s of account %s after %d attempts: %v",
+		delivery.EventType, delivery.WebhookID, delivery.AccountID, attempts, deliveryErr)
+
+	err := d.deadLetters.Save(&DeadLetter{
+		ID:        delivery.ID,
+		AccountID: delivery.AccountID,
+		WebhookID: delivery.WebhookID,
+		EventType: delivery.EventType,
+		Payload:   delivery.Payload,
+		Attempts:  attempts,
+		LastError: deliveryErr.Error(),
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		log.Errorf("failed to store the dead letter of delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// Sign returns the value of the SignatureHeader: the hex encoded HMAC-SHA256 of the timestamp and the payload joined
+// with a dot, prefixed with "sha256=". Receivers recompute it with their copy of the secret to authenticate deliveries
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
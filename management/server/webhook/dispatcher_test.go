@@ -0,0 +1,235 @@
+package webhook
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDispatcher(t *testing.T) (*Dispatcher, *InMemoryDeadLetterStore) {
+	t.Helper()
+	deadLetters := &InMemoryDeadLetterStore{}
+	dispatcher := NewDispatcher(deadLetters, Config{
+		MaxAttempts:    3,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     20 * time.Millisecond,
+		Timeout:        time.Second,
+		Workers:        1,
+		// the test endpoints listen on the loopback
+		AllowPrivateDestinations: true,
+	})
+	t.Cleanup(dispatcher.Close)
+	return dispatcher, deadLetters
+}
+
+func newTestDelivery(url string) *Delivery {
+	return &Delivery{
+		ID:        "delivery",
+		AccountID: "account",
+		WebhookID: "webhook",
+		URL:       url,
+		Secret:    "secret",
+		EventType: "user.peer.add",
+		Payload:   []byte(`{"activity_code":"user.peer.add"}`),
+	}
+}
+
+func TestDispatcher_SignedDelivery(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+	}))
+	defer server.Close()
+
+	dispatcher, deadLetters := newTestDispatcher(t)
+	delivery := newTestDelivery(server.URL)
+	dispatcher.Dispatch(delivery)
+
+	select {
+	case r := <-received:
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "user.peer.add", r.Header.Get(EventHeader))
+		assert.Equal(t, "delivery", r.Header.Get(DeliveryHeader))
+		assert.Equal(t, delivery.Payload, body)
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, Sign("secret", timestamp, body), r.Header.Get(SignatureHeader))
+		assert.NotEqual(t, Sign("other", timestamp, body), r.Header.Get(SignatureHeader))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the delivery should be received")
+	}
+
+	// let the dispatcher read the response
+	time.Sleep(100 * time.Millisecond)
+	letters, err := deadLetters.Get("account", "webhook")
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+}
+
+func TestDispatcher_Retries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	dispatcher, deadLetters := newTestDispatcher(t)
+	dispatcher.Dispatch(newTestDelivery(server.URL))
+
+	require.Eventually(t, func() bool {
+		return attempts.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// let the dispatcher read the response
+	time.Sleep(100 * time.Millisecond)
+	letters, err := deadLetters.Get("account", "webhook")
+	require.NoError(t, err)
+	assert.Empty(t, letters, "the delivery succeeded on the last attempt")
+}
+
+func TestDispatcher_DeadLetter(t *testing.T) {
+	testCases := []struct {
+		name             string
+		status           int
+		expectedAttempts int
+	}{
+		{name: "server error is retried", status: http.StatusInternalServerError, expectedAttempts: 3},
+		{name: "rate limit is retried", status: http.StatusTooManyRequests, expectedAttempts: 3},
+		{name: "client error is not retried", status: http.StatusBadRequest, expectedAttempts: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			dispatcher, deadLetters := newTestDispatcher(t)
+			delivery := newTestDelivery(server.URL)
+			dispatcher.Dispatch(delivery)
+
+			var letters []*DeadLetter
+			require.Eventually(t, func() bool {
+				letters, _ = deadLetters.Get("account", "webhook")
+				return len(letters) == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			assert.Equal(t, tc.expectedAttempts, int(attempts.Load()))
+			assert.Equal(t, tc.expectedAttempts, letters[0].Attempts)
+			assert.Equal(t, delivery.ID, letters[0].ID)
+			assert.Equal(t, delivery.EventType, letters[0].EventType)
+			assert.Equal(t, delivery.Payload, letters[0].Payload)
+			assert.Contains(t, letters[0].LastError, strconv.Itoa(tc.status))
+		})
+	}
+}
+
+func TestDispatcher_CloseStoresPendingDeliveries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	deadLetters := &InMemoryDeadLetterStore{}
+	dispatcher := NewDispatcher(deadLetters, Config{InitialBackoff: time.Hour, Workers: 1, AllowPrivateDestinations: true})
+	dispatcher.Dispatch(newTestDelivery(server.URL))
+	dispatcher.Dispatch(newTestDelivery(server.URL))
+
+	dispatcher.Close()
+	letters, err := deadLetters.Get("account", "webhook")
+	require.NoError(t, err)
+	assert.Len(t, letters, 2, "the deliveries in progress and in the queue should be stored as dead letters")
+
+	dispatcher.Dispatch(newTestDelivery(server.URL))
+	letters, err = deadLetters.Get("account", "webhook")
+	require.NoError(t, err)
+	assert.Len(t, letters, 3, "the deliveries dispatched after close should be stored as dead letters")
+}
+
+func TestDispatcher_RejectsInternalDestinations(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	deadLetters := &InMemoryDeadLetterStore{}
+	dispatcher := NewDispatcher(deadLetters, Config{MaxAttempts: 1, Workers: 1})
+	t.Cleanup(dispatcher.Close)
+	dispatcher.Dispatch(newTestDelivery(server.URL))
+
+	var letters []*DeadLetter
+	require.Eventually(t, func() bool {
+		var err error
+		letters, err = deadLetters.Get("account", "webhook")
+		return err == nil && len(letters) == 1
+	}, 5*time.Second, 10*time.Millisecond, "the delivery to the loopback should be stored as a dead letter")
+	assert.Contains(t, letters[0].LastError, "internal address")
+	assert.Zero(t, requests.Load(), "the endpoint on the loopback shouldn't be reached")
+}
+
+func TestIsForbiddenDestination(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1",
+		"0.0.0.0", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1", "224.0.0.1"} {
+		assert.True(t, IsForbiddenDestination(net.ParseIP(addr)), addr)
+	}
+	for _, addr := range []string{"203.0.113.10", "8.8.8.8", "2001:4860:4860::8888"} {
+		assert.False(t, IsForbiddenDestination(net.ParseIP(addr)), addr)
+	}
+}
+
+func TestSQLiteDeadLetterStore(t *testing.T) {
+	store, err := NewSQLiteDeadLetterStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close() //nolint
+
+	now := time.Now().UTC()
+	for i, webhookID := range []string{"webhook1", "webhook1", "webhook2"} {
+		err = store.Save(&DeadLetter{
+			ID:        "delivery" + strconv.Itoa(i),
+			AccountID: "account",
+			WebhookID: webhookID,
+			EventType: "user.peer.add",
+			Payload:   []byte(`{}`),
+			Attempts:  5,
+			LastError: "webhook endpoint responded with status 500",
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+		})
+		require.NoError(t, err)
+	}
+
+	letters, err := store.Get("account", "webhook1")
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	assert.Equal(t, "delivery1", letters[0].ID, "the newest dead letter should be first")
+	assert.Equal(t, "delivery0", letters[1].ID)
+	assert.Equal(t, []byte(`{}`), letters[0].Payload)
+	assert.Equal(t, 5, letters[0].Attempts)
+	assert.Equal(t, "webhook endpoint responded with status 500", letters[0].LastError)
+
+	require.NoError(t, store.Delete("account", "webhook1"))
+	letters, err = store.Get("account", "webhook1")
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+
+	letters, err = store.Get("account", "webhook2")
+	require.NoError(t, err)
+	assert.Len(t, letters, 1, "the dead letters of other webhooks should be kept")
+}
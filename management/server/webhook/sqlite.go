@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"database/sql"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	// deadLettersDB is the default name of the webhook dead letters database
+	deadLettersDB = "webhooks.db"
+
+	createDeadLettersTableQuery = `CREATE TABLE IF NOT EXISTS dead_letters (
+		id TEXT PRIMARY KEY,
+		account_id TEXT NOT NULL,
+		webhook_id TEXT NOT NULL,
+		event_type TEXT NOT NULL,
+		payload BLOB,
+		attempts INTEGER NOT NULL,
+		last_error TEXT,
+		created_at DATETIME NOT NULL);`
+
+	createDeadLettersIndexQuery = `CREATE INDEX IF NOT EXISTS idx_dead_letters_webhook ON dead_letters (account_id, webhook_id);`
+)
+
+// SQLiteDeadLetterStore is the implementation of the DeadLetterStore interface backed by SQLite
+type SQLiteDeadLetterStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteDeadLetterStore creates a new SQLiteDeadLetterStore with a dead letters table if not exists
+func NewSQLiteDeadLetterStore(dataDir string) (*SQLiteDeadLetterStore, error) {
+	dbFile := filepath.Join(dataDir, deadLettersDB)
+	db, err := sql.Open("sqlite3", dbFile+"?_busy_timeout=10000")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, query := range []string{createDeadLettersTableQuery, createDeadLettersIndexQuery} {
+		if _, err = db.Exec(query); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
+	return &SQLiteDeadLetterStore{db: db}, nil
+}
+
+// Save a dead letter in the SQLite dead_letters table
+func (store *SQLiteDeadLetterStore) Save(deadLetter *DeadLetter) error {
+	_, err := store.db.Exec(`INSERT INTO dead_letters (id, account_id, webhook_id, event_type, payload, attempts,
+		last_error, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		deadLetter.ID, deadLetter.AccountID, deadLetter.WebhookID, deadLetter.EventType, deadLetter.Payload,
+		deadLetter.Attempts, deadLetter.LastError, deadLetter.CreatedAt)
+	return err
+}
+
+// Get returns the dead letters of a webhook of the account ordered descending by creation time
+func (store *SQLiteDeadLetterStore) Get(accountID, webhookID string) ([]*DeadLetter, error) {
+	rows, err := store.db.Query(`SELECT id, event_type, payload, attempts, last_error, created_at FROM dead_letters
+		WHERE account_id = ? AND webhook_id = ? ORDER BY created_at DESC`, accountID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint
+
+	deadLetters := make([]*DeadLetter, 0)
+	for rows.Next() {
+		var lastError sql.NullString
+		var createdAt time.Time
+		deadLetter := &DeadLetter{AccountID: accountID, WebhookID: webhookID}
+		err = rows.Scan(&deadLetter.ID, &deadLetter.EventType, &deadLetter.Payload, &deadLetter.Attempts, &lastError, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+		deadLetter.LastError = lastError.String
+		deadLetter.CreatedAt = createdAt
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	return deadLetters, rows.Err()
+}
+
+// Delete removes all the dead letters of a webhook of the account
+func (store *SQLiteDeadLetterStore) Delete(accountID, webhookID string) error {
+	_, err := store.db.Exec(`DELETE FROM dead_letters WHERE account_id = ? AND webhook_id = ?`, accountID, webhookID)
+	return err
+}
+
+// Close the store
+func (store *SQLiteDeadLetterStore) Close() error {
+	if store.db != nil {
+		return store.db.Close()
+	}
+	return nil
+}
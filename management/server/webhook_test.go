@@ -0,0 +1,203 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/management/server/activity"
+	"github.com/netbirdio/netbird/management/server/webhook"
+)
+
+func TestWebhook_Validate(t *testing.T) {
+	valid := func() *Webhook {
+		return &Webhook{
+			ID:      "webhook",
+			Name:    "chat-ops",
+			URL:     "https://hooks.example.com/netbird",
+			Secret:  "0123456789abcdef",
+			Events:  []string{"user.peer.add", "policy.update"},
+			Enabled: true,
+		}
+	}
+
+	tt := []struct {
+		name   string
+		modify func(hook *Webhook)
+		err    bool
+	}{
+		{name: "valid", modify: func(hook *Webhook) {}},
+		{name: "all events", modify: func(hook *Webhook) { hook.Events = nil }},
+		{name: "empty name", modify: func(hook *Webhook) { hook.Name = "" }, err: true},
+		{name: "unsupported scheme", modify: func(hook *Webhook) { hook.URL = "ftp://hooks.example.com" }, err: true},
+		{name: "missing host", modify: func(hook *Webhook) { hook.URL = "https://" }, err: true},
+		{name: "loopback", modify: func(hook *Webhook) { hook.URL = "http://127.0.0.1:8080/hook" }, err: true},
+		{name: "localhost", modify: func(hook *Webhook) { hook.URL = "http://localhost/hook" }, err: true},
+		{name: "private network", modify: func(hook *Webhook) { hook.URL = "https://10.0.0.5/hook" }, err: true},
+		{name: "metadata endpoint", modify: func(hook *Webhook) { hook.URL = "http://169.254.169.254/latest/meta-data" }, err: true},
+		{name: "IPv6 loopback", modify: func(hook *Webhook) { hook.URL = "http://[::1]/hook" }, err: true},
+		{name: "public address", modify: func(hook *Webhook) { hook.URL = "https://203.0.113.10/hook" }},
+		{name: "short secret", modify: func(hook *Webhook) { hook.Secret = "secret" }, err: true},
+		{name: "unknown event", modify: func(hook *Webhook) { hook.Events = []string{"peer.teleport"} }, err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := valid()
+			tc.modify(hook)
+			if tc.err {
+				assert.Error(t, hook.Validate())
+			} else {
+				assert.NoError(t, hook.Validate())
+			}
+		})
+	}
+}
+
+func TestWebhook_Matches(t *testing.T) {
+	hook := &Webhook{Events: []string{"group.add"}, Enabled: true}
+	assert.True(t, hook.Matches("group.add"))
+	assert.False(t, hook.Matches("group.update"))
+
+	hook.Events = nil
+	assert.True(t, hook.Matches("group.update"), "a webhook without events should match all the events")
+
+	hook.Enabled = false
+	assert.False(t, hook.Matches("group.update"), "a disabled webhook should not match any event")
+}
+
+func TestDefaultAccountManager_Webhooks(t *testing.T) {
+	am, err := createManager(t)
+	require.NoError(t, err)
+
+	account, err := createAccount(am, "account", "admin", "")
+	require.NoError(t, err)
+	account.Users["network_admin"] = NewUser("network_admin", UserRoleNetworkAdmin, false, "", nil)
+	require.NoError(t, am.Store.SaveAccount(account))
+
+	hook := &Webhook{
+		ID:      "webhook",
+		Name:    "chat-ops",
+		URL:     "https://hooks.example.com/netbird",
+		Secret:  "0123456789abcdef",
+		Events:  []string{"group.add"},
+		Enabled: true,
+	}
+	require.Error(t, am.SaveWebhook(account.Id, "network_admin", hook.Copy()),
+		"only admins should be allowed to save webhooks")
+	require.NoError(t, am.SaveWebhook(account.Id, "admin", hook.Copy()))
+
+	updated := hook.Copy()
+	updated.Secret = ""
+	updated.Enabled = false
+	require.NoError(t, am.SaveWebhook(account.Id, "admin", updated))
+
+	saved, err := am.GetWebhook(account.Id, hook.ID, "network_admin")
+	require.NoError(t, err)
+	assert.Equal(t, hook.Secret, saved.Secret, "the secret should be kept when it isn't updated")
+	assert.False(t, saved.Enabled)
+
+	all, err := am.ListWebhooks(account.Id, "admin")
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	require.Error(t, am.DeleteWebhook(account.Id, hook.ID, "network_admin"),
+		"only admins should be allowed to delete webhooks")
+	require.NoError(t, am.DeleteWebhook(account.Id, hook.ID, "admin"))
+	_, err = am.GetWebhook(account.Id, hook.ID, "admin")
+	require.Error(t, err)
+}
+
+func TestDefaultAccountManager_DispatchWebhooks(t *testing.T) {
+	received := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+		received <- r
+	}))
+	defer endpoint.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	am, err := createManager(t)
+	require.NoError(t, err)
+	deadLetters := &webhook.InMemoryDeadLetterStore{}
+	am.webhooks = webhook.NewDispatcher(deadLetters, webhook.Config{
+		MaxAttempts:    2,
+		InitialBackoff: 10 * time.Millisecond,
+		// the test endpoints listen on the loopback
+		AllowPrivateDestinations: true,
+	})
+	t.Cleanup(am.webhooks.Close)
+
+	account, err := createAccount(am, "account", "admin", "")
+	require.NoError(t, err)
+
+	require.NoError(t, am.SaveWebhook(account.Id, "admin", &Webhook{
+		ID:      "groups",
+		Name:    "groups",
+		URL:     endpoint.URL,
+		Secret:  "0123456789abcdef",
+		Events:  []string{"group.add"},
+		Enabled: true,
+	}))
+	require.NoError(t, am.SaveWebhook(account.Id, "admin", &Webhook{
+		ID:      "failing",
+		Name:    "failing",
+		URL:     failing.URL,
+		Secret:  "0123456789abcdef",
+		Events:  []string{"group.add"},
+		Enabled: true,
+	}))
+
+	require.NoError(t, am.SaveGroup(account.Id, "admin", &Group{ID: "group", Name: "group"}))
+
+	select {
+	case r := <-received:
+		body := <-bodies
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.Sign("0123456789abcdef", timestamp, body), r.Header.Get(webhook.SignatureHeader))
+		assert.Equal(t, "group.add", r.Header.Get(webhook.EventHeader))
+
+		var event activity.Record
+		require.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, "group.add", event.ActivityCode)
+		assert.Equal(t, "Group created", event.Activity)
+		assert.Equal(t, "group", event.TargetID)
+		assert.Equal(t, "admin", event.InitiatorID)
+		assert.Equal(t, account.Id, event.AccountID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the group event should be delivered")
+	}
+
+	select {
+	case r := <-received:
+		t.Fatalf("only the group event should be delivered, got %s", r.Header.Get(webhook.EventHeader))
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	var letters []*webhook.DeadLetter
+	require.Eventually(t, func() bool {
+		letters, err = am.ListWebhookDeadLetters(account.Id, "failing", "admin")
+		return err == nil && len(letters) == 1
+	}, 5*time.Second, 10*time.Millisecond, "the failed delivery should be stored as a dead letter")
+	assert.Equal(t, 2, letters[0].Attempts)
+	assert.Equal(t, "group.add", letters[0].EventType)
+
+	require.NoError(t, am.DeleteWebhook(account.Id, "failing", "admin"))
+	letters, err = deadLetters.Get(account.Id, "failing")
+	require.NoError(t, err)
+	assert.Empty(t, letters, "the dead letters of a deleted webhook should be removed")
+}
@@ -18,6 +18,9 @@ const (
 	MaxNetIDChar = 40
 )
 
+// RoutingPeerCapability is reported in the peer system meta by clients able to act as routing peers
+const RoutingPeerCapability = "routing_peer"
+
 const (
 	// InvalidNetworkString invalid network type string
 	InvalidNetworkString = "Invalid"
@@ -118,6 +121,11 @@ func (r *Route) IsEqual(other *Route) bool {
 		compareList(r.PeerGroups, other.PeerGroups)
 }
 
+// IsDefaultRoute returns true if the route network is a default route. Routing peers of a default route act as exit nodes
+func (r *Route) IsDefaultRoute() bool {
+	return r.Network.IsValid() && r.Network.Bits() == 0
+}
+
 // ParseNetwork Parses a network prefix string and returns a netip.Prefix object and if is invalid, IPv4 or IPv6
 func ParseNetwork(networkString string) (NetworkType, netip.Prefix, error) {
 	prefix, err := netip.ParsePrefix(networkString)